@@ -0,0 +1,165 @@
+//Package apperror defines the errors answered by the api, every error response is a JSON
+//document holding a stable code, the HTTP status, a message translated in the request language
+//and the errors of the invalid fields
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//Codes of the errors which are not specific to a handler, they are also their i18n message keys
+const (
+	InvalidRequest     = "invalid_request"
+	Unauthorized       = "please_login"
+	Forbidden          = "forbidden"
+	NotFound           = "not_found"
+	MethodNotAllowed   = "method_not_allowed"
+	Conflict           = "conflict"
+	PreconditionFailed = "precondition_failed"
+	ValidationFailed   = "validation_failed"
+	TooManyRequests    = "too_many_requests"
+	Internal           = "internal_error"
+)
+
+//Error is an application error, Code identifies it for the clients and Key is the i18n key of its message.
+//The cause is logged, never answered
+type Error struct {
+	Code   string
+	Status int
+	Key    string
+	Vars   map[string]string
+	Fields []FieldError
+	cause  error
+}
+
+//FieldError tells why the value of a field is rejected, Key is the i18n key of its message
+type FieldError struct {
+	Field string
+	Code  string
+	Key   string
+	Vars  map[string]string
+}
+
+//New returns the error code answered with status, code is also the message key
+func New(status int, code string, cause error) *Error {
+	return &Error{Code: code, Status: status, Key: code, cause: cause}
+}
+
+//Wrap returns err as an *Error, errors which are not already one are answered with status and its generic
+//code, except the errors telling the record does not exist which are answered 404
+func Wrap(err error, status int) *Error {
+	return WrapAs(err, status, codeOf(status))
+}
+
+//WrapAs is Wrap answering the errors which are not already an *Error with the code of the handler
+func WrapAs(err error, status int, code string) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return New(http.StatusNotFound, NotFound, err)
+	}
+
+	return New(status, code, err)
+}
+
+//Validation returns the error of a body with invalid fields
+func Validation(fields ...FieldError) *Error {
+	e := New(http.StatusUnprocessableEntity, ValidationFailed, nil)
+	e.Fields = fields
+	return e
+}
+
+//WithVars sets the template data of the message
+func (me *Error) WithVars(vars map[string]string) *Error {
+	me.Vars = vars
+	return me
+}
+
+func (me *Error) Error() string {
+	if me.cause != nil {
+		return me.Code + ": " + me.cause.Error()
+	}
+	return me.Code
+}
+
+//Unwrap returns the cause of the error
+func (me *Error) Unwrap() error {
+	return me.cause
+}
+
+func codeOf(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return InvalidRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusMethodNotAllowed:
+		return MethodNotAllowed
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusPreconditionFailed:
+		return PreconditionFailed
+	case http.StatusTooManyRequests:
+		return TooManyRequests
+	}
+	return Internal
+}
+
+//Body is the JSON document of an error response
+type Body struct {
+	Code    string      `json:"code"`
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Fields  []FieldBody `json:"fields,omitempty"`
+}
+
+//FieldBody is the JSON document of a field error
+type FieldBody struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+//Body returns the document of the error with its messages translated in locale
+func (me *Error) Body(l *localizer.Localizer, locale string) Body {
+	body := Body{Code: me.Code, Status: me.Status, Message: l.Translate(me.Key, locale, me.Vars)}
+	for _, f := range me.Fields {
+		body.Fields = append(body.Fields, FieldBody{Field: f.Field, Code: f.Code, Message: l.Translate(f.Key, locale, f.Vars)})
+	}
+	return body
+}
+
+//Write answers err in the language of the Accept-Language header of r, errors which are not an *Error are internal errors
+func Write(w http.ResponseWriter, r *http.Request, l *localizer.Localizer, err error) {
+	e := Wrap(err, http.StatusInternalServerError)
+	if e.Status >= http.StatusInternalServerError {
+		log.Errorf("%s %s: %s", r.Method, r.URL.Path, e)
+	} else {
+		log.Warnf("%s %s: %s", r.Method, r.URL.Path, e)
+	}
+
+	b, err := json.Marshal(e.Body(l, r.Header.Get("Accept-Language")))
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.Status)
+	w.Write(b)
+}
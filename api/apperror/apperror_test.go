@@ -0,0 +1,86 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"gorm.io/gorm"
+)
+
+func TestWrap(t *testing.T) {
+	typed := New(http.StatusForbidden, "user.already_exists", nil)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		want   int
+	}{
+		{"no cause", nil, http.StatusForbidden, Forbidden, http.StatusForbidden},
+		{"store error", errors.New("Error 1054: Unknown column"), http.StatusBadRequest, InvalidRequest, http.StatusBadRequest},
+		{"unprocessable", errors.New("invalid"), http.StatusUnprocessableEntity, InvalidRequest, http.StatusUnprocessableEntity},
+		{"record not found", gorm.ErrRecordNotFound, http.StatusBadRequest, NotFound, http.StatusNotFound},
+		{"wrapped record not found", fmt.Errorf("page 3: %w", gorm.ErrRecordNotFound), http.StatusInternalServerError, NotFound, http.StatusNotFound},
+		{"typed", typed, http.StatusInternalServerError, "user.already_exists", http.StatusForbidden},
+		{"wrapped typed", fmt.Errorf("signup: %w", typed), http.StatusInternalServerError, "user.already_exists", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.err, tt.status)
+			if got.Code != tt.code || got.Status != tt.want {
+				t.Errorf("Wrap() = %s %d, want %s %d", got.Code, got.Status, tt.code, tt.want)
+			}
+
+			//typed errors are returned as is, even when wrapped
+			if tt.err != nil && !errors.Is(got, tt.err) && !errors.Is(tt.err, got) {
+				t.Errorf("Wrap() lost its cause %v", tt.err)
+			}
+		})
+	}
+
+	if got := WrapAs(errors.New("duplicate"), http.StatusForbidden, "user.could_not_create"); got.Code != "user.could_not_create" || got.Status != http.StatusForbidden {
+		t.Errorf("WrapAs() = %s %d", got.Code, got.Status)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	l := localizer.NewLocalizer([]string{"../../localizer/en.json", "../../localizer/fr.json"})
+
+	tests := []struct {
+		name     string
+		err      error
+		language string
+		want     Body
+	}{
+		{"internal error hides its cause", errors.New("dial tcp 127.0.0.1:3306: connection refused"), "en", Body{Code: Internal, Status: http.StatusInternalServerError, Message: "internal error"}},
+		{"translated", Wrap(gorm.ErrRecordNotFound, http.StatusBadRequest), "fr", Body{Code: NotFound, Status: http.StatusNotFound, Message: "la ressource demandée n'existe pas"}},
+		{"vars", New(http.StatusTooManyRequests, "login.locked", nil).WithVars(map[string]string{"Minutes": "2"}), "en", Body{Code: "login.locked", Status: http.StatusTooManyRequests, Message: "too many failed attempts, please try again in 2 minute(s)"}},
+		{"fields", Validation(FieldError{Field: "email", Code: "required", Key: "not_found"}), "en", Body{Code: ValidationFailed, Status: http.StatusUnprocessableEntity, Message: "some fields are invalid", Fields: []FieldBody{{Field: "email", Code: "required", Message: "the requested resource does not exist"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/pages/1", nil)
+			r.Header.Set("Accept-Language", tt.language)
+
+			w := httptest.NewRecorder()
+			Write(w, r, l, tt.err)
+
+			if w.Code != tt.want.Status || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+				t.Fatalf("Write() status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+			}
+
+			want, _ := json.Marshal(tt.want)
+			if got := w.Body.String(); got != string(want) {
+				t.Errorf("Write() body = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+type accountDeletionHandler struct {
+	Store stores.Stores
+}
+
+//Request schedules the deletion of the logged user account once the password is confirmed,
+//the account is purged when the grace period is over unless Cancel is called
+func (me accountDeletionHandler) Request(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	body, err := me.parseBody(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	user, err := me.Store.UserStore().GetByID(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	if !comparePasswords(user.Password, []byte(body.Password)) {
+		fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "invalid_credentials", err))
+		return
+	}
+
+	at, err := me.Store.AccountDeletionStore().Schedule(userID, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	json, err := json.Marshal(struct {
+		DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+	}{DeletionScheduledAt: at})
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprint(w, string(json))
+}
+
+//Cancel drops the pending deletion of the logged user account
+func (me accountDeletionHandler) Cancel(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	result, err := me.Store.AccountDeletionStore().Cancel(userID, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusBadRequest, "account_deletion.not_pending", err))
+		return
+	}
+
+	json, err := json.Marshal(struct{ Result bool }{Result: result})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+func (me accountDeletionHandler) parseBody(body io.Reader) (models.AccountDeletionBodyModel, error) {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return models.AccountDeletionBodyModel{}, err
+	}
+
+	var obj models.AccountDeletionBodyModel
+	err = json.Unmarshal(b, &obj)
+
+	if err != nil {
+		return models.AccountDeletionBodyModel{}, err
+	}
+
+	return obj, nil
+}
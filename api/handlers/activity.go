@@ -5,26 +5,26 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
-	log "github.com/sirupsen/logrus"
 )
 
 type activityHandler struct {
-	Stores *stores.StoreFactory
+	Stores stores.Stores
 }
 
 //All returns all the activities in DB
 func (app activityHandler) All(w http.ResponseWriter, r *http.Request) {
 	activities, err := app.Stores.ActivityStore().All()
 	if err != nil {
-		log.Error(err)
-		http.Error(w, "error retrieving activities", http.StatusInternalServerError)
+		fail(w, r, app.Stores, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
 	json, err := json.Marshal(activities)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, "error retrieving activities", http.StatusInternalServerError)
+		fail(w, r, app.Stores, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
 	fmt.Fprint(w, string(json))
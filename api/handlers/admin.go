@@ -0,0 +1,576 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
+	log "github.com/sirupsen/logrus"
+)
+
+//adminHandler holds the back-office endpoints, routes must be wrapped with IsLogged and roleHandler.Can
+type adminHandler struct {
+	Store stores.Stores
+}
+
+//Users searches users by email or username (q), paginated with offset and limit
+func (me adminHandler) Users(userID uint, w http.ResponseWriter, r *http.Request) {
+	offset, limit := queryPagination(r)
+
+	users, err := me.Store.UserStore().Search(r.URL.Query().Get("q"), offset, limit)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.audit(userID, r, "admin.users.search", "user", 0)
+	me.respond(w, r, users)
+}
+
+//ViewUser returns a read-only snapshot of what the user sees: account, profile, pages and conversations
+func (me adminHandler) ViewUser(userID uint, w http.ResponseWriter, r *http.Request) {
+	targetID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	user, err := me.Store.UserStore().GetByID(targetID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusNotFound))
+		return
+	}
+	user.Password = ""
+
+	pages, err := me.Store.PageStore().GetPagesByOwnerID(user.ProfileID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	conversations, err := me.Store.ConversationStore().ProfileConversations(user.ProfileID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.audit(userID, r, "admin.users.view", "user", targetID)
+	me.respond(w, r, struct {
+		User          models.User           `json:"user"`
+		Pages         []models.Page         `json:"pages"`
+		Conversations []models.Conversation `json:"conversations"`
+	}{User: user, Pages: pages, Conversations: conversations})
+}
+
+//SuspendUser suspends (suspended=true) or reinstates (suspended=false) a user
+func (me adminHandler) SuspendUser(userID uint, w http.ResponseWriter, r *http.Request) {
+	targetID, err := me.targetUser(userID, r)
+	if err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	//DELETE on the suspension resource reinstates the user
+	suspended := r.URL.Query().Get("suspended") != "false" && r.Method != http.MethodDelete
+
+	result, err := me.Store.UserStore().Suspend(targetID, suspended, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, struct{ Result bool }{Result: result})
+}
+
+//DeleteUser purges a user right away, like a member deleting its account once the grace period is over
+func (me adminHandler) DeleteUser(userID uint, w http.ResponseWriter, r *http.Request) {
+	targetID, err := me.targetUser(userID, r)
+	if err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	if err := me.Store.AccountDeletionStore().Purge(targetID, newActor(userID, r)); err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, struct{ Result bool }{Result: true})
+}
+
+//targetUser returns the id of the user an admin acts on, admins cannot act on their own account
+func (me adminHandler) targetUser(userID uint, r *http.Request) (uint, error) {
+	targetID, err := resourceID(r)
+	if err != nil {
+		return 0, apperror.Wrap(err, http.StatusBadRequest)
+	}
+
+	if targetID == userID {
+		return 0, apperror.New(http.StatusBadRequest, "admin.self_target", fmt.Errorf("user %d cannot act on its own account", userID))
+	}
+
+	return targetID, nil
+}
+
+//UnpublishPage hides a published or scheduled page from the public listing
+func (me adminHandler) UnpublishPage(userID uint, w http.ResponseWriter, r *http.Request) {
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	page, err := me.Store.PageStore().SetStatus(pageID, 0, []string{models.PagePublished, models.PageScheduled}, models.PageUnpublished, "", newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.notifyOwner(page)
+	me.respond(w, r, struct{ Result bool }{Result: true})
+}
+
+//Pages returns the moderation queue, the pages in status (pending by default) waiting the longest first,
+//paginated with offset and limit
+func (me adminHandler) Pages(userID uint, w http.ResponseWriter, r *http.Request) {
+	offset, limit := queryPagination(r)
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = models.PagePending
+	}
+
+	pages, total, err := me.Store.PageStore().ByStatus(status, offset, limit)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, struct {
+		Pages []models.Page `json:"pages"`
+		Total int64         `json:"total"`
+	}{Pages: pages, Total: total})
+}
+
+//ApprovePage publishes a pending page, or schedules it when its PublishAt is to come
+func (me adminHandler) ApprovePage(userID uint, w http.ResponseWriter, r *http.Request) {
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	existing, err := me.Store.PageStore().GetByID(pageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusNotFound))
+		return
+	}
+
+	status := models.PagePublished
+	if existing.PublishAt != nil && existing.PublishAt.After(time.Now()) {
+		status = models.PageScheduled
+	}
+
+	page, err := me.Store.PageStore().SetStatus(pageID, existing.Version, []string{models.PagePending}, status, "", newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.notifyOwner(page)
+	me.respond(w, r, page)
+}
+
+//RejectPage sends a pending page back to draft, the owner receives the reason by mail
+func (me adminHandler) RejectPage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	var body models.PageRejectionBodyModel
+	if err := me.parseBody(r, &body); err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := validators.Struct(body); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	page, err := me.Store.PageStore().SetStatus(pageID, 0, []string{models.PagePending}, models.PageDraft, body.Reason, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	owners, err := me.Store.UserStore().All(url.Values{"profile_id": {fmt.Sprint(page.OwnerID)}})
+	if err != nil || len(owners) == 0 {
+		log.Errorf("page %d rejected, its owner could not be told: %v", page.ID, err)
+	} else {
+		go me.Store.MailStore().PageRejected(owners[0].Email, page.Name, body.Reason, r.Header.Get("Accept-Language"))
+	}
+
+	me.notifyOwner(page)
+	me.respond(w, r, page)
+}
+
+//notifyOwner pushes the page whose status a moderator changed to its owner
+func (me adminHandler) notifyOwner(page models.Page) {
+	j, err := json.Marshal(page)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	me.Store.WsStore().EmitToMutationNamespace(page.OwnerID, "PAGE_UPDATED", string(j), "pages")
+}
+
+//UpdatePage applies the JSON merge patch of the body to any page like pageHandler.Update, images are stored in the owner directory
+func (me adminHandler) UpdatePage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	pageID, err := patchID(r, patch)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	existing, err := me.Store.PageStore().GetByID(pageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusNotFound))
+		return
+	}
+
+	if err := ifMatch(r, existing.Version); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	var page models.Page
+	fields, err := mergePatch(existing, patch, &page, pagePatchFields)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+	page.ID, page.OwnerID, page.Version = existing.ID, existing.OwnerID, existing.Version
+
+	if err := validators.Struct(page); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	pageObj, err := me.Store.PageStore().Update(existing.OwnerID, page, fields, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	w.Header().Set("ETag", etag(pageObj.Version))
+	me.respond(w, r, pageObj)
+}
+
+//DeleteImage removes any image
+func (me adminHandler) DeleteImage(userID uint, w http.ResponseWriter, r *http.Request) {
+	imageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	result, err := me.Store.ImageStore().Delete(imageID, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, struct{ Result bool }{Result: result})
+}
+
+//NewActivity creates an activity
+func (me adminHandler) NewActivity(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var activity models.Activity
+	if err := me.parseBody(r, &activity); err != nil || activity.Name == "" {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+	activity.ID = 0
+
+	activity, err := me.Store.ActivityStore().New(activity)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.audit(userID, r, "admin.activities.new", "activity", activity.ID)
+	me.respond(w, r, activity)
+}
+
+//UpdateActivity renames an activity
+func (me adminHandler) UpdateActivity(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var activity models.Activity
+	err := me.parseBody(r, &activity)
+	if id, ok := pathID(r, "id"); ok {
+		activity.ID = id
+	}
+
+	if err != nil || activity.ID < 1 || activity.Name == "" {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	activity, err = me.Store.ActivityStore().Update(activity)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.audit(userID, r, "admin.activities.update", "activity", activity.ID)
+	me.respond(w, r, activity)
+}
+
+//DeleteActivity removes an activity from pages and profiles and deletes it
+func (me adminHandler) DeleteActivity(userID uint, w http.ResponseWriter, r *http.Request) {
+	activityID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	result, err := me.Store.ActivityStore().Delete(activityID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.audit(userID, r, "admin.activities.delete", "activity", activityID)
+	me.respond(w, r, struct{ Result bool }{Result: result})
+}
+
+//NewLanguage creates a language
+func (me adminHandler) NewLanguage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var language models.Language
+	if err := me.parseBody(r, &language); err != nil || language.Name == "" {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+	language.ID = 0
+
+	language, err := me.Store.LanguageStore().New(language)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.audit(userID, r, "admin.languages.new", "language", language.ID)
+	me.respond(w, r, language)
+}
+
+//UpdateLanguage renames a language
+func (me adminHandler) UpdateLanguage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var language models.Language
+	err := me.parseBody(r, &language)
+	if id, ok := pathID(r, "id"); ok {
+		language.ID = id
+	}
+
+	if err != nil || language.ID < 1 || language.Name == "" {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	language, err = me.Store.LanguageStore().Update(language)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.audit(userID, r, "admin.languages.update", "language", language.ID)
+	me.respond(w, r, language)
+}
+
+//DeleteLanguage removes a language from profiles and deletes it
+func (me adminHandler) DeleteLanguage(userID uint, w http.ResponseWriter, r *http.Request) {
+	languageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	result, err := me.Store.LanguageStore().Delete(languageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.audit(userID, r, "admin.languages.delete", "language", languageID)
+	me.respond(w, r, struct{ Result bool }{Result: result})
+}
+
+//ReportedConversations lists the conversations reported by members, without their messages
+func (me adminHandler) ReportedConversations(userID uint, w http.ResponseWriter, r *http.Request) {
+	conversations, err := me.Store.ConversationStore().Reported()
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.audit(userID, r, "admin.conversations.reported", "conversation", 0)
+	me.respond(w, r, conversations)
+}
+
+//ViewConversation returns a reported conversation with its messages
+func (me adminHandler) ViewConversation(userID uint, w http.ResponseWriter, r *http.Request) {
+	conversationID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	conversation, err := me.Store.ConversationStore().GetReported(conversationID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusForbidden, apperror.Forbidden, err))
+		return
+	}
+
+	me.audit(userID, r, "admin.conversations.view", "conversation", conversationID)
+	me.respond(w, r, conversation)
+}
+
+//audit records admin reads and reference data changes, the other mutations are recorded by the stores
+func (me adminHandler) audit(actorID uint, r *http.Request, action, targetType string, targetID uint) {
+	me.Store.AuditStore().Record(newActor(actorID, r), action, targetType, targetID, nil)
+}
+
+//AuditEvents returns the audit log filtered by actor_id, action, target_type, target_id, from and to (RFC 3339)
+func (me adminHandler) AuditEvents(userID uint, w http.ResponseWriter, r *http.Request) {
+	offset, limit := queryPagination(r)
+
+	filter, err := me.auditFilter(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	events, total, err := me.Store.AuditStore().Query(filter, offset, limit)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, struct {
+		Events []models.AuditEvent `json:"events"`
+		Total  int64               `json:"total"`
+	}{Events: events, Total: total})
+}
+
+//ExportAuditEvents streams the filtered audit log as a JSON Lines attachment
+func (me adminHandler) ExportAuditEvents(userID uint, w http.ResponseWriter, r *http.Request) {
+	filter, err := me.auditFilter(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.audit(userID, r, "admin.audit.export", "audit", 0)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit-`+time.Now().Format("20060102-150405")+`.jsonl"`)
+
+	if err := me.Store.AuditStore().Export(filter, w); err != nil {
+		//headers are already sent, the truncated export is all we can do
+		log.Error(err)
+	}
+}
+
+func (me adminHandler) auditFilter(r *http.Request) (models.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := models.AuditFilter{
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+	}
+
+	for key, dst := range map[string]*uint{"actor_id": &filter.ActorID, "target_id": &filter.TargetID} {
+		if v := query.Get(key); v != "" {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return models.AuditFilter{}, err
+			}
+			*dst = uint(id)
+		}
+	}
+
+	for key, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := query.Get(key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return models.AuditFilter{}, err
+			}
+			*dst = t
+		}
+	}
+
+	return filter, nil
+}
+
+func (me adminHandler) respond(w http.ResponseWriter, r *http.Request, v interface{}) {
+	json, err := json.Marshal(v)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+func (me adminHandler) parseBody(r *http.Request, obj interface{}) error {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, obj)
+}
@@ -1,6 +1,17 @@
 package handlers
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
 
 type key string
 
@@ -16,3 +27,83 @@ func FromContext(ctx context.Context) (uint, bool) {
 	u, ok := ctx.Value(userKey).(uint)
 	return u, ok
 }
+
+//clientIP returns the remote address of r without the port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+//resourceID parses the {id} path parameter of r, or its id query parameter on the legacy routes
+func resourceID(r *http.Request) (uint, error) {
+	tmp := router.Param(r, "id")
+	if tmp == "" {
+		tmp = r.URL.Query().Get("id")
+	}
+
+	if tmp == "" {
+		return 0, fmt.Errorf("id missing")
+	}
+
+	return parseID(tmp)
+}
+
+//pathID parses the path parameter name of r, ok is false when the route has no such parameter
+//and an invalid value is returned as 0
+func pathID(r *http.Request, name string) (uint, bool) {
+	tmp := router.Param(r, name)
+	if tmp == "" {
+		return 0, false
+	}
+
+	id, _ := parseID(tmp)
+	return id, true
+}
+
+func parseID(tmp string) (uint, error) {
+	id, err := strconv.ParseUint(tmp, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if id < 1 {
+		return 0, fmt.Errorf("invalid id %d", id)
+	}
+
+	return uint(id), nil
+}
+
+//queryPagination parses the offset and limit query parameters of r, limit defaults to 50 and is capped at 200
+func queryPagination(r *http.Request) (int, int) {
+	query := r.URL.Query()
+
+	offset, err := strconv.Atoi(query.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+
+	if limit > 200 {
+		limit = 200
+	}
+
+	return offset, limit
+}
+
+//newActor describes the author of the request for the audit log
+func newActor(userID uint, r *http.Request) models.Actor {
+	return models.Actor{UserID: userID, IP: clientIP(r)}
+}
+
+//fail answers err as a JSON error translated in the request language, wrap the errors
+//with apperror to choose their status, see apperror.Write
+func fail(w http.ResponseWriter, r *http.Request, s stores.Stores, err error) {
+	apperror.Write(w, r, s.Localizer(), err)
+}
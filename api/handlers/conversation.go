@@ -5,15 +5,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strconv"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
-	log "github.com/sirupsen/logrus"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
 )
 
 type conversationHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
 func (me conversationHandler) HandleMessage(w http.ResponseWriter, r *http.Request) {
@@ -26,63 +26,54 @@ func (me conversationHandler) HandleMessage(w http.ResponseWriter, r *http.Reque
 
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	sendMessageBody := models.SendMessageBodyModel{}
 	err = json.Unmarshal(body, &sendMessageBody)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
-	_, err = sendMessageBody.Validate()
-	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+	if err := validators.Struct(sendMessageBody); err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
 	toProfile, err := me.Store.UserStore().GetProfile(sendMessageBody.ToID)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	fromUser, err := me.Store.UserStore().GetByEmail(sendMessageBody.Email, true)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	fromProfile, err := me.Store.UserStore().GetProfile(fromUser.ID)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	if fromProfile.ID == toProfile.ID {
-		http.Error(w, fmt.Errorf("%s", "invalid request").Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, nil))
 		return
 	}
 
 	conversation, err := me.Store.ConversationStore().GetByReferents(fromProfile, toProfile)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	conversation, message, err := me.Store.ConversationStore().AddMessage(conversation, fromProfile.ID, toProfile.ID, sendMessageBody.Email, sendMessageBody.Text)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
@@ -92,46 +83,58 @@ func (me conversationHandler) HandleMessage(w http.ResponseWriter, r *http.Reque
 		go me.Store.MailStore().AccountAutoCreated(fromUser.Email, fromUser.PasswordTmp, locale)
 	}
 
-	j, err := json.Marshal(&message)
-
+	//the sender is whoever typed the email, it only sees its own profile once logged in
+	j, err := json.Marshal(message.View(requestViewer(me.Store, r).relation))
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
+	recipient := profileViewer(toProfile.ID)
+
+	var event string
+	var payload interface{}
 	if !conversation.New {
-		me.Store.WsStore().EmitToMutationNamespace(message.ToID, "CONVERSATION_ADD_MESSAGE", string(j), "conversations")
+		event, payload = "CONVERSATION_ADD_MESSAGE", message.View(recipient.relation)
 	} else {
-		c, err := conversation.ToJSON()
-		if err != nil {
-			http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
-			return
-		}
-		me.Store.WsStore().EmitToMutationNamespace(message.ToID, "NEW_CONVERSATION", c, "conversations")
+		conversation.From, conversation.To = fromProfile, toProfile
+		event, payload = "NEW_CONVERSATION", conversation.View(recipient.relation)
+	}
+
+	p, err := json.Marshal(payload)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
+	me.Store.WsStore().EmitToMutationNamespace(message.ToID, event, string(p), "conversations")
+
 	fmt.Fprint(w, string(j))
 }
 
 func (me conversationHandler) ProfileConversations(userID uint, w http.ResponseWriter, r *http.Request) {
 	profileID, err := me.Store.UserStore().GetProfileID(userID)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	conversations, err := me.Store.ConversationStore().ProfileConversations(profileID)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
-	json, err := json.Marshal(&conversations)
+	viewer := profileViewer(profileID)
+	views := make([]models.ConversationView, 0, len(conversations))
+	for _, c := range conversations {
+		views = append(views, c.View(viewer.relation))
+	}
+
+	json, err := json.Marshal(views)
 
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -145,50 +148,91 @@ func (me conversationHandler) Delete(userID uint, w http.ResponseWriter, r *http
 		defer r.Body.Close()
 	}
 
-	query := r.URL.Query()
+	conversationID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	owns, interlocutorProfileID, err := me.Store.UserStore().OwnConversation(userID, conversationID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
 
-	tmp := query.Get("id")
-	if tmp == "" {
-		log.Println("id mising")
-		http.Error(w, fmt.Errorf("id missing %s", tmp).Error(), http.StatusBadRequest)
+	if !owns {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
 		return
 	}
 
-	conversationID, err := strconv.Atoi(tmp)
+	result, err := me.Store.ConversationStore().Delete(conversationID, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
-	owns, interlocutorProfileID, err := me.Store.UserStore().OwnConversation(userID, uint(conversationID))
+	ret, err := json.Marshal(struct{ Result bool }{Result: result})
+
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+	me.Store.WsStore().EmitToMutationNamespace(interlocutorProfileID, "CONVERSATION_REMOVED", fmt.Sprint(conversationID), "conversations")
+
+	fmt.Fprint(w, string(ret))
+}
+
+//Report flags a conversation the user is part of, admins can then read it
+func (me conversationHandler) Report(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	conversationID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	owns, _, err := me.Store.UserStore().OwnConversation(userID, conversationID)
+	if err != nil || !owns {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
 		return
 	}
 
-	result, err := me.Store.ConversationStore().Delete(uint(conversationID))
+	profileID, err := me.Store.UserStore().GetProfileID(userID)
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	ret, err := json.Marshal(struct{ Result bool }{Result: result})
+	body := struct {
+		Reason string `json:"reason"`
+	}{}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err == nil && len(b) > 0 {
+		err = json.Unmarshal(b, &body)
+	}
+
+	if err != nil || len(body.Reason) > 512 {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
 
+	result, err := me.Store.ConversationStore().Report(conversationID, profileID, body.Reason, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	me.Store.WsStore().EmitToMutationNamespace(interlocutorProfileID, "CONVERSATION_REMOVED", fmt.Sprint(conversationID), "conversations")
+	ret, err := json.Marshal(struct{ Result bool }{Result: result})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
 
 	fmt.Fprint(w, string(ret))
 }
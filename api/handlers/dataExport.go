@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	log "github.com/sirupsen/logrus"
+)
+
+type dataExportHandler struct {
+	Store stores.Stores
+}
+
+//Request starts the export of the logged user data, the client is notified on the websocket once done
+func (me dataExportHandler) Request(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	export, err := me.Store.DataExportStore().Request(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	json, err := json.Marshal(export)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprint(w, string(json))
+}
+
+//All lists the logged user exports
+func (me dataExportHandler) All(userID uint, w http.ResponseWriter, r *http.Request) {
+	exports, err := me.Store.DataExportStore().All(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	json, err := json.Marshal(exports)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//Download sends the ZIP archive of an export owned by the logged user
+func (me dataExportHandler) Download(userID uint, w http.ResponseWriter, r *http.Request) {
+	exportID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	export, f, err := me.Store.DataExportStore().Open(userID, exportID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusNotFound))
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="couchsport-export-`+export.CreatedAt.Format("20060102")+`.zip"`)
+	w.Header().Set("Content-Length", strconv.FormatInt(export.Size, 10))
+
+	if _, err := io.Copy(w, f); err != nil {
+		log.Error(err)
+	}
+}
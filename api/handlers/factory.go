@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
 	"github.com/amaurybrisou/couchsport.back/localizer"
 	"github.com/gorilla/websocket"
@@ -16,11 +19,18 @@ type HandlerFactory struct {
 	profileHandler      profileHandler
 	userHandler         userHandler
 	conversationHandler conversationHandler
+	twoFactorHandler    twoFactorHandler
+	roleHandler         roleHandler
+	adminHandler        adminHandler
+	dataExportHandler   dataExportHandler
+	accountDeletion     accountDeletionHandler
+	trashHandler        trashHandler
+	uploadHandler       uploadHandler
 	localizer           *localizer.Localizer
 }
 
 //NewHandlerFactory generates the handlerFactory holding every handler in the application
-func NewHandlerFactory(storeFactory *stores.StoreFactory, localizer *localizer.Localizer, wsUpgrader *websocket.Upgrader) *HandlerFactory {
+func NewHandlerFactory(storeFactory stores.Stores, localizer *localizer.Localizer, wsUpgrader *websocket.Upgrader) *HandlerFactory {
 
 	return &HandlerFactory{
 		localizer:           localizer,
@@ -32,6 +42,13 @@ func NewHandlerFactory(storeFactory *stores.StoreFactory, localizer *localizer.L
 		profileHandler:      profileHandler{Store: storeFactory},
 		userHandler:         userHandler{Store: storeFactory},
 		conversationHandler: conversationHandler{Store: storeFactory},
+		twoFactorHandler:    twoFactorHandler{Store: storeFactory},
+		roleHandler:         roleHandler{Store: storeFactory},
+		adminHandler:        adminHandler{Store: storeFactory},
+		dataExportHandler:   dataExportHandler{Store: storeFactory},
+		accountDeletion:     accountDeletionHandler{Store: storeFactory},
+		trashHandler:        trashHandler{Store: storeFactory},
+		uploadHandler:       uploadHandler{Store: storeFactory},
 	}
 }
 
@@ -40,6 +57,13 @@ func (me HandlerFactory) Localizer() *localizer.Localizer {
 	return me.localizer
 }
 
+//ErrorHandler returns the handler answering the error of status, used for the requests the router cannot dispatch
+func (me HandlerFactory) ErrorHandler(status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		apperror.Write(w, r, me.localizer, apperror.Wrap(nil, status))
+	}
+}
+
 //WsHandler returns the applicatioin Upgrader
 func (me HandlerFactory) WsHandler() *wsHandler {
 	return &me.wsHandler
@@ -79,3 +103,38 @@ func (me HandlerFactory) UserHandler() *userHandler {
 func (me HandlerFactory) ConversationHandler() *conversationHandler {
 	return &me.conversationHandler
 }
+
+//TwoFactorHandler returns the applicatioin TwoFactorHandler
+func (me HandlerFactory) TwoFactorHandler() *twoFactorHandler {
+	return &me.twoFactorHandler
+}
+
+//RoleHandler returns the applicatioin RoleHandler
+func (me HandlerFactory) RoleHandler() *roleHandler {
+	return &me.roleHandler
+}
+
+//AdminHandler returns the applicatioin AdminHandler
+func (me HandlerFactory) AdminHandler() *adminHandler {
+	return &me.adminHandler
+}
+
+//DataExportHandler returns the applicatioin DataExportHandler
+func (me HandlerFactory) DataExportHandler() *dataExportHandler {
+	return &me.dataExportHandler
+}
+
+//AccountDeletionHandler returns the applicatioin AccountDeletionHandler
+func (me HandlerFactory) AccountDeletionHandler() *accountDeletionHandler {
+	return &me.accountDeletion
+}
+
+//TrashHandler returns the application TrashHandler
+func (me HandlerFactory) TrashHandler() *trashHandler {
+	return &me.trashHandler
+}
+
+//UploadHandler returns the application UploadHandler
+func (me HandlerFactory) UploadHandler() *uploadHandler {
+	return &me.uploadHandler
+}
@@ -7,13 +7,13 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
-	log "github.com/sirupsen/logrus"
 )
 
 type imageHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
 //Delete is called to set DeletedAt field to Now, not deleting the image
@@ -24,43 +24,60 @@ func (me imageHandler) Delete(userID uint, w http.ResponseWriter, r *http.Reques
 		defer r.Body.Close()
 	}
 
-	image, err := me.parseBody(r.Body)
+	image, err := me.parseRequest(r)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	owns, err := me.Store.UserStore().OwnImage(userID, image.OwnerID, image.ID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
 		return
 	}
 
-	result, err := me.Store.ImageStore().Delete(image.ID)
+	result, err := me.Store.ImageStore().Delete(image.ID, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	json, err := json.Marshal(struct{ Result bool }{Result: result})
 
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	fmt.Fprint(w, string(json))
 }
 
+//parseRequest reads the image of the request body, the resource routes take its ID and its page ID from the path
+func (me imageHandler) parseRequest(r *http.Request) (models.Image, error) {
+	image := models.Image{}
+	if r.ContentLength != 0 {
+		var err error
+		if image, err = me.parseBody(r.Body); err != nil {
+			return models.Image{}, err
+		}
+	}
+
+	if id, ok := pathID(r, "id"); ok {
+		image.ID = id
+	}
+
+	if pageID, ok := pathID(r, "pageID"); ok {
+		image.OwnerID = pageID
+	}
+
+	return image, nil
+}
+
 func (me imageHandler) parseBody(body io.Reader) (models.Image, error) {
 	b, err := ioutil.ReadAll(body)
 	if err != nil {
@@ -5,28 +5,26 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
-	log "github.com/sirupsen/logrus"
 )
 
 type languageHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
 //All returns all languages
 func (app languageHandler) All(w http.ResponseWriter, r *http.Request) {
 	languages, err := app.Store.LanguageStore().All()
 	if err != nil {
-		log.Error(err)
-		http.Error(w, http.ErrNotSupported.Error(), http.StatusInternalServerError)
+		fail(w, r, app.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	json, err := json.Marshal(languages)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, http.ErrNotSupported.Error(), http.StatusInternalServerError)
+		fail(w, r, app.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -7,29 +7,28 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
-	log "github.com/sirupsen/logrus"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
 )
 
 type pageHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
-//All return all the pages
+//All return all the pages, their owners are seen through their privacy
 func (me pageHandler) All(w http.ResponseWriter, r *http.Request) {
 	pages, err := me.Store.PageStore().All(r.URL.Query())
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	json, err := json.Marshal(pages)
+	json, err := json.Marshal(requestViewer(me.Store, r).pages(pages))
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -37,6 +36,34 @@ func (me pageHandler) All(w http.ResponseWriter, r *http.Request) {
 
 }
 
+//Get returns a published page, or a page of the logged member whatever its status
+func (me pageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	viewer := requestViewer(me.Store, r)
+
+	//the owner also reads its pages while they are not published
+	page, err := me.Store.PageStore().GetByID(pageID)
+	if err != nil || (!page.Public && page.OwnerID != viewer.profileID) {
+		fail(w, r, me.Store, apperror.New(http.StatusNotFound, apperror.NotFound, err))
+		return
+	}
+
+	json, err := json.Marshal(page.View(viewer.relation))
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("ETag", etag(page.Version))
+	fmt.Fprint(w, string(json))
+}
+
 //ProfilePages gets all the profile pages
 func (me pageHandler) ProfilePages(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
@@ -47,22 +74,20 @@ func (me pageHandler) ProfilePages(userID uint, w http.ResponseWriter, r *http.R
 
 	profileID, err := me.Store.UserStore().GetProfileID(userID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	pages, err := me.Store.PageStore().GetPagesByOwnerID(profileID)
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	json, err := json.Marshal(pages)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -80,30 +105,38 @@ func (me pageHandler) New(userID uint, w http.ResponseWriter, r *http.Request) {
 
 	page, err := me.parseBody(r.Body)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	uploadIDs, err := attachUploads(me.Store, userID, page.Images)
+	if err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	if err := validators.Struct(page); err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
 	profileID, err := me.Store.UserStore().GetProfileID(userID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
-	pageObj, err := me.Store.PageStore().New(profileID, page)
+	pageObj, err := me.Store.PageStore().New(profileID, page, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
+	consumeUploads(me.Store, userID, uploadIDs)
 
 	json, err := json.Marshal(pageObj)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -111,7 +144,8 @@ func (me pageHandler) New(userID uint, w http.ResponseWriter, r *http.Request) {
 
 }
 
-//Update the current page
+//Update applies the JSON merge patch of the body to the page, the legacy route sends the whole page.
+//The If-Match header holds the ETag of the version the patch applies to
 func (me pageHandler) Update(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
 
@@ -119,44 +153,84 @@ func (me pageHandler) Update(userID uint, w http.ResponseWriter, r *http.Request
 		defer r.Body.Close()
 	}
 
-	page, err := me.parseBody(r.Body)
+	patch, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
-	owns, err := me.Store.UserStore().OwnPage(userID, page.ID)
+	pageID, err := patchID(r, patch)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	owns, err := me.Store.UserStore().OwnPage(userID, pageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
+		return
+	}
+
+	existing, err := me.Store.PageStore().GetByID(pageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := ifMatch(r, existing.Version); err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
-	pageObj, err := me.Store.PageStore().Update(userID, page)
+	var page models.Page
+	fields, err := mergePatch(existing, patch, &page, pagePatchFields)
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
+	page.ID, page.OwnerID, page.Version = existing.ID, existing.OwnerID, existing.Version
+
+	uploadIDs, err := attachUploads(me.Store, userID, page.Images)
+	if err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	if err := validators.Struct(page); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	//the moderators approved the former content, the new one goes through the review again
+	if page.NeedsReview(existing, fields) {
+		page.Status, page.Public = models.PagePending, false
+		fields = append(fields, "Status", "Public")
+	}
+
+	pageObj, err := me.Store.PageStore().Update(userID, page, fields, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+	consumeUploads(me.Store, userID, uploadIDs)
 
 	json, err := json.Marshal(pageObj)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
+	w.Header().Set("ETag", etag(pageObj.Version))
 	fmt.Fprint(w, string(json))
 }
 
-//Delete the page
+//Delete moves the page to the trash, see TrashHandler
 func (me pageHandler) Delete(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
 
@@ -164,44 +238,40 @@ func (me pageHandler) Delete(userID uint, w http.ResponseWriter, r *http.Request
 		defer r.Body.Close()
 	}
 
-	page, err := me.parseBody(r.Body)
+	page, err := me.parseRequest(r)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	owns, err := me.Store.UserStore().OwnPage(userID, page.ID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
 		return
 	}
 
-	result, err := me.Store.PageStore().Delete(userID, page.ID)
+	result, err := me.Store.PageStore().Delete(userID, page.ID, newActor(userID, r))
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	json, err := json.Marshal(struct{ Result bool }{Result: result})
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
 	fmt.Fprint(w, string(json))
 }
 
-//Public the page setting Public to 1 or 0
+//Publish submits the page for review when public is set, and unpublishes it or withdraws it from the review otherwise
 func (me pageHandler) Publish(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
 
@@ -209,43 +279,139 @@ func (me pageHandler) Publish(userID uint, w http.ResponseWriter, r *http.Reques
 		defer r.Body.Close()
 	}
 
-	page, err := me.parseBody(r.Body)
+	page, err := me.parseRequest(r)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	owns, err := me.Store.UserStore().OwnPage(userID, page.ID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
 		return
 	}
 
-	result, err := me.Store.PageStore().Publish(userID, page.ID, page.Public)
+	existing, err := me.Store.PageStore().GetByID(page.ID)
 	if err != nil {
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	json, err := json.Marshal(struct{ Result bool }{Result: result})
+	status := models.PagePending
+	switch {
+	case page.Public:
+	case existing.Status == models.PagePending:
+		status = models.PageDraft
+	default:
+		status = models.PageUnpublished
+	}
 
+	if _, err := me.Store.PageStore().SetStatus(page.ID, existing.Version, models.OwnerSources(status), status, "", newActor(userID, r)); err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	json, err := json.Marshal(struct{ Result bool }{Result: true})
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//SetStatus moves the page along its lifecycle: submit a draft for review, withdraw it, unpublish, archive
+//or restore it, the moderators approve the pages. The If-Match header holds the ETag of the page
+func (me pageHandler) SetStatus(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	var body models.PageStatusBodyModel
+	b, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(b, &body)
+	}
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := validators.Struct(body); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	owns, err := me.Store.UserStore().OwnPage(userID, pageID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	if !owns {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
+		return
+	}
+
+	existing, err := me.Store.PageStore().GetByID(pageID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
+	if err := ifMatch(r, existing.Version); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	page, err := me.Store.PageStore().SetStatus(pageID, existing.Version, models.OwnerSources(body.Status), body.Status, "", newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	json, err := json.Marshal(page)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("ETag", etag(page.Version))
 	fmt.Fprint(w, string(json))
 }
 
+//parseRequest reads the page of the request body, the resource routes take its ID from the path
+func (me pageHandler) parseRequest(r *http.Request) (models.Page, error) {
+	page := models.Page{}
+	if r.ContentLength != 0 {
+		var err error
+		if page, err = me.parseBody(r.Body); err != nil {
+			return models.Page{}, err
+		}
+	}
+
+	if id, ok := pathID(r, "id"); ok {
+		page.ID = id
+	}
+
+	return page, nil
+}
+
 func (me pageHandler) parseBody(body io.Reader) (models.Page, error) {
 	b, err := ioutil.ReadAll(body)
 	if err != nil {
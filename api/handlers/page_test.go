@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+//call runs handler behind IsLogged with the session cookie
+func call(h *HandlerFactory, handler func(uint, http.ResponseWriter, *http.Request), cookie *http.Cookie, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.AddCookie(cookie)
+
+	w := httptest.NewRecorder()
+	h.UserHandler().IsLogged(handler)(w, r)
+	return w
+}
+
+func TestPageHandler(t *testing.T) {
+	h, s := newTestHandlers(t)
+	owner := signUp(t, h, "owner@couchsport.test")
+	other := signUp(t, h, "other@couchsport.test")
+
+	w := call(h, h.PageHandler().New, owner, `{"name":"Hossegor","description":"surf spot","lat":43.66,"lng":-1.44}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("New() status = %d, body = %s", w.Code, w.Body)
+	}
+
+	var page models.Page
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		handler func(uint, http.ResponseWriter, *http.Request)
+		cookie  *http.Cookie
+		body    string
+		want    int
+	}{
+		{"update by another member", h.PageHandler().Update, other, `{"id":%d,"name":"Stolen","description":"surf spot"}`, http.StatusUnprocessableEntity},
+		{"update with invalid fields", h.PageHandler().Update, owner, `{"id":%d,"name":"","description":"<script>"}`, http.StatusUnprocessableEntity},
+		{"update", h.PageHandler().Update, owner, `{"id":%d,"name":"Seignosse","description":"surf spot"}`, http.StatusOK},
+		{"submit", h.PageHandler().Publish, owner, `{"id":%d,"public":true}`, http.StatusOK},
+		{"withdraw", h.PageHandler().Publish, owner, `{"id":%d,"public":false}`, http.StatusOK},
+		{"withdraw a draft", h.PageHandler().Publish, owner, `{"id":%d,"public":false}`, http.StatusConflict},
+		{"delete by another member", h.PageHandler().Delete, other, `{"id":%d}`, http.StatusUnprocessableEntity},
+		{"delete", h.PageHandler().Delete, owner, `{"id":%d}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := call(h, tt.handler, tt.cookie, fmt.Sprintf(tt.body, page.ID))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	if _, err := s.PageStore().GetByID(page.ID); err == nil {
+		t.Errorf("page %d still exists after Delete()", page.ID)
+	}
+
+	if events, total, err := s.AuditStore().Query(models.AuditFilter{TargetType: "page"}, 0, 10); err != nil || total != 5 {
+		t.Errorf("audit events = %v, total = %d, want 5, err = %v", events, total, err)
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+//pagePatchFields are the json names of the page fields a merge patch can set
+var pagePatchFields = []string{"name", "description", "long_description", "lat", "lng", "couch_number", "publish_at", "unpublish_at", "images", "activities"}
+
+//profilePatchFields are the json names of the profile fields a merge patch can set, an uploaded avatar
+//sends its file name in avatar_file and its data in avatar
+var profilePatchFields = []string{"username", "country", "firstname", "lastname", "email", "street_number", "street_name",
+	"city", "gender", "phone", "zip_code", "avatar", "languages", "activities", "privacy"}
+
+//etag returns the entity tag of version
+func etag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+//ifMatch checks the If-Match header of r against the current version of the resource, requests
+//without the header apply to the current version
+func ifMatch(r *http.Request, version uint) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return nil
+		}
+	}
+
+	return apperror.New(http.StatusPreconditionFailed, apperror.PreconditionFailed, fmt.Errorf("If-Match %s, current version %s", header, current))
+}
+
+//mergePatch applies the JSON merge patch to current and decodes the result in out. It returns the field mask
+//of the update, the Go names of the fields of out set by the patch among allowed, the other members are ignored
+func mergePatch(current interface{}, patch []byte, out interface{}, allowed []string) ([]string, error) {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := utils.MergePatch(doc, patch)
+	if err != nil {
+		return nil, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, err)
+	}
+
+	if err := json.Unmarshal(merged, out); err != nil {
+		return nil, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, err)
+	}
+
+	keys, err := utils.PatchKeys(patch)
+	if err != nil {
+		return nil, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, err)
+	}
+
+	names := goNames(reflect.TypeOf(out).Elem())
+	fields := []string{}
+	for _, k := range keys {
+		for _, a := range allowed {
+			if k == a && names[k] != "" {
+				fields = append(fields, names[k])
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+//goNames maps the json names of the fields of the struct t to their Go names, embedded structs are flattened
+func goNames(t reflect.Type) map[string]string {
+	names := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for k, v := range goNames(f.Type) {
+				names[k] = v
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = f.Name
+	}
+	return names
+}
+
+//patchID returns the {id} path parameter of r, or the id member of the body on the legacy routes
+func patchID(r *http.Request, patch []byte) (uint, error) {
+	if id, ok := pathID(r, "id"); ok {
+		if id < 1 {
+			return 0, fmt.Errorf("invalid id %s", router.Param(r, "id"))
+		}
+		return id, nil
+	}
+
+	var body struct {
+		ID uint `json:"id"`
+	}
+	if err := json.Unmarshal(patch, &body); err != nil {
+		return 0, err
+	}
+
+	if body.ID < 1 {
+		return 0, fmt.Errorf("id missing")
+	}
+
+	return body.ID, nil
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	log "github.com/sirupsen/logrus"
+)
+
+//viewer resolves the relation of a member, or an anonymous visitor, to the owners of the profiles it reads
+type viewer struct {
+	profileID uint
+}
+
+//requestViewer returns the viewer of r, the public routes are read by visitors and members alike
+func requestViewer(s stores.Stores, r *http.Request) *viewer {
+	session, err := s.SessionStore().GetSession(r)
+	if err != nil || session.Partial || session.HasExpired() {
+		return profileViewer(0)
+	}
+
+	profileID, err := s.UserStore().GetProfileID(session.OwnerID)
+	if err != nil {
+		log.Error(err)
+	}
+	return profileViewer(profileID)
+}
+
+//profileViewer returns the viewer owning profileID, 0 is a visitor
+func profileViewer(profileID uint) *viewer {
+	return &viewer{profileID: profileID}
+}
+
+//relation is the models.Relations of the viewer
+func (me *viewer) relation(profileID uint) models.Relation {
+	switch {
+	case me.profileID == 0:
+		return models.RelationVisitor
+	case profileID == me.profileID:
+		return models.RelationSelf
+	}
+
+	//the stays are not recorded yet, no member is the guest of another
+	return models.RelationMember
+}
+
+//pages returns the views of pages
+func (me *viewer) pages(pages []models.Page) []models.PageView {
+	views := make([]models.PageView, 0, len(pages))
+	for _, p := range pages {
+		views = append(views, p.View(me.relation))
+	}
+	return views
+}
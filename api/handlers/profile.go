@@ -3,20 +3,23 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/router"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
 	log "github.com/sirupsen/logrus"
 )
 
 type profileHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
-//Update the user profile
+//Update applies the JSON merge patch of the body to the profile of the user, like pageHandler.Update
 func (me profileHandler) Update(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
 
@@ -24,57 +27,246 @@ func (me profileHandler) Update(userID uint, w http.ResponseWriter, r *http.Requ
 		defer r.Body.Close()
 	}
 
-	profile, err := me.parseBody(r.Body)
+	patch, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
-	owns, err := me.Store.UserStore().OwnProfile(userID, profile.ID)
+	profileID, err := patchID(r, patch)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	owns, err := me.Store.UserStore().OwnProfile(userID, profileID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	if !owns {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusForbidden))
+		return
+	}
+
+	existing, err := me.Store.UserStore().GetProfile(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := ifMatch(r, existing.Version); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	var profile models.Profile
+	fields, err := mergePatch(existing, patch, &profile, profilePatchFields)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+	profile.ID, profile.Version = existing.ID, existing.Version
+
+	if err := checkAvatar(existing, profile, fields); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	upload, err := me.attachAvatar(userID, &profile, &fields)
+	if err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
-	profile, err = me.Store.ProfileStore().Update(userID, profile)
+	if err := validators.Struct(profile); err != nil {
+		fail(w, r, me.Store, err)
+		return
+	}
+
+	profile, err = me.Store.ProfileStore().Update(userID, profile, fields)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
+	if upload != nil {
+		consumeUploads(me.Store, userID, []uint{upload.ID})
+		me.removeVariants(*upload)
+	}
 
 	json, err := json.Marshal(profile)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("could encode output %s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
+	w.Header().Set("ETag", etag(profile.Version))
 	fmt.Fprint(w, string(json))
 
 }
 
-func (me profileHandler) parseBody(body io.Reader) (models.Profile, error) {
-	b, err := ioutil.ReadAll(body)
+//attachAvatar sets the avatar of profile to the file of the upload its avatar_upload_id references, the avatar
+//is then part of the updated fields. It returns the upload to consume once the profile is saved
+func (me profileHandler) attachAvatar(userID uint, profile *models.Profile, fields *[]string) (*models.Upload, error) {
+	if profile.AvatarUploadID == 0 {
+		return nil, nil
+	}
+
+	upload, err := me.Store.UploadStore().Ready(userID, profile.AvatarUploadID)
 	if err != nil {
-		return models.Profile{}, err
+		return nil, err
+	}
+
+	profile.Avatar, profile.AvatarFile, profile.AvatarUploadID = upload.URL, "", 0
+
+	for _, f := range *fields {
+		if f == "Avatar" {
+			return &upload, nil
+		}
+	}
+	*fields = append(*fields, "Avatar")
+
+	return &upload, nil
+}
+
+//checkAvatar refuses a patched avatar the member did not send: the avatar is set from a data URL with its
+//avatar_file or from an avatar_upload_id, it is kept as it is or removed. Any other path or link could point
+//to the file of another member, which would be removed once the avatar is replaced
+func checkAvatar(existing, profile models.Profile, fields []string) error {
+	if profile.AvatarUploadID != 0 {
+		return nil
+	}
+
+	for _, f := range fields {
+		if f != "Avatar" {
+			continue
+		}
+
+		if profile.Avatar == "" || profile.Avatar == existing.Avatar ||
+			(profile.AvatarFile != "" && strings.HasPrefix(profile.Avatar, "data:")) {
+			return nil
+		}
+
+		return apperror.New(http.StatusUnprocessableEntity, "profile.invalid_avatar", fmt.Errorf("avatar %s is not a data URL", profile.Avatar))
 	}
 
-	var obj models.Profile
-	err = json.Unmarshal(b, &obj)
+	return nil
+}
+
+//removeVariants removes the variant files of an upload used as avatar, the avatars have none
+func (me profileHandler) removeVariants(upload models.Upload) {
+	for _, file := range upload.Variants.URLs() {
+		if err := me.Store.FileStore().Delete(file); err != nil {
+			log.Warnf("avatar upload %d: %s", upload.ID, err)
+		}
+	}
+}
+
+//Directory searches the listed profiles by q (username, firstname or lastname), activity_id, language_id, country
+//and city, paginated with offset and limit. Profiles are seen through their privacy
+func (me profileHandler) Directory(w http.ResponseWriter, r *http.Request) {
+	offset, limit := queryPagination(r)
 
+	filter, err := me.directoryFilter(r)
 	if err != nil {
-		return models.Profile{}, err
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	profiles, total, err := me.Store.ProfileStore().Search(filter, offset, limit)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	viewer := requestViewer(me.Store, r)
+	views := make([]models.PublicProfile, 0, len(profiles))
+	for _, p := range profiles {
+		views = append(views, p.View(viewer.relation(p.ID)))
+	}
+
+	json, err := json.Marshal(struct {
+		Profiles []models.PublicProfile `json:"profiles"`
+		Total    int64                  `json:"total"`
+	}{Profiles: views, Total: total})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
-	return obj, nil
+	fmt.Fprint(w, string(json))
+}
+
+func (me profileHandler) directoryFilter(r *http.Request) (models.ProfileFilter, error) {
+	query := r.URL.Query()
+	filter := models.ProfileFilter{
+		Query:   query.Get("q"),
+		Country: query.Get("country"),
+		City:    query.Get("city"),
+	}
+
+	for key, dst := range map[string]*uint{"activity_id": &filter.ActivityID, "language_id": &filter.LanguageID} {
+		if v := query.Get(key); v != "" {
+			id, err := parseID(v)
+			if err != nil {
+				return models.ProfileFilter{}, err
+			}
+			*dst = id
+		}
+	}
+
+	return filter, nil
+}
+
+//Get returns a listed profile by its ID, seen through its privacy, with its published pages
+func (me profileHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(router.Param(r, "id"))
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusNotFound, apperror.NotFound, err))
+		return
+	}
+
+	profile, err := me.Store.ProfileStore().GetByID(id)
+	me.member(w, r, profile, err)
+}
+
+//GetByUsername returns a listed profile by its username like Get, a username may look like an ID
+func (me profileHandler) GetByUsername(w http.ResponseWriter, r *http.Request) {
+	profile, err := me.Store.ProfileStore().GetByUsername(router.Param(r, "username"))
+	me.member(w, r, profile, err)
+}
+
+//member answers the profile found by Get or GetByUsername with its published pages
+func (me profileHandler) member(w http.ResponseWriter, r *http.Request, profile models.Profile, err error) {
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusNotFound, apperror.NotFound, err))
+		return
+	}
+
+	pages, err := me.Store.PageStore().GetPagesByOwnerID(profile.ID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	published := []models.Page{}
+	for _, p := range pages {
+		if p.Public {
+			published = append(published, p)
+		}
+	}
+
+	viewer := requestViewer(me.Store, r)
+	json, err := json.Marshal(models.MemberView{
+		PublicProfile: profile.View(viewer.relation(profile.ID)),
+		Pages:         viewer.pages(published),
+	})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
 }
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+type roleHandler struct {
+	Store stores.Stores
+}
+
+//Can is a middleware checking the logged user has permission, compose it inside IsLogged:
+//IsLogged(Can(models.PermRolesManage, handler))
+func (me roleHandler) Can(permission string, pass func(userID uint, w http.ResponseWriter, r *http.Request)) func(userID uint, w http.ResponseWriter, r *http.Request) {
+	return func(userID uint, w http.ResponseWriter, r *http.Request) {
+		allowed, err := me.Store.RoleStore().HasPermission(userID, permission)
+		if err != nil {
+			fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+			return
+		}
+
+		if !allowed {
+			fail(w, r, me.Store, apperror.New(http.StatusForbidden, apperror.Forbidden, fmt.Errorf("user %d is missing permission %s", userID, permission)))
+			return
+		}
+
+		pass(userID, w, r)
+	}
+}
+
+//All returns every role and its permissions
+func (me roleHandler) All(userID uint, w http.ResponseWriter, r *http.Request) {
+	roles, err := me.Store.RoleStore().All()
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, roles)
+}
+
+//Mine returns the logged user roles and their permissions
+func (me roleHandler) Mine(userID uint, w http.ResponseWriter, r *http.Request) {
+	roles, err := me.Store.RoleStore().UserRoles(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, roles)
+}
+
+//Grant gives a role to a user
+func (me roleHandler) Grant(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	body, err := me.parseRequest(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	roles, err := me.Store.RoleStore().Grant(body.UserID, body.Role, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.respond(w, r, roles)
+}
+
+//Revoke removes a role from a user
+func (me roleHandler) Revoke(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	body, err := me.parseRequest(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	roles, err := me.Store.RoleStore().Revoke(body.UserID, body.Role, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.respond(w, r, roles)
+}
+
+func (me roleHandler) respond(w http.ResponseWriter, r *http.Request, v interface{}) {
+	json, err := json.Marshal(v)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//parseRequest reads the user and the role from the path on the resource routes, from the body otherwise
+func (me roleHandler) parseRequest(r *http.Request) (models.RoleBodyModel, error) {
+	userID, ok := pathID(r, "id")
+	if !ok {
+		return me.parseBody(r.Body)
+	}
+
+	obj := models.RoleBodyModel{UserID: userID, Role: router.Param(r, "role")}
+	if err := me.validate(obj); err != nil {
+		return models.RoleBodyModel{}, err
+	}
+
+	return obj, nil
+}
+
+func (me roleHandler) parseBody(body io.Reader) (models.RoleBodyModel, error) {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return models.RoleBodyModel{}, err
+	}
+
+	var obj models.RoleBodyModel
+	err = json.Unmarshal(b, &obj)
+
+	if err != nil {
+		return models.RoleBodyModel{}, err
+	}
+
+	if err := me.validate(obj); err != nil {
+		return models.RoleBodyModel{}, err
+	}
+
+	return obj, nil
+}
+
+func (me roleHandler) validate(obj models.RoleBodyModel) error {
+	if obj.UserID < 1 || models.RolePermissions[obj.Role] == nil {
+		return fmt.Errorf("invalid role %q for user %d", obj.Role, obj.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+type trashHandler struct {
+	Store stores.Stores
+}
+
+//Content returns the deleted pages and images of the logged member, they are purged once the retention period is over
+func (me trashHandler) Content(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	profileID, err := me.Store.UserStore().GetProfileID(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	trash, err := me.Store.TrashStore().Content(profileID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, trash)
+}
+
+//RestorePage takes a deleted page of the logged member out of the trash
+func (me trashHandler) RestorePage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	pageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	profileID, err := me.Store.UserStore().GetProfileID(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	page, err := me.Store.TrashStore().RestorePage(profileID, pageID, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("ETag", etag(page.Version))
+	me.respond(w, r, page)
+}
+
+//RestoreImage takes a deleted image of a page of the logged member out of the trash
+func (me trashHandler) RestoreImage(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	imageID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	pageID, err := parseID(router.Param(r, "pageID"))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	profileID, err := me.Store.UserStore().GetProfileID(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	image, err := me.Store.TrashStore().RestoreImage(profileID, pageID, imageID, newActor(userID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respond(w, r, image)
+}
+
+func (me trashHandler) respond(w http.ResponseWriter, r *http.Request, v interface{}) {
+	json, err := json.Marshal(v)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
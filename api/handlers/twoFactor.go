@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	log "github.com/sirupsen/logrus"
+)
+
+type twoFactorHandler struct {
+	Store stores.Stores
+}
+
+//Enroll generates a TOTP secret and returns the provisioning URI to render as a QR code
+func (me twoFactorHandler) Enroll(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	secret, uri, err := me.Store.TwoFactorStore().Enroll(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusBadRequest, "two_factor.could_not_enroll", err))
+		return
+	}
+
+	json, err := json.Marshal(struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}{Secret: secret, URI: uri})
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//Confirm enables 2FA once the user proves its authenticator works, returns the recovery codes
+func (me twoFactorHandler) Confirm(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	body, err := me.parseBody(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	codes, err := me.Store.TwoFactorStore().Confirm(userID, body.Code)
+	if err != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusBadRequest, "two_factor.invalid_code", err))
+		return
+	}
+
+	me.respondRecoveryCodes(w, r, codes)
+}
+
+//Verify is the second login step, it upgrades the partial session if the code is valid
+func (me twoFactorHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	session, err := me.Store.SessionStore().GetSession(r)
+	if err != nil || !session.Partial {
+		fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, apperror.Unauthorized, err))
+		return
+	}
+
+	if session.HasExpired() {
+		me.Store.SessionStore().Destroy(r, newActor(session.OwnerID, r))
+		fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "session_expired", err))
+		return
+	}
+
+	body, err := me.parseBody(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	user, err := me.Store.UserStore().GetByID(session.OwnerID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	//the codes are guessed like the passwords, they share the lockout of the login
+	ip := clientIP(r)
+	locked, err := me.Store.LoginAttemptStore().LockedFor(user.Email, ip)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if locked > 0 {
+		me.Store.SessionStore().Destroy(r, newActor(session.OwnerID, r))
+		respondLocked(w, r, me.Store, locked)
+		return
+	}
+
+	valid, err := me.Store.TwoFactorStore().Verify(session.OwnerID, body.Code)
+	if err != nil {
+		log.Error(err)
+	}
+
+	if !valid {
+		me.verifyFailed(w, r, user)
+		return
+	}
+
+	if err := me.Store.LoginAttemptStore().Succeed(user.Email); err != nil {
+		log.Error(err)
+	}
+
+	token, err := me.Store.SessionStore().Confirm(session, newActor(session.OwnerID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	cookie, err := me.Store.SessionStore().CreateCookie(token)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	http.SetCookie(w, cookie)
+
+	json, err := json.Marshal(models.LoginResponseModel{Token: token, Email: user.Email})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//verifyFailed records the failure, once the account or the ip gets locked the partial session is dropped and the
+//member must log in again with the password
+func (me twoFactorHandler) verifyFailed(w http.ResponseWriter, r *http.Request, user models.User) {
+	locked, err := me.Store.LoginAttemptStore().Fail(user.Email, user.ID, newActor(user.ID, r))
+	if err != nil {
+		log.Error(err)
+	}
+
+	if locked > 0 {
+		me.Store.SessionStore().Destroy(r, newActor(user.ID, r))
+		respondLocked(w, r, me.Store, locked)
+		return
+	}
+
+	fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "two_factor.invalid_code", err))
+}
+
+//RecoveryCodes replaces the user recovery codes, the password is required
+func (me twoFactorHandler) RecoveryCodes(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	if !me.checkPassword(userID, w, r) {
+		return
+	}
+
+	codes, err := me.Store.TwoFactorStore().RegenerateRecoveryCodes(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	me.respondRecoveryCodes(w, r, codes)
+}
+
+//Disable turns 2FA off, the password is required
+func (me twoFactorHandler) Disable(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	if !me.checkPassword(userID, w, r) {
+		return
+	}
+
+	result, err := me.Store.TwoFactorStore().Disable(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	json, err := json.Marshal(struct{ Result bool }{Result: result})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//checkPassword reads the body password and compares it to the stored hash, it writes the error response itself
+func (me twoFactorHandler) checkPassword(userID uint, w http.ResponseWriter, r *http.Request) bool {
+	body, err := me.parseBody(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return false
+	}
+
+	user, err := me.Store.UserStore().GetByID(userID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return false
+	}
+
+	if !comparePasswords(user.Password, []byte(body.Password)) {
+		fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "invalid_credentials", err))
+		return false
+	}
+
+	return true
+}
+
+func (me twoFactorHandler) respondRecoveryCodes(w http.ResponseWriter, r *http.Request, codes []string) {
+	json, err := json.Marshal(struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}{RecoveryCodes: codes})
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+func (me twoFactorHandler) parseBody(body io.Reader) (models.TwoFactorBodyModel, error) {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return models.TwoFactorBodyModel{}, err
+	}
+
+	var obj models.TwoFactorBodyModel
+	err = json.Unmarshal(b, &obj)
+
+	if err != nil {
+		return models.TwoFactorBodyModel{}, err
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
+	log "github.com/sirupsen/logrus"
+)
+
+//multipartOverhead is the room left to the multipart envelope of the file in the request size limit
+const multipartOverhead = 64 << 10
+
+type uploadHandler struct {
+	Store stores.Stores
+}
+
+//New receives the image in the file field of a multipart/form-data body, or opens a resumable upload from the
+//filename and size of a JSON body. Page images and avatars then reference the upload by its ID
+func (me uploadHandler) New(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var upload models.Upload
+	var err error
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		r.Body = http.MaxBytesReader(w, r.Body, me.Store.UploadStore().Limit()+multipartOverhead)
+		upload, err = me.receive(userID, r)
+	} else {
+		upload, err = me.start(userID, r.Body)
+	}
+
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.respond(w, r, http.StatusCreated, upload)
+}
+
+//Append writes the body at the Upload-Offset header of a resumable upload of the logged member, the offset must be
+//the size received so far. The answered Upload-Offset header is the size received once the body is written
+func (me uploadHandler) Append(userID uint, w http.ResponseWriter, r *http.Request) {
+	r.Close = true
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	uploadID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(fmt.Errorf("invalid Upload-Offset: %s", err), http.StatusBadRequest))
+		return
+	}
+
+	//one byte more than the limit lets the store tell the chunk is too large
+	r.Body = http.MaxBytesReader(w, r.Body, me.Store.UploadStore().Limit()+1)
+
+	upload, err := me.Store.UploadStore().Append(userID, uploadID, offset, r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	me.respond(w, r, http.StatusOK, upload)
+}
+
+//Get returns an upload of the logged member, the Upload-Offset header tells where a resumable upload resumes
+func (me uploadHandler) Get(userID uint, w http.ResponseWriter, r *http.Request) {
+	uploadID, err := resourceID(r)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	upload, err := me.Store.UploadStore().Get(userID, uploadID)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusNotFound))
+		return
+	}
+
+	me.respond(w, r, http.StatusOK, upload)
+}
+
+//receive streams the file field of the multipart body to the store, the fields before it are skipped
+func (me uploadHandler) receive(userID uint, r *http.Request) (models.Upload, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return models.Upload{}, apperror.Validation(validators.Field("file", "required", nil))
+		}
+
+		if err != nil {
+			return models.Upload{}, err
+		}
+
+		if part.FormName() == "file" {
+			return me.Store.UploadStore().Receive(userID, part.FileName(), part)
+		}
+
+		part.Close()
+	}
+}
+
+func (me uploadHandler) start(userID uint, body io.Reader) (models.Upload, error) {
+	var start models.UploadBodyModel
+	if err := json.NewDecoder(body).Decode(&start); err != nil {
+		return models.Upload{}, err
+	}
+
+	if err := validators.Struct(start); err != nil {
+		return models.Upload{}, err
+	}
+
+	return me.Store.UploadStore().Start(userID, start.Filename, start.Size)
+}
+
+func (me uploadHandler) respond(w http.ResponseWriter, r *http.Request, status int, upload models.Upload) {
+	json, err := json.Marshal(upload)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Received, 10))
+	w.WriteHeader(status)
+	fmt.Fprint(w, string(json))
+}
+
+//attachUploads sets the url and the variants of the images created from an upload of userID to the files of the
+//upload, the variants sent by the client are ignored. It returns the IDs of the uploads to consume once the images
+//are saved
+func attachUploads(store stores.Stores, userID uint, images []models.Image) ([]uint, error) {
+	uploadIDs := []uint{}
+	for i := range images {
+		images[i].Variants = models.ImageVariants{}
+		if images[i].UploadID == 0 {
+			continue
+		}
+
+		upload, err := store.UploadStore().Ready(userID, images[i].UploadID)
+		if err != nil {
+			return nil, err
+		}
+
+		images[i].URL, images[i].Variants, images[i].File, images[i].UploadID = upload.URL, upload.Variants, "", 0
+		uploadIDs = append(uploadIDs, upload.ID)
+	}
+
+	return uploadIDs, nil
+}
+
+//consumeUploads deletes the uploads of userID now referenced, a failure leaves them to expire
+func consumeUploads(store stores.Stores, userID uint, uploadIDs []uint) {
+	if len(uploadIDs) == 0 {
+		return
+	}
+
+	if err := store.UploadStore().Consume(userID, uploadIDs); err != nil {
+		log.Warnf("uploads %v of user %d: %s", uploadIDs, userID, err)
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
+	"time"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 
@@ -16,7 +20,7 @@ import (
 )
 
 type userHandler struct {
-	Store *stores.StoreFactory
+	Store stores.Stores
 }
 
 //All returns all the users
@@ -26,16 +30,14 @@ func (me userHandler) All(w http.ResponseWriter, r *http.Request) {
 
 	users, err := me.Store.UserStore().All(keys)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
 	json, err := json.Marshal(users)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusUnprocessableEntity)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusUnprocessableEntity))
 		return
 	}
 
@@ -46,22 +48,19 @@ func (me userHandler) All(w http.ResponseWriter, r *http.Request) {
 //Profile returns the connected user profile
 func (me userHandler) Profile(userID uint, w http.ResponseWriter, r *http.Request) {
 	profile, err := me.Store.UserStore().GetProfile(userID)
-	locale := r.Header.Get("Accept-Language")
-
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("could not get profile %s", "").Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.New(http.StatusInternalServerError, "user.could_not_get_profile", err))
 		return
 	}
 
 	json, err := json.Marshal(profile)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("user.could_not_get_profile", locale, nil)).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.New(http.StatusInternalServerError, "user.could_not_get_profile", err))
 		return
 	}
 
+	w.Header().Set("ETag", etag(profile.Version))
 	fmt.Fprint(w, string(json))
 
 }
@@ -69,31 +68,31 @@ func (me userHandler) Profile(userID uint, w http.ResponseWriter, r *http.Reques
 //SignUp create a user account
 func (me userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	r.Close = true
-	locale := r.Header.Get("Accept-Language")
-
 	if r.Body != nil {
 		defer r.Body.Close()
 	}
 
 	user, err := me.parseBody(r.Body)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_request", locale, nil)).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := validators.Struct(user); err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
 	user, err = me.Store.UserStore().New(user)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("user.could_not_create", locale, map[string]string{"Error": err.Error()})).Error(), http.StatusForbidden)
+		fail(w, r, me.Store, apperror.WrapAs(err, http.StatusForbidden, "user.could_not_create"))
 		return
 	}
 
 	json, err := json.Marshal(user)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("user.could_not_create", locale, map[string]string{"Error": err.Error()})).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -102,31 +101,31 @@ func (me userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 
 func (me userHandler) ChangePassword(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
-	locale := r.Header.Get("Accept-Language")
-
 	if r.Body != nil {
 		defer r.Body.Close()
 	}
 
 	user, err := me.parseBody(r.Body)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_request", locale, nil)).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := validators.Partial(user, "password"); err != nil {
+		fail(w, r, me.Store, err)
 		return
 	}
 
-	user, err = me.Store.UserStore().ChangePassword(userID, user)
+	user, err = me.Store.UserStore().ChangePassword(userID, user, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_request", locale, nil)).Error(), http.StatusBadRequest)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
 		return
 	}
 
 	json, err := json.Marshal(user)
 
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("internal_error", locale, nil)).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 	}
 
 	fmt.Fprint(w, string(json))
@@ -136,66 +135,132 @@ func (me userHandler) ChangePassword(userID uint, w http.ResponseWriter, r *http
 //Login authenticate the user
 func (me userHandler) Login(w http.ResponseWriter, r *http.Request) {
 	r.Close = true
-	locale := r.Header.Get("Accept-Language")
-
 	if r.Body != nil {
 		defer r.Body.Close()
 	}
 
 	user, err := me.parseBody(r.Body)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusBadRequest))
+		return
+	}
+
+	ip := clientIP(r)
+
+	locked, err := me.Store.LoginAttemptStore().LockedFor(user.Email, ip)
 	if err != nil {
 		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_request", locale, nil)).Error(), http.StatusBadRequest)
+	}
+
+	if locked > 0 {
+		respondLocked(w, r, me.Store, locked)
 		return
 	}
 
 	dbUser, err := me.Store.UserStore().GetByEmail(user.Email, false)
 	if err != nil {
 		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_credentials", locale, nil)).Error(), http.StatusUnauthorized)
+		me.loginFailed(w, r, user.Email, 0)
 		return
 	}
 
-	if r := comparePasswords(dbUser.Password, []byte(user.Password)); !r {
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_credentials", locale, nil)).Error(), http.StatusUnauthorized)
+	if ok := comparePasswords(dbUser.Password, []byte(user.Password)); !ok {
+		me.loginFailed(w, r, user.Email, dbUser.ID)
 		return
 	}
 
-	isLogged, err := me.Store.SessionStore().CreateOrRetrieve(dbUser.ID)
-	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("internal_error", locale, nil)).Error(), http.StatusInternalServerError)
+	if dbUser.SuspendedAt != nil {
+		fail(w, r, me.Store, apperror.New(http.StatusForbidden, "user.suspended", err))
 		return
 	}
 
-	if !isLogged {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("invalid_credentials", locale, nil)).Error(), http.StatusUnauthorized)
+	if models.NeedsRehash(dbUser.Password) {
+		if err := me.Store.UserStore().RehashPassword(dbUser.ID, user.Password); err != nil {
+			log.Error(err)
+		}
+	}
+
+	//the failures are cleared once the second factor is verified too, logging in again does not reset its guesses
+	if dbUser.TwoFactorEnabled {
+		me.loginPartial(dbUser, w, r)
 		return
 	}
 
-	cookie, err := me.Store.SessionStore().CreateCookie()
+	if err := me.Store.LoginAttemptStore().Succeed(user.Email); err != nil {
+		log.Error(err)
+	}
 
+	token, err := me.Store.SessionStore().CreateOrRetrieve(dbUser.ID, newActor(dbUser.ID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("internal_error", locale, nil)).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
-	http.SetCookie(w, cookie)
+	cookie, err := me.Store.SessionStore().CreateCookie(token)
 
-	type res struct {
-		Token string `json:"token"`
-		Email string `json:"email"`
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
-	responseBody := res{Token: me.Store.SessionStore().GetToken(), Email: dbUser.Email}
+	http.SetCookie(w, cookie)
+
+	responseBody := models.LoginResponseModel{Token: token, Email: dbUser.Email}
 
 	json, err := json.Marshal(responseBody)
 
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+	}
+
+	fmt.Fprint(w, string(json))
+}
+
+//loginFailed records the failure and answers with the lock if the account or the ip just got locked
+func (me userHandler) loginFailed(w http.ResponseWriter, r *http.Request, email string, userID uint) {
+	locked, err := me.Store.LoginAttemptStore().Fail(email, userID, newActor(0, r))
 	if err != nil {
 		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("internal_error", locale, nil)).Error(), http.StatusInternalServerError)
+	}
+
+	if locked > 0 {
+		respondLocked(w, r, me.Store, locked)
+		return
+	}
+
+	fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "invalid_credentials", err))
+}
+
+//respondLocked answers a locked account or ip with the time left before the next attempt
+func respondLocked(w http.ResponseWriter, r *http.Request, store stores.Stores, locked time.Duration) {
+	seconds := int(math.Ceil(locked.Seconds()))
+	minutes := int(math.Ceil(locked.Minutes()))
+
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	fail(w, r, store, apperror.New(http.StatusTooManyRequests, "login.locked", nil).WithVars(map[string]string{"Minutes": strconv.Itoa(minutes)}))
+}
+
+//loginPartial opens a partial session for users having 2FA enabled, the client must then
+//post the TOTP or a recovery code to /login/verify
+func (me userHandler) loginPartial(dbUser models.User, w http.ResponseWriter, r *http.Request) {
+	token, err := me.Store.SessionStore().CreatePartial(dbUser.ID, newActor(dbUser.ID, r))
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	cookie, err := me.Store.SessionStore().CreateCookie(token)
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
+	}
+
+	http.SetCookie(w, cookie)
+
+	json, err := json.Marshal(models.LoginResponseModel{Token: token, Email: dbUser.Email, TwoFactorRequired: true})
+	if err != nil {
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
+		return
 	}
 
 	fmt.Fprint(w, string(json))
@@ -204,30 +269,25 @@ func (me userHandler) Login(w http.ResponseWriter, r *http.Request) {
 //IsLogged is a middleware used to know if user is Logged
 func (me userHandler) IsLogged(pass func(userID uint, w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		locale := r.Header.Get("Accept-Language")
-
+		//a missing session is not a missing resource
 		session, err := me.Store.SessionStore().GetSession(r)
 		if err != nil {
-			log.Error(err)
-			http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("please_login", locale, nil)).Error(), http.StatusUnauthorized)
+			fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, apperror.Unauthorized, err))
 			return
 		}
 
-		if session.HasExpired() {
-			if ok, err := me.Store.SessionStore().Destroy(r); !ok && err != nil {
-				log.Error(err)
-				http.Error(w, fmt.Errorf("internal error %s", "").Error(), http.StatusInternalServerError)
-				return
-			}
+		if session.Partial {
+			fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "two_factor.required", err))
+			return
+		}
 
-			if err != nil {
-				log.Error(err)
-				http.Error(w, fmt.Errorf("internal error %s", "").Error(), http.StatusInternalServerError)
+		if session.HasExpired() {
+			if _, err := me.Store.SessionStore().Destroy(r, newActor(session.OwnerID, r)); err != nil {
+				fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 				return
 			}
 
-			log.Error(err)
-			http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("session_expired", locale, nil)).Error(), http.StatusUnauthorized)
+			fail(w, r, me.Store, apperror.New(http.StatusUnauthorized, "session_expired", err))
 			return
 		}
 
@@ -236,13 +296,11 @@ func (me userHandler) IsLogged(pass func(userID uint, w http.ResponseWriter, r *
 }
 
 //Logout log out the user
-func (me userHandler) Logout(_ uint, w http.ResponseWriter, r *http.Request) {
+func (me userHandler) Logout(userID uint, w http.ResponseWriter, r *http.Request) {
 	r.Close = true
-	locale := r.Header.Get("Accept-Language")
-	success, err := me.Store.SessionStore().Destroy(r)
+	success, err := me.Store.SessionStore().Destroy(r, newActor(userID, r))
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf(me.Store.Localizer().Translate("internal_error", locale, nil)).Error(), http.StatusInternalServerError)
+		fail(w, r, me.Store, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 	fmt.Fprint(w, `{ "Result" : `+strconv.FormatBool(success)+` }`)
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores/memory"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+)
+
+//newTestHandlers returns the handlers on empty in-memory stores
+func newTestHandlers(t *testing.T) (*HandlerFactory, *memory.Stores) {
+	validators.Init()
+	l := localizer.NewLocalizer([]string{"../../localizer/en.json"})
+	s := memory.New(l)
+	return NewHandlerFactory(s, l, nil), s
+}
+
+//signUp creates an account and returns the session cookie of its login
+func signUp(t *testing.T, h *HandlerFactory, email string) *http.Cookie {
+	body := `{"email":"` + email + `","password":"password"}`
+
+	w := httptest.NewRecorder()
+	h.UserHandler().SignUp(w, httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("SignUp() status = %d, body = %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	h.UserHandler().Login(w, httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Login() status = %d, body = %s", w.Code, w.Body)
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) == 0 {
+		t.Fatal("Login() did not set the session cookie")
+	}
+
+	return cookies[0]
+}
+
+func TestUserHandler_SignUp(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"created", `{"email":"new@couchsport.test","password":"password"}`, http.StatusOK},
+		{"duplicate", `{"email":"taken@couchsport.test","password":"password"}`, http.StatusForbidden},
+		{"invalid json", `{"email":`, http.StatusBadRequest},
+	}
+
+	h, _ := newTestHandlers(t)
+	signUp(t, h, "taken@couchsport.test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.UserHandler().SignUp(w, httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Errorf("SignUp() status = %d, want %d, body = %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     int
+	}{
+		{"valid", "password", http.StatusOK},
+		{"wrong password", "wrong", http.StatusUnauthorized},
+	}
+
+	h, _ := newTestHandlers(t)
+	signUp(t, h, "login@couchsport.test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"email":"login@couchsport.test","password":"` + tt.password + `"}`
+
+			w := httptest.NewRecorder()
+			h.UserHandler().Login(w, httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body)))
+			if w.Code != tt.want {
+				t.Fatalf("Login() status = %d, want %d, body = %s", w.Code, tt.want, w.Body)
+			}
+
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var res models.LoginResponseModel
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.Token == "" {
+				t.Errorf("Login() body = %s, want a token", w.Body)
+			}
+		})
+	}
+}
+
+func TestUserHandler_IsLogged(t *testing.T) {
+	h, _ := newTestHandlers(t)
+	cookie := signUp(t, h, "logged@couchsport.test")
+
+	var got uint
+	handler := h.UserHandler().IsLogged(func(userID uint, w http.ResponseWriter, r *http.Request) { got = userID })
+
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+		want   int
+	}{
+		{"no cookie", nil, http.StatusUnauthorized},
+		{"unknown session", &http.Cookie{Name: cookie.Name, Value: "unknown"}, http.StatusUnauthorized},
+		{"logged", cookie, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = 0
+			r := httptest.NewRequest(http.MethodGet, "/profiles/mine", nil)
+			if tt.cookie != nil {
+				r.AddCookie(tt.cookie)
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, r)
+			if w.Code != tt.want {
+				t.Errorf("IsLogged() status = %d, want %d", w.Code, tt.want)
+			}
+
+			if (got != 0) != (tt.want == http.StatusOK) {
+				t.Errorf("IsLogged() passed userID %d", got)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	h, _ := newTestHandlers(t)
+	cookie := signUp(t, h, "logout@couchsport.test")
+
+	request := func(handler http.HandlerFunc) int {
+		r := httptest.NewRequest(http.MethodGet, "/logout", nil)
+		r.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w.Code
+	}
+
+	if code := request(h.UserHandler().IsLogged(h.UserHandler().Logout)); code != http.StatusOK {
+		t.Fatalf("Logout() status = %d", code)
+	}
+
+	if code := request(h.UserHandler().IsLogged(h.UserHandler().Logout)); code != http.StatusUnauthorized {
+		t.Errorf("session still valid after Logout(), status = %d", code)
+	}
+}
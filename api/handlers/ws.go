@@ -1,10 +1,9 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
-	"strconv"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
@@ -13,7 +12,7 @@ import (
 //wsHandler ...
 type wsHandler struct {
 	WsUpgrader *websocket.Upgrader
-	Stores     *stores.StoreFactory
+	Stores     stores.Stores
 }
 
 //Emit message
@@ -29,20 +28,11 @@ type wsHandler struct {
 // 	}
 // }
 
-//EntryPoint Ws handler
-//params id is the profileID
-func (me *wsHandler) EntryPoint(w http.ResponseWriter, r *http.Request) {
-	stringID := r.URL.Query().Get("id")
-	if stringID == "" {
-		log.Error("ws: invalid id")
-		http.Error(w, fmt.Errorf("ws: invalid id %s", stringID).Error(), http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(stringID)
+//EntryPoint Ws handler, the connection receives the mutations of the profile of the logged user
+func (me *wsHandler) EntryPoint(userID uint, w http.ResponseWriter, r *http.Request) {
+	profileID, err := me.Stores.UserStore().GetProfileID(userID)
 	if err != nil {
-		log.Error(err)
-		http.Error(w, fmt.Errorf("%s", err).Error(), http.StatusBadRequest)
+		fail(w, r, me.Stores, apperror.Wrap(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -52,7 +42,7 @@ func (me *wsHandler) EntryPoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	me.Stores.WsStore().Register(uint(id), conn)
+	me.Stores.WsStore().Register(profileID, conn)
 }
 
 // func (me *wsHandler) echo(conn *websocket.Conn, mt int, message []byte) {
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+//the few statements gorm Migrator does not abstract, by dialect name
+const (
+	dialectMySQL    = "mysql"
+	dialectPostgres = "postgres"
+	dialectSQLite   = "sqlite"
+)
+
+//alterableConstraints tells whether foreign keys can be added to an existing table,
+//SQLite only knows the ones declared when the table is created from the models
+func alterableConstraints(tx *gorm.DB) bool {
+	return tx.Dialector.Name() != dialectSQLite
+}
+
+//existingForeignKeys returns the names of the foreign keys set on table.column
+func existingForeignKeys(tx *gorm.DB, table, column string) ([]string, error) {
+	schema := "DATABASE()"
+	if tx.Dialector.Name() == dialectPostgres {
+		schema = "CURRENT_SCHEMA()"
+	}
+
+	var names []string
+	err := tx.Raw(fmt.Sprintf(`SELECT tc.constraint_name FROM information_schema.table_constraints tc
+		INNER JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = %s AND tc.table_name = ? AND kcu.column_name = ?`, schema),
+		table, column).Scan(&names).Error
+	return names, err
+}
+
+func dropForeignKey(tx *gorm.DB, table, name string) error {
+	if tx.Dialector.Name() == dialectMySQL {
+		return tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", table, name)).Error
+	}
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", table, name)).Error
+}
+
+//withoutForeignKeyChecks lets fn drop tables referencing each other,
+//the PostgreSQL and SQLite migrators already take care of it
+func withoutForeignKeyChecks(tx *gorm.DB, fn func() error) error {
+	if tx.Dialector.Name() != dialectMySQL {
+		return fn()
+	}
+
+	if err := tx.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+		return err
+	}
+
+	err := fn()
+
+	if e := tx.Exec("SET FOREIGN_KEY_CHECKS = 1").Error; err == nil {
+		err = e
+	}
+
+	return err
+}
@@ -0,0 +1,264 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//Migration is one versioned schema change, Down must revert exactly what Up did
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+//SchemaMigration is the row recorded in schema_migrations once a migration is applied
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+//TableName is the table holding the applied migrations
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+//Status tells whether a migration has been applied and when
+type Status struct {
+	Version   uint       `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+//Migrator applies and reverts Migrations, they must be sorted by Version
+type Migrator struct {
+	Db         *gorm.DB
+	Migrations []Migration
+}
+
+//New returns a Migrator holding every migration of the application
+func New(db *gorm.DB) *Migrator {
+	return &Migrator{Db: db, Migrations: all}
+}
+
+//Up applies every pending migration and returns them
+func (me Migrator) Up() ([]Migration, error) {
+	return me.To(me.Latest())
+}
+
+//Down reverts the last applied migration, it returns false if there was none
+func (me Migrator) Down() (Migration, bool, error) {
+	current, err := me.Version()
+	if err != nil {
+		return Migration{}, false, err
+	}
+
+	if current == 0 {
+		return Migration{}, false, nil
+	}
+
+	previous := uint(0)
+	for _, m := range me.Migrations {
+		if m.Version < current {
+			previous = m.Version
+		}
+	}
+
+	reverted, err := me.To(previous)
+	if err != nil || len(reverted) == 0 {
+		return Migration{}, false, err
+	}
+
+	return reverted[0], true, nil
+}
+
+//To migrates the schema up or down to version and returns the migrations applied or reverted, in execution order
+func (me Migrator) To(version uint) ([]Migration, error) {
+	if err := me.validate(); err != nil {
+		return nil, err
+	}
+
+	if version != 0 && me.find(version) == nil {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	applied, err := me.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+
+	//up: pending migrations up to version, oldest first
+	for _, m := range me.Migrations {
+		if m.Version > version {
+			break
+		}
+
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		if err := me.run(m, true); err != nil {
+			return done, err
+		}
+		done = append(done, m)
+	}
+
+	//down: applied migrations above version, newest first
+	for i := len(me.Migrations) - 1; i >= 0; i-- {
+		m := me.Migrations[i]
+		if m.Version <= version {
+			break
+		}
+
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+
+		if err := me.run(m, false); err != nil {
+			return done, err
+		}
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+//Status lists every known migration along with its application date
+func (me Migrator) Status() ([]Status, error) {
+	applied, err := me.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, 0, len(me.Migrations))
+	for _, m := range me.Migrations {
+		s := Status{Version: m.Version, Name: m.Name}
+		if row, ok := applied[m.Version]; ok {
+			at := row.AppliedAt
+			s.AppliedAt = &at
+		}
+		status = append(status, s)
+	}
+
+	return status, nil
+}
+
+//Pending returns the migrations not applied yet
+func (me Migrator) Pending() ([]Migration, error) {
+	applied, err := me.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range me.Migrations {
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+//Version returns the highest applied version, 0 on an empty database
+func (me Migrator) Version() (uint, error) {
+	applied, err := me.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+
+	return version, nil
+}
+
+//Latest returns the highest known version
+func (me Migrator) Latest() uint {
+	if len(me.Migrations) == 0 {
+		return 0
+	}
+	return me.Migrations[len(me.Migrations)-1].Version
+}
+
+func (me Migrator) run(m Migration, up bool) error {
+	direction, fn := "up", m.Up
+	if !up {
+		direction, fn = "down", m.Down
+	}
+
+	log.Printf("migration %d %s: %s", m.Version, m.Name, direction)
+
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		if !up {
+			return tx.Where("version = ?", m.Version).Delete(&SchemaMigration{}).Error
+		}
+
+		return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("migration %d %s %s: %s", m.Version, m.Name, direction, err)
+	}
+
+	return nil
+}
+
+func (me Migrator) applied() (map[uint]SchemaMigration, error) {
+	if err := me.Db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := me.Db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]SchemaMigration, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r
+	}
+
+	return applied, nil
+}
+
+func (me Migrator) find(version uint) *Migration {
+	for i := range me.Migrations {
+		if me.Migrations[i].Version == version {
+			return &me.Migrations[i]
+		}
+	}
+	return nil
+}
+
+//validate ensures versions are strictly increasing and every migration can be reverted
+func (me Migrator) validate() error {
+	var previous uint
+	for _, m := range me.Migrations {
+		if m.Version <= previous {
+			return fmt.Errorf("migration %d %s is out of order", m.Version, m.Name)
+		}
+
+		if m.Up == nil || m.Down == nil {
+			return fmt.Errorf("migration %d %s must define Up and Down", m.Version, m.Name)
+		}
+
+		previous = m.Version
+	}
+	return nil
+}
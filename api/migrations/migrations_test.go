@@ -0,0 +1,193 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func noop(tx *gorm.DB) error { return nil }
+
+func TestMigrator_validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    bool
+	}{
+		{name: "application migrations", migrations: all, wantErr: false},
+		{
+			name: "sorted",
+			migrations: []Migration{
+				{Version: 1, Name: "one", Up: noop, Down: noop},
+				{Version: 3, Name: "three", Up: noop, Down: noop},
+			},
+			wantErr: false,
+		},
+		{
+			name: "out of order",
+			migrations: []Migration{
+				{Version: 2, Name: "two", Up: noop, Down: noop},
+				{Version: 1, Name: "one", Up: noop, Down: noop},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicated version",
+			migrations: []Migration{
+				{Version: 1, Name: "one", Up: noop, Down: noop},
+				{Version: 1, Name: "again", Up: noop, Down: noop},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "missing down",
+			migrations: []Migration{{Version: 1, Name: "one", Up: noop}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			me := Migrator{Migrations: tt.migrations}
+			if err := me.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Migrator.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newTestDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared&_foreign_keys=1"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestMigrator_sqlite(t *testing.T) {
+	me := New(newTestDb(t))
+
+	applied, err := me.Up()
+	if err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+	if len(applied) != len(all) {
+		t.Errorf("Migrator.Up() applied %d migrations, want %d", len(applied), len(all))
+	}
+
+	if applied, err := me.Up(); err != nil || len(applied) != 0 {
+		t.Errorf("Migrator.Up() twice = %d, %v, want nothing to do", len(applied), err)
+	}
+
+	if !me.Db.Migrator().HasIndex("page_activities", "uix_page_activities") {
+		t.Errorf("join table unique index is missing")
+	}
+
+	reverted, ok, err := me.Down()
+	if err != nil || !ok || reverted.Version != me.Latest() {
+		t.Errorf("Migrator.Down() = %d, %v, %v", reverted.Version, ok, err)
+	}
+
+	done, err := me.To(1)
+	if err != nil {
+		t.Fatalf("Migrator.To(1) error = %v", err)
+	}
+	if len(done) != len(all)-2 {
+		t.Errorf("Migrator.To(1) reverted %d migrations, want %d", len(done), len(all)-2)
+	}
+
+	if version, err := me.Version(); err != nil || version != 1 {
+		t.Errorf("Migrator.Version() = %d, %v, want 1", version, err)
+	}
+
+	if _, err := me.To(0); err != nil {
+		t.Fatalf("Migrator.To(0) error = %v", err)
+	}
+	if me.Db.Migrator().HasTable(&models.User{}) {
+		t.Errorf("users table still exists after reverting every migration")
+	}
+
+	status, err := me.Status()
+	if err != nil {
+		t.Fatalf("Migrator.Status() error = %v", err)
+	}
+	for _, s := range status {
+		if s.AppliedAt != nil {
+			t.Errorf("migration %d still applied", s.Version)
+		}
+	}
+
+	if _, err := me.Up(); err != nil {
+		t.Errorf("Migrator.Up() after a full revert error = %v", err)
+	}
+}
+
+func TestMigrator_tables(t *testing.T) {
+	db := newTestDb(t)
+	if _, err := New(db).Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+
+	//the frozen tables must still hold every column of the models, a new column needs its own migration
+	for _, m := range []interface{}{
+		&models.AuditEvent{}, &models.Profile{}, &models.Permission{}, &models.Role{}, &models.User{},
+		&models.RecoveryCode{}, &models.Session{}, &models.LoginAttempt{}, &models.Language{}, &models.Activity{},
+		&models.Page{}, &models.Message{}, &models.Conversation{}, &models.Image{}, &models.DataExport{},
+		&models.Fixture{}, &models.Upload{},
+	} {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			t.Fatal(err)
+		}
+		for _, column := range stmt.Schema.DBNames {
+			if !db.Migrator().HasColumn(m, column) {
+				t.Errorf("column %s.%s is not created by any migration", stmt.Schema.Table, column)
+			}
+		}
+		for _, rel := range stmt.Schema.Relationships.Many2Many {
+			if !db.Migrator().HasTable(rel.JoinTable.Table) {
+				t.Errorf("join table %s is not created by any migration", rel.JoinTable.Table)
+			}
+		}
+	}
+}
+
+func TestMigrator_joinTablesDuplicates(t *testing.T) {
+	me := New(newTestDb(t))
+	if _, err := me.To(1); err != nil {
+		t.Fatalf("Migrator.To(1) error = %v", err)
+	}
+
+	//the join tables created before the initial schema had no primary key
+	for _, query := range []string{
+		"DROP TABLE page_activities",
+		"CREATE TABLE page_activities (page_id integer, activity_id integer)",
+		"INSERT INTO profiles (id) VALUES (1)",
+		"INSERT INTO pages (id, owner_id) VALUES (1, 1)",
+		"INSERT INTO activities (id, name) VALUES (1, 'surf'), (2, 'climbing')",
+		"INSERT INTO page_activities (page_id, activity_id) VALUES (1, 1), (1, 1), (1, 2), (1, 1)",
+	} {
+		if err := me.Db.Exec(query).Error; err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	if _, err := me.To(2); err != nil {
+		t.Fatalf("Migrator.To(2) error = %v", err)
+	}
+
+	var links []struct{ PageID, ActivityID uint }
+	if err := me.Db.Raw("SELECT page_id, activity_id FROM page_activities ORDER BY activity_id").Scan(&links).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 2 || links[0].ActivityID != 1 || links[1].ActivityID != 2 {
+		t.Errorf("page_activities = %v, want each link once", links)
+	}
+	if me.Db.Migrator().HasTable("page_activities_distinct") {
+		t.Errorf("page_activities_distinct is left behind")
+	}
+}
@@ -0,0 +1,410 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+//all holds the application migrations, append new ones with the next version, never edit an applied one
+var all = []Migration{
+	{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(initialSchema...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return withoutForeignKeyChecks(tx, func() error {
+				return tx.Migrator().DropTable(initialSchema...)
+			})
+		},
+	},
+	{
+		Version: 2,
+		Name:    "join_tables_keys",
+		Up: func(tx *gorm.DB) error {
+			for _, j := range joinTables {
+				if err := j.up(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, j := range joinTables {
+				if err := j.down(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 3,
+		Name:    "foreign_keys",
+		Up: func(tx *gorm.DB) error {
+			for _, fk := range foreignKeys {
+				if err := fk.replace(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, fk := range foreignKeys {
+				if err := fk.drop(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 4,
+		Name:    "language_codes_and_fixtures",
+		Up: func(tx *gorm.DB) error {
+			//the databases created before the initial schema was frozen already have the column
+			if !tx.Migrator().HasColumn(&languageV4{}, "Code") {
+				if err := tx.Migrator().AddColumn(&languageV4{}, "Code"); err != nil {
+					return err
+				}
+			}
+
+			if !tx.Migrator().HasIndex(&languageV4{}, "Code") {
+				if err := tx.Migrator().CreateIndex(&languageV4{}, "Code"); err != nil {
+					return err
+				}
+			}
+
+			return tx.AutoMigrate(&fixtureV4{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&fixtureV4{}); err != nil {
+				return err
+			}
+
+			if tx.Migrator().HasIndex(&languageV4{}, "Code") {
+				if err := tx.Migrator().DropIndex(&languageV4{}, "Code"); err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropColumn(&languageV4{}, "Code")
+		},
+	},
+	{
+		Version: 5,
+		Name:    "page_and_profile_versions",
+		Up: func(tx *gorm.DB) error {
+			for _, m := range versionedModels {
+				if tx.Migrator().HasColumn(m, "Version") {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(m, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, m := range versionedModels {
+				if err := tx.Migrator().DropColumn(m, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 6,
+		Name:    "profile_privacy",
+		Up: func(tx *gorm.DB) error {
+			for _, f := range privacyFieldsV6 {
+				if tx.Migrator().HasColumn(&profileV6{}, f) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&profileV6{}, f); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, f := range privacyFieldsV6 {
+				if err := tx.Migrator().DropColumn(&profileV6{}, f); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 7,
+		Name:    "page_lifecycle",
+		Up: func(tx *gorm.DB) error {
+			for _, f := range pageLifecycleFieldsV7 {
+				if tx.Migrator().HasColumn(&pageV7{}, f) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&pageV7{}, f); err != nil {
+					return err
+				}
+			}
+
+			if !tx.Migrator().HasIndex(&pageV7{}, "Status") {
+				if err := tx.Migrator().CreateIndex(&pageV7{}, "Status"); err != nil {
+					return err
+				}
+			}
+
+			//the pages were published by their owner alone until now, the public ones stay published
+			return tx.Exec("UPDATE pages SET status = CASE WHEN public THEN ? ELSE ? END", "published", "unpublished").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Migrator().HasIndex(&pageV7{}, "Status") {
+				if err := tx.Migrator().DropIndex(&pageV7{}, "Status"); err != nil {
+					return err
+				}
+			}
+
+			for _, f := range pageLifecycleFieldsV7 {
+				if err := tx.Migrator().DropColumn(&pageV7{}, f); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 8,
+		Name:    "uploads",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&uploadV8{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&uploadV8{})
+		},
+	},
+	{
+		Version: 9,
+		Name:    "image_variants",
+		Up: func(tx *gorm.DB) error {
+			for _, m := range variantModels {
+				for _, c := range variantFieldsV9 {
+					if tx.Migrator().HasColumn(m, c) {
+						continue
+					}
+					if err := tx.Migrator().AddColumn(m, c); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, m := range variantModels {
+				for _, c := range variantFieldsV9 {
+					if err := tx.Migrator().DropColumn(m, c); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 10,
+		Name:    "two_factor_last_step",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&userV10{}, "TwoFactorLastStep") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&userV10{}, "TwoFactorLastStep")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&userV10{}, "TwoFactorLastStep")
+		},
+	},
+}
+
+//variantModels are the tables carrying the URLs of the image variants
+var variantModels = []interface{}{&imageV9{}, &uploadV9{}}
+
+//versionedModels are the tables updated with optimistic concurrency
+var versionedModels = []interface{}{&pageV5{}, &profileV5{}}
+
+//joinTable is a many2many table, each row links Left to Right only once
+type joinTable struct {
+	Table       string
+	Left, Right foreignKey
+}
+
+var joinTables = []joinTable{
+	{
+		Table: "page_activities",
+		Left:  foreignKey{Table: "page_activities", Column: "page_id", References: "pages", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "page_activities", Column: "activity_id", References: "activities", OnDelete: "CASCADE"},
+	},
+	{
+		Table: "profile_activities",
+		Left:  foreignKey{Table: "profile_activities", Column: "profile_id", References: "profiles", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "profile_activities", Column: "activity_id", References: "activities", OnDelete: "CASCADE"},
+	},
+	{
+		Table: "profile_languages",
+		Left:  foreignKey{Table: "profile_languages", Column: "profile_id", References: "profiles", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "profile_languages", Column: "language_id", References: "languages", OnDelete: "CASCADE"},
+	},
+	{
+		Table: "user_page_follower",
+		Left:  foreignKey{Table: "user_page_follower", Column: "user_id", References: "users", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "user_page_follower", Column: "page_id", References: "pages", OnDelete: "CASCADE"},
+	},
+	{
+		Table: "user_roles",
+		Left:  foreignKey{Table: "user_roles", Column: "user_id", References: "users", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "user_roles", Column: "role_id", References: "roles", OnDelete: "CASCADE"},
+	},
+	{
+		Table: "role_permissions",
+		Left:  foreignKey{Table: "role_permissions", Column: "role_id", References: "roles", OnDelete: "CASCADE"},
+		Right: foreignKey{Table: "role_permissions", Column: "permission_id", References: "permissions", OnDelete: "CASCADE"},
+	},
+}
+
+func (me joinTable) uniqueIndex() string {
+	return "uix_" + me.Table
+}
+
+//up drops the rows pointing to deleted records and the repeated links, then adds the unique index and the foreign keys
+func (me joinTable) up(tx *gorm.DB) error {
+	for _, fk := range []foreignKey{me.Left, me.Right} {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s NOT IN (SELECT id FROM %s)", me.Table, fk.Column, fk.References)).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := me.deduplicate(tx); err != nil {
+		return err
+	}
+
+	if !tx.Migrator().HasIndex(me.Table, me.uniqueIndex()) {
+		if err := tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s, %s)", me.uniqueIndex(), me.Table, me.Left.Column, me.Right.Column)).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := me.Left.replace(tx); err != nil {
+		return err
+	}
+
+	return me.Right.replace(tx)
+}
+
+//deduplicate keeps one row of each link, the tables created without a primary key may hold the same link several times
+func (me joinTable) deduplicate(tx *gorm.DB) error {
+	var duplicated int64
+	if err := tx.Raw(fmt.Sprintf("SELECT COUNT(*) FROM (SELECT %[2]s, %[3]s FROM %[1]s GROUP BY %[2]s, %[3]s HAVING COUNT(*) > 1) duplicated",
+		me.Table, me.Left.Column, me.Right.Column)).Scan(&duplicated).Error; err != nil {
+		return err
+	}
+
+	if duplicated == 0 {
+		return nil
+	}
+
+	for _, query := range []string{
+		"CREATE TABLE %[4]s AS SELECT DISTINCT %[2]s, %[3]s FROM %[1]s",
+		"DELETE FROM %[1]s",
+		"INSERT INTO %[1]s (%[2]s, %[3]s) SELECT %[2]s, %[3]s FROM %[4]s",
+		"DROP TABLE %[4]s",
+	} {
+		if err := tx.Exec(fmt.Sprintf(query, me.Table, me.Left.Column, me.Right.Column, me.Table+"_distinct")).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (me joinTable) down(tx *gorm.DB) error {
+	if err := me.Left.drop(tx); err != nil {
+		return err
+	}
+
+	if err := me.Right.drop(tx); err != nil {
+		return err
+	}
+
+	if !tx.Migrator().HasIndex(me.Table, me.uniqueIndex()) {
+		return nil
+	}
+
+	return tx.Migrator().DropIndex(me.Table, me.uniqueIndex())
+}
+
+//foreignKey is a constraint on Table.Column referencing References.id
+type foreignKey struct {
+	Table, Column, References string
+	OnDelete                  string
+}
+
+//foreignKeys replace the constraints AutoMigrate derives from the models, they were commented out in the stores
+var foreignKeys = []foreignKey{
+	{Table: "users", Column: "profile_id", References: "profiles", OnDelete: "CASCADE"},
+	{Table: "sessions", Column: "owner_id", References: "users", OnDelete: "CASCADE"},
+	{Table: "recovery_codes", Column: "owner_id", References: "users", OnDelete: "CASCADE"},
+	{Table: "data_exports", Column: "owner_id", References: "users", OnDelete: "CASCADE"},
+	{Table: "pages", Column: "owner_id", References: "profiles", OnDelete: "RESTRICT"},
+	{Table: "images", Column: "owner_id", References: "pages", OnDelete: "CASCADE"},
+	{Table: "conversations", Column: "from_id", References: "profiles", OnDelete: "CASCADE"},
+	{Table: "conversations", Column: "to_id", References: "profiles", OnDelete: "CASCADE"},
+	{Table: "messages", Column: "conversation_id", References: "conversations", OnDelete: "CASCADE"},
+	{Table: "messages", Column: "from_id", References: "profiles", OnDelete: "CASCADE"},
+	{Table: "messages", Column: "to_id", References: "profiles", OnDelete: "CASCADE"},
+}
+
+func (me foreignKey) name() string {
+	return "fk_" + me.Table + "_" + me.Column
+}
+
+//replace drops any constraint already set on the column, whatever its name, and creates the named one
+func (me foreignKey) replace(tx *gorm.DB) error {
+	if !alterableConstraints(tx) {
+		return nil
+	}
+
+	existing, err := existingForeignKeys(tx, me.Table, me.Column)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range existing {
+		if err := dropForeignKey(tx, me.Table, name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id) ON DELETE %s ON UPDATE CASCADE",
+		me.Table, me.name(), me.Column, me.References, me.OnDelete)).Error
+}
+
+func (me foreignKey) drop(tx *gorm.DB) error {
+	if !alterableConstraints(tx) {
+		return nil
+	}
+
+	existing, err := existingForeignKeys(tx, me.Table, me.Column)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range existing {
+		if name == me.name() {
+			return dropForeignKey(tx, me.Table, name)
+		}
+	}
+
+	return nil
+}
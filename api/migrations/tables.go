@@ -0,0 +1,367 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//The tables and columns below are frozen as the migrations created them, the models keep changing but an applied
+//migration must create the same schema forever. Each type is named after the version of the migration owning it
+
+// initialSchema are the tables of the initial schema, AutoMigrate orders them by dependency
+var initialSchema = []interface{}{
+	&auditEventV1{},
+	&profileV1{},
+	&permissionV1{},
+	&roleV1{},
+	&userV1{},
+	&recoveryCodeV1{},
+	&sessionV1{},
+	&loginAttemptV1{},
+	&languageV1{},
+	&activityV1{},
+	&pageV1{},
+	&conversationV1{},
+	&messageV1{},
+	&imageV1{},
+	&dataExportV1{},
+	&pageActivityV1{},
+	&profileActivityV1{},
+	&profileLanguageV1{},
+	&userPageFollowerV1{},
+	&userRoleV1{},
+	&rolePermissionV1{},
+}
+
+type auditEventV1 struct {
+	ID         uint      `gorm:"primarykey"`
+	CreatedAt  time.Time `gorm:"index"`
+	ActorID    uint      `gorm:"index"`
+	Action     string    `gorm:"type:varchar(100);index"`
+	TargetType string    `gorm:"type:varchar(50);index:idx_audit_target"`
+	TargetID   uint      `gorm:"index:idx_audit_target"`
+	IP         string    `gorm:"type:varchar(45)"`
+	Metadata   string    `gorm:"type:text"`
+}
+
+func (auditEventV1) TableName() string { return "audit_events" }
+
+type profileV1 struct {
+	gorm.Model
+	Username     string `gorm:"type:varchar(50);"`
+	Country      string `gorm:"type:varchar(50);"`
+	Firstname    string `gorm:"type:varchar(50);"`
+	Lastname     string `gorm:"type:varchar(50);"`
+	Email        string
+	StreetNumber uint
+	StreetName   string
+	City         string `gorm:"type:varchar(50);"`
+	Gender       string
+	Phone        string
+	ZipCode      string
+	Avatar       string
+}
+
+func (profileV1) TableName() string { return "profiles" }
+
+type permissionV1 struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"type:varchar(50);uniqueIndex"`
+}
+
+func (permissionV1) TableName() string { return "permissions" }
+
+type roleV1 struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"type:varchar(50);uniqueIndex"`
+}
+
+func (roleV1) TableName() string { return "roles" }
+
+type userV1 struct {
+	gorm.Model
+	Email               string
+	Password            string
+	Profile             profileV1 `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE"`
+	ProfileID           uint
+	Type                string
+	TwoFactorEnabled    bool `gorm:"default:false"`
+	TwoFactorSecret     string
+	SuspendedAt         *time.Time
+	DeletionScheduledAt *time.Time `gorm:"index"`
+}
+
+func (userV1) TableName() string { return "users" }
+
+type recoveryCodeV1 struct {
+	ID      uint   `gorm:"primarykey"`
+	Owner   userV1 `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	OwnerID uint   `gorm:"index"`
+	Hash    string
+	UsedAt  *time.Time
+}
+
+func (recoveryCodeV1) TableName() string { return "recovery_codes" }
+
+type sessionV1 struct {
+	Owner     userV1 `gorm:"foreignKey:OwnerID"`
+	OwnerID   uint
+	SessionID string
+	Expires   time.Time
+	Validity  uint
+	Partial   bool `gorm:"default:false"`
+}
+
+func (sessionV1) TableName() string { return "sessions" }
+
+type loginAttemptV1 struct {
+	Key         string `gorm:"primaryKey;column:attempt_key;type:varchar(191)"`
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+func (loginAttemptV1) TableName() string { return "login_attempts" }
+
+type languageV1 struct {
+	ID         uint `gorm:"primarykey"`
+	Name       string
+	NativeName string
+}
+
+func (languageV1) TableName() string { return "languages" }
+
+type activityV1 struct {
+	ID   uint `gorm:"primarykey"`
+	Name string
+}
+
+func (activityV1) TableName() string { return "activities" }
+
+type pageV1 struct {
+	gorm.Model
+	Name            string
+	Description     string
+	LongDescription string `gorm:"size:512;"`
+	Lat             float64
+	Lng             float64
+	CouchNumber     *int
+	Images          []imageV1 `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE"`
+	Owner           profileV1 `gorm:"foreignKey:OwnerID"`
+	OwnerID         uint
+	Public          bool `gorm:"default:false"`
+}
+
+func (pageV1) TableName() string { return "pages" }
+
+type conversationV1 struct {
+	gorm.Model
+	From         profileV1 `gorm:"foreignKey:FromID"`
+	FromID       uint
+	To           profileV1 `gorm:"foreignKey:ToID"`
+	ToID         uint
+	ReportedAt   *time.Time
+	ReportedByID uint
+	ReportReason string `gorm:"size:512;"`
+}
+
+func (conversationV1) TableName() string { return "conversations" }
+
+type messageV1 struct {
+	ID             uint `gorm:"primarykey"`
+	Email          string
+	Date           time.Time
+	Text           string
+	From           profileV1 `gorm:"foreignKey:FromID"`
+	FromID         uint
+	To             profileV1 `gorm:"foreignKey:ToID"`
+	ToID           uint
+	Conversation   conversationV1 `gorm:"foreignKey:ConversationID;constraint:OnDelete:CASCADE"`
+	ConversationID uint
+}
+
+func (messageV1) TableName() string { return "messages" }
+
+type imageV1 struct {
+	gorm.Model
+	URL     string
+	Alt     string
+	OwnerID uint
+}
+
+func (imageV1) TableName() string { return "images" }
+
+type dataExportV1 struct {
+	gorm.Model
+	Owner     userV1 `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	OwnerID   uint   `gorm:"index"`
+	Status    string `gorm:"type:varchar(20)"`
+	File      string
+	Size      int64
+	ExpiresAt time.Time
+}
+
+func (dataExportV1) TableName() string { return "data_exports" }
+
+//the join tables of the many2many relations, their rows are unique from join_tables_keys on
+
+type pageActivityV1 struct {
+	Page       pageV1     `gorm:"foreignKey:PageID"`
+	PageID     uint       `gorm:"primaryKey"`
+	Activity   activityV1 `gorm:"foreignKey:ActivityID"`
+	ActivityID uint       `gorm:"primaryKey"`
+}
+
+func (pageActivityV1) TableName() string { return "page_activities" }
+
+type profileActivityV1 struct {
+	Profile    profileV1  `gorm:"foreignKey:ProfileID"`
+	ProfileID  uint       `gorm:"primaryKey"`
+	Activity   activityV1 `gorm:"foreignKey:ActivityID"`
+	ActivityID uint       `gorm:"primaryKey"`
+}
+
+func (profileActivityV1) TableName() string { return "profile_activities" }
+
+type profileLanguageV1 struct {
+	Profile    profileV1  `gorm:"foreignKey:ProfileID"`
+	ProfileID  uint       `gorm:"primaryKey"`
+	Language   languageV1 `gorm:"foreignKey:LanguageID"`
+	LanguageID uint       `gorm:"primaryKey"`
+}
+
+func (profileLanguageV1) TableName() string { return "profile_languages" }
+
+type userPageFollowerV1 struct {
+	User   userV1 `gorm:"foreignKey:UserID"`
+	UserID uint   `gorm:"primaryKey"`
+	Page   pageV1 `gorm:"foreignKey:PageID"`
+	PageID uint   `gorm:"primaryKey"`
+}
+
+func (userPageFollowerV1) TableName() string { return "user_page_follower" }
+
+type userRoleV1 struct {
+	User   userV1 `gorm:"foreignKey:UserID"`
+	UserID uint   `gorm:"primaryKey"`
+	Role   roleV1 `gorm:"foreignKey:RoleID"`
+	RoleID uint   `gorm:"primaryKey"`
+}
+
+func (userRoleV1) TableName() string { return "user_roles" }
+
+type rolePermissionV1 struct {
+	Role         roleV1       `gorm:"foreignKey:RoleID"`
+	RoleID       uint         `gorm:"primaryKey"`
+	Permission   permissionV1 `gorm:"foreignKey:PermissionID"`
+	PermissionID uint         `gorm:"primaryKey"`
+}
+
+func (rolePermissionV1) TableName() string { return "role_permissions" }
+
+// languageV4 is the code column added by language_codes_and_fixtures
+type languageV4 struct {
+	ID   uint   `gorm:"primarykey"`
+	Code string `gorm:"size:3;index"`
+}
+
+func (languageV4) TableName() string { return "languages" }
+
+// fixtureV4 is the table of the loaded fixtures, created by language_codes_and_fixtures
+type fixtureV4 struct {
+	Name     string `gorm:"primaryKey;size:191"`
+	Version  uint
+	LoadedAt time.Time
+}
+
+func (fixtureV4) TableName() string { return "fixtures" }
+
+// pageV5 and profileV5 are the version columns added by page_and_profile_versions
+type pageV5 struct {
+	ID      uint `gorm:"primaryKey"`
+	Version uint `gorm:"not null;default:1"`
+}
+
+func (pageV5) TableName() string { return "pages" }
+
+type profileV5 struct {
+	ID      uint `gorm:"primaryKey"`
+	Version uint `gorm:"not null;default:1"`
+}
+
+func (profileV5) TableName() string { return "profiles" }
+
+// profileV6 is the visibility columns added by profile_privacy
+type profileV6 struct {
+	ID                     uint   `gorm:"primaryKey"`
+	EmailVisibility        string `gorm:"size:16;default:private"`
+	PhoneVisibility        string `gorm:"size:16;default:stay"`
+	StreetNameVisibility   string `gorm:"size:16;default:stay"`
+	StreetNumberVisibility string `gorm:"size:16;default:stay"`
+	ZipCodeVisibility      string `gorm:"size:16;default:members"`
+}
+
+func (profileV6) TableName() string { return "profiles" }
+
+var privacyFieldsV6 = []string{"EmailVisibility", "PhoneVisibility", "StreetNameVisibility", "StreetNumberVisibility", "ZipCodeVisibility"}
+
+// pageV7 is the lifecycle columns added by page_lifecycle
+type pageV7 struct {
+	ID              uint   `gorm:"primaryKey"`
+	Status          string `gorm:"size:16;not null;default:draft;index"`
+	PublishAt       *time.Time
+	UnpublishAt     *time.Time
+	RejectionReason string `gorm:"size:512;"`
+}
+
+func (pageV7) TableName() string { return "pages" }
+
+var pageLifecycleFieldsV7 = []string{"Status", "PublishAt", "UnpublishAt", "RejectionReason"}
+
+// uploadV8 is the table of the uploads, created by uploads
+type uploadV8 struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Owner     userV1 `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	OwnerID   uint   `gorm:"index"`
+	Filename  string `gorm:"size:255"`
+	URL       string `gorm:"size:255"`
+	Mime      string `gorm:"size:32"`
+	Size      int64
+	Received  int64
+	Complete  bool
+	ExpiresAt time.Time
+}
+
+func (uploadV8) TableName() string { return "uploads" }
+
+// imageV9 and uploadV9 are the variant columns added by image_variants
+type imageV9 struct {
+	ID           uint   `gorm:"primaryKey"`
+	ThumbnailURL string `gorm:"size:255"`
+	MediumURL    string `gorm:"size:255"`
+	LargeURL     string `gorm:"size:255"`
+}
+
+func (imageV9) TableName() string { return "images" }
+
+type uploadV9 struct {
+	ID           uint   `gorm:"primaryKey"`
+	ThumbnailURL string `gorm:"size:255"`
+	MediumURL    string `gorm:"size:255"`
+	LargeURL     string `gorm:"size:255"`
+}
+
+func (uploadV9) TableName() string { return "uploads" }
+
+var variantFieldsV9 = []string{"ThumbnailURL", "MediumURL", "LargeURL"}
+
+// userV10 is the last accepted TOTP step added by two_factor_last_step
+type userV10 struct {
+	ID                uint  `gorm:"primaryKey"`
+	TwoFactorLastStep int64 `gorm:"not null;default:0"`
+}
+
+func (userV10) TableName() string { return "users" }
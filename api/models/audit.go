@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//Actor identifies who performs an audited action, UserID is 0 for anonymous requests
+type Actor struct {
+	UserID uint
+	IP     string
+}
+
+//AuditEvent model definition, one row per security relevant or moderation action
+type AuditEvent struct {
+	ID         uint          `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time     `gorm:"index" json:"created_at"`
+	ActorID    uint          `gorm:"index" json:"actor_id"`
+	Action     string        `gorm:"type:varchar(100);index" json:"action"`
+	TargetType string        `gorm:"type:varchar(50);index:idx_audit_target" json:"target_type"`
+	TargetID   uint          `gorm:"index:idx_audit_target" json:"target_id"`
+	IP         string        `gorm:"type:varchar(45)" json:"ip"`
+	Metadata   AuditMetadata `gorm:"type:text" json:"metadata"`
+}
+
+//AuditMetadata is a JSON document stored as text and rendered as is
+type AuditMetadata string
+
+//NewAuditMetadata encodes metadata, nil or empty metadata gives an empty document
+func NewAuditMetadata(metadata map[string]interface{}) AuditMetadata {
+	if len(metadata) == 0 {
+		return ""
+	}
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return ""
+	}
+
+	return AuditMetadata(b)
+}
+
+//MarshalJSON renders the stored document instead of a quoted string
+func (m AuditMetadata) MarshalJSON() ([]byte, error) {
+	if m == "" {
+		return []byte("null"), nil
+	}
+	return []byte(m), nil
+}
+
+//AuditFilter restricts the audit events returned by auditStore, zero values are ignored
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	TargetType string
+	TargetID   uint
+	From, To   time.Time
+}
@@ -2,7 +2,7 @@ package models
 
 import (
 	"encoding/json"
-	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +16,10 @@ type Conversation struct {
 	ToID     uint      `gorm:"association_autoupdate:false;;association_autosave:false;save_associations:false;association_save_reference:false" json:"to_id"`
 	Messages []Message `gorm:"foreignkey:ConversationID;constraint:OnDelete:CASCADE" json:"messages"`
 	New      bool      `gorm:"-" json:"new"`
+	//ReportedAt is set when one of the members reports the conversation, admins can only read reported ones
+	ReportedAt   *time.Time `json:"reported_at"`
+	ReportedByID uint       `json:"reported_by_id"`
+	ReportReason string     `gorm:"size:512;" json:"report_reason"`
 }
 
 //AfterCreate empty the password column for security reasons, sets New to true and update Type to ADMIN if ID = 1
@@ -24,25 +28,6 @@ func (Conversation *Conversation) AfterCreate(tx *gorm.DB) error {
 	return nil
 }
 
-//Validate model
-func (me *Conversation) Validate(db *gorm.DB) {
-	if me.FromID < 1 {
-		db.AddError(errors.New("invalid FromID"))
-	}
-
-	if me.ToID < 1 {
-		db.AddError(errors.New("invalid ToID"))
-	}
-
-	if me.ToID == me.FromID {
-		db.AddError(errors.New("invalid Conversation"))
-	}
-
-	if len(me.Messages) < 1 && me.ID < 1 && !me.New {
-		db.AddError(errors.New("invalid Messages"))
-	}
-}
-
 //AddMessage to the expression Messages
 func (me *Conversation) AddMessage(fromID, toID uint, text string) Message {
 	m := Message{Text: text, Conversation: *me, FromID: fromID, ToID: toID}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+//DataExport statuses
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+//DataExport model definition, a ZIP archive of everything a member stored on the platform
+type DataExport struct {
+	Base
+	Owner     User      `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+	OwnerID   uint      `gorm:"index" json:"owner_id"`
+	Status    string    `gorm:"type:varchar(20)" json:"status"`
+	File      string    `json:"-"`
+	Size      int64     `json:"size"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+//HasExpired tells whether the archive can still be downloaded
+func (export *DataExport) HasExpired() bool {
+	return export.ExpiresAt.Before(time.Now())
+}
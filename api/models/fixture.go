@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+//Fixture records the version of each fixture file loaded in the db
+type Fixture struct {
+	Name     string    `gorm:"primaryKey;size:191" json:"name"`
+	Version  uint      `json:"version"`
+	LoadedAt time.Time `json:"loaded_at"`
+}
+
+//LanguagesFixture is the content of the reference languages file
+type LanguagesFixture struct {
+	Version   uint       `json:"version"`
+	Languages []Language `json:"languages"`
+}
+
+//ActivitiesFixture is the content of the reference activities file
+type ActivitiesFixture struct {
+	Version    uint       `json:"version"`
+	Activities []Activity `json:"activities"`
+}
+
+//DemoFixture is the content of the demo users file
+type DemoFixture struct {
+	Version uint       `json:"version"`
+	Users   []DemoUser `json:"users"`
+}
+
+//DemoUser is a demo account along with its profile and pages,
+//languages are matched by code, activities by name and image urls are relative to the fixture file
+type DemoUser struct {
+	Email    string   `json:"email"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"`
+	Profile  Profile  `json:"profile"`
+	Pages    []Page   `json:"pages"`
+}
@@ -1,55 +1,48 @@
 package models
 
-import (
-	"errors"
+import "strconv"
 
-	"gorm.io/gorm"
-)
+//maxURLLength is the size of the url column, the data of the uploaded images is only limited by the request size
+const maxURLLength = 255
 
 //Image model definition
 type Image struct {
 	Base
-	URL     string `valid:"requri,required" json:"url"`
-	Alt     string `valid:"text" json:"alt"`
+	URL     string `valid:"imageuri,required" json:"url"`
+	Alt     string `valid:"text,stringlength(1|255)" json:"alt"`
 	File    string `gorm:"-" json:"file"`
 	OwnerID uint   `json:"owner_id"`
+	//UploadID is a complete upload of the member the image is created from, the handlers set URL to its file
+	UploadID uint `gorm:"-" json:"upload_id,omitempty"`
+	//Variants are the resized copies of the uploaded images, empty for the links to other sites
+	Variants ImageVariants `gorm:"embedded" valid:"-" json:"variants"`
 }
 
-//IsValid tells is Image is valid
-func (image *Image) IsValid() bool {
-	if image.URL == "" {
-		return false
-	}
-
-	if len(image.URL) > 255 && image.File == "" {
-		return false
-	}
-
-	if len(image.URL) > 255 && image.ID > 0 {
-		return false
-	}
-
-	if len(image.Alt) > 255 {
-		return false
-	}
-
-	return true
+//ImageVariants are the URLs of the resized copies of an image, each fits in a square of its size
+type ImageVariants struct {
+	ThumbnailURL string `gorm:"size:255" json:"thumbnail_url"`
+	MediumURL    string `gorm:"size:255" json:"medium_url"`
+	LargeURL     string `gorm:"size:255" json:"large_url"`
 }
 
-//Validate tells is Image is valid
-func (image *Image) Validate(db *gorm.DB) {
-	if image.URL == "" {
-		db.AddError(errors.New("URL is empty"))
-		return
-	}
+//VariantColumns are the columns of ImageVariants
+var VariantColumns = []string{"thumbnail_url", "medium_url", "large_url"}
 
-	if len(image.URL) > 255 {
-		db.AddError(errors.New("invalid URL"))
-		return
+//URLs returns the URLs of the variants which exist
+func (variants ImageVariants) URLs() []string {
+	urls := []string{}
+	for _, u := range []string{variants.ThumbnailURL, variants.MediumURL, variants.LargeURL} {
+		if u != "" {
+			urls = append(urls, u)
+		}
 	}
+	return urls
+}
 
-	if len(image.Alt) > 255 {
-		db.AddError(errors.New("invalid Alt"))
-		return
+//Validate checks the length of the url of the images which are not uploaded
+func (image *Image) Validate() []InvalidField {
+	if image.File == "" && len(image.URL) > maxURLLength {
+		return []InvalidField{{Field: "url", Code: "stringlength", Vars: map[string]string{"Min": "1", "Max": strconv.Itoa(maxURLLength)}}}
 	}
+	return nil
 }
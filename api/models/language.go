@@ -1,8 +1,9 @@
 package models
 
-//Language model definition
+//Language model definition, Code is the ISO 639-1 code
 type Language struct {
 	ID         uint       `gorm:"primarykey" json:"id"`
+	Code       string     `gorm:"size:3;index" json:"code"`
 	Name       string     `gorm:"unique_index;" json:"name"`
 	NativeName string     `gorm:"unique_index;" json:"native_name"`
 	Profiles   []*Profile `gorm:"many2many:profile_languages;" json:"-"`
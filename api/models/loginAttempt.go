@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+//LoginAttempt model definition, tracks failed logins per key (account email or client IP)
+type LoginAttempt struct {
+	Key         string    `gorm:"primaryKey;column:attempt_key;type:varchar(191)" json:"key"`
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+//LockedFor returns how long the key stays locked from now
+func (attempt *LoginAttempt) LockedFor(now time.Time) time.Duration {
+	if attempt.LockedUntil.After(now) {
+		return attempt.LockedUntil.Sub(now)
+	}
+	return 0
+}
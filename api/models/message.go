@@ -1,11 +1,8 @@
 package models
 
 import (
-	"errors"
-	"fmt"
 	"time"
 
-	"github.com/asaskevich/govalidator"
 	"gorm.io/gorm"
 )
 
@@ -13,8 +10,8 @@ import (
 type Message struct {
 	ID             uint         `gorm:"primarykey" json:"id"`
 	Email          string       `valid:"email" json:"email"`
-	Date           time.Time    `sql:"DEFAULT:NOW()" json:"date"`
-	Text           string       `valid:"text,required" json:"text"`
+	Date           time.Time    `json:"date"`
+	Text           string       `valid:"longtext,required" json:"text"`
 	From           Profile      `gorm:"foreignkey:FromID" json:"from"`
 	FromID         uint         `gorm:"required" json:"from_id"`
 	To             Profile      `gorm:"foreignkey:ToID" json:"to"`
@@ -29,40 +26,17 @@ func (message *Message) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-//Validate model
-func (m *Message) Validate(db *gorm.DB) {
-	if m.Text == "" {
-		db.AddError(errors.New("Text is empty"))
-		return
-	}
-
-	if len(m.Text) > 255 {
-		db.AddError(errors.New("invalid Text"))
-		return
-	}
-
-	if m.ConversationID < 1 {
-		db.AddError(errors.New("invalid Conversation"))
-		return
-	}
-
-	if m.FromID < 1 {
-		db.AddError(errors.New("invalid FromID"))
-		return
-	}
-}
-
 //SendMessageBodyModel model definition (model used when decoding body for in conversationHandler.HandleMessage)
 type SendMessageBodyModel struct {
 	Email string `valid:"email,required" json:"email"`
-	Text  string `valid:"text,required" json:"text"`
+	Text  string `valid:"longtext,required" json:"text"`
 	ToID  uint   `valid:"numeric" json:"to_id"`
 }
 
-//Validate use govalidators to check expression values
-func (me SendMessageBodyModel) Validate() (bool, error) {
+//Validate requires the recipient, the other fields are checked by their tags
+func (me SendMessageBodyModel) Validate() []InvalidField {
 	if me.ToID < 1 {
-		return false, fmt.Errorf("invalid ToID: %v", me.ToID)
+		return []InvalidField{{Field: "to_id", Code: "required"}}
 	}
-	return govalidator.ValidateStruct(me)
+	return nil
 }
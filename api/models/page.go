@@ -1,7 +1,7 @@
 package models
 
 import (
-	"errors"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,21 +10,101 @@ import (
 //Page model definition
 type Page struct {
 	Base
-	Name            string      `valid:"text" json:"name"`
-	Description     string      `valid:"text" json:"description"`
-	LongDescription string      `gorm:"size:512;" valid:"text" json:"long_description"`
-	Images          []Image     `gorm:"foreignKey:OwnerID;references:ID;constraint:OnUpdate:CASCADE" json:"images"`
-	Lat             float64     `valid:"latitude" json:"lat"`
-	Lng             float64     `valid:"longitude" json:"lng"`
-	CouchNumber     *int        `valid:"numeric" json:"couch_number"`
-	Followers       []*User     `gorm:"many2many:user_page_follower" json:"followers"`
-	Owner           Profile     `gorm:"foreignKey:OwnerID;association_autoupdate:false;association_autocreate:false" json:"owner"`
-	OwnerID         uint        `json:"owner_id"`
-	Public          bool        `gorm:"default:1" json:"public"`
+	//Version is incremented by every update, it is the ETag of the page
+	Version         uint    `gorm:"not null;default:1" valid:"-" json:"version"`
+	Name            string  `valid:"text,required,stringlength(1|255)" json:"name"`
+	Description     string  `valid:"text,required,stringlength(1|255)" json:"description"`
+	LongDescription string  `gorm:"size:512;" valid:"longtext,stringlength(1|512)" json:"long_description"`
+	Images          []Image `gorm:"foreignKey:OwnerID;references:ID;constraint:OnUpdate:CASCADE" json:"images"`
+	Lat             float64 `valid:"latitude" json:"lat"`
+	Lng             float64 `valid:"longitude" json:"lng"`
+	CouchNumber     *int    `valid:"numeric" json:"couch_number"`
+	Followers       []*User `valid:"-" gorm:"many2many:user_page_follower" json:"followers"`
+	Owner           Profile `valid:"-" gorm:"foreignKey:OwnerID;association_autoupdate:false;association_autocreate:false" json:"owner"`
+	OwnerID         uint    `json:"owner_id"`
+	//Public is set while the page is published, see Status
+	Public bool `gorm:"default:false" valid:"-" json:"public"`
+	//Status is the step of the page in its lifecycle, it only changes through the transitions
+	Status string `gorm:"size:16;not null;default:draft;index" valid:"-" json:"status"`
+	//PublishAt publishes an approved page at that time, UnpublishAt unpublishes a published one
+	PublishAt       *time.Time  `json:"publish_at"`
+	UnpublishAt     *time.Time  `json:"unpublish_at"`
+	RejectionReason string      `gorm:"size:512;" valid:"-" json:"rejection_reason"`
 	Activities      []*Activity `gorm:"many2many:page_activities;association_autoupdate:false;association_autocreate:false" json:"activities"`
 	New             bool        `gorm:"-" json:"new"`
 }
 
+//Statuses of a page. A draft is submitted for review, the moderators approve it, it is published at once or
+//scheduled until its PublishAt, or reject it back to draft with a reason
+const (
+	PageDraft       = "draft"
+	PagePending     = "pending"
+	PageScheduled   = "scheduled"
+	PagePublished   = "published"
+	PageUnpublished = "unpublished"
+	PageArchived    = "archived"
+)
+
+//pageOwnerTransitions are the statuses the owner can move its page to from each status
+var pageOwnerTransitions = map[string][]string{
+	PageDraft:       {PagePending, PageArchived},
+	PagePending:     {PageDraft, PageArchived},
+	PageScheduled:   {PageUnpublished, PageArchived},
+	PagePublished:   {PageUnpublished, PageArchived},
+	PageUnpublished: {PagePending, PageArchived},
+	PageArchived:    {PageDraft},
+}
+
+//OwnerSources returns the statuses from which the owner can move a page to status
+func OwnerSources(status string) []string {
+	var from []string
+	for s, to := range pageOwnerTransitions {
+		for _, t := range to {
+			if t == status {
+				from = append(from, s)
+			}
+		}
+	}
+	sort.Strings(from)
+	return from
+}
+
+//NeedsReview tells whether updating the fields of existing to page changes the content the moderators approved,
+//a published or scheduled page is then reviewed again
+func (page Page) NeedsReview(existing Page, fields []string) bool {
+	if existing.Status != PagePublished && existing.Status != PageScheduled {
+		return false
+	}
+
+	for _, f := range fields {
+		switch {
+		case f == "Name" && page.Name != existing.Name,
+			f == "Description" && page.Description != existing.Description,
+			f == "LongDescription" && page.LongDescription != existing.LongDescription,
+			f == "Images" && imagesChanged(existing.Images, page.Images):
+			return true
+		}
+	}
+
+	return false
+}
+
+//imagesChanged tells whether images adds an image to existing or changes the alt of one of them
+func imagesChanged(existing, images []Image) bool {
+	alts := map[uint]string{}
+	for _, i := range existing {
+		alts[i.ID] = i.Alt
+	}
+
+	for _, i := range images {
+		if alt, ok := alts[i.ID]; !ok || alt != i.Alt {
+			return true
+		}
+	}
+
+	return false
+}
+
 //BeforeCreate is a gorm hook
 func (page *Page) BeforeCreate(tx *gorm.DB) error {
 	page.CreatedAt = time.Now()
@@ -37,25 +117,20 @@ func (page *Page) AfterCreate(tx *gorm.DB) error {
 	return nil
 }
 
-//Validate  tells wheter the page is valid
-func (page *Page) Validate(db *gorm.DB) {
-	if !page.New && page.ID < 1 {
-		db.AddError(errors.New("invalid PageID"))
-		return
-	}
-
-	if page.Name == "" {
-		db.AddError(errors.New("Name is empty"))
-		return
+//Validate requires the unpublishing to come after the publishing
+func (page Page) Validate() []InvalidField {
+	if page.PublishAt != nil && page.UnpublishAt != nil && !page.UnpublishAt.After(*page.PublishAt) {
+		return []InvalidField{{Field: "unpublish_at", Code: "after", Vars: map[string]string{"Field": "publish_at"}}}
 	}
+	return nil
+}
 
-	if page.Description == "" {
-		db.AddError(errors.New("Description is empty"))
-		return
-	}
+//PageStatusBodyModel is the body moving a page to Status
+type PageStatusBodyModel struct {
+	Status string `valid:"required,in(draft|pending|unpublished|archived)" json:"status"`
+}
 
-	if len(page.LongDescription) > 512 {
-		db.AddError(errors.New("invalid LongDescription"))
-		return
-	}
+//PageRejectionBodyModel is the body of a moderator rejecting a page
+type PageRejectionBodyModel struct {
+	Reason string `valid:"longtext,required,stringlength(1|512)" json:"reason"`
 }
@@ -0,0 +1,195 @@
+package models
+
+//Visibilities of the contact fields of a profile, from the widest to the narrowest
+const (
+	VisibilityPublic  = "public"
+	VisibilityMembers = "members"
+	VisibilityStay    = "stay"
+	VisibilityPrivate = "private"
+)
+
+//Relation of a viewer to the owner of a profile, each relation sees what the previous ones see
+type Relation int
+
+const (
+	//RelationVisitor is an anonymous visitor
+	RelationVisitor Relation = iota
+	//RelationMember is a logged member
+	RelationMember
+	//RelationGuest is a member who had an accepted stay with the owner. Stays are not recorded yet, no member
+	//is a guest and the fields visible after a stay are only shown to their owner
+	RelationGuest
+	//RelationSelf is the owner
+	RelationSelf
+)
+
+//Privacy holds the visibility of the contact fields of a profile, the member chooses it
+type Privacy struct {
+	EmailVisibility        string `gorm:"size:16;default:private" valid:"in(public|members|stay|private)" json:"email"`
+	PhoneVisibility        string `gorm:"size:16;default:private" valid:"in(public|members|stay|private)" json:"phone"`
+	StreetNameVisibility   string `gorm:"size:16;default:private" valid:"in(public|members|stay|private)" json:"street_name"`
+	StreetNumberVisibility string `gorm:"size:16;default:private" valid:"in(public|members|stay|private)" json:"street_number"`
+	ZipCodeVisibility      string `gorm:"size:16;default:members" valid:"in(public|members|stay|private)" json:"zip_code"`
+}
+
+//DefaultPrivacy is the privacy the new profiles are created with, the columns of the first profiles defaulted
+//to stay
+var DefaultPrivacy = Privacy{
+	EmailVisibility:        VisibilityPrivate,
+	PhoneVisibility:        VisibilityPrivate,
+	StreetNameVisibility:   VisibilityPrivate,
+	StreetNumberVisibility: VisibilityPrivate,
+	ZipCodeVisibility:      VisibilityMembers,
+}
+
+//PrivacyFields are the Go names of the Privacy columns of a profile
+var PrivacyFields = []string{"EmailVisibility", "PhoneVisibility", "StreetNameVisibility", "StreetNumberVisibility", "ZipCodeVisibility"}
+
+//Allows tells whether a viewer in relation sees a field of visibility, an unknown visibility is private
+func Allows(visibility string, relation Relation) bool {
+	switch visibility {
+	case VisibilityPublic:
+		return true
+	case VisibilityMembers:
+		return relation >= RelationMember
+	case VisibilityStay:
+		return relation >= RelationGuest
+	}
+	return relation == RelationSelf
+}
+
+//PublicProfile is a profile as seen by another member or a visitor, the contact fields are only set
+//when their visibility allows the viewer to see them
+type PublicProfile struct {
+	ID           uint        `json:"id"`
+	Username     string      `json:"username"`
+	Firstname    string      `json:"firstname"`
+	Lastname     string      `json:"lastname"`
+	Country      string      `json:"country"`
+	City         string      `json:"city"`
+	Gender       string      `json:"gender"`
+	Avatar       string      `json:"avatar"`
+	Email        string      `json:"email,omitempty"`
+	Phone        string      `json:"phone,omitempty"`
+	StreetName   string      `json:"street_name,omitempty"`
+	StreetNumber uint        `json:"street_number,omitempty"`
+	ZipCode      string      `json:"zip_code,omitempty"`
+	Activities   []*Activity `json:"activities"`
+	Languages    []*Language `json:"languages"`
+}
+
+//View returns the profile as seen by a viewer in relation
+func (me Profile) View(relation Relation) PublicProfile {
+	v := PublicProfile{
+		ID:         me.ID,
+		Username:   me.Username,
+		Firstname:  me.Firstname,
+		Lastname:   me.Lastname,
+		Country:    me.Country,
+		City:       me.City,
+		Gender:     me.Gender,
+		Avatar:     me.Avatar,
+		Activities: me.Activities,
+		Languages:  me.Languages,
+	}
+
+	if Allows(me.Privacy.EmailVisibility, relation) {
+		v.Email = me.Email
+	}
+	if Allows(me.Privacy.PhoneVisibility, relation) {
+		v.Phone = me.Phone
+	}
+	if Allows(me.Privacy.StreetNameVisibility, relation) {
+		v.StreetName = me.StreetName
+	}
+	if Allows(me.Privacy.StreetNumberVisibility, relation) {
+		v.StreetNumber = me.StreetNumber
+	}
+	if Allows(me.Privacy.ZipCodeVisibility, relation) {
+		v.ZipCode = me.ZipCode
+	}
+
+	return v
+}
+
+//Relations returns the relation of a viewer to the owner of a profile by its ID
+type Relations func(profileID uint) Relation
+
+//PageView is a page whose owner and followers are seen through their privacy
+type PageView struct {
+	Page
+	Owner     PublicProfile   `json:"owner"`
+	Followers []PublicProfile `json:"followers"`
+}
+
+//View returns the page as seen by a viewer
+func (me Page) View(relations Relations) PageView {
+	v := PageView{Page: me, Owner: me.Owner.View(relations(me.Owner.ID))}
+	for _, f := range me.Followers {
+		v.Followers = append(v.Followers, f.Profile.View(relations(f.Profile.ID)))
+	}
+	return v
+}
+
+//ConversationView is a conversation whose members are seen through their privacy
+type ConversationView struct {
+	Conversation
+	From     PublicProfile `json:"from"`
+	To       PublicProfile `json:"to"`
+	Messages []MessageView `json:"messages"`
+}
+
+//View returns the conversation as seen by a viewer, its messages do not repeat the conversation
+func (me Conversation) View(relations Relations) ConversationView {
+	v := ConversationView{
+		Conversation: me,
+		From:         me.From.View(relations(me.From.ID)),
+		To:           me.To.View(relations(me.To.ID)),
+		Messages:     []MessageView{},
+	}
+
+	for _, m := range me.Messages {
+		mv := m.View(relations)
+		mv.Conversation = nil
+		v.Messages = append(v.Messages, mv)
+	}
+
+	return v
+}
+
+//MessageView is a message whose members are seen through their privacy
+type MessageView struct {
+	Message
+	From         PublicProfile     `json:"from"`
+	To           PublicProfile     `json:"to"`
+	Conversation *ConversationView `json:"conversation,omitempty"`
+}
+
+//View returns the message as seen by a viewer, the email it was sent with is the one of its sender and follows
+//the email visibility of the sender profile
+func (me Message) View(relations Relations) MessageView {
+	v := MessageView{
+		Message: me,
+		From:    me.From.View(relations(me.From.ID)),
+		To:      me.To.View(relations(me.To.ID)),
+	}
+
+	if !Allows(me.From.Privacy.EmailVisibility, relations(me.From.ID)) {
+		v.Email = ""
+	}
+
+	if me.Conversation.ID != 0 {
+		c := me.Conversation
+		c.Messages = nil
+		cv := c.View(relations)
+		v.Conversation = &cv
+	}
+
+	return v
+}
+
+//MemberView is the public profile of a member with its published pages
+type MemberView struct {
+	PublicProfile
+	Pages []PageView `json:"pages"`
+}
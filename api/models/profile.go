@@ -1,31 +1,35 @@
 package models
 
 import (
-	"errors"
-
 	"gorm.io/gorm"
 )
 
 //Profile definition
 type Profile struct {
 	Base
-	Username     string `valid:"name" gorm:"type:varchar(50);" json:"username"`
-	Country      string `valid:"name" gorm:"type:varchar(50);" json:"country"`
-	Firstname    string `valid:"name" gorm:"type:varchar(50);" json:"firstname"`
-	Lastname     string `valid:"name" gorm:"type:varchar(50);" json:"lastname"`
+	//Version is incremented by every update, it is the ETag of the profile
+	Version      uint   `gorm:"not null;default:1" valid:"-" json:"version"`
+	Username     string `valid:"name,stringlength(1|50)" gorm:"type:varchar(50);" json:"username"`
+	Country      string `valid:"name,stringlength(1|50)" gorm:"type:varchar(50);" json:"country"`
+	Firstname    string `valid:"name,stringlength(1|50)" gorm:"type:varchar(50);" json:"firstname"`
+	Lastname     string `valid:"name,stringlength(1|50)" gorm:"type:varchar(50);" json:"lastname"`
 	Email        string `valid:"email" json:"email"`
 	StreetNumber uint   `valid:"numeric" json:"street_number"`
-	StreetName   string `valid:"text" json:"street_name"`
-	City         string `valid:"name" gorm:"type:varchar(50);" json:"city"`
+	StreetName   string `valid:"text,stringlength(1|255)" json:"street_name"`
+	City         string `valid:"name,stringlength(1|50)" gorm:"type:varchar(50);" json:"city"`
 	Gender       string `valid:"in(Male|Female)" json:"gender"`
-	Phone        string `valid:"alphanum" json:"phone"`
-	ZipCode      string `valid:"zipcode" json:"zip_code"`
-	Avatar       string `valid:"requri" json:"avatar"`
+	Phone        string `valid:"alphanum,stringlength(1|255)" json:"phone"`
+	ZipCode      string `valid:"zipcode,stringlength(1|255)" json:"zip_code"`
+	Avatar       string `valid:"imageuri" json:"avatar"`
 	AvatarFile   string `gorm:"-" valid:"-" json:"avatar_file"`
 	New          bool   `gorm:"-" json:"new"`
+	//Privacy tells who sees the contact fields of the profile, see View
+	Privacy Privacy `gorm:"embedded" json:"privacy"`
+	//AvatarUploadID is a complete upload of the member the avatar is set to, instead of the data of avatar_file
+	AvatarUploadID uint `gorm:"-" valid:"-" json:"avatar_upload_id,omitempty"`
 	// User                                                                             User
 	// OwnerID                                                                          uint        `gorm:"association_autoupdate:false;association_autocreate:false"`
-	OwnedPages []Page `gorm:"foreignkey:OwnerID;association_autoupdate:false;association_autocreate:false" json:"owned_pages"`
+	OwnedPages []Page `valid:"-" gorm:"foreignkey:OwnerID;association_autoupdate:false;association_autocreate:false" json:"owned_pages"`
 
 	Activities []*Activity `gorm:"many2many:profile_activities;association_autoupdate:false;association_autocreate:false" json:"activities"`
 	Languages  []*Language `gorm:"many2many:profile_languages;association_autoupdate:false;association_autocreate:false" json:"languages"`
@@ -37,45 +41,12 @@ func (p *Profile) AfterCreate(tx *gorm.DB) error {
 	return nil
 }
 
-//Validate model
-func (p *Profile) Validate(db *gorm.DB) {
-	if len(p.Username) > 255 {
-		db.AddError(errors.New("invalid Username"))
-		return
-	}
-
-	if len(p.Firstname) > 255 {
-		db.AddError(errors.New("invalid Firstname"))
-		return
-	}
-
-	if len(p.Lastname) > 255 {
-		db.AddError(errors.New("invalid Lastname"))
-		return
-	}
-
-	if len(p.Phone) > 255 {
-		db.AddError(errors.New("invalid Phone"))
-		return
-	}
-
-	if len(p.ZipCode) > 255 {
-		db.AddError(errors.New("invalid ZipCode"))
-		return
-	}
-
-	if len(p.StreetName) > 255 {
-		db.AddError(errors.New("invalid StreetName"))
-		return
-	}
-
-	if len(p.Country) > 255 {
-		db.AddError(errors.New("invalid Country"))
-		return
-	}
-
-	if len(p.City) > 255 {
-		db.AddError(errors.New("invalid City"))
-		return
-	}
+//ProfileFilter restricts the profiles of the member directory, zero values are ignored
+type ProfileFilter struct {
+	//Query is searched in the username, the firstname and the lastname
+	Query      string
+	ActivityID uint
+	LanguageID uint
+	Country    string
+	City       string
 }
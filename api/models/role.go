@@ -0,0 +1,58 @@
+package models
+
+//Role names
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleHost      = "host"
+	RoleGuest     = "guest"
+)
+
+//Permission names, checked per route by roleHandler.Can
+const (
+	PermRolesManage           = "roles.manage"
+	PermUsersManage           = "users.manage"
+	PermReferenceManage       = "reference.manage"
+	PermPagesModerate         = "pages.moderate"
+	PermImagesModerate        = "images.moderate"
+	PermConversationsModerate = "conversations.moderate"
+	PermPagesCreate           = "pages.create"
+	PermMessagesSend          = "messages.send"
+	PermAuditRead             = "audit.read"
+)
+
+//DefaultRoles are given to every new user
+var DefaultRoles = []string{RoleGuest, RoleHost}
+
+//RolePermissions is the reference set of roles and their permissions, seeded on migration
+var RolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermRolesManage, PermUsersManage, PermReferenceManage, PermPagesModerate,
+		PermImagesModerate, PermConversationsModerate, PermPagesCreate, PermMessagesSend,
+		PermAuditRead,
+	},
+	RoleModerator: {PermPagesModerate, PermImagesModerate, PermConversationsModerate, PermMessagesSend},
+	RoleHost:      {PermPagesCreate},
+	RoleGuest:     {PermMessagesSend},
+}
+
+//Role model definition
+type Role struct {
+	ID          uint          `gorm:"primarykey" json:"id"`
+	Name        string        `gorm:"type:varchar(50);uniqueIndex" json:"name"`
+	Permissions []*Permission `gorm:"many2many:role_permissions;" json:"permissions"`
+	Users       []*User       `gorm:"many2many:user_roles;" json:"-"`
+}
+
+//Permission model definition
+type Permission struct {
+	ID    uint    `gorm:"primarykey" json:"id"`
+	Name  string  `gorm:"type:varchar(50);uniqueIndex" json:"name"`
+	Roles []*Role `gorm:"many2many:role_permissions;" json:"-"`
+}
+
+//RoleBodyModel model definition (model used when decoding body in roleHandler)
+type RoleBodyModel struct {
+	UserID uint   `valid:"numeric,required" json:"user_id"`
+	Role   string `valid:"in(admin|moderator|host|guest),required" json:"role"`
+}
@@ -1,10 +1,7 @@
 package models
 
 import (
-	"errors"
 	"time"
-
-	"gorm.io/gorm"
 )
 
 //Session model definition
@@ -14,24 +11,8 @@ type Session struct {
 	SessionID string    `valid:"uuidv4" json:"session_id"`
 	Expires   time.Time `gorm:"default=now" valid:"-" json:"expires"`
 	Validity  uint      `valid:"numeric" json:"validity"`
-}
-
-//Validate model
-func (session *Session) Validate(db *gorm.DB) {
-	if session.OwnerID == 0 {
-		db.AddError(errors.New("invalid OwnerID"))
-		return
-	}
-
-	if session.SessionID == "" {
-		db.AddError(errors.New("invalid SessionID"))
-		return
-	}
-
-	if session.Expires.Before(time.Now()) {
-		db.AddError(errors.New("sesssion expired"))
-		return
-	}
+	//Partial sessions are waiting for the second authentication factor
+	Partial bool `gorm:"default:false" valid:"-" json:"partial"`
 }
 
 //HasExpired determines wether the current session has expired or not
@@ -0,0 +1,9 @@
+package models
+
+//Trash holds the deleted pages and images of a member, they can be restored until they are purged
+//RetentionDays after their deletion
+type Trash struct {
+	Pages         []Page  `json:"pages"`
+	Images        []Image `json:"images"`
+	RetentionDays int     `json:"retention_days"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+//RecoveryCode model definition, a one-time code usable in place of a TOTP code
+type RecoveryCode struct {
+	ID      uint       `gorm:"primarykey" json:"id"`
+	Owner   User       `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+	OwnerID uint       `gorm:"index" json:"owner_id"`
+	Hash    string     `json:"-"`
+	UsedAt  *time.Time `json:"used_at"`
+}
+
+//TwoFactorBodyModel model definition (model used when decoding body in twoFactorHandler)
+type TwoFactorBodyModel struct {
+	Code     string `json:"code"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+//Upload is a file sent ahead of the page image or the avatar referencing it by ID. A resumable upload announces
+//its Size and receives its content in chunks, it is Complete once Received reaches Size and its content is sniffed
+type Upload struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Owner     User      `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+	OwnerID   uint      `gorm:"index" json:"-"`
+	Filename  string    `gorm:"size:255" json:"filename"`
+	//URL is the path of the file, empty until the first chunk is received
+	URL       string    `gorm:"size:255" json:"url"`
+	Mime      string    `gorm:"size:32" json:"mime"`
+	Size      int64     `json:"size"`
+	Received  int64     `json:"received"`
+	Complete  bool      `json:"complete"`
+	ExpiresAt time.Time `json:"expires_at"`
+	//Variants of the image, they are generated once the upload is complete
+	Variants ImageVariants `gorm:"embedded" json:"variants"`
+}
+
+//HasExpired tells whether the upload can still be referenced
+func (upload *Upload) HasExpired() bool {
+	return upload.ExpiresAt.Before(time.Now())
+}
+
+//UploadBodyModel opens a resumable upload, the multipart uploads send the file right away instead
+type UploadBodyModel struct {
+	Filename string `json:"filename"`
+	Size     int64  `valid:"required" json:"size"`
+}
+
+//UploadReport is the outcome of a garbage collection of the uploaded files, the paths are the ones Save returned
+type UploadReport struct {
+	//Orphans are the files no row references, they are removed unless it is a dry run
+	Orphans []string
+	//Missing are the files a row references which do not exist
+	Missing []string
+	//Kept counts the referenced files and the ones too recent to be collected
+	Kept int
+}
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"errors"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
@@ -21,28 +21,21 @@ type User struct {
 	Type           string `valid:"in(ADMIN|USER)" json:"type"`
 	New            bool   `gorm:"-" valid:"-" json:"new"`
 	ChangePassword bool   `gorm:"-" valid:"-" json:"change_password"`
-}
-
-//Validate model
-func (user User) Validate(db *gorm.DB) {
-	if user.Email == "" {
-		db.AddError(errors.New("email is empty"))
-		return
-	}
-
-	if user.Password == "" {
-		db.AddError(errors.New("password is empty"))
-		return
-	}
-
-	if user.ProfileID == 0 && !user.New && !user.ChangePassword {
-		db.AddError(errors.New("profileID invalid"))
-	}
+	//TwoFactorEnabled is set once the user confirmed a TOTP code after enrolment
+	TwoFactorEnabled bool   `gorm:"default:false" valid:"-" json:"two_factor_enabled"`
+	TwoFactorSecret  string `valid:"-" json:"-"`
+	//TwoFactorLastStep is the time step of the last TOTP code accepted, the codes of this step or before are refused
+	TwoFactorLastStep int64   `gorm:"not null;default:0" valid:"-" json:"-"`
+	Roles             []*Role `gorm:"many2many:user_roles;" valid:"-" json:"roles"`
+	//SuspendedAt is set by an admin, suspended users cannot log in
+	SuspendedAt *time.Time `valid:"-" json:"suspended_at"`
+	//DeletionScheduledAt is set when the member asks for the deletion of its account, it can cancel until then
+	DeletionScheduledAt *time.Time `gorm:"index" valid:"-" json:"deletion_scheduled_at"`
 }
 
 //BeforeCreate generate the User ID, set Type to USER and hash the password
 func (user *User) BeforeCreate(tx *gorm.DB) error {
-	profile := Profile{Email: user.Email}
+	profile := Profile{Email: user.Email, Privacy: DefaultPrivacy}
 	user.Profile = profile
 	user.Type = "USER"
 	user.Password = hashAndSalt([]byte(user.Password))
@@ -67,14 +60,38 @@ func (user *User) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
+//passwordCost is the bcrypt cost used to hash passwords, see SetPasswordCost
+var passwordCost = bcrypt.DefaultCost
+
+//SetPasswordCost sets the bcrypt cost used for new password hashes
+func SetPasswordCost(cost int) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		log.Warnf("invalid bcrypt cost %d, keeping %d", cost, passwordCost)
+		return
+	}
+	passwordCost = cost
+}
+
+//NeedsRehash tells whether hashedPwd was generated with a weaker cost than the configured one
+func NeedsRehash(hashedPwd string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPwd))
+	if err != nil {
+		return false
+	}
+	return cost < passwordCost
+}
+
+//HashPassword hashes and salts pwd with the configured cost
+func HashPassword(pwd string) string {
+	return hashAndSalt([]byte(pwd))
+}
+
 func hashAndSalt(pwd []byte) string {
 
 	// Use GenerateFromPassword to hash & salt pwd.
-	// MinCost is just an integer constant provided by the bcrypt
-	// package along with DefaultCost & MaxCost.
-	// The cost can be any value you want provided it isn't lower
-	// than the MinCost (4)
-	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost)
+	// The cost comes from the configuration (Security.BcryptCost),
+	// stored hashes using a lower cost are upgraded on login
+	hash, err := bcrypt.GenerateFromPassword(pwd, passwordCost)
 	if err != nil {
 		log.Println(err)
 	}
@@ -82,3 +99,16 @@ func hashAndSalt(pwd []byte) string {
 	// convert the bytes to a string and return it
 	return string(hash)
 }
+
+//LoginResponseModel model definition (model answered by userHandler.Login and twoFactorHandler.Verify),
+//TwoFactorRequired asks for the TOTP code before the session is complete
+type LoginResponseModel struct {
+	Token             string `json:"token"`
+	Email             string `json:"email"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+}
+
+//AccountDeletionBodyModel is the body of an account deletion request, the password is asked again
+type AccountDeletionBodyModel struct {
+	Password string `json:"password"`
+}
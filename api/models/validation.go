@@ -0,0 +1,9 @@
+package models
+
+//InvalidField is a field breaking a rule of a model the valid tags cannot express, the validators report it like
+//the fields breaking a tag: Field is its json name, Code the validation code and Vars the data of its message
+type InvalidField struct {
+	Field string
+	Code  string
+	Vars  map[string]string
+}
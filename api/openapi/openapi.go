@@ -0,0 +1,304 @@
+// Package openapi builds an OpenAPI 3 document, the schemas of the request and response bodies
+// are derived from the Go types by reflection, following their json tags
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Version of the OpenAPI specification the documents follow
+const Version = "3.0.3"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the api
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is the base URL of the paths
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path by lowercase method
+type PathItem map[string]*Operation
+
+// Operation describes a method on a path
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody of an operation
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header is a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema is the subset of the JSON schema used by the models
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Components holds the named schemas and the security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how requests are authenticated
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Endpoint documents a route, Request and Response are values of the body types, nil when there is no body
+type Endpoint struct {
+	Tag     string
+	Summary string
+	//Security is the name of the security scheme required by the route, empty for public routes
+	Security string
+	//Query lists the query parameters read by the handler
+	Query    []string
+	Request  interface{}
+	Response interface{}
+	//ContentType of the response when it is not JSON, i.e a file download
+	ContentType string
+	//Status of the success response, 200 when zero
+	Status     int
+	Deprecated bool
+	//Versioned routes answer the ETag of the resource, their updates take a JSON merge patch and the If-Match header
+	Versioned bool
+}
+
+// New returns an empty document served at url
+func New(title, version, url string) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Servers:    []Server{{URL: url}},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}, SecuritySchemes: map[string]SecurityScheme{}},
+	}
+}
+
+// Add documents method on path, the {name} segments of path become path parameters
+func (me *Document) Add(method, path string, endpoint Endpoint) *Operation {
+	op := &Operation{Summary: endpoint.Summary, Deprecated: endpoint.Deprecated, Responses: map[string]Response{}}
+	if endpoint.Tag != "" {
+		op.Tags = []string{endpoint.Tag}
+	}
+
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			op.Parameters = append(op.Parameters, Parameter{Name: strings.Trim(segment, "{}"), In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	for _, name := range endpoint.Query {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+	}
+
+	update := endpoint.Versioned && method != "GET"
+	if update {
+		op.Parameters = append(op.Parameters, Parameter{Name: "If-Match", In: "header", Description: "ETag of the version the update applies to", Schema: &Schema{Type: "string"}})
+		op.Responses["412"] = Response{Description: "the resource was modified since the If-Match version"}
+	}
+
+	if endpoint.Request != nil {
+		contentType := "application/json"
+		if update && method == "PATCH" {
+			contentType = "application/merge-patch+json"
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{contentType: {Schema: me.Schema(endpoint.Request)}}}
+	}
+
+	status := endpoint.Status
+	if status == 0 {
+		status = 200
+	}
+
+	response := Response{Description: "success"}
+	switch {
+	case endpoint.ContentType != "":
+		response.Content = map[string]MediaType{endpoint.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case endpoint.Response != nil:
+		response.Content = map[string]MediaType{"application/json": {Schema: me.Schema(endpoint.Response)}}
+	}
+	if endpoint.Versioned {
+		response.Headers = map[string]Header{"ETag": {Description: "version of the resource", Schema: &Schema{Type: "string"}}}
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	if endpoint.Security != "" {
+		op.Security = []map[string][]string{{endpoint.Security: {}}}
+		op.Responses["401"] = Response{Description: "not logged"}
+	}
+
+	if _, ok := me.Paths[path]; !ok {
+		me.Paths[path] = PathItem{}
+	}
+	me.Paths[path][strings.ToLower(method)] = op
+
+	return op
+}
+
+// Has tells whether method on path is documented
+func (me *Document) Has(method, path string) bool {
+	_, ok := me.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// Schema returns the schema of v, named struct types are registered in the components and referenced
+func (me *Document) Schema(v interface{}) *Schema {
+	return me.schema(reflect.TypeOf(v))
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+func (me *Document) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		s := me.schema(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case deletedAtType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	}
+
+	//custom encodings can render anything
+	if t.Implements(marshalerType) {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Uint, reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: me.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: me.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return me.object(t)
+		}
+
+		name := t.Name()
+		if _, ok := me.Components.Schemas[name]; !ok {
+			//registered before its fields, the models reference each other
+			me.Components.Schemas[name] = &Schema{}
+			*me.Components.Schemas[name] = *me.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	return &Schema{}
+}
+
+// object returns the schema of the struct t, embedded structs are flattened like encoding/json does
+func (me *Document) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			//the fields of the outer struct shadow the embedded ones
+			for k, v := range me.object(f.Type).Properties {
+				if _, ok := s.Properties[k]; !ok {
+					s.Properties[k] = v
+				}
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = me.schema(f.Type)
+	}
+
+	return s
+}
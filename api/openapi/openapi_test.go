@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type base struct {
+	ID        uint           `json:"id"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+type owner struct {
+	base
+	Name  string  `json:"name"`
+	Pages []*page `json:"pages"`
+}
+
+type page struct {
+	Title    string     `json:"title"`
+	Owner    owner      `json:"owner"`
+	Secret   string     `json:"-"`
+	Hidden   string     `json:"hidden,omitempty"`
+	Date     *time.Time `json:"date"`
+	Lat      float64    `json:"lat"`
+	Untagged bool
+	internal int
+}
+
+func TestDocument_Schema(t *testing.T) {
+	doc := New("test", "v1", "/api/v1")
+
+	if s := doc.Schema([]page{}); s.Type != "array" || s.Items.Ref != "#/components/schemas/page" {
+		t.Fatalf("Schema([]page) = %+v", s)
+	}
+
+	tests := []struct {
+		schema, property string
+		want             Schema
+	}{
+		{"page", "title", Schema{Type: "string"}},
+		{"page", "hidden", Schema{Type: "string"}},
+		{"page", "date", Schema{Type: "string", Format: "date-time", Nullable: true}},
+		{"page", "lat", Schema{Type: "number", Format: "double"}},
+		{"page", "Untagged", Schema{Type: "boolean"}},
+		{"page", "owner", Schema{Ref: "#/components/schemas/owner"}},
+		{"owner", "id", Schema{Type: "integer", Format: "int64"}},
+		{"owner", "deleted_at", Schema{Type: "string", Format: "date-time", Nullable: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema+"."+tt.property, func(t *testing.T) {
+			got, ok := doc.Components.Schemas[tt.schema].Properties[tt.property]
+			if !ok {
+				t.Fatalf("%s has no property %s", tt.schema, tt.property)
+			}
+
+			if got.Ref != tt.want.Ref || got.Type != tt.want.Type || got.Format != tt.want.Format || got.Nullable != tt.want.Nullable {
+				t.Errorf("%s.%s = %+v, want %+v", tt.schema, tt.property, *got, tt.want)
+			}
+		})
+	}
+
+	for _, property := range []string{"Secret", "-", "internal", "base"} {
+		if _, ok := doc.Components.Schemas["page"].Properties[property]; ok {
+			t.Errorf("page has the property %s", property)
+		}
+	}
+
+	if items := doc.Components.Schemas["owner"].Properties["pages"].Items; items.Ref != "#/components/schemas/page" {
+		t.Errorf("owner.pages items = %+v, want a reference to page", items)
+	}
+}
+
+func TestDocument_Add(t *testing.T) {
+	doc := New("test", "v1", "/api/v1")
+	op := doc.Add("PATCH", "/pages/{pageID}/images/{id}", Endpoint{Security: "session", Request: page{}, Response: page{}})
+
+	if !doc.Has("patch", "/pages/{pageID}/images/{id}") {
+		t.Fatal("Has() = false after Add()")
+	}
+
+	if len(op.Parameters) != 2 || op.Parameters[0].Name != "pageID" || op.Parameters[1].In != "path" {
+		t.Errorf("parameters = %+v, want pageID and id in path", op.Parameters)
+	}
+
+	if op.RequestBody == nil || op.Responses["200"].Content["application/json"].Schema.Ref == "" || op.Responses["401"].Description == "" {
+		t.Errorf("operation = %+v", op)
+	}
+}
@@ -0,0 +1,190 @@
+//Package router dispatches the requests on their method and path, path segments written {name}
+//in a pattern are captured as parameters, read them with Param
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type key string
+
+const paramsKey = key("router.params")
+
+//Router matches the request path against the registered patterns, the most specific one wins:
+//static segments before parameters, exact patterns before the ones ending with / which match a whole subtree.
+//A path matching a pattern without a handler for the request method is answered 405 with the Allow header
+type Router struct {
+	routes []*route
+	//NotFound answers the requests matching no route, http.NotFound when nil
+	NotFound http.Handler
+	//MethodNotAllowed answers the requests matching a route without their method, the Allow header is already set
+	MethodNotAllowed http.Handler
+}
+
+type route struct {
+	pattern  string
+	segments []string
+	prefix   bool
+	handlers map[string]http.Handler
+}
+
+//New returns an empty Router
+func New() *Router {
+	return &Router{}
+}
+
+//Any is the method matching every request method, used for the legacy routes
+const Any = ""
+
+//Handle registers handler for method on pattern, registering the same method and pattern again replaces the handler
+func (me *Router) Handle(method, pattern string, handler http.Handler) {
+	for _, r := range me.routes {
+		if r.pattern == pattern {
+			r.handlers[method] = handler
+			return
+		}
+	}
+
+	r := &route{
+		pattern:  pattern,
+		segments: split(pattern),
+		prefix:   strings.HasSuffix(pattern, "/"),
+		handlers: map[string]http.Handler{method: handler},
+	}
+
+	me.routes = append(me.routes, r)
+	sort.SliceStable(me.routes, func(i, j int) bool { return me.routes[i].rank() < me.routes[j].rank() })
+}
+
+//HandleFunc registers the handler function for method on pattern
+func (me *Router) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	me.Handle(method, pattern, handler)
+}
+
+//ServeHTTP dispatches r to the handler of the first matching route
+func (me *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := split(r.URL.Path)
+
+	for _, route := range me.routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+
+		handler := route.handler(r.Method)
+		if handler == nil {
+			w.Header().Set("Allow", route.allow())
+			if me.MethodNotAllowed != nil {
+				me.MethodNotAllowed.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if len(params) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), paramsKey, params))
+		}
+
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	if me.NotFound != nil {
+		me.NotFound.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+//Param returns the value of the path parameter name of r, empty if the route has none
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey).(map[string]string)
+	return params[name]
+}
+
+func split(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+//rank orders the routes, the lowest rank is tried first: exact patterns with static segments
+//before parameters from left to right, then the subtree patterns, longest first
+func (me *route) rank() string {
+	if me.prefix {
+		return fmt.Sprintf("1%04d", 9999-len(me.segments))
+	}
+
+	rank := "0"
+	for _, s := range me.segments {
+		if isParam(s) {
+			rank += "1"
+		} else {
+			rank += "0"
+		}
+	}
+	return rank
+}
+
+func (me *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) < len(me.segments) || (!me.prefix && len(segments) != len(me.segments)) {
+		return nil, false
+	}
+
+	var params map[string]string
+	for i, s := range me.segments {
+		if !isParam(s) {
+			if s != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		if params == nil {
+			params = map[string]string{}
+		}
+		params[strings.Trim(s, "{}")] = segments[i]
+	}
+
+	return params, true
+}
+
+func (me *route) handler(method string) http.Handler {
+	if h, ok := me.handlers[method]; ok {
+		return h
+	}
+
+	if method == http.MethodHead {
+		if h, ok := me.handlers[http.MethodGet]; ok {
+			return h
+		}
+	}
+
+	return me.handlers[Any]
+}
+
+func (me *route) allow() string {
+	methods := []string{}
+	for m := range me.handlers {
+		methods = append(methods, m)
+	}
+
+	if _, ok := me.handlers[http.MethodGet]; ok {
+		if _, ok := me.handlers[http.MethodHead]; !ok {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
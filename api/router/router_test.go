@@ -0,0 +1,63 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	r := New()
+	named := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name + Param(r, "id") + Param(r, "role")))
+		}
+	}
+
+	r.HandleFunc(http.MethodGet, "/pages", named("list"))
+	r.HandleFunc(http.MethodPost, "/pages", named("create"))
+	r.HandleFunc(http.MethodGet, "/pages/{id}", named("get"))
+	r.HandleFunc(http.MethodDelete, "/pages/{id}", named("delete"))
+	r.HandleFunc(http.MethodGet, "/pages/new", named("new"))
+	r.HandleFunc(http.MethodPut, "/users/{id}/roles/{role}", named("grant"))
+	r.HandleFunc(Any, "/legacy", named("legacy"))
+	r.HandleFunc(Any, "/", named("static"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		body   string
+		allow  string
+	}{
+		{"static", http.MethodGet, "/pages", http.StatusOK, "list", ""},
+		{"method", http.MethodPost, "/pages", http.StatusOK, "create", ""},
+		{"param", http.MethodGet, "/pages/12", http.StatusOK, "get12", ""},
+		{"static before param", http.MethodGet, "/pages/new", http.StatusOK, "new", ""},
+		{"params", http.MethodPut, "/users/3/roles/admin", http.StatusOK, "grant3admin", ""},
+		{"head falls back to get", http.MethodHead, "/pages/12", http.StatusOK, "get12", ""},
+		{"any method", http.MethodPatch, "/legacy", http.StatusOK, "legacy", ""},
+		{"method not allowed", http.MethodPatch, "/pages/12", http.StatusMethodNotAllowed, "", "DELETE, GET, HEAD"},
+		{"subtree", http.MethodGet, "/img/a.png", http.StatusOK, "static", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.code {
+				t.Fatalf("%s %s: status %d, want %d", tt.method, tt.path, w.Code, tt.code)
+			}
+
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("%s %s: body %q, want %q", tt.method, tt.path, w.Body, tt.body)
+			}
+
+			if got := w.Header().Get("Allow"); got != tt.allow {
+				t.Errorf("%s %s: Allow %q, want %q", tt.method, tt.path, got, tt.allow)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package stores
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//accountDeletionInterval is how often run looks for accounts whose grace period is over
+const accountDeletionInterval = time.Hour
+
+type accountDeletionStore struct {
+	Db          *gorm.DB
+	FileStore   fileStore
+	Audit       auditStore
+	GracePeriod time.Duration
+	//KeepConversations anonymizes the member side of its conversations instead of deleting them
+	KeepConversations bool
+}
+
+//Schedule marks userID for deletion at the end of the grace period and returns that date,
+//without grace period the account is purged right away
+func (me accountDeletionStore) Schedule(userID uint, actor models.Actor) (time.Time, error) {
+	var user models.User
+	if err := me.Db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return *user.DeletionScheduledAt, nil
+	}
+
+	at := time.Now().Add(me.GracePeriod)
+	if err := me.Db.Model(&models.User{}).Where("id = ?", userID).Update("deletion_scheduled_at", at).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	me.Audit.Record(actor, auditUserDeleteRequest, "user", userID, map[string]interface{}{"scheduled_at": at})
+
+	if me.GracePeriod == 0 {
+		return at, me.Purge(userID, actor)
+	}
+
+	return at, nil
+}
+
+//Cancel drops the pending deletion of userID
+func (me accountDeletionStore) Cancel(userID uint, actor models.Actor) (bool, error) {
+	res := me.Db.Model(&models.User{}).
+		Where("id = ? AND deletion_scheduled_at IS NOT NULL", userID).
+		Update("deletion_scheduled_at", nil)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return false, fmt.Errorf("no deletion pending for user %d", userID)
+	}
+
+	me.Audit.Record(actor, auditUserDeleteCancel, "user", userID, nil)
+
+	return true, nil
+}
+
+//PurgeDue purges every account whose grace period ended before now and returns how many were purged
+func (me accountDeletionStore) PurgeDue(now time.Time) (int, error) {
+	var ids []uint
+	if err := me.Db.Unscoped().Model(&models.User{}).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", now).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := me.Purge(id, models.Actor{}); err != nil {
+			log.Errorf("account deletion %d: %s", id, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+//Purge removes every data of userID: pages, images and their files, sessions, exports, roles and the user itself.
+//Conversations are either deleted or kept for the counterpart with the member profile anonymized, see KeepConversations
+func (me accountDeletionStore) Purge(userID uint, actor models.Actor) error {
+	var user models.User
+	if err := me.Db.Unscoped().Preload("Profile").Where("id = ?", userID).First(&user).Error; err != nil {
+		return err
+	}
+	profileID := user.ProfileID
+
+	var pageIDs []uint
+	if err := me.Db.Unscoped().Model(&models.Page{}).Where("owner_id = ?", profileID).Pluck("id", &pageIDs).Error; err != nil {
+		return err
+	}
+
+	//files are removed once the rows are gone, a failure leaves an orphan file rather than a broken row
+	var images []models.Image
+	if err := me.Db.Unscoped().Where("owner_id IN ?", append(pageIDs, 0)).Find(&images).Error; err != nil {
+		return err
+	}
+
+	//only the files of the member go, an image or an avatar can point to the file of another member
+	var files []string
+	for _, i := range images {
+		for _, file := range append(i.Variants.URLs(), i.URL) {
+			if me.FileStore.Within(file, pageDirectories(profileID, userID)...) {
+				files = append(files, file)
+			}
+		}
+	}
+
+	if me.FileStore.Within(user.Profile.Avatar, avatarDirectories(userID)...) {
+		files = append(files, user.Profile.Avatar)
+	}
+
+	var uploads []models.Upload
+	if err := me.Db.Where("owner_id = ? AND url <> ''", userID).Find(&uploads).Error; err != nil {
+		return err
+	}
+
+	for _, u := range uploads {
+		files = append(append(files, u.URL), u.Variants.URLs()...)
+	}
+
+	var exports []string
+	if err := me.Db.Model(&models.DataExport{}).Where("owner_id = ? AND file <> ''", userID).Pluck("file", &exports).Error; err != nil {
+		return err
+	}
+
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := me.purgePages(tx, userID, pageIDs); err != nil {
+			return err
+		}
+
+		if err := me.purgeConversations(tx, profileID); err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM profile_activities WHERE profile_id = ?", profileID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM profile_languages WHERE profile_id = ?", profileID).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&models.Session{}, &models.RecoveryCode{}, &models.DataExport{}, &models.Upload{}} {
+			if err := tx.Unscoped().Where("owner_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("attempt_key = ?", accountKey(user.Email)).Delete(&models.LoginAttempt{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("id = ?", userID).Delete(&models.User{}).Error; err != nil {
+			return err
+		}
+
+		//the user references its profile, it goes last
+		return me.purgeProfile(tx, profileID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := me.FileStore.Delete(file); err != nil {
+			log.Warnf("account deletion %d: %s", userID, err)
+		}
+	}
+
+	for _, file := range exports {
+		if err := me.FileStore.FileSystem.Remove(file); err != nil && !me.FileStore.FileSystem.IsNotExist(err) {
+			log.Warnf("account deletion %d: %s", userID, err)
+		}
+	}
+
+	me.Audit.Record(actor, auditUserPurge, "user", userID, map[string]interface{}{
+		"pages": len(pageIDs),
+		"files": len(files) + len(exports),
+	})
+
+	return nil
+}
+
+func (me accountDeletionStore) purgePages(tx *gorm.DB, userID uint, pageIDs []uint) error {
+	if err := tx.Exec("DELETE FROM user_page_follower WHERE user_id = ?", userID).Error; err != nil {
+		return err
+	}
+
+	if len(pageIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Unscoped().Where("owner_id IN ?", pageIDs).Delete(&models.Image{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("DELETE FROM page_activities WHERE page_id IN ?", pageIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("DELETE FROM user_page_follower WHERE page_id IN ?", pageIDs).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("id IN ?", pageIDs).Delete(&models.Page{}).Error
+}
+
+//purgeConversations deletes the conversations of profileID, or only the ones the counterpart already left when they are kept
+func (me accountDeletionStore) purgeConversations(tx *gorm.DB, profileID uint) error {
+	req := tx.Unscoped().Model(&models.Conversation{}).Where("from_id = ? OR to_id = ?", profileID, profileID)
+	if me.KeepConversations {
+		remaining := tx.Unscoped().Model(&models.User{}).Select("profile_id")
+		req = req.Where("(from_id = ? AND to_id NOT IN (?)) OR (to_id = ? AND from_id NOT IN (?))", profileID, remaining, profileID, remaining)
+	}
+
+	var ids []uint
+	if err := req.Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	if me.KeepConversations {
+		//the counterpart keeps the messages, not the member email address
+		if err := tx.Model(&models.Message{}).Where("from_id = ?", profileID).Update("email", "").Error; err != nil {
+			return err
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("conversation_id IN ?", ids).Delete(&models.Message{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Conversation{}).Error
+}
+
+//purgeProfile deletes the profile unless kept conversations still reference it, it is then anonymized
+func (me accountDeletionStore) purgeProfile(tx *gorm.DB, profileID uint) error {
+	var referenced int64
+	if err := tx.Unscoped().Model(&models.Conversation{}).Where("from_id = ? OR to_id = ?", profileID, profileID).Count(&referenced).Error; err != nil {
+		return err
+	}
+
+	if referenced == 0 {
+		return tx.Unscoped().Where("id = ?", profileID).Delete(&models.Profile{}).Error
+	}
+
+	return tx.Unscoped().Model(&models.Profile{}).Where("id = ?", profileID).Updates(map[string]interface{}{
+		"username":      "",
+		"country":       "",
+		"firstname":     "",
+		"lastname":      "",
+		"email":         "",
+		"street_number": 0,
+		"street_name":   "",
+		"city":          "",
+		"gender":        "",
+		"phone":         "",
+		"zip_code":      "",
+		"avatar":        "",
+		"deleted_at":    time.Now(),
+	}).Error
+}
+
+//run purges the accounts whose grace period is over, it never returns
+func (me accountDeletionStore) run() {
+	ticker := time.NewTicker(accountDeletionInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		purged, err := me.PurgeDue(now)
+		if err != nil {
+			log.Errorf("account deletion: %s", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Printf("account deletion: %d accounts purged", purged)
+		}
+	}
+}
@@ -9,29 +9,6 @@ type activityStore struct {
 	Db *gorm.DB
 }
 
-//Migrate creates the db table
-func (me activityStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Activity{})
-	if err != nil {
-		panic(err)
-	}
-
-	// me.Db.Table("page_activities").AddForeignKey("activity_id", "activities(id)", "NO ACTION", "NO ACTION")
-	// me.Db.Table("page_activities").AddForeignKey("page_id", "pages(id)", "CASCADE", "NO ACTION")
-	// me.Db.Table("page_activities").AddUniqueIndex("activity_id_page_id_unique", "page_id, activity_id")
-
-	// me.Db.Table("profile_activities").AddForeignKey("activity_id", "activities(id)", "NO ACTION", "NO ACTION")
-	// me.Db.Table("profile_activities").AddForeignKey("profile_id", "profiles(id)", "CASCADE", "NO ACTION")
-	// me.Db.Table("profile_activities").AddUniqueIndex("activity_id_profile_id_unique", "profile_id, activity_id")
-
-	activities := []string{"acrosport", "alpinisme", "apnée", "badminton", "basejump", "basketball", "bmx", "canoëkayak", "canyoning", "course", "coursedorientation", "crosse", "cyclisme", "danse", "équitation", "escalade", "football", "golf", "handball", "hiking", "kitesurfing", "marathon", "paddle", "pêche", "rafting", "roller", "skateboard", "skialpin", "skidefond", "skinautique", "skinordique", "snowboard", "surf", "tennis", "tiràlarc", "ulm", "wakeboard", "yoga", "windsurf"}
-
-	for _, a := range activities {
-		me.Db.FirstOrCreate(&models.Activity{Name: a}, models.Activity{Name: a})
-	}
-
-}
-
 //All Returns all the activities
 func (me activityStore) All() ([]models.Activity, error) {
 	var activities []models.Activity
@@ -40,3 +17,38 @@ func (me activityStore) All() ([]models.Activity, error) {
 	}
 	return activities, nil
 }
+
+//New creates an activity
+func (me activityStore) New(activity models.Activity) (models.Activity, error) {
+	if err := me.Db.Create(&activity).Error; err != nil {
+		return models.Activity{}, err
+	}
+	return activity, nil
+}
+
+//Update renames an activity
+func (me activityStore) Update(activity models.Activity) (models.Activity, error) {
+	if err := me.Db.Model(&models.Activity{}).Where("id = ?", activity.ID).Update("name", activity.Name).Error; err != nil {
+		return models.Activity{}, err
+	}
+	return activity, nil
+}
+
+//Delete an activity and detach it from pages and profiles
+func (me activityStore) Delete(activityID uint) (bool, error) {
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM page_activities WHERE activity_id = ?", activityID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM profile_activities WHERE activity_id = ?", activityID).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM activities WHERE id = ?", activityID).Error
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
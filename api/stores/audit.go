@@ -0,0 +1,124 @@
+package stores
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//audit actions recorded by the stores
+const (
+	auditLogin             = "session.login"
+	auditLoginFailed       = "session.login_failed"
+	auditLoginPartial      = "session.login_partial"
+	auditLoginSecond       = "session.login_second_factor"
+	auditLogout            = "session.logout"
+	auditPasswordChange    = "user.password_change"
+	auditUserSuspend       = "user.suspend"
+	auditUserReinstate     = "user.reinstate"
+	auditUserDeleteRequest = "user.delete_request"
+	auditUserDeleteCancel  = "user.delete_cancel"
+	auditUserPurge         = "user.purge"
+	auditPageCreate        = "page.create"
+	auditPageUpdate        = "page.update"
+	auditPageDelete        = "page.delete"
+	auditPageStatus        = "page.status"
+	auditPageRestore       = "page.restore"
+	auditPagePurge         = "page.purge"
+	auditImageDelete       = "image.delete"
+	auditImageRestore      = "image.restore"
+	auditImagePurge        = "image.purge"
+	auditConvDelete        = "conversation.delete"
+	auditConvReport        = "conversation.report"
+	auditRoleGrant         = "role.grant"
+	auditRoleRevoke        = "role.revoke"
+)
+
+type auditStore struct {
+	Db *gorm.DB
+}
+
+//Record writes an audit event, failures are logged and never returned to the audited action
+func (me auditStore) Record(actor models.Actor, action, targetType string, targetID uint, metadata map[string]interface{}) {
+	event := models.AuditEvent{
+		ActorID:    actor.UserID,
+		IP:         actor.IP,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   models.NewAuditMetadata(metadata),
+	}
+
+	if err := me.Db.Create(&event).Error; err != nil {
+		log.WithFields(log.Fields{
+			"actor_id":    actor.UserID,
+			"action":      action,
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Errorf("audit: %s", err)
+	}
+}
+
+//Query returns the events matching filter, most recent first
+func (me auditStore) Query(filter models.AuditFilter, offset, limit int) ([]models.AuditEvent, int64, error) {
+	var total int64
+	if err := me.filter(filter).Model(&models.AuditEvent{}).Count(&total).Error; err != nil {
+		return []models.AuditEvent{}, 0, err
+	}
+
+	var events []models.AuditEvent
+	if err := me.filter(filter).Order("id DESC").Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+		return []models.AuditEvent{}, 0, err
+	}
+
+	return events, total, nil
+}
+
+//Export streams the events matching filter to w as JSON Lines, oldest first
+func (me auditStore) Export(filter models.AuditFilter, w io.Writer) error {
+	rows, err := me.filter(filter).Model(&models.AuditEvent{}).Order("id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	for rows.Next() {
+		var event models.AuditEvent
+		if err := me.Db.ScanRows(rows, &event); err != nil {
+			return err
+		}
+
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (me auditStore) filter(filter models.AuditFilter) *gorm.DB {
+	req := me.Db
+	if filter.ActorID > 0 {
+		req = req.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		req = req.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		req = req.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID > 0 {
+		req = req.Where("target_id = ?", filter.TargetID)
+	}
+	if !filter.From.IsZero() {
+		req = req.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		req = req.Where("created_at < ?", filter.To)
+	}
+	return req
+}
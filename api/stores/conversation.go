@@ -1,36 +1,24 @@
 package stores
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"gorm.io/gorm"
 )
 
 type conversationStore struct {
-	Db *gorm.DB
-}
-
-//Migrate creates the db table
-func (me conversationStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Message{})
-	if err != nil {
-		panic(err)
-	}
-	err = me.Db.AutoMigrate(&models.Conversation{})
-	if err != nil {
-		panic(err)
-	}
-	// me.Db.Model(&models.Message{}).AddForeignKey("owner_id", "conversations(id)", "CASCADE", "CASCADE")
-	// me.Db.Model(&models.Message{}).AddForeignKey("from_id", "profiles(id)", "CASCADE", "CASCADE")
-	// me.Db.Model(&models.Message{}).AddForeignKey("to_id", "profiles(id)", "CASCADE", "CASCADE")
-	// me.Db.Model(&models.Conversation{}).AddForeignKey("from_id", "profiles(id)", "CASCADE", "CASCADE")
-	// me.Db.Model(&models.Conversation{}).AddForeignKey("to_id", "profiles(id)", "CASCADE", "CASCADE")
+	Db    *gorm.DB
+	Audit auditStore
 }
 
 //Delete a conversation by convID (softdelete)
-func (me conversationStore) Delete(conversationID uint) (bool, error) {
+func (me conversationStore) Delete(conversationID uint, actor models.Actor) (bool, error) {
 	if err := me.Db.Exec("DELETE FROM conversations WHERE id = ?", conversationID).Error; err != nil {
 		return false, err
 	}
+	me.Audit.Record(actor, auditConvDelete, "conversation", conversationID, nil)
 	return true, nil
 }
 
@@ -84,3 +72,48 @@ func (me conversationStore) Save(conversation models.Conversation) (models.Conve
 	}
 	return conversation, nil
 }
+
+//Report flags the conversation so admins can read it
+func (me conversationStore) Report(conversationID, profileID uint, reason string, actor models.Actor) (bool, error) {
+	now := time.Now()
+	if err := me.Db.Model(&models.Conversation{}).
+		Where("id = ?", conversationID).
+		Updates(map[string]interface{}{"reported_at": &now, "reported_by_id": profileID, "report_reason": reason}).Error; err != nil {
+		return false, err
+	}
+	me.Audit.Record(actor, auditConvReport, "conversation", conversationID, map[string]interface{}{"reason": reason})
+	return true, nil
+}
+
+//Reported returns the reported conversations, most recent report first
+func (me conversationStore) Reported() ([]models.Conversation, error) {
+	var outConversations []models.Conversation
+	if err := me.Db.
+		Preload("To").
+		Preload("From").
+		Where("reported_at IS NOT NULL").
+		Order("reported_at DESC").
+		Find(&outConversations).Error; err != nil {
+		return []models.Conversation{}, err
+	}
+	return outConversations, nil
+}
+
+//GetReported returns a conversation with its messages, only if it has been reported
+func (me conversationStore) GetReported(conversationID uint) (models.Conversation, error) {
+	outConversation := models.Conversation{}
+	if err := me.Db.
+		Preload("To").
+		Preload("From").
+		Preload("Messages").
+		Where("id = ?", conversationID).
+		First(&outConversation).Error; err != nil {
+		return models.Conversation{}, err
+	}
+
+	if outConversation.ReportedAt == nil {
+		return models.Conversation{}, fmt.Errorf("conversation %d has not been reported", conversationID)
+	}
+
+	return outConversation, nil
+}
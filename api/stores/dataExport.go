@@ -0,0 +1,334 @@
+package stores
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const dataExportValidity = 7 * 24 * time.Hour
+
+//dataExportPurgeInterval is how often run looks for the expired exports
+const dataExportPurgeInterval = time.Hour
+
+type dataExportStore struct {
+	Db         *gorm.DB
+	FileStore  fileStore
+	Hub        *hub
+	ExportPath string
+}
+
+//Request creates a pending export for userID and builds the archive in the background,
+//the member is notified on the websocket hub when it is ready. A member has one pending export at most
+func (me dataExportStore) Request(userID uint) (models.DataExport, error) {
+	export := models.DataExport{
+		OwnerID:   userID,
+		Status:    models.DataExportPending,
+		ExpiresAt: time.Now().Add(dataExportValidity),
+	}
+
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		//locking the member row serializes its requests, the pending count cannot change until the export is created
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).UpdateColumn("id", gorm.Expr("id")).Error; err != nil {
+			return err
+		}
+
+		var pending int64
+		if err := tx.Model(&models.DataExport{}).Where("owner_id = ? AND status = ?", userID, models.DataExportPending).Count(&pending).Error; err != nil {
+			return err
+		}
+
+		if pending > 0 {
+			return apperror.New(http.StatusConflict, "data_export.already_pending", fmt.Errorf("an export of user %d is already in progress", userID))
+		}
+
+		return tx.Create(&export).Error
+	})
+
+	if err != nil {
+		return models.DataExport{}, err
+	}
+
+	go me.generate(export)
+
+	return export, nil
+}
+
+//All returns the exports of userID, most recent first
+func (me dataExportStore) All(userID uint) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	if err := me.Db.Where("owner_id = ?", userID).Order("id DESC").Find(&exports).Error; err != nil {
+		return []models.DataExport{}, err
+	}
+	return exports, nil
+}
+
+//Open returns a reader on the archive of exportID if userID owns it and it is still downloadable
+func (me dataExportStore) Open(userID, exportID uint) (models.DataExport, io.ReadCloser, error) {
+	var export models.DataExport
+	if err := me.Db.Where("id = ? AND owner_id = ?", exportID, userID).First(&export).Error; err != nil {
+		return models.DataExport{}, nil, err
+	}
+
+	if export.Status != models.DataExportReady || export.HasExpired() {
+		return models.DataExport{}, nil, fmt.Errorf("export %d is not available", exportID)
+	}
+
+	f, err := me.FileStore.FileSystem.Open(export.File)
+	if err != nil {
+		return models.DataExport{}, nil, err
+	}
+
+	return export, f, nil
+}
+
+//PurgeExpired deletes the exports which expired at now and their archive, it returns how many were purged
+func (me dataExportStore) PurgeExpired(now time.Time) (int, error) {
+	var exports []models.DataExport
+	if err := me.Db.Where("expires_at <= ?", now).Find(&exports).Error; err != nil {
+		return 0, err
+	}
+
+	for _, export := range exports {
+		if export.File != "" {
+			if err := me.FileStore.FileSystem.Remove(export.File); err != nil && !me.FileStore.FileSystem.IsNotExist(err) {
+				return 0, err
+			}
+		}
+
+		if err := me.Db.Unscoped().Delete(&export).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return len(exports), nil
+}
+
+//run purges the expired exports, it never returns
+func (me dataExportStore) run() {
+	ticker := time.NewTicker(dataExportPurgeInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		purged, err := me.PurgeExpired(now)
+		if err != nil {
+			log.Errorf("data export purge: %s", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Printf("data export purge: %d exports purged", purged)
+		}
+	}
+}
+
+func (me dataExportStore) generate(export models.DataExport) {
+	export.Status = models.DataExportFailed
+
+	size, file, err := me.build(export)
+	if err != nil {
+		log.Errorf("data export %d: %s", export.ID, err)
+	} else {
+		export.Status = models.DataExportReady
+		export.File = file
+		export.Size = size
+	}
+
+	if err := me.Db.Model(&models.DataExport{}).Where("id = ?", export.ID).
+		Updates(map[string]interface{}{"status": export.Status, "file": export.File, "size": export.Size}).Error; err != nil {
+		log.Errorf("data export %d: %s", export.ID, err)
+		return
+	}
+
+	var user models.User
+	if err := me.Db.Select("id", "profile_id").Where("id = ?", export.OwnerID).First(&user).Error; err != nil {
+		log.Errorf("data export %d: %s", export.ID, err)
+		return
+	}
+
+	j, err := json.Marshal(export)
+	if err != nil {
+		log.Errorf("data export %d: %s", export.ID, err)
+		return
+	}
+
+	me.Hub.EmitToMutationNamespace(user.ProfileID, "DATA_EXPORT_UPDATED", string(j), "exports")
+}
+
+func (me dataExportStore) build(export models.DataExport) (int64, string, error) {
+	dir, err := utils.CreateDirIfNotExists(me.FileStore.FileSystem, me.ExportPath)
+	if err != nil {
+		return 0, "", err
+	}
+
+	name := fmt.Sprintf("export-%d-%d-%s.zip", export.OwnerID, export.ID, utils.RandStringBytesMaskImprSrc(16))
+	path := filepath.Join(dir, name)
+
+	f, err := me.FileStore.FileSystem.OpenFile(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	cw := &countWriter{w: f}
+	zw := zip.NewWriter(cw)
+	if err := me.write(zw, export.OwnerID); err != nil {
+		return 0, "", err
+	}
+
+	if err := zw.Close(); err != nil {
+		return 0, "", err
+	}
+
+	return cw.n, path, nil
+}
+
+//write adds one JSON document per kind of data and the uploaded images to zw
+func (me dataExportStore) write(zw *zip.Writer, userID uint) error {
+	var user models.User
+	if err := me.Db.
+		Preload("Profile").
+		Preload("Profile.Languages").
+		Preload("Profile.Activities").
+		Preload("Roles").
+		Where("id = ?", userID).First(&user).Error; err != nil {
+		return err
+	}
+	user.Password = ""
+	profileID := user.ProfileID
+
+	var pages []models.Page
+	if err := me.Db.Preload("Images").Preload("Activities").Where("owner_id = ?", profileID).Find(&pages).Error; err != nil {
+		return err
+	}
+
+	var conversations []models.Conversation
+	if err := me.Db.Preload("Messages").Where("from_id = ? OR to_id = ?", profileID, profileID).Find(&conversations).Error; err != nil {
+		return err
+	}
+
+	//the counterparts email addresses are not the member's data
+	for i := range conversations {
+		for j := range conversations[i].Messages {
+			if conversations[i].Messages[j].FromID != profileID {
+				conversations[i].Messages[j].Email = ""
+			}
+		}
+	}
+
+	//the session IDs log the member in, only when its sessions were opened is exported
+	var sessions []struct {
+		Expires  time.Time `json:"expires"`
+		Validity uint      `json:"validity"`
+		Partial  bool      `json:"partial"`
+	}
+	if err := me.Db.Model(&models.Session{}).Select("expires", "validity", "partial").Where("owner_id = ?", userID).Find(&sessions).Error; err != nil {
+		return err
+	}
+
+	var follows []models.Page
+	if err := me.Db.Where("id IN (?)", me.Db.Table("user_page_follower").Select("page_id").Where("user_id = ?", userID)).Find(&follows).Error; err != nil {
+		return err
+	}
+
+	var events []models.AuditEvent
+	if err := me.Db.Where("actor_id = ?", userID).Order("id ASC").Find(&events).Error; err != nil {
+		return err
+	}
+
+	documents := []struct {
+		name string
+		v    interface{}
+	}{
+		{"user.json", user},
+		{"profile.json", user.Profile},
+		{"pages.json", pages},
+		{"conversations.json", conversations},
+		{"sessions.json", sessions},
+		{"follows.json", follows},
+		{"activity_log.json", events},
+	}
+
+	for _, d := range documents {
+		if err := writeZipJSON(zw, d.name, d.v); err != nil {
+			return err
+		}
+	}
+
+	files := []string{}
+	if user.Profile.Avatar != "" {
+		files = append(files, user.Profile.Avatar)
+	}
+	for _, p := range pages {
+		for _, i := range p.Images {
+			files = append(files, i.URL)
+		}
+	}
+
+	for _, file := range files {
+		if err := me.writeZipFile(zw, file); err != nil {
+			//a missing upload must not prevent the member from getting the rest
+			log.Errorf("data export: %s: %s", file, err)
+		}
+	}
+
+	return nil
+}
+
+//writeZipFile copies an uploaded file, stored relative to PublicPath, under images/ in zw
+func (me dataExportStore) writeZipFile(zw *zip.Writer, file string) error {
+	if strings.HasPrefix(file, "data:") || strings.Contains(file, "://") {
+		return fmt.Errorf("not an uploaded file")
+	}
+
+	src, err := me.FileStore.FileSystem.Open(filepath.Join(me.FileStore.PublicPath, filepath.Clean("/"+file)))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	rel := strings.TrimPrefix(filepath.ToSlash(filepath.Clean("/"+file)), "/")
+	rel = strings.TrimPrefix(rel, strings.Trim(filepath.ToSlash(me.FileStore.ImageBasePath), "/")+"/")
+
+	dst, err := zw.Create("images/" + rel)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+//countWriter counts the bytes written to w
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
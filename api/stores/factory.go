@@ -1,6 +1,8 @@
 package stores
 
 import (
+	"time"
+
 	"github.com/amaurybrisou/couchsport.back/api/types"
 	"github.com/amaurybrisou/couchsport.back/config"
 	"github.com/amaurybrisou/couchsport.back/localizer"
@@ -8,7 +10,9 @@ import (
 	"gorm.io/gorm"
 )
 
-//StoreFactory holds references to every Store in the application
+var _ Stores = StoreFactory{}
+
+//StoreFactory holds references to every Store in the application, it implements Stores
 type StoreFactory struct {
 	Db                *gorm.DB
 	localizer         *localizer.Localizer
@@ -23,6 +27,15 @@ type StoreFactory struct {
 	profileStore      profileStore
 	pageStore         pageStore
 	conversationStore conversationStore
+	twoFactorStore    twoFactorStore
+	loginAttemptStore loginAttemptStore
+	roleStore         roleStore
+	auditStore        auditStore
+	dataExportStore   dataExportStore
+	accountDeletion   accountDeletionStore
+	fixtureStore      fixtureStore
+	trashStore        trashStore
+	uploadStore       uploadStore
 }
 
 //NewStoreFactory is the first store layer. ask him what store you want
@@ -30,11 +43,22 @@ func NewStoreFactory(Db *gorm.DB, localizer *localizer.Localizer, c config.Confi
 
 	hub := newHub()
 
+	//a configuration which did not go through config.Load can leave them unset
+	graceDays, keepConversations := config.DefaultGraceDays, config.DefaultKeepConversations
+	if c.AccountDeletion.GraceDays != nil {
+		graceDays = *c.AccountDeletion.GraceDays
+	}
+	if c.AccountDeletion.KeepConversations != nil {
+		keepConversations = *c.AccountDeletion.KeepConversations
+	}
+
 	fileStore := fileStore{
 		FileSystem:    types.OsFS{},
 		PublicPath:    c.PublicPath,
 		ImageBasePath: c.ImageBasePath,
 		FilePrefix:    c.FilePrefix,
+		MaxWidth:      c.Images.MaxWidth,
+		MaxHeight:     c.Images.MaxHeight,
 	}
 
 	mailStore := mailStore{
@@ -47,44 +71,82 @@ func NewStoreFactory(Db *gorm.DB, localizer *localizer.Localizer, c config.Confi
 
 	profileStore := profileStore{Db: Db, FileStore: fileStore}
 
+	auditStore := auditStore{Db: Db}
+
+	userStore := userStore{Db: Db, Audit: auditStore}
+
+	roleStore := roleStore{Db: Db, Audit: auditStore}
+
 	return &StoreFactory{
+		Db:                Db,
 		localizer:         localizer,
 		wsStore:           hub,
 		mailStore:         mailStore,
 		activityStore:     activityStore{Db: Db},
 		languageStore:     languageStore{Db: Db},
-		imageStore:        imageStore{Db: Db},
-		userStore:         userStore{Db: Db},
-		sessionStore:      &sessionStore{Db: Db},
+		imageStore:        imageStore{Db: Db, Audit: auditStore},
+		userStore:         userStore,
+		sessionStore:      &sessionStore{Db: Db, Audit: auditStore},
 		fileStore:         fileStore,
 		profileStore:      profileStore,
-		pageStore:         pageStore{Db: Db, FileStore: fileStore, ProfileStore: profileStore},
-		conversationStore: conversationStore{Db: Db},
+		pageStore:         pageStore{Db: Db, Audit: auditStore, FileStore: fileStore, ProfileStore: profileStore},
+		conversationStore: conversationStore{Db: Db, Audit: auditStore},
+		auditStore:        auditStore,
+		dataExportStore:   dataExportStore{Db: Db, FileStore: fileStore, Hub: hub, ExportPath: c.ExportPath},
+		twoFactorStore:    twoFactorStore{Db: Db, Issuer: c.Name},
+		roleStore:         roleStore,
+		loginAttemptStore: loginAttemptStore{
+			Db:            Db,
+			Audit:         auditStore,
+			MaxAttempts:   c.Security.MaxLoginAttempts,
+			MaxIPAttempts: c.Security.MaxIPLoginAttempts,
+			Lockout:       time.Duration(c.Security.LockoutSeconds) * time.Second,
+			MaxLockout:    time.Duration(c.Security.MaxLockoutSeconds) * time.Second,
+		},
+		fixtureStore: fixtureStore{
+			Db:        Db,
+			FileStore: fileStore,
+			UserStore: userStore,
+			RoleStore: roleStore,
+			Path:      c.FixturePath,
+		},
+		accountDeletion: accountDeletionStore{
+			Db:                Db,
+			FileStore:         fileStore,
+			Audit:             auditStore,
+			GracePeriod:       time.Duration(graceDays) * 24 * time.Hour,
+			KeepConversations: keepConversations,
+		},
+		trashStore: trashStore{
+			Db:        Db,
+			FileStore: fileStore,
+			Audit:     auditStore,
+			Retention: time.Duration(c.Trash.RetentionDays) * 24 * time.Hour,
+		},
+		uploadStore: uploadStore{Db: Db, FileStore: fileStore, MaxSize: c.Uploads.MaxBytes},
 	}
 }
 
-//Init initialize Databse tables
+//Init starts the background jobs and seeds the reference data if populate is set,
+//the schema must be up to date, see the migrations package
 func (me StoreFactory) Init(populate bool) {
 	go me.wsStore.run()
+	go me.accountDeletion.run()
+	go me.pageStore.run()
+	go me.trashStore.run()
+	go me.uploadStore.run()
+	go me.dataExportStore.run()
 
 	if !populate {
 		return
 	}
 
-	log.Println("Migrating")
-	me.profileStore.Migrate()
-
-	me.userStore.Migrate() //profile needs profile
-
-	me.sessionStore.Migrate() //session needs user
-
-	me.languageStore.Migrate()     //language need profile
-	me.pageStore.Migrate()         //page needs profile
-	me.conversationStore.Migrate() //conversation needs profile
-
-	me.activityStore.Migrate() //activity needs page & profile
-	me.imageStore.Migrate()    //image needs page
+	log.Println("Seeding reference data")
+	me.roleStore.Seed()
 
+	if err := me.fixtureStore.LoadReference(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 //Localizer returns the application Localizer
@@ -93,56 +155,101 @@ func (me StoreFactory) Localizer() *localizer.Localizer {
 }
 
 //WsStore returns the app wesocket hub
-func (me StoreFactory) WsStore() *hub {
+func (me StoreFactory) WsStore() WsStore {
 	return me.wsStore
 }
 
 //MailStore returns the app mail client
-func (me StoreFactory) MailStore() *mailStore {
+func (me StoreFactory) MailStore() MailStore {
 	return &me.mailStore
 }
 
 //PageStore returns the app pageStore
-func (me StoreFactory) PageStore() *pageStore {
+func (me StoreFactory) PageStore() PageStore {
 	return &me.pageStore
 }
 
 //FileStore returns the app fileStore
-func (me StoreFactory) FileStore() *fileStore {
+func (me StoreFactory) FileStore() FileStore {
 	return &me.fileStore
 }
 
 //ImageStore returns the app imageStore
-func (me StoreFactory) ImageStore() *imageStore {
+func (me StoreFactory) ImageStore() ImageStore {
 	return &me.imageStore
 }
 
 //ProfileStore returns the app profileStore
-func (me StoreFactory) ProfileStore() *profileStore {
+func (me StoreFactory) ProfileStore() ProfileStore {
 	return &me.profileStore
 }
 
 //SessionStore returns the app sessionStore
-func (me StoreFactory) SessionStore() *sessionStore {
+func (me StoreFactory) SessionStore() SessionStore {
 	return me.sessionStore
 }
 
 //LanguageStore returns the app languageStore
-func (me StoreFactory) LanguageStore() *languageStore {
+func (me StoreFactory) LanguageStore() LanguageStore {
 	return &me.languageStore
 }
 
 //ActivityStore returns the app activityStore
-func (me StoreFactory) ActivityStore() *activityStore {
+func (me StoreFactory) ActivityStore() ActivityStore {
 	return &me.activityStore
 }
 
 //UserStore returns the app userStore
-func (me StoreFactory) UserStore() *userStore {
+func (me StoreFactory) UserStore() UserStore {
 	return &me.userStore
 }
 
 //ConversationStore returns the app userStore
-func (me StoreFactory) ConversationStore() *conversationStore {
+func (me StoreFactory) ConversationStore() ConversationStore {
 	return &me.conversationStore
 }
+
+//TwoFactorStore returns the app twoFactorStore
+func (me StoreFactory) TwoFactorStore() TwoFactorStore {
+	return &me.twoFactorStore
+}
+
+//LoginAttemptStore returns the app loginAttemptStore
+func (me StoreFactory) LoginAttemptStore() LoginAttemptStore {
+	return &me.loginAttemptStore
+}
+
+//RoleStore returns the app roleStore
+func (me StoreFactory) RoleStore() RoleStore {
+	return &me.roleStore
+}
+
+//AuditStore returns the app auditStore
+func (me StoreFactory) AuditStore() AuditStore {
+	return &me.auditStore
+}
+
+//DataExportStore returns the app dataExportStore
+func (me StoreFactory) DataExportStore() DataExportStore {
+	return &me.dataExportStore
+}
+
+//FixtureStore returns the app fixtureStore
+func (me StoreFactory) FixtureStore() FixtureStore {
+	return &me.fixtureStore
+}
+
+//AccountDeletionStore returns the app accountDeletionStore
+func (me StoreFactory) AccountDeletionStore() AccountDeletionStore {
+	return &me.accountDeletion
+}
+
+//TrashStore returns the app trashStore
+func (me StoreFactory) TrashStore() TrashStore {
+	return &me.trashStore
+}
+
+//UploadStore returns the app uploadStore
+func (me StoreFactory) UploadStore() UploadStore {
+	return &me.uploadStore
+}
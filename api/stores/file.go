@@ -2,10 +2,13 @@ package stores
 
 import (
 	"fmt"
+	"image"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/types"
 	"github.com/amaurybrisou/couchsport.back/api/utils"
 	log "github.com/sirupsen/logrus"
@@ -14,6 +17,8 @@ import (
 type fileStore struct {
 	PublicPath, ImageBasePath, FilePrefix string
 	FileSystem                            types.FileSystem
+	//MaxWidth and MaxHeight bound the images SaveImage writes, zero is not enforced
+	MaxWidth, MaxHeight int
 }
 
 //Save a file on the filesystem at path computed from ImageBasePath + directory + UserID
@@ -57,3 +62,161 @@ func (me fileStore) Save(directory, filename string, buf io.Reader) (string, err
 
 	return filepath.Join(path, filename), nil
 }
+
+//Append writes buf at the end of the file Save would write, the file is created if it does not exist.
+//It returns its path like Save, even when the copy fails part way
+func (me fileStore) Append(directory, filename string, buf io.Reader) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("filename is incorrect")
+	}
+
+	path := me.ImageBasePath
+	if directory != "" {
+		path += string(os.PathSeparator) + directory
+	}
+
+	fsPath, err := utils.CreateDirIfNotExists(me.FileSystem, filepath.Join(me.PublicPath, path))
+	if err != nil {
+		return "", err
+	}
+
+	filename = me.FilePrefix + filename
+	path = filepath.Join(path, filename)
+
+	f, err := me.FileSystem.OpenAppend(filepath.Join(fsPath, filename))
+	if err != nil {
+		return "", err
+	}
+
+	count, err := io.Copy(f, buf)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	log.Printf("%d bytes appended at %s", count, fsPath+"/"+filename)
+
+	return path, err
+}
+
+//SaveImage encodes img as mime and saves it like Save, scaled down to the max dimensions. The encoded image
+//carries no metadata, EXIF and GPS included. With variants, the thumbnail, medium and large copies are saved next to
+//it, their filename prefixed by their name. The files written are removed when one of them fails
+func (me fileStore) SaveImage(directory, filename, mime string, img image.Image, variants bool) (string, models.ImageVariants, error) {
+	saved := []string{}
+	save := func(filename string, img image.Image) (string, error) {
+		buf, err := utils.ImageToTypedImage(mime, img)
+		if err != nil {
+			return "", err
+		}
+
+		path, err := me.Save(directory, filename, buf)
+		if err != nil {
+			return "", err
+		}
+
+		saved = append(saved, path)
+		return path, nil
+	}
+
+	img = utils.Fit(img, me.MaxWidth, me.MaxHeight)
+
+	path, err := save(filename, img)
+	if err != nil {
+		return "", models.ImageVariants{}, err
+	}
+
+	urls := models.ImageVariants{}
+	if !variants {
+		return path, urls, nil
+	}
+
+	for _, v := range []struct {
+		name string
+		size int
+		url  *string
+	}{
+		{"thumbnail.", utils.ThumbnailSize, &urls.ThumbnailURL},
+		{"medium.", utils.MediumSize, &urls.MediumURL},
+		{"large.", utils.LargeSize, &urls.LargeURL},
+	} {
+		if *v.url, err = save(v.name+filename, utils.Fit(img, v.size, v.size)); err != nil {
+			for _, p := range saved {
+				if err := me.Delete(p); err != nil {
+					log.Warnf("image %s: %s", p, err)
+				}
+			}
+			return "", models.ImageVariants{}, err
+		}
+	}
+
+	return path, urls, nil
+}
+
+//Open returns the content of the file at path, a value Save returned
+func (me fileStore) Open(path string) (io.ReadCloser, error) {
+	if !me.Uploaded(path) {
+		return nil, fmt.Errorf("not an uploaded file: %.32s", path)
+	}
+
+	return me.FileSystem.Open(filepath.Join(me.PublicPath, filepath.Clean(string(os.PathSeparator)+path)))
+}
+
+//Size returns the size in bytes of the file at path, a value Save returned
+func (me fileStore) Size(path string) (int64, error) {
+	if !me.Uploaded(path) {
+		return 0, fmt.Errorf("not an uploaded file: %.32s", path)
+	}
+
+	info, err := me.FileSystem.Stat(filepath.Join(me.PublicPath, filepath.Clean(string(os.PathSeparator)+path)))
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+//Uploaded tells whether path is a file written by Save rather than a link to another site or inline data
+func (me fileStore) Uploaded(path string) bool {
+	if path == "" || strings.HasPrefix(path, "data:") || strings.Contains(path, "://") {
+		return false
+	}
+
+	base := filepath.Clean(string(os.PathSeparator) + me.ImageBasePath)
+	return strings.HasPrefix(filepath.Clean(string(os.PathSeparator)+path), base+string(os.PathSeparator))
+}
+
+//Within tells whether path is a file written by Save in one of directories, as named when saved
+func (me fileStore) Within(path string, directories ...string) bool {
+	if !me.Uploaded(path) {
+		return false
+	}
+
+	dir := filepath.Dir(cleanUpload(path))
+	for _, d := range directories {
+		if dir == cleanUpload(filepath.Join(me.ImageBasePath, d)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Delete removes a file previously written by Save, path is the value Save returned.
+//A file that no longer exists is not an error
+func (me fileStore) Delete(path string) error {
+	if !me.Uploaded(path) {
+		return fmt.Errorf("not an uploaded file: %.32s", path)
+	}
+
+	fsPath := filepath.Join(me.PublicPath, filepath.Clean(string(os.PathSeparator)+path))
+	if err := me.FileSystem.Remove(fsPath); err != nil {
+		if me.FileSystem.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("removed file %s", fsPath)
+
+	return nil
+}
@@ -1,11 +1,14 @@
 package stores
 
 import (
+	"image"
 	"io"
+	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/types"
 	"github.com/golang/leveldb/db"
 	"github.com/golang/leveldb/memfs"
@@ -20,6 +23,42 @@ func (mem memFS) OpenFile(name string) (io.WriteCloser, error) {
 	return f, err
 }
 
+//OpenAppend rewrites the content of name in a new file, memfs files cannot be reopened for writing
+func (mem memFS) OpenAppend(name string) (io.WriteCloser, error) {
+	var content []byte
+	if f, err := mem._os.Open(name); err == nil {
+		content, err = ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := mem._os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (mem memFS) Open(name string) (io.ReadCloser, error) {
+	return mem._os.Open(name)
+}
+
+func (mem memFS) Remove(name string) error {
+	return mem._os.Remove(name)
+}
+
+func (mem memFS) List(dir string) ([]string, error) {
+	return mem._os.List(dir)
+}
+
 func (mem memFS) Stat(name string) (os.FileInfo, error) {
 	return mem._os.Stat(name)
 }
@@ -199,3 +238,167 @@ func TestFileStore_Save(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_Delete(t *testing.T) {
+	memos := memFS{_os: memfs.New()}
+	app := fileStore{
+		FileSystem:    memos,
+		PublicPath:    "public/",
+		ImageBasePath: "static/img",
+		FilePrefix:    "isupload.",
+	}
+
+	saved, err := app.Save("user-3", "to-delete.jpg", strings.NewReader(`tototototo`))
+	if err != nil {
+		t.Fatalf("FileStore.Save() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "saved file", path: saved, wantErr: false},
+		{name: "already removed", path: saved, wantErr: false},
+		{name: "empty path", path: "", wantErr: true},
+		{name: "remote url", path: "https://example.com/image.jpg", wantErr: true},
+		{name: "data url", path: "data:image/png;base64,AAAA", wantErr: true},
+		{name: "outside the image base path", path: "exports/1.zip", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := app.Delete(tt.path); (err != nil) != tt.wantErr {
+				t.Errorf("FileStore.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := memos.Stat(app.PublicPath + saved); err == nil {
+		t.Errorf("file %s still exists", saved)
+	}
+}
+
+func TestFileStore_Within(t *testing.T) {
+	app := fileStore{ImageBasePath: "static/img"}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "in a directory", path: "static/img/user-3/isupload.me.png", want: true},
+		{name: "rooted", path: "/static/img/upload-3/isupload.me.png", want: true},
+		{name: "other member", path: "static/img/user-4/isupload.me.png", want: false},
+		{name: "member with a longer id", path: "static/img/user-31/isupload.me.png", want: false},
+		{name: "climbing out", path: "static/img/user-3/../user-4/isupload.me.png", want: false},
+		{name: "nested", path: "static/img/user-3/more/isupload.me.png", want: false},
+		{name: "remote url", path: "https://example.com/static/img/user-3/me.png", want: false},
+		{name: "data url", path: "data:image/png;base64,AAAA", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := app.Within(tt.path, "user-3", "upload-3"); got != tt.want {
+				t.Errorf("FileStore.Within(%s) = %t, want %t", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStore_Append(t *testing.T) {
+	memos := memFS{_os: memfs.New()}
+	app := fileStore{
+		FileSystem:    memos,
+		PublicPath:    "public/",
+		ImageBasePath: "static/img",
+		FilePrefix:    "isupload.",
+	}
+
+	for _, chunk := range []string{"toto", "", "tutu"} {
+		got, err := app.Append("upload-3", "chunked.jpg", strings.NewReader(chunk))
+		if err != nil {
+			t.Fatalf("FileStore.Append(%q) error = %v", chunk, err)
+		}
+
+		if want := "static/img/upload-3/isupload.chunked.jpg"; got != want {
+			t.Fatalf("FileStore.Append(%q) = %v, want %v", chunk, got, want)
+		}
+	}
+
+	size, err := app.Size("static/img/upload-3/isupload.chunked.jpg")
+	if err != nil || size != 8 {
+		t.Fatalf("FileStore.Size() = %d, %v, want 8", size, err)
+	}
+
+	f, err := app.Open("static/img/upload-3/isupload.chunked.jpg")
+	if err != nil {
+		t.Fatalf("FileStore.Open() error = %v", err)
+	}
+	defer f.Close()
+
+	if b, err := ioutil.ReadAll(f); err != nil || string(b) != "tototutu" {
+		t.Errorf("FileStore.Open() content = %q, %v, want tototutu", b, err)
+	}
+
+	if _, err := app.Append("upload-3", "", strings.NewReader("toto")); err == nil {
+		t.Errorf("FileStore.Append() without filename error = nil, want an error")
+	}
+}
+
+func TestFileStore_SaveImage(t *testing.T) {
+	memos := memFS{_os: memfs.New()}
+	app := fileStore{
+		FileSystem:    memos,
+		PublicPath:    "public/",
+		ImageBasePath: "static/img",
+		FilePrefix:    "isupload.",
+		MaxWidth:      1000,
+		MaxHeight:     1000,
+	}
+
+	size := func(path string) image.Point {
+		f, err := app.Open(path)
+		if err != nil {
+			t.Fatalf("FileStore.Open(%s) error = %v", path, err)
+		}
+		defer f.Close()
+
+		img, _, err := image.Decode(f)
+		if err != nil {
+			t.Fatalf("FileStore.SaveImage() wrote %s, not an image: %v", path, err)
+		}
+		return img.Bounds().Size()
+	}
+
+	got, variants, err := app.SaveImage("page-3", "beach.png", "image/png", image.NewGray(image.Rect(0, 0, 1200, 600)), true)
+	if err != nil {
+		t.Fatalf("FileStore.SaveImage() error = %v", err)
+	}
+
+	if want := "static/img/page-3/isupload.beach.png"; got != want || size(got) != image.Pt(1000, 500) {
+		t.Errorf("FileStore.SaveImage() = %v of %v, want %v of 1000x500", got, size(got), want)
+	}
+
+	want := map[string]image.Point{
+		"static/img/page-3/isupload.thumbnail.beach.png": image.Pt(256, 128),
+		"static/img/page-3/isupload.medium.beach.png":    image.Pt(800, 400),
+		"static/img/page-3/isupload.large.beach.png":     image.Pt(1000, 500),
+	}
+	for _, v := range variants.URLs() {
+		if s, ok := want[v]; !ok || size(v) != s {
+			t.Errorf("FileStore.SaveImage() variant %v of %v, want one of %v", v, size(v), want)
+		}
+		delete(want, v)
+	}
+
+	if len(want) > 0 {
+		t.Errorf("FileStore.SaveImage() variants %v not saved", want)
+	}
+
+	if _, variants, err := app.SaveImage("user-3", "avatar.png", "image/png", image.NewGray(image.Rect(0, 0, 10, 10)), false); err != nil || variants != (models.ImageVariants{}) {
+		t.Errorf("FileStore.SaveImage() without variants = %+v, %v", variants, err)
+	}
+
+	if _, _, err := app.SaveImage("page-3", "beach.svg", "image/svg+xml", image.NewGray(image.Rect(0, 0, 10, 10)), true); err == nil {
+		t.Errorf("FileStore.SaveImage() of an unsupported type error = nil, want an error")
+	}
+}
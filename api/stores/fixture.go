@@ -0,0 +1,281 @@
+package stores
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//fixture files, relative to fixtureStore.Path
+const (
+	fixtureLanguages  = "reference/languages.json"
+	fixtureActivities = "reference/activities.json"
+	fixtureDemo       = "demo/users.json"
+)
+
+type fixtureStore struct {
+	Db        *gorm.DB
+	FileStore fileStore
+	UserStore userStore
+	RoleStore roleStore
+	Path      string
+}
+
+//LoadReference inserts or updates the languages and activities, files already loaded at their version are skipped
+func (me fixtureStore) LoadReference() error {
+	var languages models.LanguagesFixture
+	if err := me.load(fixtureLanguages, &languages, func() uint { return languages.Version }, func() error {
+		return me.loadLanguages(languages.Languages)
+	}); err != nil {
+		return err
+	}
+
+	var activities models.ActivitiesFixture
+	return me.load(fixtureActivities, &activities, func() uint { return activities.Version }, func() error {
+		return me.loadActivities(activities.Activities)
+	})
+}
+
+//LoadDemo creates the demo users with their profile and pages, existing emails are left untouched.
+//The reference data must be loaded first
+func (me fixtureStore) LoadDemo() error {
+	var demo models.DemoFixture
+	return me.load(fixtureDemo, &demo, func() uint { return demo.Version }, func() error {
+		for _, u := range demo.Users {
+			if err := me.loadDemoUser(u); err != nil {
+				return fmt.Errorf("%s: %s", u.Email, err)
+			}
+		}
+		return nil
+	})
+}
+
+//load reads name into content and calls fn unless this version of the file was already loaded
+func (me fixtureStore) load(name string, content interface{}, version func() uint, fn func() error) error {
+	b, err := ioutil.ReadFile(filepath.Join(me.Path, name))
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, content); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+
+	var loaded models.Fixture
+	err = me.Db.Where("name = ?", name).First(&loaded).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err == nil && loaded.Version >= version() {
+		log.Printf("fixture %s v%d already loaded", name, loaded.Version)
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+
+	log.Printf("fixture %s v%d loaded", name, version())
+
+	return me.Db.Save(&models.Fixture{Name: name, Version: version(), LoadedAt: time.Now()}).Error
+}
+
+//loadLanguages matches languages on their code, rows created before codes existed are matched on their native name
+func (me fixtureStore) loadLanguages(languages []models.Language) error {
+	return me.Db.Transaction(func(tx *gorm.DB) error {
+		for _, l := range languages {
+			var existing models.Language
+			err := tx.Where("code = ?", l.Code).
+				Or("(code IS NULL OR code = '') AND native_name = ?", l.NativeName).
+				First(&existing).Error
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				l.ID = 0
+				if err := tx.Create(&l).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Model(&existing).Updates(map[string]interface{}{
+				"code":        l.Code,
+				"name":        l.Name,
+				"native_name": l.NativeName,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (me fixtureStore) loadActivities(activities []models.Activity) error {
+	return me.Db.Transaction(func(tx *gorm.DB) error {
+		for _, a := range activities {
+			if err := tx.FirstOrCreate(&models.Activity{}, models.Activity{Name: a.Name}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (me fixtureStore) loadDemoUser(u models.DemoUser) error {
+	var count int64
+	if err := me.Db.Model(&models.User{}).Where("email = ?", u.Email).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	user, err := me.UserStore.New(models.User{Email: u.Email, Password: u.Password})
+	if err != nil {
+		return err
+	}
+
+	for _, role := range u.Roles {
+		if err := me.RoleStore.grant(user.ID, role); err != nil {
+			return err
+		}
+	}
+
+	languages, err := me.languages(u.Profile.Languages)
+	if err != nil {
+		return err
+	}
+
+	activities, err := me.activities(u.Profile.Activities)
+	if err != nil {
+		return err
+	}
+
+	profile := models.Profile{}
+	profile.ID = user.ProfileID
+	if err := me.Db.Model(&profile).Updates(map[string]interface{}{
+		"username":      u.Profile.Username,
+		"firstname":     u.Profile.Firstname,
+		"lastname":      u.Profile.Lastname,
+		"gender":        u.Profile.Gender,
+		"phone":         u.Profile.Phone,
+		"street_number": u.Profile.StreetNumber,
+		"street_name":   u.Profile.StreetName,
+		"zip_code":      u.Profile.ZipCode,
+		"city":          u.Profile.City,
+		"country":       u.Profile.Country,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := me.Db.Model(&profile).Association("Languages").Replace(languages); err != nil {
+		return err
+	}
+
+	if err := me.Db.Model(&profile).Association("Activities").Replace(activities); err != nil {
+		return err
+	}
+
+	for _, page := range u.Pages {
+		if err := me.loadDemoPage(profile.ID, page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//loadDemoPage copies the page images from the fixture directory to the public one, like an upload would
+func (me fixtureStore) loadDemoPage(profileID uint, page models.Page) error {
+	activities, err := me.activities(page.Activities)
+	if err != nil {
+		return err
+	}
+
+	directory := pageDirectory(profileID)
+
+	images := make([]models.Image, 0, len(page.Images))
+	for _, i := range page.Images {
+		f, err := me.FileStore.FileSystem.Open(filepath.Join(me.Path, filepath.Dir(fixtureDemo), i.URL))
+		if err != nil {
+			return err
+		}
+
+		url, err := me.FileStore.Save(directory, filepath.Base(i.URL), f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		images = append(images, models.Image{URL: url, Alt: i.Alt})
+	}
+
+	page.ID = 0
+	page.OwnerID = profileID
+	page.Images = images
+	page.Activities = activities
+	page.Followers = nil
+	//the demo pages are published as they are
+	page.Status = models.PageDraft
+	if page.Public {
+		page.Status = models.PagePublished
+	}
+
+	return me.Db.Create(&page).Error
+}
+
+func (me fixtureStore) languages(in []*models.Language) ([]*models.Language, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	codes := make([]string, 0, len(in))
+	for _, l := range in {
+		codes = append(codes, l.Code)
+	}
+
+	var languages []*models.Language
+	if err := me.Db.Where("code IN ?", codes).Find(&languages).Error; err != nil {
+		return nil, err
+	}
+
+	if len(languages) != len(in) {
+		return nil, fmt.Errorf("unknown language in %v", codes)
+	}
+
+	return languages, nil
+}
+
+func (me fixtureStore) activities(in []*models.Activity) ([]*models.Activity, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(in))
+	for _, a := range in {
+		names = append(names, a.Name)
+	}
+
+	var activities []*models.Activity
+	if err := me.Db.Where("name IN ?", names).Find(&activities).Error; err != nil {
+		return nil, err
+	}
+
+	if len(activities) != len(in) {
+		return nil, fmt.Errorf("unknown activity in %v", names)
+	}
+
+	return activities, nil
+}
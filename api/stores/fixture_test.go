@@ -0,0 +1,78 @@
+package stores
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+const testFixturePath = "../../fixtures"
+
+func readFixture(t *testing.T, name string, content interface{}) {
+	b, err := ioutil.ReadFile(filepath.Join(testFixturePath, name))
+	if err != nil {
+		t.Fatalf("could not read %s: %s", name, err)
+	}
+
+	if err := json.Unmarshal(b, content); err != nil {
+		t.Fatalf("could not parse %s: %s", name, err)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	var languages models.LanguagesFixture
+	readFixture(t, fixtureLanguages, &languages)
+
+	codes := map[string]bool{}
+	for _, l := range languages.Languages {
+		if len(l.Code) != 2 || l.Name == "" || l.NativeName == "" {
+			t.Errorf("invalid language %+v", l)
+		}
+		if codes[l.Code] {
+			t.Errorf("duplicated language code %s", l.Code)
+		}
+		codes[l.Code] = true
+	}
+
+	var activities models.ActivitiesFixture
+	readFixture(t, fixtureActivities, &activities)
+
+	names := map[string]bool{}
+	for _, a := range activities.Activities {
+		if a.Name == "" || names[a.Name] {
+			t.Errorf("invalid or duplicated activity %q", a.Name)
+		}
+		names[a.Name] = true
+	}
+
+	var demo models.DemoFixture
+	readFixture(t, fixtureDemo, &demo)
+
+	for _, u := range demo.Users {
+		for _, l := range u.Profile.Languages {
+			if !codes[l.Code] {
+				t.Errorf("%s: unknown language %s", u.Email, l.Code)
+			}
+		}
+
+		activities := u.Profile.Activities
+		for _, p := range u.Pages {
+			activities = append(activities, p.Activities...)
+
+			for _, i := range p.Images {
+				if _, err := ioutil.ReadFile(filepath.Join(testFixturePath, filepath.Dir(fixtureDemo), i.URL)); err != nil {
+					t.Errorf("%s: missing image %s", u.Email, i.URL)
+				}
+			}
+		}
+
+		for _, a := range activities {
+			if !names[a.Name] {
+				t.Errorf("%s: unknown activity %s", u.Email, a.Name)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ package stores
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
@@ -23,8 +24,9 @@ type query struct {
 //hub maintains the set of active clients and broadcasts messages to the
 // clients.
 type hub struct {
-	// Registered clients.
+	// Registered clients, written by run and read by the emitters under mu.
 	clients map[uint]*client
+	mu      sync.RWMutex
 
 	close chan bool
 	// Inbound messages from the clients.
@@ -57,13 +59,18 @@ func (me *hub) run() {
 		case q := <-me.dispatch:
 			me.handleQueries(q)
 		case client := <-me.register:
+			me.mu.Lock()
 			me.clients[client.ID] = client
+			me.mu.Unlock()
 		case client := <-me.unregister:
+			me.mu.Lock()
 			if _, ok := me.clients[client.ID]; ok {
 				delete(me.clients, client.ID)
 				close(client.send)
 			}
+			me.mu.Unlock()
 		case message := <-me.broadcast:
+			me.mu.Lock()
 			for _, client := range me.clients {
 				select {
 				case client.send <- message:
@@ -72,6 +79,7 @@ func (me *hub) run() {
 					delete(me.clients, client.ID)
 				}
 			}
+			me.mu.Unlock()
 		}
 	}
 }
@@ -138,25 +146,37 @@ func (me *hub) EmitToMutationNamespace(profileID uint, action, message, namespac
 	}
 }
 
+//emit sends q to its client without blocking, the send channel is only closed by run under the write lock
 func (me *hub) emit(q query) error {
+	jsonBody, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+
 	c := me.clients[q.ID]
 	if c == nil {
 		return fmt.Errorf("%s", "client not connected")
 	}
 
-	jsonBody, err := json.Marshal(q)
-	if err != nil {
-		return err
+	select {
+	case c.send <- jsonBody:
+	default:
+		return fmt.Errorf("client %d is not reading its messages", q.ID)
 	}
 
-	c.send <- jsonBody
-
 	return nil
 }
 
 func (me *hub) Close(signalDone chan bool) {
-	log.Printf("Pool length : %d", len(me.clients))
-	for i := 0; i < len(me.clients)*2; i++ {
+	me.mu.RLock()
+	clients := len(me.clients)
+	me.mu.RUnlock()
+
+	log.Printf("Pool length : %d", clients)
+	for i := 0; i < clients*2; i++ {
 		me.close <- true
 	}
 	signalDone <- true
@@ -0,0 +1,35 @@
+package stores
+
+import (
+	"sync"
+	"testing"
+)
+
+//TestHub_Emit is meant for go test -race, the clients are emitted to while the hub registers and unregisters them
+func TestHub_Emit(t *testing.T) {
+	h := newHub()
+	go h.run()
+
+	var wg sync.WaitGroup
+	for i := uint(1); i <= 10; i++ {
+		c := &client{ID: i, hub: h, send: make(chan []byte, 1)}
+		h.register <- c
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				h.Emit(c.ID, "message.new", "hello")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			h.unregister <- c
+		}()
+	}
+	wg.Wait()
+
+	if err := h.emit(query{ID: 1}); err == nil {
+		t.Errorf("emitting to an unregistered client should fail")
+	}
+}
@@ -6,16 +6,8 @@ import (
 )
 
 type imageStore struct {
-	Db *gorm.DB
-}
-
-//Migrate creates the db table
-func (me imageStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Image{})
-	if err != nil {
-		panic(err)
-	}
-	// me.Db.Model(&models.Image{}).AddForeignKey("owner_id", "pages(id)", "CASCADE", "RESTRICT")
+	Db    *gorm.DB
+	Audit auditStore
 }
 
 //All returns all the images in db
@@ -27,11 +19,18 @@ func (me imageStore) All() ([]models.Image, error) {
 	return images, nil
 }
 
-//Delete an image by ID
-func (me imageStore) Delete(imageID uint) (bool, error) {
-	if err := me.Db.Unscoped().Exec("DELETE FROM images WHERE id = ? ", imageID).Error; err != nil {
-		return false, err
+//Delete moves an image to the trash
+func (me imageStore) Delete(imageID uint, actor models.Actor) (bool, error) {
+	res := me.Db.Where("id = ?", imageID).Delete(&models.Image{})
+	if res.Error != nil {
+		return false, res.Error
 	}
 
+	if res.RowsAffected == 0 {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	me.Audit.Record(actor, auditImageDelete, "image", imageID, nil)
+
 	return true, nil
 }
@@ -0,0 +1,246 @@
+package stores
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"github.com/gorilla/websocket"
+)
+
+//VersionConflict is the error of an update applied to a version of a resource which is not the last one
+func VersionConflict(resource string, id, version uint) error {
+	return apperror.New(http.StatusPreconditionFailed, apperror.PreconditionFailed, fmt.Errorf("%s %d is no longer at version %d", resource, id, version))
+}
+
+//Stores gives access to every store, the handlers depend on it rather than on StoreFactory
+//so they can run against the in-memory stores of the memory package
+type Stores interface {
+	Localizer() *localizer.Localizer
+	WsStore() WsStore
+	MailStore() MailStore
+	PageStore() PageStore
+	FileStore() FileStore
+	ImageStore() ImageStore
+	ProfileStore() ProfileStore
+	SessionStore() SessionStore
+	LanguageStore() LanguageStore
+	ActivityStore() ActivityStore
+	UserStore() UserStore
+	ConversationStore() ConversationStore
+	TwoFactorStore() TwoFactorStore
+	LoginAttemptStore() LoginAttemptStore
+	RoleStore() RoleStore
+	AuditStore() AuditStore
+	DataExportStore() DataExportStore
+	FixtureStore() FixtureStore
+	AccountDeletionStore() AccountDeletionStore
+	TrashStore() TrashStore
+	UploadStore() UploadStore
+}
+
+//WsStore pushes mutations to the connected members
+type WsStore interface {
+	Register(profileID uint, conn *websocket.Conn)
+	Emit(profileID uint, action, message string)
+	EmitToNamespace(profileID uint, action, message, namespace string)
+	EmitToMutationNamespace(profileID uint, action, message, namespace string)
+	Close(signalDone chan bool)
+}
+
+//MailStore sends the application emails
+type MailStore interface {
+	AccountAutoCreated(email, password, locale string)
+	PageRejected(email, page, reason, locale string)
+}
+
+//PageStore manages the pages
+type PageStore interface {
+	All(keys url.Values) ([]models.Page, error)
+	GetByID(pageID uint) (models.Page, error)
+	GetPagesByOwnerID(profileID uint) ([]models.Page, error)
+	New(profileID uint, page models.Page, actor models.Actor) (models.Page, error)
+	//Update writes the fields of page, their Go names, if page.Version is still the last version
+	//of the page, VersionConflict otherwise. It returns the updated page
+	Update(userID uint, page models.Page, fields []string, actor models.Actor) (models.Page, error)
+	Delete(userID, pageID uint, actor models.Actor) (bool, error)
+	//SetStatus moves pageID to status if it is still in one of the statuses from, the page.invalid_transition
+	//conflict otherwise, and still at version unless it is 0, VersionConflict otherwise. reason is kept for the
+	//owner when the moderators reject the page
+	SetStatus(pageID, version uint, from []string, status, reason string, actor models.Actor) (models.Page, error)
+	//ByStatus returns a page of the pages in status, the least recently updated first, and their total
+	ByStatus(status string, offset, limit int) ([]models.Page, int64, error)
+	//ApplySchedule publishes the scheduled pages whose PublishAt is past and unpublishes the published pages
+	//whose UnpublishAt is past, it returns how many pages changed
+	ApplySchedule(now time.Time) (int, error)
+}
+
+//FileStore writes and removes the uploaded files
+type FileStore interface {
+	Save(directory, filename string, buf io.Reader) (string, error)
+	Delete(path string) error
+}
+
+//ImageStore manages the page images
+type ImageStore interface {
+	All() ([]models.Image, error)
+	Delete(imageID uint, actor models.Actor) (bool, error)
+}
+
+//ProfileStore manages the profiles
+type ProfileStore interface {
+	All() ([]models.Profile, error)
+	//Search returns a page of the listed profiles matching filter by username and their total, a profile is listed
+	//once it has a username and while its user is not suspended
+	Search(filter models.ProfileFilter, offset, limit int) ([]models.Profile, int64, error)
+	GetByID(profileID uint) (models.Profile, error)
+	GetByUsername(username string) (models.Profile, error)
+	//Update writes the fields of profile like PageStore.Update
+	Update(userID uint, profile models.Profile, fields []string) (models.Profile, error)
+}
+
+//SessionStore opens and checks the login sessions, the tokens are returned to the caller
+type SessionStore interface {
+	CreateOrRetrieve(userID uint, actor models.Actor) (string, error)
+	CreatePartial(userID uint, actor models.Actor) (string, error)
+	Confirm(session *models.Session, actor models.Actor) (string, error)
+	GetSession(r *http.Request) (*models.Session, error)
+	GetCookieFromRequest(r *http.Request) (*http.Cookie, error)
+	Destroy(r *http.Request, actor models.Actor) (bool, error)
+	DestroyAllByUserID(userID uint) (bool, error)
+	CreateCookie(token string) (*http.Cookie, error)
+}
+
+//LanguageStore manages the languages reference data
+type LanguageStore interface {
+	All() ([]models.Language, error)
+	New(language models.Language) (models.Language, error)
+	Update(language models.Language) (models.Language, error)
+	Delete(languageID uint) (bool, error)
+}
+
+//ActivityStore manages the activities reference data
+type ActivityStore interface {
+	All() ([]models.Activity, error)
+	New(activity models.Activity) (models.Activity, error)
+	Update(activity models.Activity) (models.Activity, error)
+	Delete(activityID uint) (bool, error)
+}
+
+//UserStore manages the user accounts and tells who owns what
+type UserStore interface {
+	All(keys url.Values) ([]models.User, error)
+	Search(query string, offset, limit int) ([]models.User, error)
+	Suspend(userID uint, suspended bool, actor models.Actor) (bool, error)
+	New(user models.User) (models.User, error)
+	NewWithoutPassword(email string) (models.User, error)
+	ChangePassword(userID uint, user models.User, actor models.Actor) (models.User, error)
+	RehashPassword(userID uint, password string) error
+	GetProfile(userID uint) (models.Profile, error)
+	GetProfileID(userID uint) (uint, error)
+	GetByID(userID uint) (models.User, error)
+	GetByEmail(email string, create bool) (models.User, error)
+	OwnImage(userID, pageID, imageID uint) (bool, error)
+	OwnPage(userID, pageID uint) (bool, error)
+	OwnConversation(userID, conversationID uint) (bool, uint, error)
+	OwnProfile(userID, profileID uint) (bool, error)
+}
+
+//ConversationStore manages the conversations between profiles and their reports
+type ConversationStore interface {
+	Delete(conversationID uint, actor models.Actor) (bool, error)
+	ProfileConversations(profileID uint) ([]models.Conversation, error)
+	GetByReferents(fromProfile, toProfile models.Profile) (models.Conversation, error)
+	AddMessage(conversation models.Conversation, fromID, toID uint, fromEmail, text string) (models.Conversation, models.Message, error)
+	Save(conversation models.Conversation) (models.Conversation, error)
+	Report(conversationID, profileID uint, reason string, actor models.Actor) (bool, error)
+	Reported() ([]models.Conversation, error)
+	GetReported(conversationID uint) (models.Conversation, error)
+}
+
+//TwoFactorStore manages the TOTP secrets and the recovery codes
+type TwoFactorStore interface {
+	Enroll(userID uint) (string, string, error)
+	Confirm(userID uint, code string) ([]string, error)
+	Verify(userID uint, code string) (bool, error)
+	RegenerateRecoveryCodes(userID uint) ([]string, error)
+	Disable(userID uint) (bool, error)
+}
+
+//LoginAttemptStore counts the failed logins and locks accounts and ips
+type LoginAttemptStore interface {
+	LockedFor(email, ip string) (time.Duration, error)
+	Fail(email string, userID uint, actor models.Actor) (time.Duration, error)
+	Succeed(email string) error
+}
+
+//RoleStore manages the roles, their permissions and who holds them
+type RoleStore interface {
+	Seed()
+	All() ([]models.Role, error)
+	UserRoles(userID uint) ([]*models.Role, error)
+	HasPermission(userID uint, permission string) (bool, error)
+	Grant(userID uint, roleName string, actor models.Actor) ([]*models.Role, error)
+	Revoke(userID uint, roleName string, actor models.Actor) ([]*models.Role, error)
+}
+
+//AuditStore records and queries the audit log
+type AuditStore interface {
+	Record(actor models.Actor, action, targetType string, targetID uint, metadata map[string]interface{})
+	Query(filter models.AuditFilter, offset, limit int) ([]models.AuditEvent, int64, error)
+	Export(filter models.AuditFilter, w io.Writer) error
+}
+
+//DataExportStore builds and serves the members data archives
+type DataExportStore interface {
+	//Request answers a data_export.already_pending conflict while an export of userID is being built
+	Request(userID uint) (models.DataExport, error)
+	All(userID uint) ([]models.DataExport, error)
+	Open(userID, exportID uint) (models.DataExport, io.ReadCloser, error)
+	PurgeExpired(now time.Time) (int, error)
+}
+
+//FixtureStore loads the reference and demo data
+type FixtureStore interface {
+	LoadReference() error
+	LoadDemo() error
+}
+
+//AccountDeletionStore schedules and purges the account deletions
+type AccountDeletionStore interface {
+	Schedule(userID uint, actor models.Actor) (time.Time, error)
+	Cancel(userID uint, actor models.Actor) (bool, error)
+	PurgeDue(now time.Time) (int, error)
+	Purge(userID uint, actor models.Actor) error
+}
+
+//UploadStore receives the files the page images and the avatars reference by upload ID, and collects the uploaded
+//files no row references anymore for the gc command
+type UploadStore interface {
+	Limit() int64
+	Receive(userID uint, filename string, r io.Reader) (models.Upload, error)
+	Start(userID uint, filename string, size int64) (models.Upload, error)
+	//Append writes the chunk of the upload at offset, the upload.offset_mismatch conflict when it is not
+	//the received size
+	Append(userID, uploadID uint, offset int64, chunk io.Reader) (models.Upload, error)
+	Get(userID, uploadID uint) (models.Upload, error)
+	Ready(userID, uploadID uint) (models.Upload, error)
+	Consume(userID uint, uploadIDs []uint) error
+	PurgeExpired(now time.Time) (int, error)
+	Collect(now time.Time, minAge time.Duration, dryRun bool) (models.UploadReport, error)
+}
+
+//TrashStore keeps the deleted pages and images until their retention period is over
+type TrashStore interface {
+	Content(profileID uint) (models.Trash, error)
+	//RestorePage takes the deleted pageID of profileID out of the trash, NotFound when it is not there
+	RestorePage(profileID, pageID uint, actor models.Actor) (models.Page, error)
+	//RestoreImage takes the deleted imageID of pageID out of the trash like RestorePage
+	RestoreImage(profileID, pageID, imageID uint, actor models.Actor) (models.Image, error)
+	PurgeDue(now time.Time) (int, error)
+}
@@ -0,0 +1,109 @@
+package stores
+
+import (
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/gorm"
+)
+
+type loginAttemptStore struct {
+	Db                         *gorm.DB
+	Audit                      auditStore
+	MaxAttempts, MaxIPAttempts int
+	Lockout, MaxLockout        time.Duration
+}
+
+//LockedFor returns the remaining lock duration for the account email or the client ip, the longest wins
+func (me loginAttemptStore) LockedFor(email, ip string) (time.Duration, error) {
+	var attempts []models.LoginAttempt
+	if err := me.Db.Where("attempt_key IN ?", []string{accountKey(email), ipKey(ip)}).Find(&attempts).Error; err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var locked time.Duration
+	for _, a := range attempts {
+		if d := a.LockedFor(now); d > locked {
+			locked = d
+		}
+	}
+
+	return locked, nil
+}
+
+//Fail records a failed login for email from the ip of actor and returns the resulting lock duration,
+//userID is the account of email, 0 when no account has it
+func (me loginAttemptStore) Fail(email string, userID uint, actor models.Actor) (time.Duration, error) {
+	var locked time.Duration
+
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		for key, max := range map[string]int{accountKey(email): me.MaxAttempts, ipKey(actor.IP): me.MaxIPAttempts} {
+			d, err := me.fail(tx, key, max)
+			if err != nil {
+				return err
+			}
+			if d > locked {
+				locked = d
+			}
+		}
+		return nil
+	})
+
+	me.Audit.Record(actor, auditLoginFailed, "user", userID, map[string]interface{}{"email": email, "locked_seconds": int(locked.Seconds())})
+
+	return locked, err
+}
+
+//Succeed clears the failures of the account, the ip counter is left to decay
+func (me loginAttemptStore) Succeed(email string) error {
+	return me.Db.Where("attempt_key = ?", accountKey(email)).Delete(&models.LoginAttempt{}).Error
+}
+
+func (me loginAttemptStore) fail(tx *gorm.DB, key string, max int) (time.Duration, error) {
+	now := time.Now()
+
+	attempt := models.LoginAttempt{Key: key}
+	if err := tx.Where("attempt_key = ?", key).FirstOrInit(&attempt).Error; err != nil {
+		return 0, err
+	}
+
+	//failures older than the longest lock are forgotten
+	if now.Sub(attempt.LastFailure) > me.MaxLockout {
+		attempt.Failures = 0
+	}
+
+	attempt.Failures++
+	attempt.LastFailure = now
+
+	if attempt.Failures >= max {
+		attempt.LockedUntil = now.Add(me.backoff(attempt.Failures - max))
+	}
+
+	if err := tx.Save(&attempt).Error; err != nil {
+		return 0, err
+	}
+
+	return attempt.LockedFor(now), nil
+}
+
+//backoff doubles the lockout for every failure past the threshold
+func (me loginAttemptStore) backoff(over int) time.Duration {
+	d := me.Lockout
+	for i := 0; i < over && d < me.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > me.MaxLockout {
+		d = me.MaxLockout
+	}
+	return d
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
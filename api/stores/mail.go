@@ -43,46 +43,45 @@ func (me *mailStore) sendMailTLS(mail models.Mail) error {
 	// from the very beginning (no starttls)
 	conn, err := tls.Dial("tcp", smtpServer, tlsconfig)
 	if err != nil {
-		log.Error(err)
+		return err
 	}
 
 	c, err := smtp.NewClient(conn, me.Server)
 	if err != nil {
-		log.Error(err)
+		conn.Close()
+		return err
 	}
+	defer c.Close()
 
 	// Auth
 	if err = c.Auth(auth); err != nil {
-		log.Error(err)
+		return err
 	}
 
 	// To && From
 	if err = c.Mail(mail.From); err != nil {
-		log.Error(err)
+		return err
 	}
 
 	if err = c.Rcpt(mail.To[0]); err != nil {
-		log.Error(err)
+		return err
 	}
 
 	// Data
 	w, err := c.Data()
 	if err != nil {
-		log.Error(err)
+		return err
 	}
 
-	_, err = w.Write([]byte(mail.Body))
-	if err != nil {
-		log.Error(err)
+	if _, err = w.Write([]byte(mail.Body)); err != nil {
+		return err
 	}
 
-	err = w.Close()
-	if err != nil {
-		log.Error(err)
+	if err = w.Close(); err != nil {
+		return err
 	}
 
-	err = c.Quit()
-	if err != nil {
+	if err = c.Quit(); err != nil {
 		return err
 	}
 
@@ -132,3 +131,30 @@ func (me *mailStore) AccountAutoCreated(email, password, locale string) {
 		log.Error(err)
 	}
 }
+
+//PageRejected tells the owner of a page why the moderators did not publish it
+func (me *mailStore) PageRejected(email, page, reason, locale string) {
+	log.Printf("sending 'PageRejected' email to %s", email)
+
+	template := "api/templates/mail/page_rejected.html"
+	fileName := "page_rejected.html"
+
+	mail := models.NewMail(
+		me.Email,
+		[]string{email},
+		me.Localizer.Translate("page_rejected.title", locale, nil),
+	)
+
+	body, err := me.Localizer.ParseTemplateI18n(fileName, template, locale, map[string]string{"email": email, "page": page, "reason": reason})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	headers := mail.GetHeaders()
+	mail.Body = headers + body
+
+	if err := me.send(*mail, true); err != nil {
+		log.Error(err)
+	}
+}
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/gorm"
+)
+
+type accountDeletionStore struct {
+	db          *database
+	FileStore   *FileStore
+	Audit       auditStore
+	GracePeriod time.Duration
+	//KeepConversations anonymizes the member side of its conversations instead of deleting them
+	KeepConversations bool
+}
+
+//Schedule marks userID for deletion at the end of the grace period and returns that date,
+//without grace period the account is purged right away
+func (me accountDeletionStore) Schedule(userID uint, actor models.Actor) (time.Time, error) {
+	me.db.Lock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		me.db.Unlock()
+		return time.Time{}, ErrNotFound
+	}
+
+	if at := me.db.users[u].DeletionScheduledAt; at != nil {
+		me.db.Unlock()
+		return *at, nil
+	}
+
+	at := time.Now().Add(me.GracePeriod)
+	me.db.users[u].DeletionScheduledAt = &at
+
+	me.Audit.record(actor, auditUserDeleteRequest, "user", userID, map[string]interface{}{"scheduled_at": at})
+	me.db.Unlock()
+
+	if me.GracePeriod == 0 {
+		return at, me.Purge(userID, actor)
+	}
+
+	return at, nil
+}
+
+//Cancel drops the pending deletion of userID
+func (me accountDeletionStore) Cancel(userID uint, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 || me.db.users[u].DeletionScheduledAt == nil {
+		return false, fmt.Errorf("no deletion pending for user %d", userID)
+	}
+
+	me.db.users[u].DeletionScheduledAt = nil
+
+	me.Audit.record(actor, auditUserDeleteCancel, "user", userID, nil)
+
+	return true, nil
+}
+
+//PurgeDue purges every account whose grace period ended before now and returns how many were purged
+func (me accountDeletionStore) PurgeDue(now time.Time) (int, error) {
+	me.db.Lock()
+	ids := []uint{}
+	for _, u := range me.db.users {
+		if u.DeletionScheduledAt != nil && !u.DeletionScheduledAt.After(now) {
+			ids = append(ids, u.ID)
+		}
+	}
+	me.db.Unlock()
+
+	purged := 0
+	for _, id := range ids {
+		if err := me.Purge(id, models.Actor{}); err == nil {
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+//Purge removes every data of userID, see stores accountDeletionStore.Purge
+func (me accountDeletionStore) Purge(userID uint, actor models.Actor) error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := -1
+	for i, user := range me.db.users {
+		if user.ID == userID {
+			u = i
+		}
+	}
+
+	if u < 0 {
+		return ErrNotFound
+	}
+
+	user := me.db.users[u]
+	profileID := user.ProfileID
+
+	pageIDs := map[uint]bool{}
+	pages := me.db.pages[:0]
+	for _, p := range me.db.pages {
+		if p.OwnerID == profileID {
+			pageIDs[p.ID] = true
+			continue
+		}
+		pages = append(pages, p)
+	}
+	me.db.pages = pages
+
+	//only the files of the member go, an image or an avatar can point to the file of another member
+	files := []string{}
+	images := me.db.images[:0]
+	for _, i := range me.db.images {
+		if !pageIDs[i.OwnerID] {
+			images = append(images, i)
+			continue
+		}
+
+		for _, file := range append(i.Variants.URLs(), i.URL) {
+			if me.FileStore.Within(file, pageDirectories(profileID, userID)...) {
+				files = append(files, file)
+			}
+		}
+	}
+	me.db.images = images
+
+	for _, p := range me.db.profiles {
+		if p.ID == profileID && me.FileStore.Within(p.Avatar, avatarDirectories(userID)...) {
+			files = append(files, p.Avatar)
+		}
+	}
+
+	me.purgeConversations(profileID)
+
+	exports := me.db.exports[:0]
+	for _, e := range me.db.exports {
+		if e.OwnerID == userID {
+			files = append(files, e.File)
+			continue
+		}
+		exports = append(exports, e)
+	}
+	me.db.exports = exports
+
+	uploads := me.db.uploads[:0]
+	for _, up := range me.db.uploads {
+		if up.OwnerID == userID {
+			files = append(append(files, up.URL), up.Variants.URLs()...)
+			continue
+		}
+		uploads = append(uploads, up)
+	}
+	me.db.uploads = uploads
+
+	deleteSessions(me.db, func(s models.Session) bool { return s.OwnerID == userID })
+	deleteRecoveryCodes(me.db, userID)
+	delete(me.db.loginAttempts, accountKey(user.Email))
+	delete(me.db.userRoles, userID)
+
+	me.db.users = append(me.db.users[:u], me.db.users[u+1:]...)
+
+	me.purgeProfile(profileID)
+
+	for _, f := range files {
+		delete(me.db.files, f)
+	}
+
+	me.Audit.record(actor, auditUserPurge, "user", userID, map[string]interface{}{
+		"pages": len(pageIDs),
+		"files": len(files),
+	})
+
+	return nil
+}
+
+//purgeConversations deletes the conversations of profileID, or only the ones the counterpart already left when they are kept
+func (me accountDeletionStore) purgeConversations(profileID uint) {
+	remaining := map[uint]bool{}
+	for _, u := range me.db.users {
+		if u.ProfileID != profileID {
+			remaining[u.ProfileID] = true
+		}
+	}
+
+	deleteConversations(me.db, func(c models.Conversation) bool {
+		if c.FromID != profileID && c.ToID != profileID {
+			return false
+		}
+
+		if !me.KeepConversations {
+			return true
+		}
+
+		return (c.FromID == profileID && !remaining[c.ToID]) || (c.ToID == profileID && !remaining[c.FromID])
+	})
+
+	if me.KeepConversations {
+		//the counterpart keeps the messages, not the member email address
+		for i, m := range me.db.messages {
+			if m.FromID == profileID {
+				me.db.messages[i].Email = ""
+			}
+		}
+	}
+}
+
+//purgeProfile deletes the profile unless kept conversations still reference it, it is then anonymized
+func (me accountDeletionStore) purgeProfile(profileID uint) {
+	referenced := false
+	for _, c := range me.db.conversations {
+		if c.FromID == profileID || c.ToID == profileID {
+			referenced = true
+		}
+	}
+
+	for i, p := range me.db.profiles {
+		if p.ID != profileID {
+			continue
+		}
+
+		if !referenced {
+			me.db.profiles = append(me.db.profiles[:i], me.db.profiles[i+1:]...)
+			return
+		}
+
+		anonymized := models.Profile{Base: p.Base}
+		anonymized.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		me.db.profiles[i] = anonymized
+		return
+	}
+}
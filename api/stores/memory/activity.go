@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type activityStore struct {
+	db *database
+}
+
+//All Returns all the activities
+func (me activityStore) All() ([]models.Activity, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return append([]models.Activity{}, me.db.activities...), nil
+}
+
+//New creates an activity
+func (me activityStore) New(activity models.Activity) (models.Activity, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	activity.ID = me.db.nextID("activities")
+	me.db.activities = append(me.db.activities, activity)
+	return activity, nil
+}
+
+//Update renames an activity
+func (me activityStore) Update(activity models.Activity) (models.Activity, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i, a := range me.db.activities {
+		if a.ID == activity.ID {
+			me.db.activities[i].Name = activity.Name
+		}
+	}
+	return activity, nil
+}
+
+//Delete an activity and detach it from pages and profiles
+func (me activityStore) Delete(activityID uint) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	activities := me.db.activities[:0]
+	for _, a := range me.db.activities {
+		if a.ID != activityID {
+			activities = append(activities, a)
+		}
+	}
+	me.db.activities = activities
+
+	for i := range me.db.pages {
+		me.db.pages[i].Activities = withoutActivity(me.db.pages[i].Activities, activityID)
+	}
+	for i := range me.db.profiles {
+		me.db.profiles[i].Activities = withoutActivity(me.db.profiles[i].Activities, activityID)
+	}
+
+	return true, nil
+}
+
+func withoutActivity(in []*models.Activity, activityID uint) []*models.Activity {
+	out := []*models.Activity{}
+	for _, a := range in {
+		if a.ID != activityID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+//activities returns the existing activities among in, the database must be locked
+func activities(db *database, in []*models.Activity) []*models.Activity {
+	out := []*models.Activity{}
+	for _, a := range in {
+		for _, existing := range db.activities {
+			if existing.ID == a.ID {
+				activity := existing
+				out = append(out, &activity)
+			}
+		}
+	}
+	return out
+}
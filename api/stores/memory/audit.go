@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+//audit actions recorded by the stores, the same as the stores package ones
+const (
+	auditLogin             = "session.login"
+	auditLoginFailed       = "session.login_failed"
+	auditLoginPartial      = "session.login_partial"
+	auditLoginSecond       = "session.login_second_factor"
+	auditLogout            = "session.logout"
+	auditPasswordChange    = "user.password_change"
+	auditUserSuspend       = "user.suspend"
+	auditUserReinstate     = "user.reinstate"
+	auditUserDeleteRequest = "user.delete_request"
+	auditUserDeleteCancel  = "user.delete_cancel"
+	auditUserPurge         = "user.purge"
+	auditPageCreate        = "page.create"
+	auditPageUpdate        = "page.update"
+	auditPageDelete        = "page.delete"
+	auditPageStatus        = "page.status"
+	auditPageRestore       = "page.restore"
+	auditPagePurge         = "page.purge"
+	auditImageDelete       = "image.delete"
+	auditImageRestore      = "image.restore"
+	auditImagePurge        = "image.purge"
+	auditConvDelete        = "conversation.delete"
+	auditConvReport        = "conversation.report"
+	auditRoleGrant         = "role.grant"
+	auditRoleRevoke        = "role.revoke"
+)
+
+type auditStore struct {
+	db *database
+}
+
+//Record appends an audit event
+func (me auditStore) Record(actor models.Actor, action, targetType string, targetID uint, metadata map[string]interface{}) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	me.record(actor, action, targetType, targetID, metadata)
+}
+
+//record appends an audit event, the database must be locked
+func (me auditStore) record(actor models.Actor, action, targetType string, targetID uint, metadata map[string]interface{}) {
+	me.db.events = append(me.db.events, models.AuditEvent{
+		ID:         me.db.nextID("audit_events"),
+		CreatedAt:  time.Now(),
+		ActorID:    actor.UserID,
+		IP:         actor.IP,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   models.NewAuditMetadata(metadata),
+	})
+}
+
+//Query returns the events matching filter, most recent first
+func (me auditStore) Query(filter models.AuditFilter, offset, limit int) ([]models.AuditEvent, int64, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	events := []models.AuditEvent{}
+	for i := len(me.db.events) - 1; i >= 0; i-- {
+		if matches(filter, me.db.events[i]) {
+			events = append(events, me.db.events[i])
+		}
+	}
+
+	from, to := paginate(len(events), offset, limit)
+	return events[from:to], int64(len(events)), nil
+}
+
+//Export writes the events matching filter to w as JSON Lines, oldest first
+func (me auditStore) Export(filter models.AuditFilter, w io.Writer) error {
+	me.db.Lock()
+	events := make([]models.AuditEvent, len(me.db.events))
+	copy(events, me.db.events)
+	me.db.Unlock()
+
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if !matches(filter, e) {
+			continue
+		}
+
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func matches(filter models.AuditFilter, e models.AuditEvent) bool {
+	return (filter.ActorID == 0 || e.ActorID == filter.ActorID) &&
+		(filter.Action == "" || e.Action == filter.Action) &&
+		(filter.TargetType == "" || e.TargetType == filter.TargetType) &&
+		(filter.TargetID == 0 || e.TargetID == filter.TargetID) &&
+		(filter.From.IsZero() || !e.CreatedAt.Before(filter.From)) &&
+		(filter.To.IsZero() || e.CreatedAt.Before(filter.To))
+}
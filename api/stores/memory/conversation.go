@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type conversationStore struct {
+	db    *database
+	Audit auditStore
+}
+
+//Delete a conversation and its messages
+func (me conversationStore) Delete(conversationID uint, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	deleteConversations(me.db, func(c models.Conversation) bool { return c.ID == conversationID })
+	me.Audit.record(actor, auditConvDelete, "conversation", conversationID, nil)
+
+	return true, nil
+}
+
+//ProfileConversations returns the conversations of profileID with their members and messages
+func (me conversationStore) ProfileConversations(profileID uint) ([]models.Conversation, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	conversations := []models.Conversation{}
+	for _, c := range me.db.conversations {
+		if !c.DeletedAt.Valid && (c.FromID == profileID || c.ToID == profileID) {
+			conversations = append(conversations, me.load(c, true))
+		}
+	}
+	return conversations, nil
+}
+
+//load attaches the members and optionally the messages of c, the database must be locked
+func (me conversationStore) load(c models.Conversation, messages bool) models.Conversation {
+	if p := me.db.profile(c.FromID); p >= 0 {
+		c.From = me.db.profiles[p]
+	}
+	if p := me.db.profile(c.ToID); p >= 0 {
+		c.To = me.db.profiles[p]
+	}
+
+	if messages {
+		c.Messages = []models.Message{}
+		for _, m := range me.db.messages {
+			if m.ConversationID == c.ID {
+				c.Messages = append(c.Messages, m)
+			}
+		}
+	}
+
+	return c
+}
+
+//GetByReferents returns the conversation between the two profiles, it is created if needed
+func (me conversationStore) GetByReferents(fromProfile, toProfile models.Profile) (models.Conversation, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, c := range me.db.conversations {
+		if c.DeletedAt.Valid {
+			continue
+		}
+		if (c.FromID == fromProfile.ID && c.ToID == toProfile.ID) || (c.FromID == toProfile.ID && c.ToID == fromProfile.ID) {
+			return c, nil
+		}
+	}
+
+	now := time.Now()
+	c := models.Conversation{FromID: fromProfile.ID, ToID: toProfile.ID}
+	c.ID = me.db.nextID("conversations")
+	c.CreatedAt, c.UpdatedAt = now, now
+	me.db.conversations = append(me.db.conversations, c)
+
+	c.New = true
+	return c, nil
+}
+
+//AddMessage appends a message to conversation
+func (me conversationStore) AddMessage(conversation models.Conversation, fromID, toID uint, fromEmail, text string) (models.Conversation, models.Message, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if me.db.conversation(conversation.ID) < 0 {
+		return models.Conversation{}, models.Message{}, ErrNotFound
+	}
+
+	m := models.Message{
+		ID:             me.db.nextID("messages"),
+		Text:           text,
+		Date:           time.Now(),
+		FromID:         fromID,
+		ToID:           toID,
+		Email:          fromEmail,
+		ConversationID: conversation.ID,
+	}
+	me.db.messages = append(me.db.messages, m)
+
+	m.Conversation = conversation
+	conversation.Messages = append(conversation.Messages, m)
+	conversation.From.Email = m.Email
+
+	return conversation, m, nil
+}
+
+//Save updates the conversation columns
+func (me conversationStore) Save(conversation models.Conversation) (models.Conversation, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	c := me.db.conversation(conversation.ID)
+	if c < 0 {
+		return models.Conversation{}, ErrNotFound
+	}
+
+	stored := conversation
+	stored.From, stored.To, stored.Messages = models.Profile{}, models.Profile{}, nil
+	me.db.conversations[c] = stored
+
+	return conversation, nil
+}
+
+//Report flags the conversation so admins can read it
+func (me conversationStore) Report(conversationID, profileID uint, reason string, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if c := me.db.conversation(conversationID); c >= 0 {
+		now := time.Now()
+		me.db.conversations[c].ReportedAt = &now
+		me.db.conversations[c].ReportedByID = profileID
+		me.db.conversations[c].ReportReason = reason
+	}
+
+	me.Audit.record(actor, auditConvReport, "conversation", conversationID, map[string]interface{}{"reason": reason})
+
+	return true, nil
+}
+
+//Reported returns the reported conversations, most recent report first
+func (me conversationStore) Reported() ([]models.Conversation, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	conversations := []models.Conversation{}
+	for _, c := range me.db.conversations {
+		if !c.DeletedAt.Valid && c.ReportedAt != nil {
+			conversations = append(conversations, me.load(c, false))
+		}
+	}
+
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[i].ReportedAt.After(*conversations[j].ReportedAt)
+	})
+
+	return conversations, nil
+}
+
+//GetReported returns a conversation with its messages, only if it has been reported
+func (me conversationStore) GetReported(conversationID uint) (models.Conversation, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	c := me.db.conversation(conversationID)
+	if c < 0 {
+		return models.Conversation{}, ErrNotFound
+	}
+
+	if me.db.conversations[c].ReportedAt == nil {
+		return models.Conversation{}, fmt.Errorf("conversation %d has not been reported", conversationID)
+	}
+
+	return me.load(me.db.conversations[c], true), nil
+}
+
+//deleteConversations deletes the conversations matching fn and their messages, the database must be locked
+func deleteConversations(db *database, fn func(models.Conversation) bool) {
+	deleted := map[uint]bool{}
+	conversations := db.conversations[:0]
+	for _, c := range db.conversations {
+		if fn(c) {
+			deleted[c.ID] = true
+			continue
+		}
+		conversations = append(conversations, c)
+	}
+	db.conversations = conversations
+
+	messages := db.messages[:0]
+	for _, m := range db.messages {
+		if !deleted[m.ConversationID] {
+			messages = append(messages, m)
+		}
+	}
+	db.messages = messages
+}
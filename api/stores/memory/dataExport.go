@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+const dataExportValidity = 7 * 24 * time.Hour
+
+type dataExportStore struct {
+	db      *database
+	WsStore *WsStore
+}
+
+//Request builds the archive of userID right away, the returned export is still pending like
+//with the database store, the member is notified on the hub once it is ready. A member has one pending export at most
+func (me dataExportStore) Request(userID uint) (models.DataExport, error) {
+	me.db.Lock()
+
+	for _, e := range me.db.exports {
+		if e.OwnerID == userID && e.Status == models.DataExportPending {
+			me.db.Unlock()
+			return models.DataExport{}, apperror.New(http.StatusConflict, "data_export.already_pending", fmt.Errorf("an export of user %d is already in progress", userID))
+		}
+	}
+
+	export := models.DataExport{
+		OwnerID:   userID,
+		Status:    models.DataExportPending,
+		ExpiresAt: time.Now().Add(dataExportValidity),
+	}
+	export.ID = me.db.nextID("data_exports")
+	export.CreatedAt, export.UpdatedAt = time.Now(), time.Now()
+
+	ready := export
+	ready.Status = models.DataExportFailed
+	profileID := uint(0)
+
+	if b, err := me.build(userID); err == nil {
+		ready.Status = models.DataExportReady
+		ready.File = fmt.Sprintf("exports/export-%d-%d.zip", userID, export.ID)
+		ready.Size = int64(len(b))
+		me.db.files[ready.File] = b
+	}
+
+	if u := me.db.user(userID); u >= 0 {
+		profileID = me.db.users[u].ProfileID
+	}
+
+	me.db.exports = append(me.db.exports, ready)
+	me.db.Unlock()
+
+	if j, err := json.Marshal(ready); err == nil {
+		me.WsStore.EmitToMutationNamespace(profileID, "DATA_EXPORT_UPDATED", string(j), "exports")
+	}
+
+	return export, nil
+}
+
+//build writes the member documents to a ZIP archive, the database must be locked
+func (me dataExportStore) build(userID uint) ([]byte, error) {
+	u := me.db.user(userID)
+	if u < 0 {
+		return nil, ErrNotFound
+	}
+
+	user := me.db.users[u]
+	user.Password = ""
+	profile, _ := me.db.userProfile(userID)
+
+	pages := []models.Page{}
+	for _, p := range me.db.pages {
+		if p.OwnerID == profile.ID && !p.DeletedAt.Valid {
+			p.Images = me.db.pageImages(p.ID)
+			pages = append(pages, p)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, v := range map[string]interface{}{"user.json": user, "profile.json": profile, "pages.json": pages} {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+//All returns the exports of userID, most recent first
+func (me dataExportStore) All(userID uint) ([]models.DataExport, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	exports := []models.DataExport{}
+	for i := len(me.db.exports) - 1; i >= 0; i-- {
+		if me.db.exports[i].OwnerID == userID {
+			exports = append(exports, me.db.exports[i])
+		}
+	}
+	return exports, nil
+}
+
+//Open returns a reader on the archive of exportID if userID owns it and it is still downloadable
+func (me dataExportStore) Open(userID, exportID uint) (models.DataExport, io.ReadCloser, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, e := range me.db.exports {
+		if e.ID != exportID || e.OwnerID != userID {
+			continue
+		}
+
+		if e.Status != models.DataExportReady || e.HasExpired() {
+			return models.DataExport{}, nil, fmt.Errorf("export %d is not available", exportID)
+		}
+
+		return e, ioutil.NopCloser(bytes.NewReader(me.db.files[e.File])), nil
+	}
+
+	return models.DataExport{}, nil, ErrNotFound
+}
+
+//PurgeExpired deletes the exports which expired at now and their archive, it returns how many were purged
+func (me dataExportStore) PurgeExpired(now time.Time) (int, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	purged := 0
+	exports := me.db.exports[:0]
+	for _, e := range me.db.exports {
+		if e.ExpiresAt.After(now) {
+			exports = append(exports, e)
+			continue
+		}
+
+		delete(me.db.files, e.File)
+		purged++
+	}
+	me.db.exports = exports
+
+	return purged, nil
+}
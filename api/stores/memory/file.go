@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+//FileStore keeps the saved files in memory, keyed by the path Save returns
+type FileStore struct {
+	db *database
+	//MaxWidth and MaxHeight bound the images SaveImage writes
+	MaxWidth, MaxHeight int
+}
+
+//Save stores the content of buf and returns its path under the image base path
+func (me *FileStore) Save(directory, filename string, buf io.Reader) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("filename is incorrect")
+	}
+
+	b, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) == 0 {
+		return "", fmt.Errorf("file not created, no data to write")
+	}
+
+	p := path.Join(imageBasePath, directory, filename)
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	me.db.files[p] = b
+
+	return p, nil
+}
+
+//Append adds the content of buf at the end of the file Save would write, it is created if it does not exist
+func (me *FileStore) Append(directory, filename string, buf io.Reader) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("filename is incorrect")
+	}
+
+	b, err := ioutil.ReadAll(buf)
+
+	p := path.Join(imageBasePath, directory, filename)
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	me.db.files[p] = append(me.db.files[p], b...)
+
+	return p, err
+}
+
+//Delete removes a file previously written by Save, a file that no longer exists is not an error
+func (me *FileStore) Delete(p string) error {
+	if p == "" || strings.HasPrefix(p, "data:") || strings.Contains(p, "://") {
+		return fmt.Errorf("not an uploaded file: %.32s", p)
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	delete(me.db.files, path.Clean("/"+p))
+
+	return nil
+}
+
+//Within tells whether p is a file written by Save in one of directories, see stores fileStore.Within
+func (me *FileStore) Within(p string, directories ...string) bool {
+	if p == "" || strings.HasPrefix(p, "data:") || strings.Contains(p, "://") {
+		return false
+	}
+
+	dir := path.Dir(path.Clean("/" + p))
+	for _, d := range directories {
+		if dir == path.Join(imageBasePath, d) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Get returns the content of the file at p
+func (me *FileStore) Get(p string) ([]byte, bool) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	b, ok := me.db.files[p]
+	return b, ok
+}
+
+//Len returns the number of files stored
+func (me *FileStore) Len() int {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return len(me.db.files)
+}
+
+//SaveImage encodes img as mime and saves it scaled down to the max dimensions, with its variants when asked,
+//see stores fileStore.SaveImage
+func (me *FileStore) SaveImage(directory, filename, mime string, img image.Image, variants bool) (string, models.ImageVariants, error) {
+	save := func(filename string, img image.Image) (string, error) {
+		buf, err := utils.ImageToTypedImage(mime, img)
+		if err != nil {
+			return "", err
+		}
+		return me.Save(directory, filename, buf)
+	}
+
+	img = utils.Fit(img, me.MaxWidth, me.MaxHeight)
+
+	p, err := save(filename, img)
+	if err != nil || !variants {
+		return p, models.ImageVariants{}, err
+	}
+
+	urls := models.ImageVariants{}
+	if urls.ThumbnailURL, err = save("thumbnail."+filename, utils.Fit(img, utils.ThumbnailSize, utils.ThumbnailSize)); err != nil {
+		return "", models.ImageVariants{}, err
+	}
+	if urls.MediumURL, err = save("medium."+filename, utils.Fit(img, utils.MediumSize, utils.MediumSize)); err != nil {
+		return "", models.ImageVariants{}, err
+	}
+	if urls.LargeURL, err = save("large."+filename, utils.Fit(img, utils.LargeSize, utils.LargeSize)); err != nil {
+		return "", models.ImageVariants{}, err
+	}
+
+	return p, urls, nil
+}
+
+//saveImages decodes and saves the base64 images like the database pageStore, the ones failing to decode are skipped
+func (me *FileStore) saveImages(directory string, images []models.Image) []models.Image {
+	out := []models.Image{}
+	for idx, i := range images {
+		if i.File == "" || idx >= 6 {
+			out = append(out, i)
+			continue
+		}
+
+		filename, variants, err := me.saveImage(directory, i.File, i.URL, true)
+		if err != nil {
+			continue
+		}
+
+		i.File = ""
+		i.URL = filename
+		i.Variants = variants
+		out = append(out, i)
+	}
+	return out
+}
+
+func (me *FileStore) saveImage(directory, filename, b64 string, variants bool) (string, models.ImageVariants, error) {
+	mime, img, err := utils.B64ToImage(b64)
+	if err != nil {
+		return "", models.ImageVariants{}, err
+	}
+
+	filename, err = utils.Sanitize(filename)
+	if err != nil {
+		filename = utils.RandStringBytesMaskImprSrc(len(filename)) + "." + mime
+	}
+
+	return me.SaveImage(directory, filename, mime, img, variants)
+}
@@ -0,0 +1,250 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+//same files as the stores package, relative to fixtureStore.Path
+const (
+	fixtureLanguages  = "reference/languages.json"
+	fixtureActivities = "reference/activities.json"
+	fixtureDemo       = "demo/users.json"
+)
+
+type fixtureStore struct {
+	db        *database
+	UserStore userStore
+	RoleStore roleStore
+	Path      string
+}
+
+//LoadReference inserts or updates the languages and activities, files already loaded at their version are skipped
+func (me fixtureStore) LoadReference() error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	var languages models.LanguagesFixture
+	if err := me.load(fixtureLanguages, &languages, func() uint { return languages.Version }, func() error {
+		me.loadLanguages(languages.Languages)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var activities models.ActivitiesFixture
+	return me.load(fixtureActivities, &activities, func() uint { return activities.Version }, func() error {
+		me.loadActivities(activities.Activities)
+		return nil
+	})
+}
+
+//LoadDemo creates the demo users with their profile and pages, existing emails are left untouched
+func (me fixtureStore) LoadDemo() error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	var demo models.DemoFixture
+	return me.load(fixtureDemo, &demo, func() uint { return demo.Version }, func() error {
+		for _, u := range demo.Users {
+			if err := me.loadDemoUser(u); err != nil {
+				return fmt.Errorf("%s: %s", u.Email, err)
+			}
+		}
+		return nil
+	})
+}
+
+//load reads name into content and calls fn unless this version of the file was already loaded, the database must be locked
+func (me fixtureStore) load(name string, content interface{}, version func() uint, fn func() error) error {
+	b, err := ioutil.ReadFile(filepath.Join(me.Path, name))
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, content); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+
+	if loaded, ok := me.db.fixtures[name]; ok && loaded >= version() {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+
+	me.db.fixtures[name] = version()
+	return nil
+}
+
+func (me fixtureStore) loadLanguages(languages []models.Language) {
+	for _, l := range languages {
+		found := false
+		for i, existing := range me.db.languages {
+			if existing.Code == l.Code || (existing.Code == "" && existing.NativeName == l.NativeName) {
+				me.db.languages[i].Code, me.db.languages[i].Name, me.db.languages[i].NativeName = l.Code, l.Name, l.NativeName
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			l.ID = me.db.nextID("languages")
+			me.db.languages = append(me.db.languages, l)
+		}
+	}
+}
+
+func (me fixtureStore) loadActivities(activities []models.Activity) {
+	for _, a := range activities {
+		found := false
+		for _, existing := range me.db.activities {
+			if existing.Name == a.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			me.db.activities = append(me.db.activities, models.Activity{ID: me.db.nextID("activities"), Name: a.Name})
+		}
+	}
+}
+
+func (me fixtureStore) loadDemoUser(u models.DemoUser) error {
+	for _, existing := range me.db.users {
+		if existing.Email == u.Email {
+			return nil
+		}
+	}
+
+	user, err := me.UserStore.create(models.User{Email: u.Email, Password: u.Password})
+	if err != nil {
+		return err
+	}
+
+	for _, role := range u.Roles {
+		if me.RoleStore.role(role) < 0 {
+			return fmt.Errorf("unknown role %s", role)
+		}
+
+		if !hasRole(me.db, user.ID, role) {
+			me.db.userRoles[user.ID] = append(me.db.userRoles[user.ID], role)
+		}
+
+		if role == models.RoleAdmin {
+			me.db.users[me.db.user(user.ID)].Type = "ADMIN"
+		}
+	}
+
+	languages, err := me.languages(u.Profile.Languages)
+	if err != nil {
+		return err
+	}
+
+	activities, err := me.activities(u.Profile.Activities)
+	if err != nil {
+		return err
+	}
+
+	p := me.db.profile(user.ProfileID)
+	profile := u.Profile
+	profile.Base = me.db.profiles[p].Base
+	profile.Email = me.db.profiles[p].Email
+	profile.Languages = languages
+	profile.Activities = activities
+	me.db.profiles[p] = profile
+
+	for _, page := range u.Pages {
+		if err := me.loadDemoPage(profile.ID, page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//loadDemoPage copies the page images from the fixture directory to the file store, like an upload would
+func (me fixtureStore) loadDemoPage(profileID uint, page models.Page) error {
+	activities, err := me.activities(page.Activities)
+	if err != nil {
+		return err
+	}
+
+	directory := pageDirectory(profileID)
+
+	now := time.Now()
+	page.ID = me.db.nextID("pages")
+	page.CreatedAt, page.UpdatedAt = now, now
+	page.OwnerID = profileID
+	page.Activities = activities
+	page.Followers = nil
+	//the demo pages are published as they are
+	page.Status = models.PageDraft
+	if page.Public {
+		page.Status = models.PagePublished
+	}
+
+	for _, i := range page.Images {
+		b, err := ioutil.ReadFile(filepath.Join(me.Path, filepath.Dir(fixtureDemo), i.URL))
+		if err != nil {
+			return err
+		}
+
+		url := path.Join(imageBasePath, directory, filepath.Base(i.URL))
+		me.db.files[url] = b
+
+		image := models.Image{URL: url, Alt: i.Alt, OwnerID: page.ID}
+		image.ID = me.db.nextID("images")
+		image.CreatedAt, image.UpdatedAt = now, now
+		me.db.images = append(me.db.images, image)
+	}
+
+	page.Images = nil
+	me.db.pages = append(me.db.pages, page)
+
+	return nil
+}
+
+func (me fixtureStore) languages(in []*models.Language) ([]*models.Language, error) {
+	out := []*models.Language{}
+	for _, l := range in {
+		for _, existing := range me.db.languages {
+			if existing.Code == l.Code {
+				language := existing
+				out = append(out, &language)
+			}
+		}
+	}
+
+	if len(out) != len(in) {
+		return nil, fmt.Errorf("unknown language in %v", in)
+	}
+
+	return out, nil
+}
+
+func (me fixtureStore) activities(in []*models.Activity) ([]*models.Activity, error) {
+	out := []*models.Activity{}
+	for _, a := range in {
+		for _, existing := range me.db.activities {
+			if existing.Name == a.Name {
+				activity := existing
+				out = append(out, &activity)
+			}
+		}
+	}
+
+	if len(out) != len(in) {
+		return nil, fmt.Errorf("unknown activity in %v", in)
+	}
+
+	return out, nil
+}
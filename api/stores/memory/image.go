@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/gorm"
+)
+
+type imageStore struct {
+	db    *database
+	Audit auditStore
+}
+
+//All returns all the images which are not deleted
+func (me imageStore) All() ([]models.Image, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	images := []models.Image{}
+	for _, i := range me.db.images {
+		if !i.DeletedAt.Valid {
+			images = append(images, i)
+		}
+	}
+	return images, nil
+}
+
+//Delete moves an image to the trash
+func (me imageStore) Delete(imageID uint, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i := range me.db.images {
+		if me.db.images[i].ID == imageID && !me.db.images[i].DeletedAt.Valid {
+			me.db.images[i].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			me.Audit.record(actor, auditImageDelete, "image", imageID, nil)
+			return true, nil
+		}
+	}
+
+	return false, ErrNotFound
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type languageStore struct {
+	db *database
+}
+
+//All returns all the languages
+func (me languageStore) All() ([]models.Language, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return append([]models.Language{}, me.db.languages...), nil
+}
+
+//New creates a language
+func (me languageStore) New(language models.Language) (models.Language, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	language.ID = me.db.nextID("languages")
+	me.db.languages = append(me.db.languages, language)
+	return language, nil
+}
+
+//Update a language names
+func (me languageStore) Update(language models.Language) (models.Language, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i, l := range me.db.languages {
+		if l.ID == language.ID {
+			me.db.languages[i].Name = language.Name
+			me.db.languages[i].NativeName = language.NativeName
+		}
+	}
+	return language, nil
+}
+
+//Delete a language and detach it from profiles
+func (me languageStore) Delete(languageID uint) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	languages := me.db.languages[:0]
+	for _, l := range me.db.languages {
+		if l.ID != languageID {
+			languages = append(languages, l)
+		}
+	}
+	me.db.languages = languages
+
+	for i, p := range me.db.profiles {
+		kept := []*models.Language{}
+		for _, l := range p.Languages {
+			if l.ID != languageID {
+				kept = append(kept, l)
+			}
+		}
+		me.db.profiles[i].Languages = kept
+	}
+
+	return true, nil
+}
+
+//languages returns the existing languages among in, the database must be locked
+func languages(db *database, in []*models.Language) []*models.Language {
+	out := []*models.Language{}
+	for _, l := range in {
+		for _, existing := range db.languages {
+			if existing.ID == l.ID {
+				language := existing
+				out = append(out, &language)
+			}
+		}
+	}
+	return out
+}
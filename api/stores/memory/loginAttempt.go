@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type loginAttemptStore struct {
+	db                         *database
+	Audit                      auditStore
+	MaxAttempts, MaxIPAttempts int
+	Lockout, MaxLockout        time.Duration
+}
+
+//LockedFor returns the remaining lock duration for the account email or the client ip, the longest wins
+func (me loginAttemptStore) LockedFor(email, ip string) (time.Duration, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	now := time.Now()
+	var locked time.Duration
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		attempt := me.db.loginAttempts[key]
+		if d := attempt.LockedFor(now); d > locked {
+			locked = d
+		}
+	}
+
+	return locked, nil
+}
+
+//Fail records a failed login for email from the ip of actor and returns the resulting lock duration,
+//userID is the account of email, 0 when no account has it
+func (me loginAttemptStore) Fail(email string, userID uint, actor models.Actor) (time.Duration, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	var locked time.Duration
+	for key, max := range map[string]int{accountKey(email): me.MaxAttempts, ipKey(actor.IP): me.MaxIPAttempts} {
+		if d := me.fail(key, max); d > locked {
+			locked = d
+		}
+	}
+
+	me.Audit.record(actor, auditLoginFailed, "user", userID, map[string]interface{}{"email": email, "locked_seconds": int(locked.Seconds())})
+
+	return locked, nil
+}
+
+//Succeed clears the failures of the account, the ip counter is left to decay
+func (me loginAttemptStore) Succeed(email string) error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	delete(me.db.loginAttempts, accountKey(email))
+	return nil
+}
+
+func (me loginAttemptStore) fail(key string, max int) time.Duration {
+	now := time.Now()
+
+	attempt, ok := me.db.loginAttempts[key]
+	if !ok {
+		attempt = models.LoginAttempt{Key: key}
+	}
+
+	if now.Sub(attempt.LastFailure) > me.MaxLockout {
+		attempt.Failures = 0
+	}
+
+	attempt.Failures++
+	attempt.LastFailure = now
+
+	if attempt.Failures >= max {
+		d := me.Lockout
+		for i := 0; i < attempt.Failures-max && d < me.MaxLockout; i++ {
+			d *= 2
+		}
+		if d > me.MaxLockout {
+			d = me.MaxLockout
+		}
+		attempt.LockedUntil = now.Add(d)
+	}
+
+	me.db.loginAttempts[key] = attempt
+
+	return attempt.LockedFor(now)
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"sync"
+)
+
+//Mail is a mail the MailStore would have sent
+type Mail struct {
+	Template, To, Password, Locale string
+	Reason                         string
+}
+
+//MailStore records the mails instead of sending them
+type MailStore struct {
+	mu   sync.Mutex
+	sent []Mail
+}
+
+//AccountAutoCreated records the mail sending the password of an account created on first message
+func (me *MailStore) AccountAutoCreated(email, password, locale string) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.sent = append(me.sent, Mail{Template: "account_auto_created", To: email, Password: password, Locale: locale})
+}
+
+//PageRejected records the mail telling the owner of a page why it was rejected
+func (me *MailStore) PageRejected(email, page, reason, locale string) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.sent = append(me.sent, Mail{Template: "page_rejected", To: email, Reason: reason, Locale: locale})
+}
+
+//Sent returns the recorded mails
+func (me *MailStore) Sent() []Mail {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	return append([]Mail{}, me.sent...)
+}
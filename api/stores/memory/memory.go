@@ -0,0 +1,358 @@
+//Package memory implements every store of the stores package in memory, handler tests use it
+//to run without a database server
+package memory
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"gorm.io/gorm"
+)
+
+//ErrNotFound is returned when a record does not exist, it is the database error so the handlers answer the same
+var ErrNotFound = gorm.ErrRecordNotFound
+
+//imageBasePath is the path the files are saved under, as configured by default
+const imageBasePath = "/static/img"
+
+var _ stores.Stores = &Stores{}
+
+//Stores holds the in-memory stores, they all share the same database
+type Stores struct {
+	db                *database
+	localizer         *localizer.Localizer
+	wsStore           *WsStore
+	mailStore         *MailStore
+	fileStore         *FileStore
+	sessionStore      *sessionStore
+	pageStore         pageStore
+	imageStore        imageStore
+	profileStore      profileStore
+	languageStore     languageStore
+	activityStore     activityStore
+	userStore         userStore
+	conversationStore conversationStore
+	twoFactorStore    twoFactorStore
+	loginAttemptStore loginAttemptStore
+	roleStore         roleStore
+	auditStore        auditStore
+	dataExportStore   dataExportStore
+	fixtureStore      fixtureStore
+	accountDeletion   accountDeletionStore
+	trashStore        trashStore
+	uploadStore       uploadStore
+}
+
+//New returns empty stores, the roles are seeded like the migrations would
+func New(localizer *localizer.Localizer) *Stores {
+	db := newDatabase()
+
+	wsStore := &WsStore{}
+	fileStore := &FileStore{db: db, MaxWidth: 2048, MaxHeight: 2048}
+	auditStore := auditStore{db: db}
+	userStore := userStore{db: db, Audit: auditStore}
+	roleStore := roleStore{db: db, Audit: auditStore}
+
+	s := &Stores{
+		db:                db,
+		localizer:         localizer,
+		wsStore:           wsStore,
+		mailStore:         &MailStore{},
+		fileStore:         fileStore,
+		sessionStore:      &sessionStore{db: db, Audit: auditStore},
+		pageStore:         pageStore{db: db, Audit: auditStore, FileStore: fileStore},
+		imageStore:        imageStore{db: db, Audit: auditStore},
+		profileStore:      profileStore{db: db, FileStore: fileStore},
+		languageStore:     languageStore{db: db},
+		activityStore:     activityStore{db: db},
+		userStore:         userStore,
+		conversationStore: conversationStore{db: db, Audit: auditStore},
+		twoFactorStore:    twoFactorStore{db: db, Issuer: "CouchSport"},
+		loginAttemptStore: loginAttemptStore{db: db, Audit: auditStore, MaxAttempts: 5, MaxIPAttempts: 20, Lockout: 30 * time.Second, MaxLockout: time.Hour},
+		roleStore:         roleStore,
+		auditStore:        auditStore,
+		dataExportStore:   dataExportStore{db: db, WsStore: wsStore},
+		fixtureStore:      fixtureStore{db: db, UserStore: userStore, RoleStore: roleStore},
+		accountDeletion:   accountDeletionStore{db: db, FileStore: fileStore, Audit: auditStore, GracePeriod: 14 * 24 * time.Hour, KeepConversations: true},
+		trashStore:        trashStore{db: db, FileStore: fileStore, Audit: auditStore, Retention: 30 * 24 * time.Hour},
+		uploadStore:       uploadStore{db: db, FileStore: fileStore, MaxSize: 10 << 20},
+	}
+
+	roleStore.Seed()
+
+	return s
+}
+
+//Localizer returns the application Localizer
+func (me *Stores) Localizer() *localizer.Localizer {
+	return me.localizer
+}
+
+//WsStore returns the in-memory websocket hub
+func (me *Stores) WsStore() stores.WsStore {
+	return me.wsStore
+}
+
+//MailStore returns the in-memory mail client
+func (me *Stores) MailStore() stores.MailStore {
+	return me.mailStore
+}
+
+//PageStore returns the in-memory pageStore
+func (me *Stores) PageStore() stores.PageStore {
+	return me.pageStore
+}
+
+//FileStore returns the in-memory fileStore
+func (me *Stores) FileStore() stores.FileStore {
+	return me.fileStore
+}
+
+//ImageStore returns the in-memory imageStore
+func (me *Stores) ImageStore() stores.ImageStore {
+	return me.imageStore
+}
+
+//ProfileStore returns the in-memory profileStore
+func (me *Stores) ProfileStore() stores.ProfileStore {
+	return me.profileStore
+}
+
+//SessionStore returns the in-memory sessionStore
+func (me *Stores) SessionStore() stores.SessionStore {
+	return me.sessionStore
+}
+
+//LanguageStore returns the in-memory languageStore
+func (me *Stores) LanguageStore() stores.LanguageStore {
+	return me.languageStore
+}
+
+//ActivityStore returns the in-memory activityStore
+func (me *Stores) ActivityStore() stores.ActivityStore {
+	return me.activityStore
+}
+
+//UserStore returns the in-memory userStore
+func (me *Stores) UserStore() stores.UserStore {
+	return me.userStore
+}
+
+//ConversationStore returns the in-memory conversationStore
+func (me *Stores) ConversationStore() stores.ConversationStore {
+	return me.conversationStore
+}
+
+//TwoFactorStore returns the in-memory twoFactorStore
+func (me *Stores) TwoFactorStore() stores.TwoFactorStore {
+	return me.twoFactorStore
+}
+
+//LoginAttemptStore returns the in-memory loginAttemptStore
+func (me *Stores) LoginAttemptStore() stores.LoginAttemptStore {
+	return me.loginAttemptStore
+}
+
+//RoleStore returns the in-memory roleStore
+func (me *Stores) RoleStore() stores.RoleStore {
+	return me.roleStore
+}
+
+//AuditStore returns the in-memory auditStore
+func (me *Stores) AuditStore() stores.AuditStore {
+	return me.auditStore
+}
+
+//DataExportStore returns the in-memory dataExportStore
+func (me *Stores) DataExportStore() stores.DataExportStore {
+	return me.dataExportStore
+}
+
+//FixtureStore returns the in-memory fixtureStore
+func (me *Stores) FixtureStore() stores.FixtureStore {
+	return me.fixtureStore
+}
+
+//AccountDeletionStore returns the in-memory accountDeletionStore
+func (me *Stores) AccountDeletionStore() stores.AccountDeletionStore {
+	return me.accountDeletion
+}
+
+//TrashStore returns the in-memory trashStore
+func (me *Stores) TrashStore() stores.TrashStore {
+	return me.trashStore
+}
+
+//UploadStore returns the in-memory uploadStore
+func (me *Stores) UploadStore() stores.UploadStore {
+	return me.uploadStore
+}
+
+//Ws returns the hub with the emitted messages
+func (me *Stores) Ws() *WsStore {
+	return me.wsStore
+}
+
+//Mails returns the mail client with the sent mails
+func (me *Stores) Mails() *MailStore {
+	return me.mailStore
+}
+
+//Files returns the file store with the saved files
+func (me *Stores) Files() *FileStore {
+	return me.fileStore
+}
+
+//SetFixturePath sets the directory the fixtures are read from
+func (me *Stores) SetFixturePath(path string) {
+	me.fixtureStore.Path = path
+}
+
+//database holds the rows of every table in primary key order, the stores lock it for the whole of each method
+type database struct {
+	sync.Mutex
+	sequences     map[string]uint
+	users         []models.User
+	profiles      []models.Profile
+	pages         []models.Page
+	images        []models.Image
+	languages     []models.Language
+	activities    []models.Activity
+	conversations []models.Conversation
+	messages      []models.Message
+	sessions      []models.Session
+	roles         []models.Role
+	userRoles     map[uint][]string
+	recoveryCodes []models.RecoveryCode
+	loginAttempts map[string]models.LoginAttempt
+	events        []models.AuditEvent
+	exports       []models.DataExport
+	uploads       []models.Upload
+	files         map[string][]byte
+	fixtures      map[string]uint
+}
+
+func newDatabase() *database {
+	return &database{
+		sequences:     map[string]uint{},
+		userRoles:     map[uint][]string{},
+		loginAttempts: map[string]models.LoginAttempt{},
+		files:         map[string][]byte{},
+		fixtures:      map[string]uint{},
+	}
+}
+
+//nextID returns the next auto increment value of table
+func (me *database) nextID(table string) uint {
+	me.sequences[table]++
+	return me.sequences[table]
+}
+
+//user returns the index of userID in users, -1 if it does not exist or is soft deleted
+func (me *database) user(userID uint) int {
+	for i, u := range me.users {
+		if u.ID == userID && !u.DeletedAt.Valid {
+			return i
+		}
+	}
+	return -1
+}
+
+func (me *database) profile(profileID uint) int {
+	for i, p := range me.profiles {
+		if p.ID == profileID && !p.DeletedAt.Valid {
+			return i
+		}
+	}
+	return -1
+}
+
+func (me *database) page(pageID uint) int {
+	for i, p := range me.pages {
+		if p.ID == pageID && !p.DeletedAt.Valid {
+			return i
+		}
+	}
+	return -1
+}
+
+func (me *database) conversation(conversationID uint) int {
+	for i, c := range me.conversations {
+		if c.ID == conversationID && !c.DeletedAt.Valid {
+			return i
+		}
+	}
+	return -1
+}
+
+//pageImages returns the images of pageID
+func (me *database) pageImages(pageID uint) []models.Image {
+	images := []models.Image{}
+	for _, i := range me.images {
+		if i.OwnerID == pageID && !i.DeletedAt.Valid {
+			images = append(images, i)
+		}
+	}
+	return images
+}
+
+//userProfile returns the profile of userID
+func (me *database) userProfile(userID uint) (models.Profile, error) {
+	u := me.user(userID)
+	if u < 0 {
+		return models.Profile{}, ErrNotFound
+	}
+
+	p := me.profile(me.users[u].ProfileID)
+	if p < 0 {
+		return models.Profile{}, ErrNotFound
+	}
+
+	return me.profiles[p], nil
+}
+
+//assign copies the fields of src, their Go names, to dst which must be a pointer to a struct of the same type
+func assign(dst, src interface{}, fields []string) {
+	d, s := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for _, f := range fields {
+		d.FieldByName(f).Set(s.FieldByName(f))
+	}
+}
+
+//like matches value against a SQL LIKE pattern, case insensitively
+func like(value, pattern string) bool {
+	parts := strings.Split(strings.ToLower(pattern), "%")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(regexp.QuoteMeta(p), "_", ".")
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(strings.ToLower(value))
+}
+
+//containing tells whether one of values contains query, whatever the case, the wildcards of query are matched literally
+func containing(query string, values ...string) bool {
+	query = strings.ToLower(query)
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), query) {
+			return true
+		}
+	}
+	return false
+}
+
+//paginate returns the bounds of the offset and limit window on a slice of length n
+func paginate(n, offset, limit int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
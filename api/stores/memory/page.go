@@ -0,0 +1,315 @@
+package memory
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"gorm.io/gorm"
+)
+
+type pageStore struct {
+	db        *database
+	Audit     auditStore
+	FileStore *FileStore
+}
+
+//All returns the published pages matching keys, see stores pageStore.All
+func (me pageStore) All(keys url.Values) ([]models.Page, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	random := keys.Get("name") == "random"
+
+	pages := []models.Page{}
+	for _, p := range me.db.pages {
+		if p.DeletedAt.Valid || p.Status != models.PagePublished || !me.matches(p, keys, random) {
+			continue
+		}
+
+		page, _ := me.get(p.ID)
+		if _, ok := keys["profile"]; ok {
+			if o := me.db.profile(page.OwnerID); o >= 0 {
+				page.Owner = me.db.profiles[o]
+			}
+		}
+
+		pages = append(pages, page)
+	}
+
+	if random && len(pages) > 0 {
+		i := rand.Intn(len(pages))
+		pages = pages[i : i+1]
+	}
+
+	return pages, nil
+}
+
+func (me pageStore) matches(p models.Page, keys url.Values, random bool) bool {
+	for k, v := range keys {
+		switch k {
+		case "id":
+			if fmt.Sprint(p.ID) != v[0] {
+				return false
+			}
+		case "name":
+			if !random && p.Name != v[0] {
+				return false
+			}
+		case "owner_id":
+			if fmt.Sprint(p.OwnerID) != v[0] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//get returns pageID with its images, the database must be locked
+func (me pageStore) get(pageID uint) (models.Page, error) {
+	p := me.db.page(pageID)
+	if p < 0 {
+		return models.Page{}, ErrNotFound
+	}
+
+	page := me.db.pages[p]
+	page.Images = me.db.pageImages(pageID)
+	return page, nil
+}
+
+//GetByID returns a page with its images and activities
+func (me pageStore) GetByID(pageID uint) (models.Page, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return me.get(pageID)
+}
+
+//GetPagesByOwnerID returns the pages of profileID
+func (me pageStore) GetPagesByOwnerID(profileID uint) ([]models.Page, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	pages := []models.Page{}
+	for _, p := range me.db.pages {
+		if p.OwnerID == profileID && !p.DeletedAt.Valid {
+			page, _ := me.get(p.ID)
+			pages = append(pages, page)
+		}
+	}
+
+	return pages, nil
+}
+
+//New creates a page, the base64 images are saved in the FileStore
+func (me pageStore) New(profileID uint, page models.Page, actor models.Actor) (models.Page, error) {
+	images := me.FileStore.saveImages(pageDirectory(profileID), page.Images)
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	now := time.Now()
+	page.ID = me.db.nextID("pages")
+	page.CreatedAt, page.UpdatedAt = now, now
+	page.OwnerID = profileID
+	page.Status, page.Public, page.RejectionReason = models.PageDraft, false, ""
+	page.Version = 1
+	page.Followers = nil
+	page.Images = nil
+	page.Activities = activities(me.db, page.Activities)
+	me.db.pages = append(me.db.pages, page)
+
+	me.addImages(page.ID, images)
+
+	me.Audit.record(actor, auditPageCreate, "page", page.ID, nil)
+
+	page, err := me.get(page.ID)
+	page.New = true
+	return page, err
+}
+
+//addImages inserts the new images of pageID, the database must be locked
+func (me pageStore) addImages(pageID uint, images []models.Image) {
+	for _, i := range images {
+		if i.ID > 0 {
+			continue
+		}
+		i.ID = me.db.nextID("images")
+		i.OwnerID = pageID
+		i.CreatedAt, i.UpdatedAt = time.Now(), time.Now()
+		me.db.images = append(me.db.images, i)
+	}
+}
+
+//Update the fields of the page, new images are added and the activities replaced when they are patched
+func (me pageStore) Update(userID uint, page models.Page, fields []string, actor models.Actor) (models.Page, error) {
+	var images []models.Image
+	for _, f := range fields {
+		if f != "Images" {
+			continue
+		}
+
+		//the images go to the directory of the owner whoever edits the page
+		me.db.Lock()
+		p := me.db.page(page.ID)
+		var ownerID uint
+		if p >= 0 {
+			ownerID = me.db.pages[p].OwnerID
+		}
+		me.db.Unlock()
+
+		if p < 0 {
+			return models.Page{}, ErrNotFound
+		}
+
+		images = me.FileStore.saveImages(pageDirectory(ownerID), page.Images)
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	p := me.db.page(page.ID)
+	if p < 0 {
+		return models.Page{}, ErrNotFound
+	}
+
+	existing := me.db.pages[p]
+	if existing.Version != page.Version {
+		return models.Page{}, stores.VersionConflict("page", page.ID, page.Version)
+	}
+
+	for _, f := range fields {
+		switch f {
+		case "Images":
+		case "Activities":
+			existing.Activities = activities(me.db, page.Activities)
+		default:
+			assign(&existing, page, []string{f})
+		}
+	}
+
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	me.db.pages[p] = existing
+
+	me.addImages(page.ID, images)
+
+	me.Audit.record(actor, auditPageUpdate, "page", page.ID, map[string]interface{}{"fields": fields})
+
+	return me.get(page.ID)
+}
+
+//Delete moves the page to the trash, its images stay with it
+func (me pageStore) Delete(userID, pageID uint, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	p := me.db.page(pageID)
+	if p < 0 {
+		return false, ErrNotFound
+	}
+
+	me.db.pages[p].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	me.Audit.record(actor, auditPageDelete, "page", pageID, nil)
+
+	return true, nil
+}
+
+//SetStatus moves pageID to status if it is still in one of the statuses from, see stores pageStore.SetStatus
+func (me pageStore) SetStatus(pageID, version uint, from []string, status, reason string, actor models.Actor) (models.Page, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	p := me.db.page(pageID)
+	if p >= 0 && version != 0 && me.db.pages[p].Version != version {
+		return models.Page{}, stores.VersionConflict("page", pageID, version)
+	}
+
+	if p < 0 || !contains(from, me.db.pages[p].Status) {
+		return models.Page{}, apperror.New(http.StatusConflict, "page.invalid_transition", fmt.Errorf("page %d is not in %v", pageID, from))
+	}
+
+	page := &me.db.pages[p]
+	page.Status, page.Public, page.RejectionReason = status, status == models.PagePublished, reason
+	page.Version++
+	page.UpdatedAt = time.Now()
+
+	metadata := map[string]interface{}{"status": status}
+	if reason != "" {
+		metadata["reason"] = reason
+	}
+	me.Audit.record(actor, auditPageStatus, "page", pageID, metadata)
+
+	return me.get(pageID)
+}
+
+//ByStatus returns a page of the pages in status with their owner, the least recently updated first
+func (me pageStore) ByStatus(status string, offset, limit int) ([]models.Page, int64, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	pages := []models.Page{}
+	for _, p := range me.db.pages {
+		if p.DeletedAt.Valid || p.Status != status {
+			continue
+		}
+
+		page, _ := me.get(p.ID)
+		if o := me.db.profile(page.OwnerID); o >= 0 {
+			page.Owner = me.db.profiles[o]
+		}
+		pages = append(pages, page)
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool { return pages[i].UpdatedAt.Before(pages[j].UpdatedAt) })
+
+	from, to := paginate(len(pages), offset, limit)
+	return pages[from:to], int64(len(pages)), nil
+}
+
+//ApplySchedule publishes the scheduled pages and unpublishes the published ones whose time has come
+func (me pageStore) ApplySchedule(now time.Time) (int, error) {
+	me.db.Lock()
+	var due []models.Page
+	for _, p := range me.db.pages {
+		if p.DeletedAt.Valid {
+			continue
+		}
+		if (p.Status == models.PageScheduled && p.PublishAt != nil && !p.PublishAt.After(now)) ||
+			(p.Status == models.PagePublished && p.UnpublishAt != nil && !p.UnpublishAt.After(now)) {
+			due = append(due, p)
+		}
+	}
+	me.db.Unlock()
+
+	changed := 0
+	for _, p := range due {
+		to := models.PagePublished
+		if p.Status == models.PagePublished {
+			to = models.PageUnpublished
+		}
+
+		if _, err := me.SetStatus(p.ID, 0, []string{p.Status}, to, "", models.Actor{}); err != nil {
+			continue
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
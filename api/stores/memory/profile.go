@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+type profileStore struct {
+	db        *database
+	FileStore *FileStore
+}
+
+//All returns all profiles
+func (me profileStore) All() ([]models.Profile, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	profiles := []models.Profile{}
+	for _, p := range me.db.profiles {
+		if !p.DeletedAt.Valid {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+//Search returns a page of the listed profiles matching filter, see stores profileStore.Search
+func (me profileStore) Search(filter models.ProfileFilter, offset, limit int) ([]models.Profile, int64, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	profiles := []models.Profile{}
+	for _, p := range me.db.profiles {
+		if me.listed(p) && matchesProfile(filter, p) {
+			profiles = append(profiles, p)
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Username < profiles[j].Username })
+
+	from, to := paginate(len(profiles), offset, limit)
+	return profiles[from:to], int64(len(profiles)), nil
+}
+
+//GetByID returns a listed profile
+func (me profileStore) GetByID(profileID uint) (models.Profile, error) {
+	return me.get(func(p models.Profile) bool { return p.ID == profileID })
+}
+
+//GetByUsername returns a listed profile
+func (me profileStore) GetByUsername(username string) (models.Profile, error) {
+	return me.get(func(p models.Profile) bool { return p.Username == username })
+}
+
+func (me profileStore) get(match func(models.Profile) bool) (models.Profile, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, p := range me.db.profiles {
+		if me.listed(p) && match(p) {
+			return p, nil
+		}
+	}
+	return models.Profile{}, ErrNotFound
+}
+
+//listed tells whether p has a username and a user who is neither suspended nor pending deletion, the database must be locked
+func (me profileStore) listed(p models.Profile) bool {
+	if p.DeletedAt.Valid || p.Username == "" {
+		return false
+	}
+
+	for _, u := range me.db.users {
+		if u.ProfileID == p.ID && !u.DeletedAt.Valid {
+			return u.SuspendedAt == nil && u.DeletionScheduledAt == nil
+		}
+	}
+	return false
+}
+
+func matchesProfile(filter models.ProfileFilter, p models.Profile) bool {
+	if filter.Query != "" && !containing(filter.Query, p.Username, p.Firstname, p.Lastname) {
+		return false
+	}
+
+	if filter.ActivityID > 0 {
+		found := false
+		for _, a := range p.Activities {
+			found = found || a.ID == filter.ActivityID
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if filter.LanguageID > 0 {
+		found := false
+		for _, l := range p.Languages {
+			found = found || l.ID == filter.LanguageID
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return (filter.Country == "" || strings.EqualFold(p.Country, filter.Country)) &&
+		(filter.City == "" || strings.EqualFold(p.City, filter.City))
+}
+
+//Update the fields of the profile, the languages and activities are replaced when they are patched. The file
+//of the replaced avatar is removed, so is the new one when the update fails
+func (me profileStore) Update(userID uint, profile models.Profile, fields []string) (models.Profile, error) {
+	uploaded := ""
+	if profile.AvatarFile != "" {
+		filename, _, err := me.FileStore.saveImage(avatarDirectory(userID), profile.AvatarFile, profile.Avatar, false)
+		if err != nil {
+			return models.Profile{}, err
+		}
+		profile.AvatarFile = ""
+		profile.Avatar = filename
+		uploaded = filename
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	p := me.db.profile(profile.ID)
+	if p < 0 {
+		delete(me.db.files, uploaded)
+		return models.Profile{}, ErrNotFound
+	}
+
+	existing := me.db.profiles[p]
+	if existing.Version != profile.Version {
+		delete(me.db.files, uploaded)
+		return models.Profile{}, stores.VersionConflict("profile", profile.ID, profile.Version)
+	}
+
+	//only the files of the member go, the links to other sites and the files of other members are left alone
+	if previous := existing.Avatar; contains(fields, "Avatar") && previous != profile.Avatar && me.FileStore.Within(previous, avatarDirectories(userID)...) {
+		delete(me.db.files, previous)
+	}
+
+	for _, f := range fields {
+		switch f {
+		case "Languages":
+			existing.Languages = languages(me.db, profile.Languages)
+		case "Activities":
+			existing.Activities = activities(me.db, profile.Activities)
+		default:
+			assign(&existing, profile, []string{f})
+		}
+	}
+
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	me.db.profiles[p] = existing
+
+	return existing, nil
+}
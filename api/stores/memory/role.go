@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type roleStore struct {
+	db    *database
+	Audit auditStore
+}
+
+//Seed creates the roles and permissions of models.RolePermissions
+func (me roleStore) Seed() {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	names := make([]string, 0, len(models.RolePermissions))
+	for name := range models.RolePermissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	permissions := map[string]*models.Permission{}
+	for _, r := range me.db.roles {
+		for _, p := range r.Permissions {
+			permissions[p.Name] = p
+		}
+	}
+
+	for _, name := range names {
+		role := models.Role{Name: name}
+		for _, p := range models.RolePermissions[name] {
+			if permissions[p] == nil {
+				permissions[p] = &models.Permission{ID: me.db.nextID("permissions"), Name: p}
+			}
+			role.Permissions = append(role.Permissions, permissions[p])
+		}
+
+		if r := me.role(name); r >= 0 {
+			role.ID = me.db.roles[r].ID
+			me.db.roles[r] = role
+			continue
+		}
+
+		role.ID = me.db.nextID("roles")
+		me.db.roles = append(me.db.roles, role)
+	}
+}
+
+func (me roleStore) role(name string) int {
+	for i, r := range me.db.roles {
+		if r.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//All returns every role with its permissions
+func (me roleStore) All() ([]models.Role, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return append([]models.Role{}, me.db.roles...), nil
+}
+
+//UserRoles returns the roles of userID
+func (me roleStore) UserRoles(userID uint) ([]*models.Role, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if me.db.user(userID) < 0 {
+		return nil, ErrNotFound
+	}
+
+	return roles(me.db, userID), nil
+}
+
+//HasPermission tells whether one of userID roles grants permission
+func (me roleStore) HasPermission(userID uint, permission string) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, r := range roles(me.db, userID) {
+		for _, p := range r.Permissions {
+			if p.Name == permission {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+//Grant gives roleName to userID, granting admin also flags the user Type as ADMIN
+func (me roleStore) Grant(userID uint, roleName string, actor models.Actor) ([]*models.Role, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 || me.role(roleName) < 0 {
+		return nil, ErrNotFound
+	}
+
+	if !hasRole(me.db, userID, roleName) {
+		me.db.userRoles[userID] = append(me.db.userRoles[userID], roleName)
+	}
+
+	if roleName == models.RoleAdmin {
+		me.db.users[u].Type = "ADMIN"
+	}
+
+	me.Audit.record(actor, auditRoleGrant, "user", userID, map[string]interface{}{"role": roleName})
+
+	return roles(me.db, userID), nil
+}
+
+//Revoke removes roleName from userID, the last admin cannot be revoked
+func (me roleStore) Revoke(userID uint, roleName string, actor models.Actor) ([]*models.Role, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 || me.role(roleName) < 0 {
+		return nil, ErrNotFound
+	}
+
+	if roleName == models.RoleAdmin {
+		admins := 0
+		for id := range me.db.userRoles {
+			if id != userID && hasRole(me.db, id, models.RoleAdmin) {
+				admins++
+			}
+		}
+
+		if admins == 0 {
+			return nil, fmt.Errorf("cannot revoke the last admin")
+		}
+	}
+
+	names := []string{}
+	for _, n := range me.db.userRoles[userID] {
+		if n != roleName {
+			names = append(names, n)
+		}
+	}
+	me.db.userRoles[userID] = names
+
+	if roleName == models.RoleAdmin {
+		me.db.users[u].Type = "USER"
+	}
+
+	me.Audit.record(actor, auditRoleRevoke, "user", userID, map[string]interface{}{"role": roleName})
+
+	return roles(me.db, userID), nil
+}
+
+//roles returns the roles of userID, the database must be locked
+func roles(db *database, userID uint) []*models.Role {
+	out := []*models.Role{}
+	for _, r := range db.roles {
+		if hasRole(db, userID, r.Name) {
+			role := r
+			out = append(out, &role)
+		}
+	}
+	return out
+}
+
+func hasRole(db *database, userID uint, name string) bool {
+	for _, n := range db.userRoles[userID] {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
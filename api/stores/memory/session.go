@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/gofrs/uuid"
+)
+
+//same values as the stores package
+const (
+	tokenKey               = "user-token"
+	sessionValidity        = 60 * 60
+	partialSessionValidity = 5 * 60
+)
+
+type sessionStore struct {
+	db    *database
+	Audit auditStore
+}
+
+//CreateOrRetrieve returns the token of the valid session of userID, or replaces its sessions by a new one
+func (me *sessionStore) CreateOrRetrieve(userID uint, actor models.Actor) (string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	now := time.Now()
+	for _, s := range me.db.sessions {
+		if s.OwnerID == userID && !s.Partial && s.Expires.After(now) {
+			me.Audit.record(actor, auditLogin, "user", userID, map[string]interface{}{"resumed": true})
+			return s.SessionID, nil
+		}
+	}
+
+	deleteSessions(me.db, func(s models.Session) bool { return s.OwnerID == userID })
+
+	token, err := me.create(userID, sessionValidity, false)
+	if err != nil {
+		return "", err
+	}
+
+	me.Audit.record(actor, auditLogin, "user", userID, nil)
+	return token, nil
+}
+
+//CreatePartial replaces the partial sessions of userID by a new one and returns its token
+func (me *sessionStore) CreatePartial(userID uint, actor models.Actor) (string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	deleteSessions(me.db, func(s models.Session) bool { return s.OwnerID == userID && s.Partial })
+
+	token, err := me.create(userID, partialSessionValidity, true)
+	if err != nil {
+		return "", err
+	}
+
+	me.Audit.record(actor, auditLoginPartial, "user", userID, nil)
+	return token, nil
+}
+
+//create appends a new session of userID and returns its token, the database must be locked
+func (me *sessionStore) create(userID uint, validity uint, partial bool) (string, error) {
+	token, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	me.db.sessions = append(me.db.sessions, models.Session{
+		SessionID: token.String(),
+		OwnerID:   userID,
+		Expires:   time.Now().Add(time.Duration(validity) * time.Second),
+		Validity:  validity,
+		Partial:   partial,
+	})
+
+	return token.String(), nil
+}
+
+//Confirm turns a partial session into a full one and drops the other sessions of its owner
+func (me *sessionStore) Confirm(session *models.Session, actor models.Actor) (string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	deleteSessions(me.db, func(s models.Session) bool {
+		return s.OwnerID == session.OwnerID && s.SessionID != session.SessionID
+	})
+
+	session.Partial = false
+	session.Expires = time.Now().Add(sessionValidity * time.Second)
+	session.Validity = sessionValidity
+	for i, s := range me.db.sessions {
+		if s.SessionID == session.SessionID {
+			me.db.sessions[i] = *session
+		}
+	}
+
+	me.Audit.record(actor, auditLoginSecond, "user", session.OwnerID, nil)
+	return session.SessionID, nil
+}
+
+//GetSession returns the session whose token is in the request cookie
+func (me *sessionStore) GetSession(r *http.Request) (*models.Session, error) {
+	cookie, err := me.GetCookieFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if cookie.Value == "" {
+		return nil, http.ErrNoCookie
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, s := range me.db.sessions {
+		if s.SessionID == cookie.Value {
+			return &s, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+//GetCookieFromRequest returns the session cookie of r
+func (me *sessionStore) GetCookieFromRequest(r *http.Request) (*http.Cookie, error) {
+	c, err := r.Cookie(tokenKey)
+	if err != nil {
+		return nil, http.ErrNoCookie
+	}
+	return c, nil
+}
+
+//Destroy drops the request session
+func (me *sessionStore) Destroy(r *http.Request, actor models.Actor) (bool, error) {
+	session, err := me.GetSession(r)
+	if err != nil {
+		return false, err
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	deleteSessions(me.db, func(s models.Session) bool { return s.SessionID == session.SessionID })
+	if !session.Partial {
+		me.Audit.record(actor, auditLogout, "user", session.OwnerID, nil)
+	}
+
+	return true, nil
+}
+
+//DestroyAllByUserID drops the sessions of userID
+func (me *sessionStore) DestroyAllByUserID(userID uint) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	deleteSessions(me.db, func(s models.Session) bool { return s.OwnerID == userID })
+	return true, nil
+}
+
+//CreateCookie returns the cookie carrying token
+func (me *sessionStore) CreateCookie(token string) (*http.Cookie, error) {
+	if token == "" {
+		return nil, fmt.Errorf("cannot generate cookie without token")
+	}
+
+	return &http.Cookie{
+		Name:     tokenKey,
+		Value:    token,
+		Expires:  time.Now().Add(sessionValidity * time.Second),
+		SameSite: http.SameSiteLaxMode,
+	}, nil
+}
+
+//deleteSessions drops the sessions matching drop, the database must be locked
+func deleteSessions(db *database, drop func(models.Session) bool) {
+	sessions := db.sessions[:0]
+	for _, s := range db.sessions {
+		if !drop(s) {
+			sessions = append(sessions, s)
+		}
+	}
+	db.sessions = sessions
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/gorm"
+)
+
+type trashStore struct {
+	db        *database
+	FileStore *FileStore
+	Audit     auditStore
+	Retention time.Duration
+}
+
+//Content returns the deleted pages and images of profileID, see stores trashStore.Content
+func (me trashStore) Content(profileID uint) (models.Trash, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	trash := models.Trash{Pages: []models.Page{}, Images: []models.Image{}, RetentionDays: int(me.Retention / (24 * time.Hour))}
+
+	for _, p := range me.db.pages {
+		if p.OwnerID == profileID && p.DeletedAt.Valid {
+			p.Images = me.db.pageImages(p.ID)
+			trash.Pages = append(trash.Pages, p)
+		}
+	}
+
+	for _, i := range me.db.images {
+		if p := me.db.page(i.OwnerID); p >= 0 && me.db.pages[p].OwnerID == profileID && i.DeletedAt.Valid {
+			trash.Images = append(trash.Images, i)
+		}
+	}
+
+	sort.SliceStable(trash.Pages, func(i, j int) bool { return trash.Pages[i].DeletedAt.Time.After(trash.Pages[j].DeletedAt.Time) })
+	sort.SliceStable(trash.Images, func(i, j int) bool { return trash.Images[i].DeletedAt.Time.After(trash.Images[j].DeletedAt.Time) })
+
+	return trash, nil
+}
+
+//RestorePage takes the page of profileID out of the trash
+func (me trashStore) RestorePage(profileID, pageID uint, actor models.Actor) (models.Page, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i, p := range me.db.pages {
+		if p.ID == pageID && p.OwnerID == profileID && p.DeletedAt.Valid {
+			me.db.pages[i].DeletedAt = gorm.DeletedAt{}
+			me.db.pages[i].Version++
+
+			me.Audit.record(actor, auditPageRestore, "page", pageID, nil)
+
+			page := me.db.pages[i]
+			page.Images = me.db.pageImages(pageID)
+			return page, nil
+		}
+	}
+
+	return models.Page{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("page %d is not in the trash of profile %d", pageID, profileID))
+}
+
+//RestoreImage takes the image of pageID out of the trash, the page must belong to profileID and not be deleted
+func (me trashStore) RestoreImage(profileID, pageID, imageID uint, actor models.Actor) (models.Image, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if p := me.db.page(pageID); p >= 0 && me.db.pages[p].OwnerID == profileID {
+		for i, image := range me.db.images {
+			if image.ID == imageID && image.OwnerID == pageID && image.DeletedAt.Valid {
+				me.db.images[i].DeletedAt = gorm.DeletedAt{}
+
+				me.Audit.record(actor, auditImageRestore, "image", imageID, nil)
+
+				return me.db.images[i], nil
+			}
+		}
+	}
+
+	return models.Image{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("image %d of page %d is not in the trash of profile %d", imageID, pageID, profileID))
+}
+
+//PurgeDue permanently deletes the pages and images deleted for longer than the retention period at now,
+//see stores trashStore.PurgeDue
+func (me trashStore) PurgeDue(now time.Time) (int, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	before := now.Add(-me.Retention)
+	due := func(d gorm.DeletedAt) bool { return d.Valid && !d.Time.After(before) }
+
+	directories := me.directories()
+
+	purgedPages := map[uint]bool{}
+	pages := me.db.pages[:0]
+	for _, p := range me.db.pages {
+		if due(p.DeletedAt) {
+			purgedPages[p.ID] = true
+			me.Audit.record(models.Actor{}, auditPagePurge, "page", p.ID, nil)
+			continue
+		}
+		pages = append(pages, p)
+	}
+	me.db.pages = pages
+
+	purged := len(purgedPages)
+	images := me.db.images[:0]
+	for _, i := range me.db.images {
+		if !purgedPages[i.OwnerID] && !due(i.DeletedAt) {
+			images = append(images, i)
+			continue
+		}
+
+		if !purgedPages[i.OwnerID] {
+			me.Audit.record(models.Actor{}, auditImagePurge, "image", i.ID, nil)
+		}
+		for _, file := range append(i.Variants.URLs(), i.URL) {
+			if me.FileStore.Within(file, directories[i.OwnerID]...) {
+				delete(me.db.files, file)
+			}
+		}
+		purged++
+	}
+	me.db.images = images
+
+	return purged, nil
+}
+
+//directories returns the directories the files of the images of each page can be removed from: the ones of the
+//page owner. An image pointing to the file of another member leaves it alone, the database must be locked
+func (me trashStore) directories() map[uint][]string {
+	directories := map[uint][]string{}
+	for _, p := range me.db.pages {
+		for _, u := range me.db.users {
+			if u.ProfileID == p.OwnerID {
+				directories[p.ID] = pageDirectories(p.OwnerID, u.ID)
+			}
+		}
+	}
+	return directories
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+const recoveryCodesCount = 10
+
+type twoFactorStore struct {
+	db     *database
+	Issuer string
+}
+
+//Enroll generates a new TOTP secret for userID and returns it along with its provisioning URI
+func (me twoFactorStore) Enroll(userID uint) (string, string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		return "", "", ErrNotFound
+	}
+
+	if me.db.users[u].TwoFactorEnabled {
+		return "", "", fmt.Errorf("two factor authentication already enabled")
+	}
+
+	secret, err := utils.NewTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	me.db.users[u].TwoFactorSecret = secret
+
+	return secret, utils.TOTPProvisioningURI(me.Issuer, me.db.users[u].Email, secret), nil
+}
+
+//Confirm enables 2FA for userID if code matches the enrolled secret and returns fresh recovery codes
+func (me twoFactorStore) Confirm(userID uint, code string) ([]string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		return nil, ErrNotFound
+	}
+	user := me.db.users[u]
+
+	if user.TwoFactorEnabled {
+		return nil, fmt.Errorf("two factor authentication already enabled")
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, fmt.Errorf("two factor authentication not enrolled")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep)
+	if !ok {
+		return nil, fmt.Errorf("invalid code")
+	}
+
+	codes, err := me.replaceRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	me.db.users[u].TwoFactorEnabled = true
+	me.db.users[u].TwoFactorLastStep = step
+
+	return codes, nil
+}
+
+//Verify checks code against the user TOTP secret, then against its unused recovery codes
+func (me twoFactorStore) Verify(userID uint, code string) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		return false, ErrNotFound
+	}
+	user := me.db.users[u]
+
+	if !user.TwoFactorEnabled {
+		return false, fmt.Errorf("two factor authentication not enabled")
+	}
+
+	//the code of a step is accepted once, a replayed one is refused
+	if step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep); ok {
+		me.db.users[u].TwoFactorLastStep = step
+		return true, nil
+	}
+
+	hash := hashRecoveryCode(code)
+	for i, rc := range me.db.recoveryCodes {
+		if rc.OwnerID != userID || rc.UsedAt != nil || subtle.ConstantTimeCompare([]byte(rc.Hash), []byte(hash)) != 1 {
+			continue
+		}
+
+		now := time.Now()
+		me.db.recoveryCodes[i].UsedAt = &now
+		return true, nil
+	}
+
+	return false, nil
+}
+
+//RegenerateRecoveryCodes invalidates every recovery code of userID and returns new ones
+func (me twoFactorStore) RegenerateRecoveryCodes(userID uint) ([]string, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return me.replaceRecoveryCodes(userID)
+}
+
+//Disable removes the TOTP secret and the recovery codes of userID
+func (me twoFactorStore) Disable(userID uint) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if u := me.db.user(userID); u >= 0 {
+		me.db.users[u].TwoFactorEnabled = false
+		me.db.users[u].TwoFactorSecret = ""
+	}
+
+	deleteRecoveryCodes(me.db, userID)
+
+	return true, nil
+}
+
+func (me twoFactorStore) replaceRecoveryCodes(userID uint) ([]string, error) {
+	deleteRecoveryCodes(me.db, userID)
+
+	codes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := utils.NewRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		me.db.recoveryCodes = append(me.db.recoveryCodes, models.RecoveryCode{
+			ID:      me.db.nextID("recovery_codes"),
+			OwnerID: userID,
+			Hash:    hashRecoveryCode(code),
+		})
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+//deleteRecoveryCodes drops the recovery codes of userID, the database must be locked
+func deleteRecoveryCodes(db *database, userID uint) {
+	codes := db.recoveryCodes[:0]
+	for _, rc := range db.recoveryCodes {
+		if rc.OwnerID != userID {
+			codes = append(codes, rc)
+		}
+	}
+	db.recoveryCodes = codes
+}
+
+//hashRecoveryCode is the digest the stores package stores
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
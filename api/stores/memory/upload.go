@@ -0,0 +1,393 @@
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+const uploadValidity = 24 * time.Hour
+
+//uploadChunks serializes the writes of the chunks, the offset of a chunk is checked and the chunk written at once
+var uploadChunks sync.Mutex
+
+type uploadStore struct {
+	db        *database
+	FileStore *FileStore
+	MaxSize   int64
+}
+
+//Limit returns the size in bytes of the largest file accepted
+func (me uploadStore) Limit() int64 {
+	return me.MaxSize
+}
+
+//Receive saves the file of userID read from r in one go, see stores uploadStore.Receive
+func (me uploadStore) Receive(userID uint, filename string, r io.Reader) (models.Upload, error) {
+	upload := newUpload(userID, filename)
+
+	p, err := me.FileStore.Save(uploadDirectory(userID), upload.Filename, io.LimitReader(r, me.MaxSize+1))
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	b, _ := me.FileStore.Get(p)
+	upload.URL, upload.Size, upload.Received = p, int64(len(b)), int64(len(b))
+
+	if upload.Size > me.MaxSize {
+		me.FileStore.Delete(p)
+		return models.Upload{}, uploadTooLarge(me.MaxSize)
+	}
+
+	if err := me.finish(&upload, b); err != nil {
+		return models.Upload{}, err
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	upload.ID = me.db.nextID("uploads")
+	me.db.uploads = append(me.db.uploads, upload)
+
+	return upload, nil
+}
+
+//Start opens the resumable upload of a file of size bytes for userID
+func (me uploadStore) Start(userID uint, filename string, size int64) (models.Upload, error) {
+	if size <= 0 {
+		return models.Upload{}, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, fmt.Errorf("invalid upload size %d", size))
+	}
+
+	if size > me.MaxSize {
+		return models.Upload{}, uploadTooLarge(me.MaxSize)
+	}
+
+	upload := newUpload(userID, filename)
+	upload.Size = size
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	upload.ID = me.db.nextID("uploads")
+	me.db.uploads = append(me.db.uploads, upload)
+
+	return upload, nil
+}
+
+//Append writes chunk at offset in the resumable upload of userID, see stores uploadStore.Append
+func (me uploadStore) Append(userID, uploadID uint, offset int64, chunk io.Reader) (models.Upload, error) {
+	upload, err := me.appendable(userID, uploadID, offset)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	//the chunk is read before the lock is taken, a slow client only holds its own request
+	b, copyErr := ioutil.ReadAll(io.LimitReader(chunk, upload.Size-upload.Received))
+
+	if n, _ := chunk.Read(make([]byte, 1)); n > 0 && copyErr == nil {
+		copyErr = uploadTooLarge(upload.Size - offset)
+	}
+
+	upload, err = me.write(userID, uploadID, offset, b)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	if upload.Received == upload.Size {
+		content, _ := me.FileStore.Get(upload.URL)
+		if err := me.finish(&upload, content); err != nil {
+			me.remove(uploadID)
+			return models.Upload{}, err
+		}
+
+		me.update(upload)
+	}
+
+	if copyErr != nil {
+		return models.Upload{}, copyErr
+	}
+
+	return upload, nil
+}
+
+//write appends b to the file of the upload if it is still at offset, the chunks of all the uploads are serialized
+//while their offset is checked and their content appended
+func (me uploadStore) write(userID, uploadID uint, offset int64, b []byte) (models.Upload, error) {
+	uploadChunks.Lock()
+	defer uploadChunks.Unlock()
+
+	upload, err := me.appendable(userID, uploadID, offset)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	p, err := me.FileStore.Append(uploadDirectory(userID), upload.Filename, bytes.NewReader(b))
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	content, _ := me.FileStore.Get(p)
+	upload.URL, upload.Received = p, int64(len(content))
+	me.update(upload)
+
+	return upload, nil
+}
+
+//appendable returns the upload uploadID of userID if a chunk can be appended at offset, see stores uploadStore.appendable
+func (me uploadStore) appendable(userID, uploadID uint, offset int64) (models.Upload, error) {
+	upload, err := me.Get(userID, uploadID)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	if upload.HasExpired() {
+		return models.Upload{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("upload %d has expired", uploadID))
+	}
+
+	if upload.Complete || upload.Received == upload.Size || offset != upload.Received {
+		return models.Upload{}, apperror.New(http.StatusConflict, "upload.offset_mismatch", fmt.Errorf("upload %d received %d bytes, not %d", uploadID, upload.Received, offset)).
+			WithVars(map[string]string{"Received": strconv.FormatInt(upload.Received, 10)})
+	}
+
+	return upload, nil
+}
+
+//update saves upload over its row
+func (me uploadStore) update(upload models.Upload) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i, u := range me.db.uploads {
+		if u.ID == upload.ID {
+			me.db.uploads[i] = upload
+		}
+	}
+}
+
+//Get returns the upload uploadID of userID
+func (me uploadStore) Get(userID, uploadID uint) (models.Upload, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, u := range me.db.uploads {
+		if u.ID == uploadID && u.OwnerID == userID {
+			return u, nil
+		}
+	}
+
+	return models.Upload{}, ErrNotFound
+}
+
+//Ready returns the upload uploadID of userID if it can be referenced, see stores uploadStore.Ready
+func (me uploadStore) Ready(userID, uploadID uint) (models.Upload, error) {
+	upload, err := me.Get(userID, uploadID)
+	if err == nil && (!upload.Complete || upload.HasExpired()) {
+		err = fmt.Errorf("upload %d is not complete or has expired", uploadID)
+	}
+
+	if err != nil {
+		return models.Upload{}, apperror.New(http.StatusUnprocessableEntity, "upload.unavailable", err)
+	}
+
+	return upload, nil
+}
+
+//Consume deletes the uploads of userID, their files stay
+func (me uploadStore) Consume(userID uint, uploadIDs []uint) error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	consumed := map[uint]bool{}
+	for _, id := range uploadIDs {
+		consumed[id] = true
+	}
+
+	uploads := me.db.uploads[:0]
+	for _, u := range me.db.uploads {
+		if u.OwnerID != userID || !consumed[u.ID] {
+			uploads = append(uploads, u)
+		}
+	}
+	me.db.uploads = uploads
+
+	return nil
+}
+
+//PurgeExpired deletes the uploads which expired at now and their file unless an image or an avatar references it
+func (me uploadStore) PurgeExpired(now time.Time) (int, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	referenced := me.referenced(false)
+
+	purged := 0
+	uploads := me.db.uploads[:0]
+	for _, u := range me.db.uploads {
+		if u.ExpiresAt.After(now) {
+			uploads = append(uploads, u)
+			continue
+		}
+
+		for _, file := range append(u.Variants.URLs(), u.URL) {
+			if !referenced[file] {
+				delete(me.db.files, file)
+			}
+		}
+		purged++
+	}
+	me.db.uploads = uploads
+
+	return purged, nil
+}
+
+//Collect removes the uploaded files no image, avatar or upload references, the files kept in memory have no
+//modification time so minAge is not checked
+func (me uploadStore) Collect(now time.Time, minAge time.Duration, dryRun bool) (models.UploadReport, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	report := models.UploadReport{Orphans: []string{}, Missing: []string{}}
+
+	referenced := me.referenced(true)
+
+	for p := range me.db.files {
+		if !strings.HasPrefix(p, imageBasePath+"/") {
+			continue
+		}
+
+		if referenced[p] {
+			report.Kept++
+			continue
+		}
+
+		report.Orphans = append(report.Orphans, p)
+	}
+	sort.Strings(report.Orphans)
+
+	if !dryRun {
+		for _, p := range report.Orphans {
+			delete(me.db.files, p)
+		}
+	}
+
+	for p := range referenced {
+		if _, ok := me.db.files[p]; !ok && strings.HasPrefix(p, imageBasePath+"/") {
+			report.Missing = append(report.Missing, p)
+		}
+	}
+	sort.Strings(report.Missing)
+
+	return report, nil
+}
+
+//referenced returns the paths the images, their variants included, and the avatars reference, and the ones of the uploads with uploads
+func (me uploadStore) referenced(uploads bool) map[string]bool {
+	referenced := map[string]bool{}
+	for _, i := range me.db.images {
+		for _, file := range append(i.Variants.URLs(), i.URL) {
+			referenced[file] = true
+		}
+	}
+	for _, p := range me.db.profiles {
+		referenced[p.Avatar] = true
+	}
+	if uploads {
+		for _, u := range me.db.uploads {
+			for _, file := range append(u.Variants.URLs(), u.URL) {
+				referenced[file] = true
+			}
+		}
+	}
+	return referenced
+}
+
+//finish decodes the content b of upload and saves it again with its variants, see stores uploadStore.finish
+func (me uploadStore) finish(upload *models.Upload, b []byte) error {
+	mime, img, err := utils.DecodeImage(b)
+	if errors.Is(err, utils.ErrTooManyPixels) {
+		me.FileStore.Delete(upload.URL)
+		return apperror.New(http.StatusRequestEntityTooLarge, "upload.too_many_pixels", err).WithVars(map[string]string{"Max": strconv.Itoa(utils.MaxPixels / 1000 / 1000)})
+	}
+
+	if err != nil {
+		me.FileStore.Delete(upload.URL)
+		return apperror.New(http.StatusUnsupportedMediaType, "upload.unsupported_type", err)
+	}
+
+	p, variants, err := me.FileStore.SaveImage(uploadDirectory(upload.OwnerID), upload.Filename, mime, img, true)
+	if err != nil {
+		me.FileStore.Delete(upload.URL)
+		return err
+	}
+
+	upload.URL, upload.Variants, upload.Mime, upload.Complete = p, variants, mime, true
+
+	return nil
+}
+
+func (me uploadStore) remove(uploadID uint) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for i, u := range me.db.uploads {
+		if u.ID == uploadID {
+			me.db.uploads = append(me.db.uploads[:i], me.db.uploads[i+1:]...)
+			return
+		}
+	}
+}
+
+func newUpload(userID uint, filename string) models.Upload {
+	name, err := utils.Sanitize(filename)
+	if err != nil || name == "" {
+		name = "upload"
+	}
+
+	now := time.Now()
+	return models.Upload{
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerID:   userID,
+		Filename:  utils.RandStringBytesMaskImprSrc(12) + "." + name,
+		ExpiresAt: now.Add(uploadValidity),
+	}
+}
+
+func uploadDirectory(userID uint) string {
+	return "upload-" + strconv.FormatUint(uint64(userID), 10)
+}
+
+func pageDirectory(profileID uint) string {
+	return "page-" + strconv.FormatUint(uint64(profileID), 10)
+}
+
+//pageDirectories are the directories the page images of the profileID of userID are saved in, see stores pageDirectories
+func pageDirectories(profileID, userID uint) []string {
+	return []string{pageDirectory(profileID), pageDirectory(userID), uploadDirectory(userID)}
+}
+
+//avatarDirectories are the directories the avatars of userID are saved in, by a data URL or an upload
+func avatarDirectories(userID uint) []string {
+	return []string{avatarDirectory(userID), uploadDirectory(userID)}
+}
+
+func avatarDirectory(userID uint) string {
+	return "user-" + strconv.FormatUint(uint64(userID), 10)
+}
+
+func uploadTooLarge(max int64) error {
+	return apperror.New(http.StatusRequestEntityTooLarge, "upload.too_large", fmt.Errorf("more than %d bytes", max)).
+		WithVars(map[string]string{"Max": strconv.FormatInt(max, 10)})
+}
@@ -0,0 +1,360 @@
+package memory
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+type userStore struct {
+	db    *database
+	Audit auditStore
+}
+
+//All returns the users matching keys, see stores userStore.All
+func (me userStore) All(keys url.Values) ([]models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	users := []models.User{}
+	for _, u := range me.db.users {
+		if u.DeletedAt.Valid || !me.matches(u, keys) {
+			continue
+		}
+
+		if _, ok := keys["profile"]; ok {
+			if p := me.db.profile(u.ProfileID); p >= 0 {
+				u.Profile = me.db.profiles[p]
+			}
+		}
+
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+func (me userStore) matches(u models.User, keys url.Values) bool {
+	for k, v := range keys {
+		switch k {
+		case "id":
+			if fmt.Sprint(u.ID) != v[0] {
+				return false
+			}
+		case "profile_id":
+			if fmt.Sprint(u.ProfileID) != v[0] {
+				return false
+			}
+		case "username":
+			p := me.db.profile(u.ProfileID)
+			if p < 0 || !like(me.db.profiles[p].Username, v[0]) {
+				return false
+			}
+		case "email":
+			if !like(u.Email, v[0]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//Search users whose email or username contains query, most recent first
+func (me userStore) Search(query string, offset, limit int) ([]models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	users := []models.User{}
+	for i := len(me.db.users) - 1; i >= 0; i-- {
+		u := me.db.users[i]
+		if u.DeletedAt.Valid {
+			continue
+		}
+
+		if p := me.db.profile(u.ProfileID); p >= 0 {
+			u.Profile = me.db.profiles[p]
+		}
+
+		if query != "" && !containing(query, u.Email, u.Profile.Username) {
+			continue
+		}
+
+		u.Password = ""
+		u.Roles = roles(me.db, u.ID)
+		users = append(users, u)
+	}
+
+	from, to := paginate(len(users), offset, limit)
+	return users[from:to], nil
+}
+
+//Suspend or reinstate userID, suspending drops its sessions
+func (me userStore) Suspend(userID uint, suspended bool, actor models.Actor) (bool, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		return false, ErrNotFound
+	}
+
+	me.db.users[u].SuspendedAt = nil
+	action := auditUserReinstate
+	if suspended {
+		now := time.Now()
+		me.db.users[u].SuspendedAt = &now
+		deleteSessions(me.db, func(s models.Session) bool { return s.OwnerID == userID })
+		action = auditUserSuspend
+	}
+
+	me.Audit.record(actor, action, "user", userID, nil)
+
+	return true, nil
+}
+
+//New user, with its empty profile and the default roles
+func (me userStore) New(user models.User) (models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return me.create(user)
+}
+
+//create is New, the database must be locked
+func (me userStore) create(user models.User) (models.User, error) {
+	for _, u := range me.db.users {
+		if u.Email == user.Email && !u.DeletedAt.Valid {
+			return models.User{}, apperror.New(http.StatusForbidden, "user.already_exists", nil)
+		}
+	}
+
+	if user.Email == "" || user.Password == "" {
+		return models.User{}, fmt.Errorf("email or password is empty")
+	}
+
+	now := time.Now()
+
+	profile := models.Profile{Email: user.Email}
+	profile.ID = me.db.nextID("profiles")
+	profile.CreatedAt, profile.UpdatedAt = now, now
+	profile.Version = 1
+	profile.Privacy = models.DefaultPrivacy
+	me.db.profiles = append(me.db.profiles, profile)
+
+	user.ID = me.db.nextID("users")
+	user.CreatedAt, user.UpdatedAt = now, now
+	user.Profile = profile
+	user.ProfileID = profile.ID
+	user.Type = "USER"
+	if user.ID == 1 {
+		user.Type = "ADMIN"
+	}
+	user.Password = models.HashPassword(user.Password)
+	me.db.users = append(me.db.users, user)
+
+	names := models.DefaultRoles
+	if user.ID == 1 {
+		names = append([]string{models.RoleAdmin}, names...)
+	}
+	me.db.userRoles[user.ID] = append([]string{}, names...)
+
+	user.Password = ""
+	user.New = true
+	user.Profile.New = true
+
+	return user, nil
+}
+
+//NewWithoutPassword creates a user with a random password, returned in PasswordTmp
+func (me userStore) NewWithoutPassword(email string) (models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return me.createWithoutPassword(email)
+}
+
+func (me userStore) createWithoutPassword(email string) (models.User, error) {
+	password := utils.RandStringBytesMaskImprSrc(len(email))
+	return me.create(models.User{
+		Email:       email,
+		Password:    password,
+		PasswordTmp: password,
+	})
+}
+
+//ChangePassword hashes and stores user.Password
+func (me userStore) ChangePassword(userID uint, user models.User, actor models.Actor) (models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	user.ChangePassword = true
+	if u := me.db.user(userID); u >= 0 {
+		me.db.users[u].Password = models.HashPassword(user.Password)
+	}
+
+	me.Audit.record(actor, auditPasswordChange, "user", userID, nil)
+
+	return user, nil
+}
+
+//RehashPassword stores a new hash of password
+func (me userStore) RehashPassword(userID uint, password string) error {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if u := me.db.user(userID); u >= 0 {
+		me.db.users[u].Password = models.HashPassword(password)
+	}
+
+	return nil
+}
+
+//GetProfile returns the user profile
+func (me userStore) GetProfile(userID uint) (models.Profile, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	return me.db.userProfile(userID)
+}
+
+//GetProfileID returns the profileID of the submitted userID
+func (me userStore) GetProfileID(userID uint) (uint, error) {
+	profile, err := me.GetProfile(userID)
+	return profile.ID, err
+}
+
+//GetByID returns the user with its profile
+func (me userStore) GetByID(userID uint) (models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	u := me.db.user(userID)
+	if u < 0 {
+		return models.User{}, ErrNotFound
+	}
+
+	user := me.db.users[u]
+	if p := me.db.profile(user.ProfileID); p >= 0 {
+		user.Profile = me.db.profiles[p]
+	}
+
+	return user, nil
+}
+
+//GetByEmail returns the user, it is created without password if it does not exist and create is set
+func (me userStore) GetByEmail(email string, create bool) (models.User, error) {
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	for _, u := range me.db.users {
+		if u.Email == email && !u.DeletedAt.Valid {
+			return u, nil
+		}
+	}
+
+	if create {
+		return me.createWithoutPassword(email)
+	}
+
+	return models.User{}, ErrNotFound
+}
+
+//OwnImage tells you wheter the userID owns PageID whose owns the imageID as well
+func (me userStore) OwnImage(userID, pageID, imageID uint) (bool, error) {
+	if userID < 1 {
+		return false, fmt.Errorf("userID cannot be below 1")
+	}
+
+	profileID, err := me.GetProfileID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	p := me.db.page(pageID)
+	if p >= 0 && me.db.pages[p].OwnerID == profileID {
+		for _, i := range me.db.pageImages(pageID) {
+			if i.ID == imageID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, fmt.Errorf("user %v doesn't own this image %v", userID, imageID)
+}
+
+//OwnPage tells you wheter the userID owns the pageID
+func (me userStore) OwnPage(userID, pageID uint) (bool, error) {
+	if userID < 1 {
+		return false, fmt.Errorf("userID cannot be below 1")
+	}
+
+	profileID, err := me.GetProfileID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	if p := me.db.page(pageID); p < 0 || me.db.pages[p].OwnerID != profileID {
+		return false, fmt.Errorf("user %v doesn't own this page %v", userID, pageID)
+	}
+
+	return true, nil
+}
+
+//OwnConversation tells you wheter the userID owns the conversation and returns the other member profileID
+func (me userStore) OwnConversation(userID, conversationID uint) (bool, uint, error) {
+	if userID < 1 {
+		return false, 0, fmt.Errorf("userID cannot be below 1")
+	}
+
+	profileID, err := me.GetProfileID(userID)
+	if err != nil {
+		return false, 0, err
+	}
+
+	me.db.Lock()
+	defer me.db.Unlock()
+
+	c := me.db.conversation(conversationID)
+	if c < 0 {
+		return false, 0, ErrNotFound
+	}
+
+	conversation := me.db.conversations[c]
+	switch profileID {
+	case conversation.FromID:
+		return true, conversation.ToID, nil
+	case conversation.ToID:
+		return true, conversation.FromID, nil
+	}
+
+	return false, 0, ErrNotFound
+}
+
+//OwnProfile tells you wheter the userID owns the profile
+func (me userStore) OwnProfile(userID, profileID uint) (bool, error) {
+	if profileID < 1 || userID < 1 {
+		return false, fmt.Errorf("profileID or userID cannot be below 1")
+	}
+
+	user, err := me.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	if user.ProfileID != profileID {
+		return false, fmt.Errorf("user %v doesn't own this profile %v", userID, profileID)
+	}
+
+	return true, nil
+}
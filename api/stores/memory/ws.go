@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+//Emission is a message sent to a member through the hub
+type Emission struct {
+	ProfileID uint   `json:"ID"`
+	Action    string `json:"action"`
+	Data      string `json:"data"`
+	Namespace string `json:"namespace"`
+	Mutation  string `json:"mutation"`
+}
+
+//WsStore records every emission and writes it to the registered connection of the member, if any
+type WsStore struct {
+	mu      sync.Mutex
+	clients map[uint]*websocket.Conn
+	emitted []Emission
+}
+
+//Register keeps conn as the connection of profileID
+func (me *WsStore) Register(profileID uint, conn *websocket.Conn) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if me.clients == nil {
+		me.clients = map[uint]*websocket.Conn{}
+	}
+
+	if c, ok := me.clients[profileID]; ok {
+		c.Close()
+	}
+
+	me.clients[profileID] = conn
+}
+
+//Emit sends action to profileID
+func (me *WsStore) Emit(profileID uint, action, message string) {
+	me.emit(Emission{ProfileID: profileID, Action: action, Data: message})
+}
+
+//EmitToNamespace sends action in namespace to profileID
+func (me *WsStore) EmitToNamespace(profileID uint, action, message, namespace string) {
+	me.emit(Emission{ProfileID: profileID, Action: action, Data: message, Namespace: namespace})
+}
+
+//EmitToMutationNamespace sends the store mutation in namespace to profileID
+func (me *WsStore) EmitToMutationNamespace(profileID uint, action, message, namespace string) {
+	me.emit(Emission{ProfileID: profileID, Mutation: action, Data: message, Namespace: namespace})
+}
+
+func (me *WsStore) emit(e Emission) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.emitted = append(me.emitted, e)
+
+	conn, ok := me.clients[e.ProfileID]
+	if !ok {
+		return
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("memory ws: %s", err)
+		return
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, b); err != nil {
+		log.Errorf("memory ws: %s", err)
+	}
+}
+
+//Close closes the registered connections
+func (me *WsStore) Close(signalDone chan bool) {
+	me.mu.Lock()
+	for id, c := range me.clients {
+		c.Close()
+		delete(me.clients, id)
+	}
+	me.mu.Unlock()
+
+	signalDone <- true
+}
+
+//Emitted returns the recorded emissions
+func (me *WsStore) Emitted() []Emission {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	return append([]Emission{}, me.emitted...)
+}
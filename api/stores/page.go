@@ -1,51 +1,55 @@
 package stores
 
 import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
 	"net/url"
-	"strconv"
+	"time"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/utils"
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+//pageScheduleInterval is how often run publishes and unpublishes the scheduled pages
+const pageScheduleInterval = time.Minute
+
 type pageStore struct {
 	Db           *gorm.DB
+	Audit        auditStore
 	FileStore    fileStore
 	ImageStore   imageStore
 	ProfileStore profileStore
 }
 
-//Migrate creates the model schema in database
-func (me pageStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Page{})
-	if err != nil {
-		panic(err)
-	}
-	// me.Db.Model(&models.Page{}).AddForeignKey("owner_id", "profiles(id)", "NO ACTION", "RESTRICT")
-}
-
-//All returns all pages in Database
+//All returns the published pages
 //Additional keys (url.Values) can be specified :
 //followers : returns pages followers
 //profile : returns pages profiles
 //id: fetch a specific page
 func (me pageStore) All(keys url.Values) ([]models.Page, error) {
-	var req = me.Db
+	var req = me.Db.Model(&models.Page{})
+
+	req = req.Where("status = ?", models.PagePublished)
 
-	req = req.Preload("Images").Preload("Activities")
+	preloads := []string{"Images", "Activities"}
+	random := false
 
 	for i, v := range keys {
 		switch i {
 		case "followers":
-			req = req.Preload("Followers")
+			preloads = append(preloads, "Followers", "Followers.Profile")
 		case "profile":
-			req = req.Preload("Owner").Preload("Owner.Languages")
+			preloads = append(preloads, "Owner", "Owner.Languages")
 		case "id":
 			req = req.Where("ID= ?", v)
 		case "name":
 			if v[0] == "random" {
-				req = req.Order("RAND() LIMIT 1")
+				random = true
 				break
 			}
 			req = req.Where("Name= ?", v)
@@ -54,6 +58,29 @@ func (me pageStore) All(keys url.Values) ([]models.Page, error) {
 		}
 	}
 
+	//random offset rather than ORDER BY RAND(), every database spells it differently
+	if random {
+		var count int64
+		if err := req.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+			return []models.Page{}, err
+		}
+
+		if count == 0 {
+			return []models.Page{}, nil
+		}
+
+		offset, err := rand.Int(rand.Reader, big.NewInt(count))
+		if err != nil {
+			return []models.Page{}, err
+		}
+
+		req = req.Order("id").Offset(int(offset.Int64())).Limit(1)
+	}
+
+	for _, p := range preloads {
+		req = req.Preload(p)
+	}
+
 	var pages []models.Page
 	if err := req.Find(&pages).Error; err != nil {
 		return []models.Page{}, err
@@ -61,6 +88,15 @@ func (me pageStore) All(keys url.Values) ([]models.Page, error) {
 	return pages, nil
 }
 
+//GetByID returns a page with its images and activities
+func (me pageStore) GetByID(pageID uint) (models.Page, error) {
+	var page models.Page
+	if err := me.Db.Preload("Activities").Preload("Images").Where("id = ?", pageID).First(&page).Error; err != nil {
+		return models.Page{}, err
+	}
+	return page, nil
+}
+
 //GetPagesByOwnerID return all profile details
 func (me pageStore) GetPagesByOwnerID(profileID uint) ([]models.Page, error) {
 	var pages []models.Page
@@ -72,13 +108,13 @@ func (me pageStore) GetPagesByOwnerID(profileID uint) ([]models.Page, error) {
 }
 
 //New creates a page
-func (me pageStore) New(profileID uint, page models.Page) (models.Page, error) {
+func (me pageStore) New(profileID uint, page models.Page, actor models.Actor) (models.Page, error) {
 	page.New = true
 
 	page.OwnerID = profileID
+	page.Status, page.Public, page.RejectionReason = models.PageDraft, false, ""
 
-	directory := "page-" + strconv.FormatUint(uint64(profileID), 10)
-	images, err := me.downloadImages(directory, page.Images)
+	images, err := me.downloadImages(pageDirectory(profileID), page.Images)
 	if err != nil {
 		return models.Page{}, err
 	}
@@ -89,49 +125,221 @@ func (me pageStore) New(profileID uint, page models.Page) (models.Page, error) {
 		return models.Page{}, err
 	}
 
+	me.Audit.Record(actor, auditPageCreate, "page", page.ID, nil)
+
 	return page, nil
 }
 
-//Update the page
-func (me pageStore) Update(userID uint, page models.Page) (models.Page, error) {
+//Update the fields of the page, the new images are downloaded and the activities replaced when they are patched
+func (me pageStore) Update(userID uint, page models.Page, fields []string, actor models.Actor) (models.Page, error) {
 	page.New = false
 
-	if len(page.Images) > 0 {
-		directory := "page-" + strconv.FormatUint(uint64(userID), 10)
-		images, err := me.downloadImages(directory, page.Images)
-		if err != nil {
+	columns, associations := splitFields(fields, "Images", "Activities")
+
+	var images []models.Image
+	if associations["Images"] && len(page.Images) > 0 {
+		//the images go to the directory of the owner whoever edits the page
+		var owners []uint
+		if err := me.Db.Model(&models.Page{}).Where("id = ?", page.ID).Pluck("owner_id", &owners).Error; err != nil {
 			return models.Page{}, err
 		}
+		if len(owners) == 0 {
+			return models.Page{}, gorm.ErrRecordNotFound
+		}
 
-		page.Images = images
-		// me.Db.Model(&page).Association("Images").Replace(images)
+		var err error
+		if images, err = me.downloadImages(pageDirectory(owners[0]), page.Images); err != nil {
+			return models.Page{}, err
+		}
 	}
 
-	me.Db.Unscoped().Table("page_activities").Where("activity_id NOT IN (?)", me.getActivitiesIDS(page.Activities)).Where("page_id = ?", page.ID).Delete(&models.Image{})
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		version := page.Version
+		page.Version++
+
+		res := tx.Model(&page).Where("version = ?", version).Select(append(columns, "Version", "UpdatedAt")).Updates(&page)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return VersionConflict("page", page.ID, version)
+		}
 
-	if err := me.Db.Session(&gorm.Session{FullSaveAssociations: true}).Where("id = ?", page.ID).Updates(&page).Error; err != nil {
+		if associations["Activities"] {
+			if err := tx.Model(&page).Association("Activities").Replace(page.Activities); err != nil {
+				return err
+			}
+		}
+
+		for _, i := range images {
+			if i.ID == 0 {
+				i.OwnerID = page.ID
+				if err := tx.Create(&i).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := tx.Model(&models.Image{}).Where("id = ? AND owner_id = ?", i.ID, page.ID).Update("alt", i.Alt).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return models.Page{}, err
 	}
 
-	return page, nil
+	me.Audit.Record(actor, auditPageUpdate, "page", page.ID, map[string]interface{}{"fields": fields})
+
+	return me.GetByID(page.ID)
 }
 
-//Delete set page.DeletedAt to time.Now() // soft delete thus
-func (me pageStore) Delete(userID, pageID uint) (bool, error) {
-	if err := me.Db.Exec("DELETE FROM pages WHERE id = ?", pageID).Error; err != nil {
-		return false, err
+//splitFields separates the association names from the column fields
+func splitFields(fields []string, associations ...string) ([]string, map[string]bool) {
+	columns := []string{}
+	set := map[string]bool{}
+	for _, f := range fields {
+		association := false
+		for _, a := range associations {
+			if f == a {
+				association = true
+				set[a] = true
+			}
+		}
+		if !association {
+			columns = append(columns, f)
+		}
 	}
+	return columns, set
+}
+
+//Delete moves the page to the trash, its images stay with it until the trash is purged
+func (me pageStore) Delete(userID, pageID uint, actor models.Actor) (bool, error) {
+	res := me.Db.Where("id = ?", pageID).Delete(&models.Page{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	me.Audit.Record(actor, auditPageDelete, "page", pageID, nil)
 
 	return true, nil
 }
 
-//Publish set page.Public field to 0 or 1
-func (me pageStore) Publish(userID, pageID uint, status bool) (bool, error) {
-	if err := me.Db.Table("pages").Where("id = ?", pageID).Update("Public", status).Error; err != nil {
-		return false, err
+//SetStatus moves pageID to status if it is still in one of the statuses from and at version, the page is
+//published only in the published status
+func (me pageStore) SetStatus(pageID, version uint, from []string, status, reason string, actor models.Actor) (models.Page, error) {
+	req := me.Db.Model(&models.Page{}).Where("id = ? AND status IN ?", pageID, from)
+	if version != 0 {
+		req = req.Where("version = ?", version)
 	}
 
-	return true, nil
+	res := req.Updates(map[string]interface{}{
+		"status":           status,
+		"public":           status == models.PagePublished,
+		"rejection_reason": reason,
+		"version":          gorm.Expr("version + 1"),
+	})
+	if res.Error != nil {
+		return models.Page{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		if version != 0 {
+			var current []uint
+			if err := me.Db.Model(&models.Page{}).Where("id = ?", pageID).Pluck("version", &current).Error; err != nil {
+				return models.Page{}, err
+			}
+
+			if len(current) == 1 && current[0] != version {
+				return models.Page{}, VersionConflict("page", pageID, version)
+			}
+		}
+
+		return models.Page{}, apperror.New(http.StatusConflict, "page.invalid_transition", fmt.Errorf("page %d is not in %v", pageID, from))
+	}
+
+	metadata := map[string]interface{}{"status": status}
+	if reason != "" {
+		metadata["reason"] = reason
+	}
+	me.Audit.Record(actor, auditPageStatus, "page", pageID, metadata)
+
+	return me.GetByID(pageID)
+}
+
+//ByStatus returns a page of the pages in status with their owner, the least recently updated first
+func (me pageStore) ByStatus(status string, offset, limit int) ([]models.Page, int64, error) {
+	var total int64
+	if err := me.Db.Model(&models.Page{}).Where("status = ?", status).Count(&total).Error; err != nil {
+		return []models.Page{}, 0, err
+	}
+
+	var pages []models.Page
+	if err := me.Db.
+		Preload("Images").
+		Preload("Activities").
+		Preload("Owner").
+		Where("status = ?", status).
+		Order("updated_at, id").
+		Offset(offset).Limit(limit).
+		Find(&pages).Error; err != nil {
+		return []models.Page{}, 0, err
+	}
+
+	return pages, total, nil
+}
+
+//ApplySchedule publishes the scheduled pages and unpublishes the published ones whose time has come
+func (me pageStore) ApplySchedule(now time.Time) (int, error) {
+	changed := 0
+	for _, s := range []struct {
+		from, to, column string
+	}{
+		{models.PageScheduled, models.PagePublished, "publish_at"},
+		{models.PagePublished, models.PageUnpublished, "unpublish_at"},
+	} {
+		var ids []uint
+		if err := me.Db.Model(&models.Page{}).
+			Where("status = ? AND "+s.column+" IS NOT NULL AND "+s.column+" <= ?", s.from, now).
+			Pluck("id", &ids).Error; err != nil {
+			return changed, err
+		}
+
+		for _, id := range ids {
+			if _, err := me.SetStatus(id, 0, []string{s.from}, s.to, "", models.Actor{}); err != nil {
+				log.Errorf("page schedule %d: %s", id, err)
+				continue
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+//run applies the publishing schedule, it never returns
+func (me pageStore) run() {
+	ticker := time.NewTicker(pageScheduleInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		changed, err := me.ApplySchedule(now)
+		if err != nil {
+			log.Errorf("page schedule: %s", err)
+			continue
+		}
+
+		if changed > 0 {
+			log.Printf("page schedule: %d pages published or unpublished", changed)
+		}
+	}
 }
 
 // func (me pageStore) getImagesIDS(images []models.Image) []uint {
@@ -142,30 +350,14 @@ func (me pageStore) Publish(userID, pageID uint, status bool) (bool, error) {
 // 	return tmp
 // }
 
-func (me pageStore) getActivitiesIDS(activities []*models.Activity) []uint {
-	tmp := []uint{0}
-	for _, l := range activities {
-		tmp = append(tmp, (*l).ID)
-	}
-	return tmp
-}
-
 func (me pageStore) downloadImages(directory string, images []models.Image) ([]models.Image, error) {
 	var tmpImages []models.Image
 	if len(images) > 0 {
 		for idx, i := range images {
-			if !i.IsValid() {
-				continue
-			}
 			if i.File != "" && idx < 6 {
 
 				//decode b64 string to bytes
-				mime, buf, err := utils.B64ToImage(i.URL)
-				if err != nil {
-					continue
-				}
-
-				img, err := utils.ImageToTypedImage(mime, buf)
+				mime, img, err := utils.B64ToImage(i.URL)
 				if err != nil {
 					continue
 				}
@@ -175,13 +367,14 @@ func (me pageStore) downloadImages(directory string, images []models.Image) ([]m
 					i.File = utils.RandStringBytesMaskImprSrc(len(i.File)) + "." + mime
 				}
 
-				filename, err := me.FileStore.Save(directory, i.File, img)
+				filename, variants, err := me.FileStore.SaveImage(directory, i.File, mime, img, true)
 				if err != nil {
 					continue
 				}
 
 				i.File = ""
 				i.URL = filename
+				i.Variants = variants
 
 				tmpImages = append(tmpImages, i)
 			} else {
@@ -1,10 +1,11 @@
 package stores
 
 import (
-	"strconv"
+	"strings"
 
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/utils"
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -13,14 +14,6 @@ type profileStore struct {
 	FileStore fileStore
 }
 
-//Migrate creates the table in database
-func (me profileStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Profile{})
-	if err != nil {
-		panic(err)
-	}
-}
-
 //GetProfiles returns all profiles in database
 func (me profileStore) All() ([]models.Profile, error) {
 	var profiles []models.Profile
@@ -31,8 +24,76 @@ func (me profileStore) All() ([]models.Profile, error) {
 	return profiles, nil
 }
 
-//Update the profile
-func (me profileStore) Update(userID uint, profile models.Profile) (models.Profile, error) {
+//Search returns a page of the listed profiles matching filter, by username, and their total
+func (me profileStore) Search(filter models.ProfileFilter, offset, limit int) ([]models.Profile, int64, error) {
+	var total int64
+	if err := me.filter(filter).Count(&total).Error; err != nil {
+		return []models.Profile{}, 0, err
+	}
+
+	var profiles []models.Profile
+	if err := me.filter(filter).
+		Preload("Activities").
+		Preload("Languages").
+		Order("profiles.username").
+		Offset(offset).Limit(limit).
+		Find(&profiles).Error; err != nil {
+		return []models.Profile{}, 0, err
+	}
+
+	return profiles, total, nil
+}
+
+//GetByID returns a listed profile with its activities and languages
+func (me profileStore) GetByID(profileID uint) (models.Profile, error) {
+	return me.get(me.listed().Where("profiles.id = ?", profileID))
+}
+
+//GetByUsername returns a listed profile with its activities and languages
+func (me profileStore) GetByUsername(username string) (models.Profile, error) {
+	return me.get(me.listed().Where("profiles.username = ?", username))
+}
+
+func (me profileStore) get(req *gorm.DB) (models.Profile, error) {
+	var profile models.Profile
+	if err := req.Preload("Activities").Preload("Languages").First(&profile).Error; err != nil {
+		return models.Profile{}, err
+	}
+	return profile, nil
+}
+
+//listed selects the profiles having a username whose user is neither suspended nor pending deletion
+func (me profileStore) listed() *gorm.DB {
+	return me.Db.Model(&models.Profile{}).
+		Joins("JOIN users ON users.profile_id = profiles.id AND users.deleted_at IS NULL").
+		Where("users.suspended_at IS NULL AND users.deletion_scheduled_at IS NULL").
+		Where("profiles.username <> ''")
+}
+
+func (me profileStore) filter(filter models.ProfileFilter) *gorm.DB {
+	req := me.listed()
+	if filter.Query != "" {
+		req = containing(req, filter.Query, "profiles.username", "profiles.firstname", "profiles.lastname")
+	}
+	if filter.ActivityID > 0 {
+		req = req.Where("profiles.id IN (SELECT profile_id FROM profile_activities WHERE activity_id = ?)", filter.ActivityID)
+	}
+	if filter.LanguageID > 0 {
+		req = req.Where("profiles.id IN (SELECT profile_id FROM profile_languages WHERE language_id = ?)", filter.LanguageID)
+	}
+	if filter.Country != "" {
+		req = req.Where("LOWER(profiles.country) = ?", strings.ToLower(filter.Country))
+	}
+	if filter.City != "" {
+		req = req.Where("LOWER(profiles.city) = ?", strings.ToLower(filter.City))
+	}
+	return req
+}
+
+//Update the fields of the profile, the avatar is saved and the languages and activities replaced when they are patched.
+//The file of the replaced avatar is removed, so is the new one when the update fails
+func (me profileStore) Update(userID uint, profile models.Profile, fields []string) (models.Profile, error) {
+	uploaded := ""
 	if profile.AvatarFile != "" {
 
 		filename, err := me.saveAvatar(userID, profile.AvatarFile, profile.Avatar)
@@ -41,51 +102,78 @@ func (me profileStore) Update(userID uint, profile models.Profile) (models.Profi
 		}
 		profile.AvatarFile = ""
 		profile.Avatar = filename
+		uploaded = filename
 	}
 
-	me.Db.Unscoped().
-		Table("profile_activities").
-		Where("activity_id NOT IN (?)", me.getActivitiesIDS(profile.Activities)).
-		Where("profile_id = ?", profile.ID).
-		Delete(&models.Profile{})
-	me.Db.Unscoped().
-		Table("profile_languages").
-		Where("language_id NOT IN (?)", me.getLanguagesIDS(profile.Languages)).
-		Where("profile_id = ?", profile.ID).
-		Delete(&models.Profile{})
+	columns, associations := splitFields(fields, "Languages", "Activities", "Privacy")
+	if associations["Privacy"] {
+		//the privacy is embedded, its columns are updated one by one
+		columns = append(columns, models.PrivacyFields...)
+	}
+
+	var previous []string
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Profile{}).Where("id = ?", profile.ID).Pluck("avatar", &previous).Error; err != nil {
+			return err
+		}
+
+		version := profile.Version
+		profile.Version++
+
+		res := tx.Model(&profile).Where("version = ?", version).Select(append(columns, "Version", "UpdatedAt")).Updates(&profile)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return VersionConflict("profile", profile.ID, version)
+		}
+
+		if associations["Languages"] {
+			if err := tx.Model(&profile).Association("Languages").Replace(profile.Languages); err != nil {
+				return err
+			}
+		}
+
+		if associations["Activities"] {
+			if err := tx.Model(&profile).Association("Activities").Replace(profile.Activities); err != nil {
+				return err
+			}
+		}
 
-	if err := me.Db.
-		Model(&profile).Updates(&profile).Error; err != nil {
+		return nil
+	})
+	if err != nil {
+		if uploaded != "" {
+			me.removeAvatar(userID, uploaded)
+		}
 		return models.Profile{}, err
 	}
 
+	for _, c := range columns {
+		if c == "Avatar" && len(previous) > 0 && previous[0] != "" && previous[0] != profile.Avatar {
+			me.removeAvatar(userID, previous[0])
+		}
+	}
+
 	return profile, nil
 }
 
-func (me profileStore) getLanguagesIDS(languages []*models.Language) []uint {
-	tmp := []uint{0}
-	for _, l := range languages {
-		tmp = append(tmp, (*l).ID)
+//removeAvatar removes the file of an avatar of userID no row references anymore. Only the files in the avatar
+//and upload directories of userID are removed, the links to other sites and the files of other members are left alone
+func (me profileStore) removeAvatar(userID uint, avatar string) {
+	if !me.FileStore.Within(avatar, avatarDirectories(userID)...) {
+		return
 	}
-	return tmp
-}
 
-func (me profileStore) getActivitiesIDS(activities []*models.Activity) []uint {
-	tmp := []uint{0}
-	for _, l := range activities {
-		tmp = append(tmp, (*l).ID)
+	if err := me.FileStore.Delete(avatar); err != nil {
+		log.Warnf("avatar %s: %s", avatar, err)
 	}
-	return tmp
 }
 
-func (me profileStore) saveAvatar(profileID uint, filename, b64 string) (string, error) {
+func (me profileStore) saveAvatar(userID uint, filename, b64 string) (string, error) {
 	//decode b64 string to bytes
-	mime, buf, err := utils.B64ToImage(b64)
-	if err != nil {
-		return "", err
-	}
-
-	img, err := utils.ImageToTypedImage(mime, buf)
+	mime, img, err := utils.B64ToImage(b64)
 	if err != nil {
 		return "", err
 	}
@@ -95,8 +183,7 @@ func (me profileStore) saveAvatar(profileID uint, filename, b64 string) (string,
 		filename = utils.RandStringBytesMaskImprSrc(len(filename)) + "." + mime
 	}
 
-	directory := "user-" + strconv.FormatUint(uint64(profileID), 10)
-	filename, err = me.FileStore.Save(directory, filename, img)
+	filename, _, err = me.FileStore.SaveImage(avatarDirectory(userID), filename, mime, img, false)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,162 @@
+package stores
+
+import (
+	"fmt"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"gorm.io/gorm"
+)
+
+type roleStore struct {
+	Db    *gorm.DB
+	Audit auditStore
+}
+
+//Seed creates the roles and permissions and gives roles to users having none
+func (me roleStore) Seed() {
+	for name, permissions := range models.RolePermissions {
+		role := models.Role{Name: name}
+		me.Db.FirstOrCreate(&role, models.Role{Name: name})
+
+		var perms []*models.Permission
+		for _, p := range permissions {
+			perm := models.Permission{Name: p}
+			me.Db.FirstOrCreate(&perm, models.Permission{Name: p})
+			perms = append(perms, &perm)
+		}
+
+		if err := me.Db.Model(&role).Association("Permissions").Replace(perms); err != nil {
+			panic(err)
+		}
+	}
+
+	me.backfill()
+}
+
+//backfill gives the default roles to users created before roles existed, ADMIN users get the admin role
+func (me roleStore) backfill() {
+	var users []models.User
+	if err := me.Db.Where("id NOT IN (?)", me.Db.Table("user_roles").Select("user_id")).Find(&users).Error; err != nil {
+		panic(err)
+	}
+
+	for _, u := range users {
+		names := models.DefaultRoles
+		if u.Type == "ADMIN" {
+			names = append([]string{models.RoleAdmin}, names...)
+		}
+
+		for _, n := range names {
+			if err := me.grant(u.ID, n); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+//All returns every role with its permissions
+func (me roleStore) All() ([]models.Role, error) {
+	var roles []models.Role
+	if err := me.Db.Preload("Permissions").Find(&roles).Error; err != nil {
+		return []models.Role{}, err
+	}
+	return roles, nil
+}
+
+//UserRoles returns the roles of userID
+func (me roleStore) UserRoles(userID uint) ([]*models.Role, error) {
+	var user models.User
+	if err := me.Db.Preload("Roles").Preload("Roles.Permissions").Where("id = ?", userID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return user.Roles, nil
+}
+
+//HasPermission tells whether one of userID roles grants permission
+func (me roleStore) HasPermission(userID uint, permission string) (bool, error) {
+	var count int64
+	err := me.Db.Table("user_roles").
+		Joins("INNER JOIN role_permissions ON role_permissions.role_id = user_roles.role_id").
+		Joins("INNER JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("user_roles.user_id = ? AND permissions.name = ?", userID, permission).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+//Grant gives roleName to userID, granting admin also flags the user Type as ADMIN
+func (me roleStore) Grant(userID uint, roleName string, actor models.Actor) ([]*models.Role, error) {
+	if err := me.Db.Where("id = ?", userID).First(&models.User{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := me.grant(userID, roleName); err != nil {
+		return nil, err
+	}
+
+	me.Audit.Record(actor, auditRoleGrant, "user", userID, map[string]interface{}{"role": roleName})
+
+	return me.UserRoles(userID)
+}
+
+//grant gives roleName to userID without recording it, the seeds and fixtures use it
+func (me roleStore) grant(userID uint, roleName string) error {
+	var role models.Role
+	if err := me.Db.Where("name = ?", roleName).First(&role).Error; err != nil {
+		return err
+	}
+
+	user := models.User{}
+	user.ID = userID
+	if err := me.Db.Model(&user).Association("Roles").Append(&role); err != nil {
+		return err
+	}
+
+	if roleName == models.RoleAdmin {
+		return me.Db.Model(&models.User{}).Where("id = ?", userID).Update("type", "ADMIN").Error
+	}
+
+	return nil
+}
+
+//Revoke removes roleName from userID, the last admin cannot be revoked
+func (me roleStore) Revoke(userID uint, roleName string, actor models.Actor) ([]*models.Role, error) {
+	if err := me.Db.Where("id = ?", userID).First(&models.User{}).Error; err != nil {
+		return nil, err
+	}
+
+	var role models.Role
+	if err := me.Db.Where("name = ?", roleName).First(&role).Error; err != nil {
+		return nil, err
+	}
+
+	if roleName == models.RoleAdmin {
+		var admins int64
+		if err := me.Db.Table("user_roles").Where("role_id = ? AND user_id <> ?", role.ID, userID).Count(&admins).Error; err != nil {
+			return nil, err
+		}
+
+		if admins == 0 {
+			return nil, fmt.Errorf("cannot revoke the last admin")
+		}
+	}
+
+	user := models.User{}
+	user.ID = userID
+	if err := me.Db.Model(&user).Association("Roles").Delete(&role); err != nil {
+		return nil, err
+	}
+
+	if roleName == models.RoleAdmin {
+		if err := me.Db.Model(&models.User{}).Where("id = ?", userID).Update("type", "USER").Error; err != nil {
+			return nil, err
+		}
+	}
+
+	me.Audit.Record(actor, auditRoleRevoke, "user", userID, map[string]interface{}{"role": roleName})
+
+	return me.UserRoles(userID)
+}
@@ -0,0 +1,25 @@
+package stores
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//likeEscaper escapes the LIKE wildcards with '!', the escape character of containing
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+//containing keeps the rows of req where one of columns contains query, whatever the case.
+//LIKE is case sensitive on some databases, the wildcards of query are matched literally
+func containing(req *gorm.DB, query string, columns ...string) *gorm.DB {
+	like := "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+
+	conditions := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		conditions = append(conditions, "LOWER("+column+") LIKE ? ESCAPE '!'")
+		args = append(args, like)
+	}
+
+	return req.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
@@ -13,58 +13,108 @@ import (
 
 const tokenKey = "user-token"
 const sessionValidity = 60 * 60
+const partialSessionValidity = 5 * 60
 
 type sessionStore struct {
-	Db     *gorm.DB
-	token  string
-	userID uint
+	Db    *gorm.DB
+	Audit auditStore
 }
 
-func (me sessionStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.Session{})
-	if err != nil {
-		panic(err)
-	}
-	// me.Db.Model(&models.Session{}).AddForeignKey("owner_id", "users(id)", "CASCADE", "CASCADE")
-
-}
-
-func (me *sessionStore) CreateOrRetrieve(userID uint) (bool, error) {
-	me.userID = userID
-
+//CreateOrRetrieve returns the token of the valid session of userID, or replaces its sessions by a new one
+func (me *sessionStore) CreateOrRetrieve(userID uint, actor models.Actor) (string, error) {
 	out := models.Session{}
-	if err := me.Db.Where("owner_id = ?", userID).Where("(UNIX_TIMESTAMP(expires) - UNIX_TIMESTAMP()) > 0").First(&out).Error; err == gorm.ErrRecordNotFound {
+	if err := me.Db.Where("owner_id = ?", userID).Where("partial = ?", false).Where("expires > ?", time.Now()).First(&out).Error; err == gorm.ErrRecordNotFound {
 		// record not found => remove all from user and create fresh session
-		ok, err := me.DestroyAllByUserID(userID)
-		if err != nil {
-			return ok, err
+		if _, err := me.DestroyAllByUserID(userID); err != nil {
+			return "", err
 		}
 
-		token, err := uuid.NewV4()
+		session, err := me.create(me.Db, userID, sessionValidity, false)
 		if err != nil {
-			return false, err
+			return "", err
 		}
 
-		session := models.Session{
-			SessionID: token.String(),
-			OwnerID:   userID,
-			Expires:   time.Now().Add(time.Duration(sessionValidity) * time.Second),
-			Validity:  sessionValidity,
+		me.Audit.Record(actor, auditLogin, "user", userID, nil)
+		return session.SessionID, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	me.Audit.Record(actor, auditLogin, "user", userID, map[string]interface{}{"resumed": true})
+
+	return out.SessionID, nil
+}
+
+//CreatePartial opens a partial session for userID and returns its token, the session is only usable to
+//submit the second authentication factor. The full sessions of userID are kept until it is confirmed
+func (me *sessionStore) CreatePartial(userID uint, actor models.Actor) (string, error) {
+	var session models.Session
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		//only the last password check may be completed
+		if err := tx.Where("owner_id = ? AND partial = ?", userID, true).Delete(&models.Session{}).Error; err != nil {
+			return err
 		}
 
-		if err := me.Db.Create(&session).Error; err != nil {
-			return false, err
+		var err error
+		session, err = me.create(tx, userID, partialSessionValidity, true)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	me.Audit.Record(actor, auditLoginPartial, "user", userID, nil)
+	return session.SessionID, nil
+}
+
+//create stores a new session of userID
+func (me *sessionStore) create(tx *gorm.DB, userID uint, validity uint, partial bool) (models.Session, error) {
+	token, err := uuid.NewV4()
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	session := models.Session{
+		SessionID: token.String(),
+		OwnerID:   userID,
+		Expires:   time.Now().Add(time.Duration(validity) * time.Second),
+		Validity:  validity,
+		Partial:   partial,
+	}
+
+	if err := tx.Create(&session).Error; err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+//Confirm turns a partial session into a full one once the second factor is verified, the other
+//sessions of the user are dropped. It returns the session token
+func (me *sessionStore) Confirm(session *models.Session, actor models.Actor) (string, error) {
+	expires := time.Now().Add(time.Duration(sessionValidity) * time.Second)
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("owner_id = ? AND session_id <> ?", session.OwnerID, session.SessionID).Delete(&models.Session{}).Error; err != nil {
+			return err
 		}
 
-		me.token = session.SessionID
-		return true, nil
+		return tx.Model(&models.Session{}).
+			Where("session_id = ?", session.SessionID).
+			Updates(map[string]interface{}{"partial": false, "expires": expires, "validity": sessionValidity}).Error
+	})
+	if err != nil {
+		return "", err
 	}
 
-	me.token = out.SessionID
+	session.Partial = false
+	session.Expires = expires
+	session.Validity = sessionValidity
+	me.Audit.Record(actor, auditLoginSecond, "user", session.OwnerID, nil)
 
-	return true, nil
+	return session.SessionID, nil
 }
 
+//GetSession returns the session whose token is in the request cookie
 func (me *sessionStore) GetSession(r *http.Request) (*models.Session, error) {
 
 	cookie, err := me.GetCookieFromRequest(r)
@@ -77,14 +127,11 @@ func (me *sessionStore) GetSession(r *http.Request) (*models.Session, error) {
 	}
 
 	var session = models.Session{}
-	if errs := me.Db.Where("session_id = ?", cookie.Value).First(&session).Error; err != nil {
+	if err := me.Db.Where("session_id = ?", cookie.Value).First(&session).Error; err != nil {
 		log.Errorln(err)
-		return nil, errs
+		return nil, err
 	}
 
-	me.token = session.SessionID
-	me.userID = session.OwnerID
-
 	return &session, nil
 }
 
@@ -105,16 +152,22 @@ func (me *sessionStore) GetCookieFromRequest(r *http.Request) (*http.Cookie, err
 
 }
 
-func (me *sessionStore) Destroy(r *http.Request) (bool, error) {
-	if me.token == "" {
-		return false, http.ErrNoCookie
+//Destroy drops the request session, only closing a full session is audited as a logout
+func (me *sessionStore) Destroy(r *http.Request, actor models.Actor) (bool, error) {
+	session, err := me.GetSession(r)
+	if err != nil {
+		return false, err
 	}
 
-	if err := me.Db.Where("owner_id = ?", me.userID).Delete(&models.Session{}).Error; err != nil {
+	if err := me.Db.Where("session_id = ?", session.SessionID).Delete(&models.Session{}).Error; err != nil {
 		log.Errorln(err)
 		return false, err
 	}
 
+	if !session.Partial {
+		me.Audit.Record(actor, auditLogout, "user", session.OwnerID, nil)
+	}
+
 	return true, nil
 }
 
@@ -127,18 +180,17 @@ func (me *sessionStore) DestroyAllByUserID(userID uint) (bool, error) {
 	return true, nil
 }
 
-func (me *sessionStore) CreateCookie() (*http.Cookie, error) {
-	if me.token == "" {
+//CreateCookie returns the cookie carrying the session token
+func (me *sessionStore) CreateCookie(token string) (*http.Cookie, error) {
+	if token == "" {
 		return nil, fmt.Errorf("cannot generate cookie without token")
 	}
 
+	//the cookie is not sent with the requests other sites post to the api
 	return &http.Cookie{
-		Name:    tokenKey,
-		Value:   me.token,
-		Expires: time.Now().Add(sessionValidity * time.Second),
+		Name:     tokenKey,
+		Value:    token,
+		Expires:  time.Now().Add(sessionValidity * time.Second),
+		SameSite: http.SameSiteLaxMode,
 	}, nil
 }
-
-func (me sessionStore) GetToken() string {
-	return me.token
-}
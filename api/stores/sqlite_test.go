@@ -0,0 +1,836 @@
+package stores
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/migrations"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/config"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//newTestStores returns a StoreFactory on a private in-memory SQLite database with every migration applied
+func newTestStores(t *testing.T) *StoreFactory {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared&_foreign_keys=1"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrations.New(db).Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "couchsport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	c := config.Config{PublicPath: dir, ImageBasePath: "/static/img", FilePrefix: "isupload.", ExportPath: dir + "/exports", FixturePath: testFixturePath}
+
+	stores := NewStoreFactory(db, nil, c)
+	stores.RoleStore().Seed()
+
+	return stores
+}
+
+func newTestUser(t *testing.T, stores *StoreFactory, email string) models.User {
+	user, err := stores.UserStore().New(models.User{Email: email, Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestSessionStore_CreateOrRetrieve(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "session@couchsport.test")
+
+	sessions := stores.SessionStore()
+	first, err := sessions.CreateOrRetrieve(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatalf("CreateOrRetrieve() error = %v", err)
+	}
+
+	if token, err := sessions.CreateOrRetrieve(user.ID, models.Actor{}); err != nil || token != first {
+		t.Errorf("CreateOrRetrieve() = %q, %v, a valid session should be resumed", token, err)
+	}
+
+	stores.Db.Model(&models.Session{}).Where("owner_id = ?", user.ID).Update("expires", time.Now().Add(-time.Minute))
+
+	if token, err := sessions.CreateOrRetrieve(user.ID, models.Actor{}); err != nil || token == first {
+		t.Errorf("CreateOrRetrieve() = %q, %v, an expired session should be replaced", token, err)
+	}
+}
+
+func TestSessionStore_Confirm(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "partial@couchsport.test")
+
+	sessions := stores.SessionStore()
+	full, err := sessions.CreateOrRetrieve(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partial, err := sessions.CreatePartial(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	stores.Db.Model(&models.Session{}).Where("session_id = ?", full).Count(&count)
+	if count != 1 {
+		t.Fatalf("a password check alone should keep the full session")
+	}
+
+	if token, err := sessions.Confirm(&models.Session{SessionID: partial, OwnerID: user.ID}, models.Actor{}); err != nil || token != partial {
+		t.Fatalf("Confirm() = %q, %v, want %q", token, err, partial)
+	}
+
+	var left []models.Session
+	stores.Db.Where("owner_id = ?", user.ID).Find(&left)
+	if len(left) != 1 || left[0].SessionID != partial || left[0].Partial {
+		t.Errorf("sessions after Confirm() = %+v, want only the confirmed one", left)
+	}
+}
+
+func TestSessionStore_GetSession(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "cookie@couchsport.test")
+
+	sessions := stores.SessionStore()
+	token, err := sessions.CreateOrRetrieve(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: tokenKey, Value: token})
+	session, err := sessions.GetSession(r)
+	if err != nil || session.OwnerID != user.ID {
+		t.Fatalf("GetSession() = %v, %v, want the session of user %d", session, err, user.ID)
+	}
+
+	//an unknown token used to answer an empty session without error
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: tokenKey, Value: "unknown"})
+	if session, err := sessions.GetSession(r); err == nil {
+		t.Errorf("GetSession() = %v, want an error for an unknown token", session)
+	}
+}
+
+func TestUserStore_OwnProfile(t *testing.T) {
+	stores := newTestStores(t)
+	//a profile without user shifts the ids, the profiles do not share the id of their user
+	if err := stores.Db.Create(&models.Profile{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	user := newTestUser(t, stores, "owner@couchsport.test")
+	other := newTestUser(t, stores, "other@couchsport.test")
+
+	if user.ID == user.ProfileID {
+		t.Fatalf("the test needs a profile id different from the user id, both are %d", user.ID)
+	}
+
+	if ok, err := stores.UserStore().OwnProfile(user.ID, user.ProfileID); !ok || err != nil {
+		t.Errorf("OwnProfile() = %v, %v, the user should own its profile", ok, err)
+	}
+
+	if ok, err := stores.UserStore().OwnProfile(user.ID, other.ProfileID); ok || err == nil {
+		t.Errorf("OwnProfile() = %v, %v, the user should not own the profile of another", ok, err)
+	}
+
+	if ok, _ := stores.UserStore().OwnProfile(user.ID, user.ID); ok {
+		t.Errorf("OwnProfile() should compare the profile id, not the user id")
+	}
+}
+
+func TestPageStore_AllRandom(t *testing.T) {
+	stores := newTestStores(t)
+
+	random := url.Values{"name": []string{"random"}}
+	pages, err := stores.PageStore().All(random)
+	if err != nil || len(pages) != 0 {
+		t.Fatalf("All(random) on an empty table = %d pages, %v", len(pages), err)
+	}
+
+	user := newTestUser(t, stores, "pages@couchsport.test")
+	for _, name := range []string{"one", "two", "three"} {
+		page, err := stores.PageStore().New(user.ProfileID, models.Page{Name: name, Description: name}, models.Actor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stores.PageStore().SetStatus(page.ID, 0, []string{models.PageDraft}, models.PagePublished, "", models.Actor{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages, err = stores.PageStore().All(random)
+	if err != nil || len(pages) != 1 {
+		t.Errorf("All(random) = %d pages, %v, want 1", len(pages), err)
+	}
+}
+
+func TestPageStore_SetStatus(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "status@couchsport.test")
+
+	page, err := stores.PageStore().New(user.ProfileID, models.Page{Name: "Hossegor", Description: "surf spot"}, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//another update moved the page to version 2 since its version 1 was read
+	if _, err := stores.PageStore().Update(user.ID, models.Page{Base: page.Base, Version: 1, Lat: 43.66}, []string{"Lat"}, models.Actor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		version uint
+		from    []string
+		code    string
+	}{
+		{name: "stale version", version: 1, from: []string{models.PageDraft}, code: apperror.PreconditionFailed},
+		{name: "invalid transition", version: 2, from: []string{models.PagePublished}, code: "page.invalid_transition"},
+		{name: "current version", version: 2, from: []string{models.PageDraft}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stores.PageStore().SetStatus(page.ID, tt.version, tt.from, models.PagePending, "", models.Actor{})
+			if tt.code == "" {
+				if err != nil || got.Status != models.PagePending || got.Version != tt.version+1 {
+					t.Errorf("SetStatus() = %s version %d, %v, want %s version %d", got.Status, got.Version, err, models.PagePending, tt.version+1)
+				}
+				return
+			}
+
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) || appErr.Code != tt.code {
+				t.Errorf("SetStatus() error = %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestUserStore_Search(t *testing.T) {
+	stores := newTestStores(t)
+	newTestUser(t, stores, "Jane.Doe@couchsport.test")
+	newTestUser(t, stores, "john@couchsport.test")
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "", want: 2},
+		{query: "jane", want: 1},
+		{query: "JOHN", want: 1},
+		{query: "couchsport", want: 2},
+		{query: "nobody", want: 0},
+		{query: "_", want: 0},
+		{query: "%", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			users, err := stores.UserStore().Search(tt.query, 0, 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(users) != tt.want {
+				t.Errorf("Search(%q) = %d users, want %d", tt.query, len(users), tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleStore(t *testing.T) {
+	stores := newTestStores(t)
+	//the first user is the admin
+	admin := newTestUser(t, stores, "admin@couchsport.test")
+	member := newTestUser(t, stores, "member@couchsport.test")
+	roles := stores.RoleStore()
+
+	can := func(userID uint, permission string) bool {
+		t.Helper()
+		ok, err := roles.HasPermission(userID, permission)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+
+	if !can(member.ID, models.PermPagesCreate) || can(member.ID, models.PermPagesModerate) {
+		t.Fatalf("a new member should have the default roles only")
+	}
+
+	granted, err := roles.Grant(member.ID, models.RoleModerator, models.Actor{})
+	if err != nil || len(granted) != len(models.DefaultRoles)+1 {
+		t.Fatalf("Grant() = %d roles, %v", len(granted), err)
+	}
+	if !can(member.ID, models.PermPagesModerate) || can(member.ID, models.PermRolesManage) {
+		t.Errorf("a moderator should moderate the pages and not manage the roles")
+	}
+
+	if _, err := roles.Grant(member.ID, "owner", models.Actor{}); err == nil {
+		t.Errorf("Grant() of an unknown role should fail")
+	}
+
+	if _, err := roles.Revoke(member.ID, models.RoleModerator, models.Actor{}); err != nil {
+		t.Fatal(err)
+	}
+	if can(member.ID, models.PermPagesModerate) {
+		t.Errorf("the revoked role should not grant its permissions anymore")
+	}
+
+	if _, err := roles.Revoke(admin.ID, models.RoleAdmin, models.Actor{}); err == nil {
+		t.Errorf("Revoke() of the last admin should fail")
+	}
+
+	if _, err := roles.Grant(member.ID, models.RoleAdmin, models.Actor{}); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := stores.UserStore().GetByID(member.ID); user.Type != "ADMIN" {
+		t.Errorf("an admin should have the ADMIN type, got %q", user.Type)
+	}
+
+	if _, err := roles.Revoke(admin.ID, models.RoleAdmin, models.Actor{}); err != nil {
+		t.Fatalf("Revoke() of an admin among two: %v", err)
+	}
+	if user, _ := stores.UserStore().GetByID(admin.ID); user.Type != "USER" || can(admin.ID, models.PermRolesManage) {
+		t.Errorf("the revoked admin should be a user again, got %q", user.Type)
+	}
+
+	if _, err := roles.Grant(999, models.RoleModerator, models.Actor{}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Grant() to an unknown user error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	for action, want := range map[string]int64{"role.grant": 2, "role.revoke": 2} {
+		if _, total, err := stores.AuditStore().Query(models.AuditFilter{Action: action}, 0, 10); err != nil || total != want {
+			t.Errorf("%s audit events = %d, %v, want %d", action, total, err, want)
+		}
+	}
+}
+
+func TestAuditStore(t *testing.T) {
+	stores := newTestStores(t)
+	now := time.Now()
+
+	events := []models.AuditEvent{
+		{CreatedAt: now.Add(-3 * time.Hour), ActorID: 1, Action: "page.create", TargetType: "page", TargetID: 10},
+		{CreatedAt: now.Add(-2 * time.Hour), ActorID: 1, Action: "page.update", TargetType: "page", TargetID: 10},
+		{CreatedAt: now.Add(-time.Hour), ActorID: 2, Action: "page.create", TargetType: "page", TargetID: 11, Metadata: models.NewAuditMetadata(map[string]interface{}{"name": "Hossegor"})},
+		{CreatedAt: now, ActorID: 2, Action: "user.suspend", TargetType: "user", TargetID: 1},
+	}
+	if err := stores.Db.Create(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.AuditFilter
+		want   []uint
+	}{
+		{name: "all", filter: models.AuditFilter{}, want: []uint{4, 3, 2, 1}},
+		{name: "actor", filter: models.AuditFilter{ActorID: 1}, want: []uint{2, 1}},
+		{name: "action", filter: models.AuditFilter{Action: "page.create"}, want: []uint{3, 1}},
+		{name: "target", filter: models.AuditFilter{TargetType: "page", TargetID: 10}, want: []uint{2, 1}},
+		{name: "from", filter: models.AuditFilter{From: now.Add(-90 * time.Minute)}, want: []uint{4, 3}},
+		{name: "to", filter: models.AuditFilter{To: now.Add(-90 * time.Minute)}, want: []uint{2, 1}},
+		{name: "period", filter: models.AuditFilter{From: now.Add(-150 * time.Minute), To: now.Add(-30 * time.Minute)}, want: []uint{3, 2}},
+		{name: "combined", filter: models.AuditFilter{ActorID: 2, Action: "page.create"}, want: []uint{3}},
+		{name: "none", filter: models.AuditFilter{ActorID: 3}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := stores.AuditStore().Query(tt.filter, 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []uint
+			for _, e := range got {
+				ids = append(ids, e.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("Query() = %v, total %d, want %v", ids, total, tt.want)
+			}
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		got, total, err := stores.AuditStore().Query(models.AuditFilter{}, 1, 2)
+		if err != nil || total != 4 || len(got) != 2 || got[0].ID != 3 || got[1].ID != 2 {
+			t.Errorf("Query(offset 1, limit 2) = %+v, total %d, %v, want the events 3 and 2 of 4", got, total, err)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := stores.AuditStore().Export(models.AuditFilter{ActorID: 2}, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Export() = %d lines, want 2: %s", len(lines), b.String())
+		}
+
+		var first, second struct {
+			ID       uint                   `json:"id"`
+			Action   string                 `json:"action"`
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+			t.Fatal(err)
+		}
+
+		if first.ID != 3 || second.ID != 4 {
+			t.Errorf("Export() = events %d and %d, want the oldest first", first.ID, second.ID)
+		}
+		if first.Metadata["name"] != "Hossegor" || second.Metadata != nil {
+			t.Errorf("Export() metadata = %v and %v, want the stored document", first.Metadata, second.Metadata)
+		}
+	})
+}
+
+func TestLoginAttemptStore_backoff(t *testing.T) {
+	attempts := loginAttemptStore{Lockout: 30 * time.Second, MaxLockout: 5 * time.Minute}
+
+	tests := []struct {
+		over int
+		want time.Duration
+	}{
+		{over: 0, want: 30 * time.Second},
+		{over: 1, want: time.Minute},
+		{over: 2, want: 2 * time.Minute},
+		{over: 3, want: 4 * time.Minute},
+		{over: 4, want: 5 * time.Minute},
+		{over: 100, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := attempts.backoff(tt.over); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.over, got, tt.want)
+		}
+	}
+}
+
+func TestLoginAttemptStore(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "locked@couchsport.test")
+
+	stores.loginAttemptStore.MaxAttempts, stores.loginAttemptStore.MaxIPAttempts = 3, 100
+	stores.loginAttemptStore.Lockout, stores.loginAttemptStore.MaxLockout = 30*time.Second, time.Hour
+	attempts := stores.LoginAttemptStore()
+	actor := models.Actor{IP: "192.0.2.1"}
+
+	fail := func(n int) time.Duration {
+		t.Helper()
+		var locked time.Duration
+		for i := 0; i < n; i++ {
+			d, err := attempts.Fail(user.Email, user.ID, actor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			locked = d
+		}
+		return locked
+	}
+
+	t.Run("success resets the counter", func(t *testing.T) {
+		if locked := fail(2); locked != 0 {
+			t.Fatalf("locked for %v below the threshold", locked)
+		}
+		if err := attempts.Succeed(user.Email); err != nil {
+			t.Fatal(err)
+		}
+		if locked := fail(2); locked != 0 {
+			t.Errorf("locked for %v, the failures before the success should be forgotten", locked)
+		}
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		if locked := fail(1); locked <= 0 || locked > 30*time.Second {
+			t.Fatalf("locked for %v at the threshold, want 30s", locked)
+		}
+		if locked, _ := attempts.LockedFor(user.Email, "198.51.100.1"); locked <= 0 {
+			t.Errorf("the account should be locked from any ip")
+		}
+		if locked := fail(1); locked <= 30*time.Second || locked > time.Minute {
+			t.Errorf("locked for %v past the threshold, want 1m", locked)
+		}
+	})
+
+	t.Run("lockout expiry", func(t *testing.T) {
+		stores.Db.Model(&models.LoginAttempt{}).Where("attempt_key = ?", accountKey(user.Email)).
+			Update("locked_until", time.Now().Add(-time.Second))
+		if locked, _ := attempts.LockedFor(user.Email, actor.IP); locked != 0 {
+			t.Errorf("locked for %v after the lockout ended", locked)
+		}
+
+		//failures older than the longest lockout are forgotten
+		stores.Db.Model(&models.LoginAttempt{}).Where("attempt_key = ?", accountKey(user.Email)).
+			Update("last_failure", time.Now().Add(-2*time.Hour))
+		if locked := fail(1); locked != 0 {
+			t.Errorf("locked for %v, the old failures should be forgotten", locked)
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		events, total, err := stores.AuditStore().Query(models.AuditFilter{Action: auditLoginFailed, TargetID: user.ID}, 0, 100)
+		if err != nil || total != 7 {
+			t.Fatalf("Query() = %d events, %v, want one per failure", total, err)
+		}
+		if events[0].IP != actor.IP || events[0].TargetType != "user" {
+			t.Errorf("failed login event = %+v", events[0])
+		}
+
+		if _, err := attempts.Fail("nobody@couchsport.test", 0, actor); err != nil {
+			t.Fatal(err)
+		}
+		if _, total, _ := stores.AuditStore().Query(models.AuditFilter{Action: auditLoginFailed}, 0, 100); total != 8 {
+			t.Errorf("the failures of unknown emails should be audited too, %d events", total)
+		}
+	})
+}
+
+func TestFixtureStore(t *testing.T) {
+	stores := newTestStores(t)
+	fixtures := stores.FixtureStore()
+
+	for i := 0; i < 2; i++ {
+		if err := fixtures.LoadReference(); err != nil {
+			t.Fatalf("LoadReference() error = %v", err)
+		}
+		if err := fixtures.LoadDemo(); err != nil {
+			t.Fatalf("LoadDemo() error = %v", err)
+		}
+	}
+
+	var languages, activities, users, pages, images int64
+	stores.Db.Model(&models.Language{}).Count(&languages)
+	stores.Db.Model(&models.Activity{}).Count(&activities)
+	stores.Db.Model(&models.User{}).Count(&users)
+	stores.Db.Model(&models.Page{}).Count(&pages)
+	stores.Db.Model(&models.Image{}).Count(&images)
+
+	if languages != 182 || activities != 39 || users != 3 || pages != 2 || images != 3 {
+		t.Errorf("loaded %d languages, %d activities, %d users, %d pages, %d images",
+			languages, activities, users, pages, images)
+	}
+
+	admin, err := stores.UserStore().GetByEmail("admin@couchsport.test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := stores.RoleStore().HasPermission(admin.ID, models.PermRolesManage); err != nil || !ok {
+		t.Errorf("demo admin should manage roles: %v, %v", ok, err)
+	}
+}
+
+func TestAccountDeletionStore_Schedule(t *testing.T) {
+	stores := newTestStores(t)
+
+	if stores.accountDeletion.GracePeriod != config.DefaultGraceDays*24*time.Hour || !stores.accountDeletion.KeepConversations {
+		t.Fatalf("unset settings should default, got %v and %v", stores.accountDeletion.GracePeriod, stores.accountDeletion.KeepConversations)
+	}
+
+	user := newTestUser(t, stores, "later@couchsport.test")
+	at, err := stores.AccountDeletionStore().Schedule(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at.Before(time.Now().Add(stores.accountDeletion.GracePeriod - time.Minute)) {
+		t.Errorf("the deletion should wait for the grace period, scheduled at %v", at)
+	}
+
+	var count int64
+	stores.Db.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("the account should be kept during the grace period")
+	}
+
+	stores.accountDeletion.GracePeriod = 0
+	user = newTestUser(t, stores, "now@couchsport.test")
+	if _, err := stores.AccountDeletionStore().Schedule(user.ID, models.Actor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stores.Db.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("without grace period the account should be purged right away")
+	}
+}
+
+func TestAccountDeletionStore_Purge(t *testing.T) {
+	for _, keep := range []bool{true, false} {
+		t.Run(map[bool]string{true: "keep", false: "delete"}[keep], func(t *testing.T) {
+			stores := newTestStores(t)
+			stores.accountDeletion.KeepConversations = keep
+
+			if err := stores.FixtureStore().LoadReference(); err != nil {
+				t.Fatal(err)
+			}
+			if err := stores.FixtureStore().LoadDemo(); err != nil {
+				t.Fatal(err)
+			}
+
+			surfer, _ := stores.UserStore().GetByEmail("surfer@couchsport.test", false)
+			climber, _ := stores.UserStore().GetByEmail("climber@couchsport.test", false)
+
+			conversation := models.Conversation{FromID: surfer.ProfileID, ToID: climber.ProfileID}
+			conversation.AddMessage(surfer.ProfileID, climber.ProfileID, "hello")
+			conversation.Messages[0].Email = surfer.Email
+			if err := stores.Db.Create(&conversation).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			var images []models.Image
+			stores.Db.Where("owner_id IN (?)", stores.Db.Model(&models.Page{}).Select("id").Where("owner_id = ?", surfer.ProfileID)).Find(&images)
+			if len(images) == 0 {
+				t.Fatal("the demo surfer should own images")
+			}
+
+			//an avatar pointing to the file of another member
+			foreign, err := stores.fileStore.Save(avatarDirectory(climber.ID), "me.png", strings.NewReader("png"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stores.Db.Model(&models.Profile{}).Where("id = ?", surfer.ProfileID).Update("avatar", foreign)
+
+			at, err := stores.AccountDeletionStore().Schedule(surfer.ID, models.Actor{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if purged, err := stores.AccountDeletionStore().PurgeDue(at.Add(-time.Hour)); err != nil || purged != 0 {
+				t.Fatalf("PurgeDue() before the grace period = %d, %v", purged, err)
+			}
+
+			if purged, err := stores.AccountDeletionStore().PurgeDue(at.Add(time.Hour)); err != nil || purged != 1 {
+				t.Fatalf("PurgeDue() after the grace period = %d, %v", purged, err)
+			}
+
+			var count int64
+			stores.Db.Unscoped().Model(&models.User{}).Where("id = ?", surfer.ID).Count(&count)
+			if count != 0 {
+				t.Errorf("user still exists")
+			}
+
+			stores.Db.Unscoped().Model(&models.Page{}).Where("owner_id = ?", surfer.ProfileID).Count(&count)
+			if count != 0 {
+				t.Errorf("pages still exist")
+			}
+
+			for _, i := range images {
+				if _, err := os.Stat(stores.fileStore.PublicPath + i.URL); !os.IsNotExist(err) {
+					t.Errorf("image %s still exists: %v", i.URL, err)
+				}
+			}
+
+			if _, err := os.Stat(stores.fileStore.PublicPath + "/" + foreign); err != nil {
+				t.Errorf("the avatar of another member was removed: %v", err)
+			}
+
+			var conversations, messages int64
+			stores.Db.Model(&models.Conversation{}).Where("id = ?", conversation.ID).Count(&conversations)
+			stores.Db.Model(&models.Message{}).Where("conversation_id = ? AND email <> ''", conversation.ID).Count(&messages)
+			if keep && (conversations != 1 || messages != 0) {
+				t.Errorf("conversation should be kept anonymized, got %d conversations and %d messages with an email", conversations, messages)
+			}
+			if !keep && conversations != 0 {
+				t.Errorf("conversation should be deleted")
+			}
+
+			var profile models.Profile
+			err = stores.Db.Unscoped().Where("id = ?", surfer.ProfileID).First(&profile).Error
+			if keep && (err != nil || profile.Firstname != "" || profile.Email != "") {
+				t.Errorf("profile should be anonymized: %+v, %v", profile, err)
+			}
+			if !keep && err == nil {
+				t.Errorf("profile should be deleted")
+			}
+		})
+	}
+}
+
+func TestProfileStore_UpdateAvatar(t *testing.T) {
+	stores := newTestStores(t)
+	owner := newTestUser(t, stores, "owner@couchsport.test")
+	other := newTestUser(t, stores, "other@couchsport.test")
+
+	foreign, err := stores.fileStore.Save(avatarDirectory(owner.ID), "me.png", strings.NewReader("png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//a row pointing to the file of another member, as written before the handlers refused it
+	if err := stores.Db.Model(&models.Profile{}).Where("id = ?", other.ProfileID).Update("avatar", foreign).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	profile := models.Profile{Avatar: ""}
+	profile.ID, profile.Version = other.ProfileID, 1
+	if _, err := stores.ProfileStore().Update(other.ID, profile, []string{"Avatar"}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	if _, err := os.Stat(stores.fileStore.PublicPath + "/" + foreign); err != nil {
+		t.Errorf("the avatar of another member was removed: %v", err)
+	}
+}
+
+func TestUploadStore_Append(t *testing.T) {
+	stores := newTestStores(t)
+	stores.uploadStore.MaxSize = 1 << 20
+	user := newTestUser(t, stores, "uploader@couchsport.test")
+	uploads := stores.UploadStore()
+
+	var content bytes.Buffer
+	if err := png.Encode(&content, image.NewGray(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+
+	slow, err := uploads.Start(user.ID, "slow.png", int64(content.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, err := uploads.Start(user.ID, "fast.png", int64(content.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the chunk of slow is still being received while fast is sent
+	r, w := io.Pipe()
+	done := make(chan error)
+	go func() {
+		_, err := uploads.Append(user.ID, slow.ID, 0, r)
+		done <- err
+	}()
+	w.Write(content.Bytes()[:10])
+
+	finished := make(chan error)
+	go func() {
+		_, err := uploads.Append(user.ID, fast.ID, 0, bytes.NewReader(content.Bytes()))
+		finished <- err
+	}()
+
+	select {
+	case err := <-finished:
+		if err != nil {
+			t.Errorf("Append() of fast = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Append() of fast waited for the chunk of slow")
+	}
+
+	w.Write(content.Bytes()[10:])
+	w.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("Append() of slow = %v", err)
+	}
+
+	if got, err := uploads.Get(user.ID, slow.ID); err != nil || !got.Complete || got.Received != int64(content.Len()) {
+		t.Errorf("slow upload = %+v, %v, want it complete", got, err)
+	}
+
+	if _, err := uploads.Append(user.ID, slow.ID, int64(content.Len()), bytes.NewReader(nil)); err == nil {
+		t.Errorf("Append() to a complete upload: no error")
+	}
+}
+
+func TestDataExportStore(t *testing.T) {
+	stores := newTestStores(t)
+	user := newTestUser(t, stores, "export@couchsport.test")
+
+	token, err := stores.SessionStore().CreateOrRetrieve(user.ID, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export := models.DataExport{OwnerID: user.ID, Status: models.DataExportPending, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := stores.Db.Create(&export).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var e *apperror.Error
+	if _, err := stores.DataExportStore().Request(user.ID); !errors.As(err, &e) || e.Status != http.StatusConflict || e.Code != "data_export.already_pending" {
+		t.Errorf("Request() with an export pending error = %v, want the already pending conflict", err)
+	}
+
+	size, file, err := stores.dataExportStore.build(export)
+	if err != nil || size == 0 {
+		t.Fatalf("build() = %d, %v", size, err)
+	}
+	stores.Db.Model(&export).Updates(map[string]interface{}{"status": models.DataExportReady, "file": file, "size": size})
+
+	archive, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range zr.File {
+		if f.Name != "sessions.json" {
+			continue
+		}
+		found = true
+		r, _ := f.Open()
+		content, _ := ioutil.ReadAll(r)
+		r.Close()
+		if strings.Contains(string(content), token) || strings.Contains(string(content), "session_id") {
+			t.Errorf("sessions.json exports the session IDs: %s", content)
+		}
+		if !strings.Contains(string(content), "expires") {
+			t.Errorf("sessions.json misses the sessions: %s", content)
+		}
+	}
+	if !found {
+		t.Errorf("sessions.json is missing")
+	}
+
+	if purged, err := stores.DataExportStore().PurgeExpired(time.Now()); err != nil || purged != 0 {
+		t.Fatalf("PurgeExpired() before the expiry = %d, %v", purged, err)
+	}
+
+	if purged, err := stores.DataExportStore().PurgeExpired(time.Now().Add(2 * time.Hour)); err != nil || purged != 1 {
+		t.Fatalf("PurgeExpired() after the expiry = %d, %v", purged, err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("the archive still exists: %v", err)
+	}
+
+	var count int64
+	stores.Db.Unscoped().Model(&models.DataExport{}).Where("id = ?", export.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("the export row still exists")
+	}
+}
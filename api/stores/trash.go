@@ -0,0 +1,224 @@
+package stores
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//trashPurgeInterval is how often run looks for the deleted pages and images whose retention period is over
+const trashPurgeInterval = time.Hour
+
+type trashStore struct {
+	Db        *gorm.DB
+	FileStore fileStore
+	Audit     auditStore
+	Retention time.Duration
+}
+
+//Content returns the deleted pages of profileID with their images, and the images deleted from its other pages,
+//the most recently deleted first
+func (me trashStore) Content(profileID uint) (models.Trash, error) {
+	trash := models.Trash{Pages: []models.Page{}, Images: []models.Image{}, RetentionDays: int(me.Retention / (24 * time.Hour))}
+
+	if err := me.Db.Unscoped().
+		Preload("Images").
+		Preload("Activities").
+		Where("owner_id = ? AND deleted_at IS NOT NULL", profileID).
+		Order("deleted_at DESC, id").
+		Find(&trash.Pages).Error; err != nil {
+		return models.Trash{}, err
+	}
+
+	if err := me.Db.Unscoped().
+		Select("images.*").
+		Joins("INNER JOIN pages ON pages.id = images.owner_id").
+		Where("pages.owner_id = ? AND pages.deleted_at IS NULL AND images.deleted_at IS NOT NULL", profileID).
+		Order("images.deleted_at DESC, images.id").
+		Find(&trash.Images).Error; err != nil {
+		return models.Trash{}, err
+	}
+
+	return trash, nil
+}
+
+//RestorePage takes the page of profileID out of the trash, it comes back in the status it had
+func (me trashStore) RestorePage(profileID, pageID uint, actor models.Actor) (models.Page, error) {
+	res := me.Db.Unscoped().Model(&models.Page{}).
+		Where("id = ? AND owner_id = ? AND deleted_at IS NOT NULL", pageID, profileID).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if res.Error != nil {
+		return models.Page{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return models.Page{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("page %d is not in the trash of profile %d", pageID, profileID))
+	}
+
+	me.Audit.Record(actor, auditPageRestore, "page", pageID, nil)
+
+	var page models.Page
+	if err := me.Db.Preload("Activities").Preload("Images").Where("id = ?", pageID).First(&page).Error; err != nil {
+		return models.Page{}, err
+	}
+	return page, nil
+}
+
+//RestoreImage takes the image of pageID out of the trash, the page must belong to profileID and not be deleted
+func (me trashStore) RestoreImage(profileID, pageID, imageID uint, actor models.Actor) (models.Image, error) {
+	pages := me.Db.Model(&models.Page{}).Select("id").Where("owner_id = ?", profileID)
+
+	res := me.Db.Unscoped().Model(&models.Image{}).
+		Where("id = ? AND owner_id = ? AND owner_id IN (?) AND deleted_at IS NOT NULL", imageID, pageID, pages).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return models.Image{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return models.Image{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("image %d of page %d is not in the trash of profile %d", imageID, pageID, profileID))
+	}
+
+	me.Audit.Record(actor, auditImageRestore, "image", imageID, nil)
+
+	var image models.Image
+	if err := me.Db.Where("id = ?", imageID).First(&image).Error; err != nil {
+		return models.Image{}, err
+	}
+	return image, nil
+}
+
+//PurgeDue permanently deletes the pages and images deleted for longer than the retention period at now, the
+//images of the purged pages and the uploaded files go with them. It returns how many pages and images were purged
+func (me trashStore) PurgeDue(now time.Time) (int, error) {
+	before := now.Add(-me.Retention)
+
+	var pageIDs []uint
+	if err := me.Db.Unscoped().Model(&models.Page{}).
+		Where("deleted_at IS NOT NULL AND deleted_at <= ?", before).
+		Pluck("id", &pageIDs).Error; err != nil {
+		return 0, err
+	}
+
+	var images []models.Image
+	if err := me.Db.Unscoped().
+		Where("(deleted_at IS NOT NULL AND deleted_at <= ?) OR owner_id IN ?", before, append(pageIDs, 0)).
+		Find(&images).Error; err != nil {
+		return 0, err
+	}
+
+	if len(pageIDs) == 0 && len(images) == 0 {
+		return 0, nil
+	}
+
+	directories, err := me.directories(images)
+	if err != nil {
+		return 0, err
+	}
+
+	imageIDs := []uint{0}
+	for _, i := range images {
+		imageIDs = append(imageIDs, i.ID)
+	}
+
+	err = me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("id IN ?", imageIDs).Delete(&models.Image{}).Error; err != nil {
+			return err
+		}
+
+		if len(pageIDs) == 0 {
+			return nil
+		}
+
+		if err := tx.Exec("DELETE FROM page_activities WHERE page_id IN ?", pageIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM user_page_follower WHERE page_id IN ?", pageIDs).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("id IN ?", pageIDs).Delete(&models.Page{}).Error
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	//files are removed once the rows are gone, a failure leaves an orphan file rather than a broken row
+	for _, i := range images {
+		for _, file := range append(i.Variants.URLs(), i.URL) {
+			if !me.FileStore.Within(file, directories[i.OwnerID]...) {
+				continue
+			}
+
+			if err := me.FileStore.Delete(file); err != nil {
+				log.Warnf("trash purge of image %d: %s", i.ID, err)
+			}
+		}
+	}
+
+	purged := map[uint]bool{}
+	for _, id := range pageIDs {
+		purged[id] = true
+		me.Audit.Record(models.Actor{}, auditPagePurge, "page", id, nil)
+	}
+
+	for _, i := range images {
+		if !purged[i.OwnerID] {
+			me.Audit.Record(models.Actor{}, auditImagePurge, "image", i.ID, nil)
+		}
+	}
+
+	return len(pageIDs) + len(images), nil
+}
+
+//directories returns the directories the files of images can be removed from by the page they belong to: the
+//ones of the page owner. An image pointing to the file of another member leaves it alone
+func (me trashStore) directories(images []models.Image) (map[uint][]string, error) {
+	pageIDs := []uint{0}
+	for _, i := range images {
+		pageIDs = append(pageIDs, i.OwnerID)
+	}
+
+	var owners []struct {
+		ID, OwnerID, UserID uint
+	}
+	if err := me.Db.Unscoped().Model(&models.Page{}).
+		Select("pages.id, pages.owner_id, users.id AS user_id").
+		Joins("JOIN users ON users.profile_id = pages.owner_id").
+		Where("pages.id IN ?", pageIDs).
+		Scan(&owners).Error; err != nil {
+		return nil, err
+	}
+
+	directories := map[uint][]string{}
+	for _, o := range owners {
+		directories[o.ID] = pageDirectories(o.OwnerID, o.UserID)
+	}
+
+	return directories, nil
+}
+
+//run purges the trash, it never returns
+func (me trashStore) run() {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		purged, err := me.PurgeDue(now)
+		if err != nil {
+			log.Errorf("trash purge: %s", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Printf("trash purge: %d pages and images purged", purged)
+		}
+	}
+}
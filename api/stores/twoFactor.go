@@ -0,0 +1,193 @@
+package stores
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+	"gorm.io/gorm"
+)
+
+const recoveryCodesCount = 10
+
+type twoFactorStore struct {
+	Db     *gorm.DB
+	Issuer string
+}
+
+//Enroll generates a new TOTP secret for userID and returns it along with its provisioning URI.
+//2FA is not enabled until Confirm is called with a valid code
+func (me twoFactorStore) Enroll(userID uint) (string, string, error) {
+	var user models.User
+	if err := me.Db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return "", "", err
+	}
+
+	if user.TwoFactorEnabled {
+		return "", "", fmt.Errorf("two factor authentication already enabled")
+	}
+
+	secret, err := utils.NewTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := me.Db.Model(&models.User{}).Where("id = ?", userID).Update("two_factor_secret", secret).Error; err != nil {
+		return "", "", err
+	}
+
+	return secret, utils.TOTPProvisioningURI(me.Issuer, user.Email, secret), nil
+}
+
+//Confirm enables 2FA for userID if code matches the enrolled secret and returns fresh recovery codes
+func (me twoFactorStore) Confirm(userID uint, code string) ([]string, error) {
+	var user models.User
+	if err := me.Db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, fmt.Errorf("two factor authentication already enabled")
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, fmt.Errorf("two factor authentication not enrolled")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep)
+	if !ok {
+		return nil, fmt.Errorf("invalid code")
+	}
+
+	var codes []string
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"two_factor_enabled": true, "two_factor_last_step": step}).Error; err != nil {
+			return err
+		}
+
+		var err error
+		codes, err = me.replaceRecoveryCodes(tx, userID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+//Verify checks code against the user TOTP secret, then against its unused recovery codes
+func (me twoFactorStore) Verify(userID uint, code string) (bool, error) {
+	var user models.User
+	if err := me.Db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, err
+	}
+
+	if !user.TwoFactorEnabled {
+		return false, fmt.Errorf("two factor authentication not enabled")
+	}
+
+	if step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep); ok {
+		return me.acceptStep(userID, step)
+	}
+
+	var recoveryCodes []models.RecoveryCode
+	if err := me.Db.Where("owner_id = ? AND used_at IS NULL", userID).Find(&recoveryCodes).Error; err != nil {
+		return false, err
+	}
+
+	hash := hashRecoveryCode(code)
+	for _, rc := range recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(rc.Hash), []byte(hash)) != 1 {
+			continue
+		}
+
+		res := me.Db.Model(&models.RecoveryCode{}).
+			Where("id = ? AND used_at IS NULL", rc.ID).
+			Update("used_at", time.Now())
+		if res.Error != nil {
+			return false, res.Error
+		}
+
+		return res.RowsAffected == 1, nil
+	}
+
+	return false, nil
+}
+
+//acceptStep records step as the last accepted one of userID, unless a concurrent request already accepted it or a later one
+func (me twoFactorStore) acceptStep(userID uint, step int64) (bool, error) {
+	res := me.Db.Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+//RegenerateRecoveryCodes invalidates every recovery code of userID and returns new ones
+func (me twoFactorStore) RegenerateRecoveryCodes(userID uint) ([]string, error) {
+	var codes []string
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = me.replaceRecoveryCodes(tx, userID)
+		return err
+	})
+
+	return codes, err
+}
+
+//Disable removes the TOTP secret and the recovery codes of userID
+func (me twoFactorStore) Disable(userID uint) (bool, error) {
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"two_factor_enabled": false, "two_factor_secret": ""}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("owner_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (me twoFactorStore) replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("owner_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := utils.NewRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.Create(&models.RecoveryCode{OwnerID: userID, Hash: hashRecoveryCode(code)}).Error; err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+//recovery codes carry enough entropy for a plain digest, and it keeps Verify cheap
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,504 @@
+package stores
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//uploadDirectories are the prefixes of the directories the page images, the avatars and the uploads are saved in
+var uploadDirectories = []string{"page-", "user-", "upload-"}
+
+//uploadValidity is how long an upload can be referenced, the expired ones are purged with their file
+const uploadValidity = 24 * time.Hour
+
+//uploadPurgeInterval is how often run looks for the expired uploads
+const uploadPurgeInterval = time.Hour
+
+//uploadChunks serializes the writes of the spooled chunks, the offset of a chunk is checked and the chunk written at once
+var uploadChunks sync.Mutex
+
+type uploadStore struct {
+	Db        *gorm.DB
+	FileStore fileStore
+	MaxSize   int64
+}
+
+//Limit returns the size in bytes of the largest file accepted
+func (me uploadStore) Limit() int64 {
+	return me.MaxSize
+}
+
+//Receive saves the file of userID read from r in one go, it is rejected when it is larger than the limit or
+//is not a png, jpeg or gif image whatever its name says
+func (me uploadStore) Receive(userID uint, filename string, r io.Reader) (models.Upload, error) {
+	upload := newUpload(userID, filename)
+
+	//one byte more than the limit tells the file is too large
+	path, err := me.FileStore.Save(uploadDirectory(userID), upload.Filename, io.LimitReader(r, me.MaxSize+1))
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	upload.URL = path
+	if upload.Size, err = me.FileStore.Size(path); err != nil {
+		me.discard(path)
+		return models.Upload{}, err
+	}
+
+	if upload.Size > me.MaxSize {
+		me.discard(path)
+		return models.Upload{}, uploadTooLarge(me.MaxSize)
+	}
+
+	upload.Received = upload.Size
+	if err := me.finish(&upload); err != nil {
+		return models.Upload{}, err
+	}
+
+	if err := me.Db.Create(&upload).Error; err != nil {
+		me.discard(path)
+		return models.Upload{}, err
+	}
+
+	return upload, nil
+}
+
+//Start opens the resumable upload of a file of size bytes for userID, its content is then sent with Append
+func (me uploadStore) Start(userID uint, filename string, size int64) (models.Upload, error) {
+	if size <= 0 {
+		return models.Upload{}, apperror.New(http.StatusBadRequest, apperror.InvalidRequest, fmt.Errorf("invalid upload size %d", size))
+	}
+
+	if size > me.MaxSize {
+		return models.Upload{}, uploadTooLarge(me.MaxSize)
+	}
+
+	upload := newUpload(userID, filename)
+	upload.Size = size
+
+	if err := me.Db.Create(&upload).Error; err != nil {
+		return models.Upload{}, err
+	}
+
+	return upload, nil
+}
+
+//Append writes chunk at offset in the resumable upload of userID, offset must be the size received so far. What is
+//written is kept when the chunk is cut short, the upload is resumed from its received size. The upload is
+//complete once its size is received, it is deleted when it turns out not to be an image
+func (me uploadStore) Append(userID, uploadID uint, offset int64, chunk io.Reader) (models.Upload, error) {
+	upload, err := me.appendable(userID, uploadID, offset)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	//the chunk is read from the network before the lock is taken, a slow client only holds its own request
+	spool, copyErr := spoolChunk(io.LimitReader(chunk, upload.Size-upload.Received))
+	if spool == nil {
+		return models.Upload{}, copyErr
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if n, _ := chunk.Read(make([]byte, 1)); n > 0 && copyErr == nil {
+		copyErr = uploadTooLarge(upload.Size - offset)
+	}
+
+	upload, err = me.write(userID, uploadID, offset, spool)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	if upload.Received == upload.Size {
+		if err := me.finish(&upload); err != nil {
+			me.Db.Delete(&upload)
+			return models.Upload{}, err
+		}
+
+		if err := me.Db.Model(&upload).Select(append([]string{"URL", "Mime", "Complete", "UpdatedAt"}, models.VariantColumns...)).Updates(&upload).Error; err != nil {
+			return models.Upload{}, err
+		}
+	}
+
+	if copyErr != nil {
+		return models.Upload{}, copyErr
+	}
+
+	return upload, nil
+}
+
+//write appends the spooled chunk to the file of the upload if it is still at offset. The chunks of all the uploads
+//are serialized while their offset is checked and their spooled content copied, the last one completes the upload
+func (me uploadStore) write(userID, uploadID uint, offset int64, spool *os.File) (models.Upload, error) {
+	uploadChunks.Lock()
+	defer uploadChunks.Unlock()
+
+	upload, err := me.appendable(userID, uploadID, offset)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return models.Upload{}, err
+	}
+
+	path, copyErr := me.FileStore.Append(uploadDirectory(userID), upload.Filename, spool)
+	if path == "" {
+		return models.Upload{}, copyErr
+	}
+
+	upload.URL = path
+	if upload.Received, err = me.FileStore.Size(path); err != nil {
+		return models.Upload{}, err
+	}
+
+	if err := me.Db.Model(&upload).Select("URL", "Received", "UpdatedAt").Updates(&upload).Error; err != nil {
+		return models.Upload{}, err
+	}
+
+	return upload, copyErr
+}
+
+//appendable returns the upload uploadID of userID if a chunk can be appended at offset: it has not expired and
+//offset is the size received so far, short of the full size
+func (me uploadStore) appendable(userID, uploadID uint, offset int64) (models.Upload, error) {
+	upload, err := me.Get(userID, uploadID)
+	if err != nil {
+		return models.Upload{}, err
+	}
+
+	if upload.HasExpired() {
+		return models.Upload{}, apperror.New(http.StatusNotFound, apperror.NotFound, fmt.Errorf("upload %d has expired", uploadID))
+	}
+
+	if upload.Complete || upload.Received == upload.Size || offset != upload.Received {
+		return models.Upload{}, apperror.New(http.StatusConflict, "upload.offset_mismatch", fmt.Errorf("upload %d received %d bytes, not %d", uploadID, upload.Received, offset)).
+			WithVars(map[string]string{"Received": strconv.FormatInt(upload.Received, 10)})
+	}
+
+	return upload, nil
+}
+
+//spoolChunk copies chunk to a temporary file, the file holds what was read when the copy fails. It is nil when it
+//could not be created
+func spoolChunk(chunk io.Reader) (*os.File, error) {
+	spool, err := ioutil.TempFile("", "upload-chunk")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(spool, chunk)
+	return spool, err
+}
+
+//Get returns the upload uploadID of userID
+func (me uploadStore) Get(userID, uploadID uint) (models.Upload, error) {
+	var upload models.Upload
+	if err := me.Db.Where("id = ? AND owner_id = ?", uploadID, userID).First(&upload).Error; err != nil {
+		return models.Upload{}, err
+	}
+
+	return upload, nil
+}
+
+//Ready returns the upload uploadID of userID if a page image or an avatar can reference it, the upload.unavailable
+//error otherwise
+func (me uploadStore) Ready(userID, uploadID uint) (models.Upload, error) {
+	upload, err := me.Get(userID, uploadID)
+	if err == nil && (!upload.Complete || upload.HasExpired()) {
+		err = fmt.Errorf("upload %d is not complete or has expired", uploadID)
+	}
+
+	if err != nil {
+		return models.Upload{}, apperror.New(http.StatusUnprocessableEntity, "upload.unavailable", err)
+	}
+
+	return upload, nil
+}
+
+//Consume deletes the uploads of userID whose file a page image or an avatar now references, the files stay
+func (me uploadStore) Consume(userID uint, uploadIDs []uint) error {
+	return me.Db.Where("id IN ? AND owner_id = ?", uploadIDs, userID).Delete(&models.Upload{}).Error
+}
+
+//PurgeExpired deletes the uploads which expired at now and their file unless an image or an avatar references it,
+//it returns how many were purged
+func (me uploadStore) PurgeExpired(now time.Time) (int, error) {
+	var uploads []models.Upload
+	if err := me.Db.Where("expires_at <= ?", now).Find(&uploads).Error; err != nil {
+		return 0, err
+	}
+
+	if len(uploads) == 0 {
+		return 0, nil
+	}
+
+	for _, upload := range uploads {
+		if err := me.Db.Delete(&upload).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	//the uploads are gone, what is still referenced is used by an image or an avatar
+	referenced, err := me.referenced()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, upload := range uploads {
+		for _, path := range append(upload.Variants.URLs(), upload.URL) {
+			if path != "" && !referenced[cleanUpload(path)] {
+				me.discard(path)
+			}
+		}
+	}
+
+	return len(uploads), nil
+}
+
+//run purges the expired uploads, it never returns
+func (me uploadStore) run() {
+	ticker := time.NewTicker(uploadPurgeInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		purged, err := me.PurgeExpired(now)
+		if err != nil {
+			log.Errorf("upload purge: %s", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Printf("upload purge: %d uploads purged", purged)
+		}
+	}
+}
+
+//finish decodes the received file of upload and marks it complete. The image is saved again over it, upright,
+//without its metadata and within the max dimensions, with its variants. The file is removed when it is not a png,
+//jpeg or gif image
+func (me uploadStore) finish(upload *models.Upload) error {
+	f, err := me.FileStore.Open(upload.URL)
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	mime, img, err := utils.DecodeImage(data)
+	if errors.Is(err, utils.ErrTooManyPixels) {
+		me.discard(upload.URL)
+		return apperror.New(http.StatusRequestEntityTooLarge, "upload.too_many_pixels", err).WithVars(map[string]string{"Max": strconv.Itoa(utils.MaxPixels / 1000 / 1000)})
+	}
+
+	if err != nil {
+		me.discard(upload.URL)
+		return apperror.New(http.StatusUnsupportedMediaType, "upload.unsupported_type", err)
+	}
+
+	path, variants, err := me.FileStore.SaveImage(uploadDirectory(upload.OwnerID), upload.Filename, mime, img, true)
+	if err != nil {
+		me.discard(upload.URL)
+		return err
+	}
+
+	upload.URL, upload.Variants, upload.Mime, upload.Complete = path, variants, mime, true
+
+	return nil
+}
+
+func (me uploadStore) discard(path string) {
+	if err := me.FileStore.Delete(path); err != nil {
+		log.Warnf("upload %s: %s", path, err)
+	}
+}
+
+//newUpload returns an upload of userID expiring after uploadValidity, its file is named after filename with
+//a random prefix so the uploads of a same name do not overwrite each other
+func newUpload(userID uint, filename string) models.Upload {
+	name, err := utils.Sanitize(filename)
+	if err != nil || name == "" {
+		name = "upload"
+	}
+
+	return models.Upload{
+		OwnerID:   userID,
+		Filename:  utils.RandStringBytesMaskImprSrc(12) + "." + name,
+		ExpiresAt: time.Now().Add(uploadValidity),
+	}
+}
+
+func uploadDirectory(userID uint) string {
+	return "upload-" + strconv.FormatUint(uint64(userID), 10)
+}
+
+func pageDirectory(profileID uint) string {
+	return "page-" + strconv.FormatUint(uint64(profileID), 10)
+}
+
+//pageDirectories are the directories the page images of the profileID of userID are saved in: their own one, the
+//uploads and the one named after userID the updates used to save them in
+func pageDirectories(profileID, userID uint) []string {
+	return []string{pageDirectory(profileID), pageDirectory(userID), uploadDirectory(userID)}
+}
+
+//avatarDirectories are the directories the avatars of userID are saved in, by a data URL or an upload
+func avatarDirectories(userID uint) []string {
+	return []string{avatarDirectory(userID), uploadDirectory(userID)}
+}
+
+func avatarDirectory(userID uint) string {
+	return "user-" + strconv.FormatUint(uint64(userID), 10)
+}
+
+//uploadTooLarge is the error of a file larger than max bytes
+func uploadTooLarge(max int64) error {
+	return apperror.New(http.StatusRequestEntityTooLarge, "upload.too_large", fmt.Errorf("more than %d bytes", max)).
+		WithVars(map[string]string{"Max": strconv.FormatInt(max, 10)})
+}
+
+//Collect reconciles the upload directories with the images, deleted ones included, the profile avatars and the uploads. The files
+//no row references are removed unless dryRun is set, the ones younger than minAge at now are left alone as their row
+//may not be written yet. The referenced files which do not exist are reported as missing
+func (me uploadStore) Collect(now time.Time, minAge time.Duration, dryRun bool) (models.UploadReport, error) {
+	report := models.UploadReport{Orphans: []string{}, Missing: []string{}}
+
+	referenced, err := me.referenced()
+	if err != nil {
+		return report, err
+	}
+
+	fs := me.FileStore.FileSystem
+	base := filepath.Join(me.FileStore.PublicPath, me.FileStore.ImageBasePath)
+
+	directories, err := fs.List(base)
+	if err != nil && !fs.IsNotExist(err) {
+		return report, err
+	}
+	sort.Strings(directories)
+
+	seen := map[string]bool{}
+	for _, directory := range directories {
+		if !hasAnyPrefix(directory, uploadDirectories) {
+			continue
+		}
+
+		names, err := fs.List(filepath.Join(base, directory))
+		if err != nil {
+			return report, err
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !strings.HasPrefix(name, me.FileStore.FilePrefix) {
+				continue
+			}
+
+			info, err := fs.Stat(filepath.Join(base, directory, name))
+			if err != nil {
+				return report, err
+			}
+
+			if info.IsDir() {
+				continue
+			}
+
+			path := filepath.Join(me.FileStore.ImageBasePath, directory, name)
+			key := cleanUpload(path)
+			seen[key] = true
+
+			if referenced[key] || now.Sub(info.ModTime()) < minAge {
+				report.Kept++
+				continue
+			}
+
+			if !dryRun {
+				if err := me.FileStore.Delete(path); err != nil {
+					log.Warnf("upload gc: %s", err)
+					continue
+				}
+			}
+
+			report.Orphans = append(report.Orphans, path)
+		}
+	}
+
+	for key := range referenced {
+		if seen[key] {
+			continue
+		}
+
+		if _, err := fs.Stat(filepath.Join(me.FileStore.PublicPath, key)); err != nil && fs.IsNotExist(err) {
+			report.Missing = append(report.Missing, key)
+		}
+	}
+	sort.Strings(report.Missing)
+
+	return report, nil
+}
+
+//referenced returns the cleaned paths of the uploaded files the images and their variants, the avatars and the
+//uploads reference
+func (me uploadStore) referenced() (map[string]bool, error) {
+	var paths []string
+	for _, column := range append([]string{"url"}, models.VariantColumns...) {
+		var images, uploads []string
+		if err := me.Db.Unscoped().Model(&models.Image{}).Where(column+" <> ''").Pluck(column, &images).Error; err != nil {
+			return nil, err
+		}
+
+		if err := me.Db.Model(&models.Upload{}).Where(column+" <> ''").Pluck(column, &uploads).Error; err != nil {
+			return nil, err
+		}
+
+		paths = append(append(paths, images...), uploads...)
+	}
+
+	var avatars []string
+	if err := me.Db.Unscoped().Model(&models.Profile{}).Where("avatar <> ''").Pluck("avatar", &avatars).Error; err != nil {
+		return nil, err
+	}
+
+	referenced := map[string]bool{}
+	for _, path := range append(paths, avatars...) {
+		if me.FileStore.Uploaded(path) {
+			referenced[cleanUpload(path)] = true
+		}
+	}
+
+	return referenced, nil
+}
+
+//cleanUpload returns path rooted and cleaned, the paths written by Save compare equal whatever their spelling
+func cleanUpload(path string) string {
+	return filepath.Clean(string(os.PathSeparator) + path)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,23 +2,19 @@ package stores
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
 	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/utils"
 	"gorm.io/gorm"
 )
 
 type userStore struct {
-	Db *gorm.DB
-}
-
-func (me userStore) Migrate() {
-	err := me.Db.AutoMigrate(&models.User{})
-	if err != nil {
-		panic(err)
-	}
-	// me.Db.Model(&models.User{}).AddForeignKey("profile_id", "profiles(id)", "CASCADE", "CASCADE")
+	Db    *gorm.DB
+	Audit auditStore
 }
 
 //All user fetch
@@ -36,10 +32,12 @@ func (me userStore) All(keys url.Values) ([]models.User, error) {
 			req = req.Preload("Friends")
 		case "id":
 			req = req.Where("ID= ?", v)
+		case "profile_id":
+			req = req.Where("profile_id = ?", v)
 		case "username":
-			req = req.Where("username  LIKE ?", v)
+			req = req.Where("LOWER(username) LIKE LOWER(?)", v)
 		case "email":
-			req = req.Where("email LIKE ?", v)
+			req = req.Where("LOWER(email) LIKE LOWER(?)", v)
 		}
 	}
 
@@ -50,6 +48,67 @@ func (me userStore) All(keys url.Values) ([]models.User, error) {
 	return users, nil
 }
 
+//Search users whose email or username contains query, most recent first
+func (me userStore) Search(query string, offset, limit int) ([]models.User, error) {
+	req := me.Db.Model(&models.User{}).
+		Preload("Profile").
+		Preload("Roles").
+		Joins("LEFT JOIN profiles ON profiles.id = users.profile_id")
+
+	if query != "" {
+		req = containing(req, query, "users.email", "profiles.username")
+	}
+
+	var users []models.User
+	if err := req.Order("users.id DESC").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return []models.User{}, err
+	}
+
+	for i := range users {
+		users[i].Password = ""
+	}
+
+	return users, nil
+}
+
+//Suspend or reinstate userID, suspending drops its sessions
+func (me userStore) Suspend(userID uint, suspended bool, actor models.Actor) (bool, error) {
+	var suspendedAt *time.Time
+	if suspended {
+		now := time.Now()
+		suspendedAt = &now
+	}
+
+	err := me.Db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.User{}).Where("id = ?", userID).Update("suspended_at", suspendedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if !suspended {
+			return nil
+		}
+
+		return tx.Where("owner_id = ?", userID).Delete(&models.Session{}).Error
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	action := auditUserSuspend
+	if !suspended {
+		action = auditUserReinstate
+	}
+	me.Audit.Record(actor, action, "user", userID, nil)
+
+	return true, nil
+}
+
 //New user
 func (me userStore) New(user models.User) (models.User, error) {
 	user.New = true
@@ -60,24 +119,54 @@ func (me userStore) New(user models.User) (models.User, error) {
 	}
 
 	if count > 0 {
-		return models.User{}, fmt.Errorf("user already exist")
+		return models.User{}, apperror.New(http.StatusForbidden, "user.already_exists", nil)
 	}
 
 	if err := me.Db.Create(&user).Error; err != nil {
 		return models.User{}, err
 	}
 
+	if err := me.assignDefaultRoles(&user); err != nil {
+		return models.User{}, err
+	}
+
 	return user, nil
 }
 
-func (me userStore) ChangePassword(userID uint, user models.User) (models.User, error) {
+//assignDefaultRoles gives the new user models.DefaultRoles, the very first user is admin as well
+func (me userStore) assignDefaultRoles(user *models.User) error {
+	names := models.DefaultRoles
+	if user.ID == 1 {
+		names = append([]string{models.RoleAdmin}, names...)
+	}
+
+	var roles []*models.Role
+	if err := me.Db.Where("name IN ?", names).Find(&roles).Error; err != nil {
+		return err
+	}
+
+	if len(roles) == 0 {
+		return nil
+	}
+
+	return me.Db.Model(user).Association("Roles").Append(roles)
+}
+
+func (me userStore) ChangePassword(userID uint, user models.User, actor models.Actor) (models.User, error) {
 	user.ChangePassword = true
-	if err := me.Db.Model(&user).Where("id = ?", userID).Update("Password", user.Password).Error; err != nil {
+	//the BeforeUpdate hook cannot alter a single column update, hash it here
+	if err := me.Db.Model(&user).Where("id = ?", userID).Update("Password", models.HashPassword(user.Password)).Error; err != nil {
 		return user, err
 	}
+	me.Audit.Record(actor, auditPasswordChange, "user", userID, nil)
 	return user, nil
 }
 
+//RehashPassword stores a new hash of password, used to upgrade hashes made with a weaker cost
+func (me userStore) RehashPassword(userID uint, password string) error {
+	return me.Db.Model(&models.User{}).Where("id = ?", userID).Update("password", models.HashPassword(password)).Error
+}
+
 //GetProfile returns the user profile
 func (me userStore) GetProfile(userID uint) (models.Profile, error) {
 	var out = models.User{}
@@ -121,7 +210,7 @@ func (me userStore) OwnImage(userID, pageID, imageID uint) (bool, error) {
 	}
 
 	var count struct{ Count int }
-	err = me.Db.Table("images").Select("COUNT(*) AS count").Joins("INNER JOIN pages ON pages.id = images.owner_id").Where("images.id = ? AND pages.id = ? AND pages.owner_id = ?", imageID, pageID, profileID).Find(&count).Error
+	err = me.Db.Table("images").Select("COUNT(*) AS count").Joins("INNER JOIN pages ON pages.id = images.owner_id").Where("images.id = ? AND pages.id = ? AND pages.owner_id = ? AND images.deleted_at IS NULL AND pages.deleted_at IS NULL", imageID, pageID, profileID).Find(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -201,8 +290,8 @@ func (me userStore) OwnProfile(userID, profileID uint) (bool, error) {
 		return false, err
 	}
 
-	if user.ID != profileID {
-		return false, fmt.Errorf("user %v doesn't own this profile %v", profileID, profileID)
+	if user.ProfileID != profileID {
+		return false, fmt.Errorf("user %v doesn't own this profile %v", userID, profileID)
 	}
 
 	return true, nil
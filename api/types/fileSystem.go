@@ -8,6 +8,12 @@ import (
 //Filesystem is the interface used in the app (fileStore)
 type FileSystem interface {
 	OpenFile(name string) (io.WriteCloser, error)
+	//OpenAppend opens name to write at its end, it is created if it does not exist
+	OpenAppend(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Remove(name string) error
+	//List returns the names of the entries of the directory dir
+	List(dir string) ([]string, error)
 	MkdirAll(path string) error
 	Stat(name string) (os.FileInfo, error)
 	IsNotExist(error) bool
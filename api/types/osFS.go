@@ -12,7 +12,29 @@ func (OsFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
 func (OsFS) MkdirAll(path string) error            { return os.MkdirAll(path, 0700) }
 
 func (OsFS) OpenFile(name string) (io.WriteCloser, error) {
-	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE, 0666)
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (OsFS) OpenAppend(name string) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+}
+
+func (OsFS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+func (OsFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (OsFS) List(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return f.Readdirnames(-1)
 }
 
 func (OsFS) IsNotExist(err error) bool {
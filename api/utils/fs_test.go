@@ -19,6 +19,22 @@ func (mem memFS) OpenFile(name string) (io.WriteCloser, error) {
 	return f, err
 }
 
+func (mem memFS) OpenAppend(name string) (io.WriteCloser, error) {
+	return mem._os.Open(name)
+}
+
+func (mem memFS) Open(name string) (io.ReadCloser, error) {
+	return mem._os.Open(name)
+}
+
+func (mem memFS) Remove(name string) error {
+	return mem._os.Remove(name)
+}
+
+func (mem memFS) List(dir string) ([]string, error) {
+	return mem._os.List(dir)
+}
+
 func (mem memFS) Stat(name string) (os.FileInfo, error) {
 	return mem._os.Stat(name)
 }
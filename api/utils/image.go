@@ -9,56 +9,54 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"net/http"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
 
 // B64ToImage accepts a b64 image string (having content-type specified) and returns a
-// base64 encoded string.
+// base64 encoded string. The content type of the data URL is not trusted, the format is sniffed from the data,
+// see DecodeImage
 func B64ToImage(b64 string) (string, image.Image, error) {
 	i := strings.Index(b64, ",")
 	if i < 0 {
 		return "", nil, fmt.Errorf("no comma in image")
 	}
 
-	start := strings.Index(b64, ":")
-	end := strings.Index(b64, ";")
-
-	mime := b64[start+1 : end]
-	// pass reader to NewDecoder
-	dec := base64.NewDecoder(base64.StdEncoding, strings.NewReader(b64[i+1:]))
+	data, err := base64.StdEncoding.DecodeString(b64[i+1:])
+	if err != nil {
+		log.Error(err)
+		return "", nil, err
+	}
 
-	var err error
-	var img image.Image
+	mime, img, err := DecodeImage(data)
+	if err != nil {
+		log.Error(err)
+		return "", nil, err
+	}
 
-	switch mime {
-	case "image/png":
-		img, err = png.Decode(dec)
-		if err != nil {
-			break
-		}
-		return "image/png", img, err
-	case "image/jpg":
-		fallthrough
-	case "image/jpeg":
-		img, err = jpeg.Decode(dec)
-		if err != nil {
-			break
-		}
-		return "image/jpg", img, err
-	case "image/gif":
-		img, err = gif.Decode(dec)
-		if err != nil {
-			break
-		}
-		return "image/gif", img, err
-	default:
-		return "", nil, fmt.Errorf("image format not allowed: %s", mime)
+	if mime == "image/jpeg" {
+		mime = "image/jpg"
 	}
 
-	log.Error(err)
-	return "", nil, err
+	return mime, img, nil
+}
+
+//JPEGQuality is the quality the jpeg images are encoded with
+const JPEGQuality = 85
+
+//imageTypes are the sniffed content types of the images accepted
+var imageTypes = map[string]bool{"image/png": true, "image/jpeg": true, "image/gif": true}
+
+//ImageType returns the content type sniffed from head, the first bytes of a file. The types other
+//than png, jpeg and gif are an error
+func ImageType(head []byte) (string, error) {
+	mime := http.DetectContentType(head)
+	if !imageTypes[mime] {
+		return "", fmt.Errorf("image format not allowed: %s", mime)
+	}
+	return mime, nil
 }
 
 //ImageToTypedImage converts an image into a type Image of type png, jpg, gif
@@ -79,7 +77,7 @@ func ImageToTypedImage(mime string, img image.Image) (io.Reader, error) {
 		fallthrough
 	case "image/jpeg":
 		log.Printf("encoding as %s", mime)
-		err = jpeg.Encode(f, img, nil)
+		err = jpeg.Encode(f, img, &jpeg.Options{Quality: JPEGQuality})
 		if err != nil {
 			log.Error(err)
 			break
@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+//exifOrientation is the tag of the orientation of the image in the EXIF metadata
+const exifOrientation = 0x0112
+
+//The variants of an image fit in a square of their size, they are prefixed by their name on the file system
+const (
+	ThumbnailSize = 256
+	MediumSize    = 800
+	LargeSize     = 1600
+)
+
+//MaxPixels bounds the width times the height of the images decoded, a few kilobytes can declare an image
+//taking gigabytes once decoded
+const MaxPixels = 50 * 1000 * 1000
+
+//ErrTooManyPixels is returned by DecodeImage for an image larger than MaxPixels
+var ErrTooManyPixels = errors.New("image too large")
+
+//DecodeImage decodes the png, jpeg or gif image of data, its format is sniffed from its content. The jpeg images are
+//turned upright following their EXIF orientation, the decoded image carries no metadata. The dimensions are read
+//from the header first, an image larger than MaxPixels is not decoded
+func DecodeImage(data []byte) (string, image.Image, error) {
+	mime, err := ImageType(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > MaxPixels {
+		return "", nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if mime == "image/jpeg" {
+		img = Orient(img, Orientation(data))
+	}
+
+	return mime, img, nil
+}
+
+//Orientation returns the EXIF orientation of the jpeg image of data, from 1 to 8, 1 when it has none
+func Orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	//the segments following the start of image, up to the start of scan
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+//tiffOrientation reads the orientation in the first directory of the TIFF structure of the EXIF metadata
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for e := 0; e < entries; e++ {
+		entry := ifd + 2 + e*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) != exifOrientation {
+			continue
+		}
+
+		if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+			return o
+		}
+		return 1
+	}
+
+	return 1
+}
+
+//Orient turns img upright from its EXIF orientation, the orientations 5 to 8 swap its width and height
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	src := toNRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
+
+//Fit scales img down so it fits in maxWidth x maxHeight, keeping its aspect ratio. The pixels are averaged over the
+//area they cover. Smaller images are returned as they are, a zero bound is not enforced
+func Fit(img image.Image, maxWidth, maxHeight int) image.Image {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	scale := 1.0
+	if maxWidth > 0 && w > maxWidth {
+		scale = float64(maxWidth) / float64(w)
+	}
+	if maxHeight > 0 && h > maxHeight && float64(maxHeight)/float64(h) < scale {
+		scale = float64(maxHeight) / float64(h)
+	}
+
+	if scale == 1.0 {
+		return img
+	}
+
+	dw, dh := int(float64(w)*scale+0.5), int(float64(h)*scale+0.5)
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	return resize(toNRGBA(img), dw, dh)
+}
+
+//resize scales src down to width x height, each pixel is the average of the source pixels it covers weighted by
+//their alpha so the transparent pixels do not darken the edges
+func resize(src *image.NRGBA, width, height int) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*h/height, (y+1)*h/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*w/width, (x+1)*w/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				p := src.Pix[src.PixOffset(x0, sy):src.PixOffset(x1, sy)]
+				for i := 0; i < len(p); i += 4 {
+					alpha := uint64(p[i+3])
+					r += uint64(p[i]) * alpha
+					g += uint64(p[i+1]) * alpha
+					b += uint64(p[i+2]) * alpha
+					a += alpha
+					n++
+				}
+			}
+
+			d := dst.PixOffset(x, y)
+			if a > 0 {
+				dst.Pix[d], dst.Pix[d+1], dst.Pix[d+2] = uint8(r/a), uint8(g/a), uint8(b/a)
+			}
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+//toNRGBA returns img as a non premultiplied RGBA image whose bounds start at 0,0
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Src)
+
+	return dst
+}
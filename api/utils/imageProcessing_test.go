@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+)
+
+//withOrientation returns the jpeg data with an EXIF segment holding orientation, written in order
+func withOrientation(data []byte, order binary.ByteOrder, orientation uint16) []byte {
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	binary.Write(&tiff, order, uint16(42))
+	binary.Write(&tiff, order, uint32(8))
+	//one entry: orientation, SHORT, one value padded to four bytes, then no next directory
+	binary.Write(&tiff, order, uint16(1))
+	binary.Write(&tiff, order, []uint16{exifOrientation, 3})
+	binary.Write(&tiff, order, uint32(1))
+	binary.Write(&tiff, order, []uint16{orientation, 0})
+	binary.Write(&tiff, order, uint32(0))
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	var out bytes.Buffer
+	out.Write(data[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	binary.Write(&out, binary.BigEndian, uint16(len(segment)+2))
+	out.Write(segment)
+	out.Write(data[2:])
+
+	return out.Bytes()
+}
+
+func testJPEG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestOrientation(t *testing.T) {
+	plain := testJPEG(t, 4, 2)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "no exif", data: plain, want: 1},
+		{name: "big endian", data: withOrientation(plain, binary.BigEndian, 6), want: 6},
+		{name: "little endian", data: withOrientation(plain, binary.LittleEndian, 3), want: 3},
+		{name: "out of range", data: withOrientation(plain, binary.BigEndian, 9), want: 1},
+		{name: "truncated", data: withOrientation(plain, binary.BigEndian, 6)[:20], want: 1},
+		{name: "not a jpeg", data: []byte("GIF89a"), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orientation(tt.data); got != tt.want {
+				t.Errorf("Orientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	//a 3x2 image whose top left pixel is marked
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		marked      image.Point
+	}{
+		{orientation: 1, size: image.Pt(3, 2), marked: image.Pt(0, 0)},
+		{orientation: 2, size: image.Pt(3, 2), marked: image.Pt(2, 0)},
+		{orientation: 3, size: image.Pt(3, 2), marked: image.Pt(2, 1)},
+		{orientation: 4, size: image.Pt(3, 2), marked: image.Pt(0, 1)},
+		{orientation: 5, size: image.Pt(2, 3), marked: image.Pt(0, 0)},
+		{orientation: 6, size: image.Pt(2, 3), marked: image.Pt(1, 0)},
+		{orientation: 7, size: image.Pt(2, 3), marked: image.Pt(1, 2)},
+		{orientation: 8, size: image.Pt(2, 3), marked: image.Pt(0, 2)},
+	}
+	for _, tt := range tests {
+		got := Orient(src, tt.orientation)
+		if got.Bounds().Size() != tt.size {
+			t.Errorf("Orient(%d) size = %v, want %v", tt.orientation, got.Bounds().Size(), tt.size)
+			continue
+		}
+
+		if r, _, _, _ := got.At(tt.marked.X, tt.marked.Y).RGBA(); r == 0 {
+			t.Errorf("Orient(%d) moved the marked pixel away from %v", tt.orientation, tt.marked)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name                string
+		width, height       int
+		maxWidth, maxHeight int
+		want                image.Point
+	}{
+		{name: "smaller", width: 100, height: 50, maxWidth: 200, maxHeight: 200, want: image.Pt(100, 50)},
+		{name: "too wide", width: 400, height: 100, maxWidth: 200, maxHeight: 200, want: image.Pt(200, 50)},
+		{name: "too tall", width: 100, height: 400, maxWidth: 200, maxHeight: 200, want: image.Pt(50, 200)},
+		{name: "both", width: 400, height: 300, maxWidth: 200, maxHeight: 100, want: image.Pt(133, 100)},
+		{name: "no bound", width: 400, height: 300, maxWidth: 0, maxHeight: 0, want: image.Pt(400, 300)},
+		{name: "thin", width: 1000, height: 1, maxWidth: 10, maxHeight: 10, want: image.Pt(10, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fit(image.NewGray(image.Rect(0, 0, tt.width, tt.height)), tt.maxWidth, tt.maxHeight)
+			if got.Bounds().Size() != tt.want {
+				t.Errorf("Fit() size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	data := withOrientation(testJPEG(t, 4, 2), binary.BigEndian, 6)
+
+	mime, img, err := DecodeImage(data)
+	if err != nil || mime != "image/jpeg" {
+		t.Fatalf("DecodeImage() = %s, %v", mime, err)
+	}
+
+	if img.Bounds().Size() != image.Pt(2, 4) {
+		t.Errorf("DecodeImage() size = %v, want the image turned upright", img.Bounds().Size())
+	}
+
+	if _, _, err := DecodeImage([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")); err == nil {
+		t.Errorf("DecodeImage() of a svg: no error")
+	}
+
+	//the logical screen of the gif is declared 65535x65535, its single pixel frame is never decoded
+	var bomb bytes.Buffer
+	if err := gif.Encode(&bomb, image.NewPaletted(image.Rect(0, 0, 1, 1), palette.Plan9), nil); err != nil {
+		t.Fatal(err)
+	}
+	copy(bomb.Bytes()[6:10], []byte{0xFF, 0xFF, 0xFF, 0xFF})
+	if _, _, err := DecodeImage(bomb.Bytes()); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("DecodeImage() of a decompression bomb = %v, want %v", err, ErrTooManyPixels)
+	}
+}
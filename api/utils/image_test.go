@@ -24,22 +24,22 @@ func TestB64ToImage(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "incorrect mime type",
+			name:    "jpeg declared as gif",
 			args:    args{b64: "data:image/gif;base64,/9j/4AAQSkZJRgABAgAAAQABAAD/7QCcUGhvdG9zaG9wIDMuMAA4QklNBAQAAAAAAIAcAmcAFEJDamk0N3gteHg5UHNqQXFVWkRIHAIoAGJGQk1EMDEwMDBhYmYwMzAwMDA5OTBhMDAwMDAzMTQwMDAwZTAxNTAwMDBlZjE2MDAwMGE3MWQwMDAwY2EyYjAwMDBhZTJkMDAwMGUzMmYwMDAwZDYzMTAwMDA2YzRkMDAwMP/iAhxJQ0NfUFJPRklMRQABAQAAAgxsY21zAhAAAG1udHJSR0IgWFlaIAfcAAEAGQADACkAOWFjc3BBUFBMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD21gABAAAAANMtbGNtcwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACmRlc2MAAAD8AAAAXmNwcnQAAAFcAAAAC3d0cHQAAAFoAAAAFGJrcHQAAAF8AAAAFHJYWVoAAAGQAAAAFGdYWVoAAAGkAAAAFGJYWVoAAAG4AAAAFHJUUkMAAAHMAAAAQGdUUkMAAAHMAAAAQGJUUkMAAAHMAAAAQGRlc2MAAAAAAAAAA2MyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAHRleHQAAAAARkIAAFhZWiAAAAAAAAD21gABAAAAANMtWFlaIAAAAAAAAAMWAAADMwAAAqRYWVogAAAAAAAAb6IAADj1AAADkFhZWiAAAAAAAABimQAAt4UAABjaWFlaIAAAAAAAACSgAAAPhAAAts9jdXJ2AAAAAAAAABoAAADLAckDYwWSCGsL9hA/FVEbNCHxKZAyGDuSRgVRd13ta3B6BYmxmnysab9908PpMP///9sAQwAJBgcIBwYJCAgICgoJCw4XDw4NDQ4cFBURFyIeIyMhHiAgJSo1LSUnMiggIC4/LzI3OTw8PCQtQkZBOkY1Ozw5/9sAQwEKCgoODA4bDw8bOSYgJjk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5/8IAEQgBgAGAAwAiAAERAQIRAf/EABoAAAIDAQEAAAAAAAAAAAAAAAABAgMEBQb/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/2gAMAwAAARECEQAAAZAZgAAAEM067DLpGCvNgACGZNc6AF5gABjm9gFwAWAKaYCAJGIVgAABCE6XAXAAyAAAAAAC5HX4/H6u+zPuayxqmtc69c3LNdC88vQ5+7PTLtnh1xnrxbtc3yOvyE7OGdE7a7a7Oni5lufbw+jbQnrEYUapqTLLnNq5/YucdeTbnpY4RuaNGP0FzlA6/LAEAAAAAXI6ufl9OUdFk3k2492ufF2w057547clzj3Q0Z0+d1cu+G2uvVrhn5PWyZ6T1FLpcrqd+LkbsXQ4fVrXRw75Yde/CF0NCcvqZOjefA3GjPeuvbXccnvczpXlSB0+eAAAAAAAmKJghghgmAmCphYJkCYJgIHVeitZ6NOFzJpomAEXKwLkTBDAAQAAAAAAAAAAAABa6LI8/dOieLPbboydDXGvHs5pdswXzqraVClmsa003wsMkjO+pjsq1yusya9+Xn9DF1M9qGHTwAAAAAAAAAAAAAAAAAALgupOP1rsOvJN69/K6euEeb0uWlllPVnoybMWm8OZbRLHq31ui4qutymospuOhVGXX51HQ5G2dGBrygAAAAAAAACGIViBiBgIACvpGrq4haVELPpHbVmQ4uyoqx1ERknO9sImuFtaC6kELagAEAAAAAACEqLVYAAAAAASiEgEABDBMAAAAAAAAAAAAAATABAwABDBDIxWtwVaHnnFoAAAAAAxBIAQykMESQEMcbzj113Dk2R0iqwZCJaUI0GaJrWOBtjkDWsrL1UFigEnWywCk0yyzHfFomAAAAAMSGs/nq9Hi49tbMjkmN6WtN1FlhC2gOhyHL6V8nr5RJBEkyBIEpMgTIipqkMEMJgqGmRrshFujn2GuNES9UsmkUozUcvOnqWutUXdTt4341+zM78Pn93zE8nZ0su85YEbmfe893zcBkJggAAAAAAAAAkmVGUZCjKJCFkSyRKIlkBDVABxJzwVL1vD9bz66LZTz0jCxRmp1xXFi7OVnyHH9dyOnLi9jn79Y7AAAxAAmQmFIGIaGkyQIJKRGLiEJIsvp156GSdbLEayAFXE78l19SOXn365gslvM9C7qeCWdbTgczrw9Fnkc/qORvnYg1hgAAAAhkIYIkVEmyCaHKMiMJRIgRvla+fowV21dOIxXINBsx6pZ1a+fnpTpx2t9nHbgzd0+TCzs15pptt84rPQYcXQZzPp4ripzLIOTIkgi2CJMgrCq1azGmhyjIjFkR36t03QTeOvHr6E9Y5T6OLXKBMI3VjXU4/R0Y68e/oi6uP14zWOGW1Z5uhtZjl05jPl2cOas6aWsRcjfniSQhoQIZCJaZkanhY4tVJxkQ24uhL06aObjt2NXnutFXL6vMXq5M+rXOA1rm9WOa9XNvhz78rTTzm+5TwLrnd0uba31s1lecVxlWsOP2dC5OeZ9+e+uMLnTGqFk4QsIyqZKIlhMikgDYEalKEyPW5XXmudwu5z8d+H1sE7j0GjzfopuvOp645paHcZbbVb6PKVc/RXbTXWvT5SS+py02Jbs59+bCECSVmDLrOO+BrjFES2ItSUrKpUghSFRKLixORIDUJwsIdTm787zuOrPXDq28uUprjrFN+bRrm7a5kQnFu/k3TWjJotnbFKF7U9qukxxuysXU2ELnZV05XoEdTikrK9FSUZRGqYKyuQnZWsbKJlwGsubnLLTuwZ6Z68lLfY51ymIhDWIa8W2xlUxlNiMrgvTObbnt1L+bXNdjPyGbcdlszowZeNvCtrl05dDTytGbtoqnLfc1Fme6sTjYtFjiiTZVOyqtEJZbnuc/uk3Tk6MprkWdW5OPh1ZmVXZWZ9+LXZKFZVcriIyxZqjs5qt6FVscdKr6Mx3OTmt1iEprXOKshStJkSaqLZE7cyl6VnIuy2WRtWp2RIqcCoo2anr6Ml0TOPxK9t5rzt53ZZpYt+fAaaXiVk4CRVWV1TON0OyFtGrLzmujFzSBIsiOEKxsBlCaGgAGRU4xBmeOvo4/cmohKORCUOmd1WblytDlO5w/R5beR1/NFsoLeZkGTFIhG6ohKTC2EjNux9esKrsBFRKULRiCSAcXGACmxAAU5rISr1fmexlpJRl5FlUumceHVmlBqWfq/M6Y3clOyxwlU5RmkpQnTTZVKxRCM0AIQ2QlMpMAaYJIaFDlF1JAJESuUKI0xpnHpdHM3zXnozjvOKsWaTrkoRmSsjMlKMknIdhOLqTQScWJNAphBWIi2CZslxx2UzVA46wA4UkVKJEeWxmbROETRKDucKxf/EAC4QAAICAQMCBAYCAwEBAAAAAAECAAMRBBIhEBMgIjEzFDAyNEBBBSMkQ1BCYP/aAAgBAAABBQL/AKzNtXvid7BBz4++J6eLvz0/AY7RUzWn5q/Vrveo5or3320sWZ3cM5tUVkmlDZbZ6W61mW5rGxee1H3rUpysb3NY+yzz9ms7ljO4a3uoVP8AXWXvel2Y2sys+4UVbmqpL22bGfU32bGs7lRsz29NZZYdzh6S1l/y1Pmubv3WhadLpXFdunDfEaji7WxfsNF9z/t1/uafHwurffc776qQfhY3ufyB/wAjf/j6UHsyz69d7oP+FovcrO19YMXW/ZaT2F/rup95z59Z9a/ZaH3bfrLdu35a/Xrzm4LnR6D7g6mwG73NbF+w0X3P+3X+7R9nf7iOyacXPcsb3Nf9xpmK6UXvZ0t+vX+6B/g6L3Khus133Fv2Wk9i7izT8aSzh9b9ajH8foPdt+u76/lNnaNJqJqqbXurptTT112JZVSysaLrm1NNpiU2rpqab9/wt+7UU322VVXJRbXvAr1D1sopqg0t7zV1lmrTUCsIKKZZ9b6e+2yxVSumu1W02nOnltT2s+mvFdFFyV21s7Oprptq3H4a+99Tyum019dh019hdbAP+AunqMYKB/0dyiZB8BYCA5/Jdgg7rYR9478stKMM7T6d+d/mdwmK+5RdkvbtZ3ZF78rt3m8AWYAq7pMR94mo+qtcab8iyvfHZF0+iGW/2a37j/TKff1R/vuP9FBA0+k5NXv6v39V7Gm9jSnF+p4s1B/o07AVaPlpqYw21fkag8rxodB9f+zXfcf6ZR7+q9+4f4+kRGRWqSVfcav7jVexpvYo97Wfcan2NIiMFapDLVLjUe7+RqByrE0aI4sPD6hizht1MrO22wm27VV4XT2CuvTV7oMo95JfUHOnqs21aCvddqGzZZiyjTuKxp0DmXk5RCKfyTadi2lQrbSbCWawsD6Jprg296m9YDiWWPZEbYe4S1++6aemxIbWKI22M5aA4llrWdBjNljWH/tA/wDz+4dMzP8AxMiblm9Zvm6bjMnxj8MsojaqoT42fGWGfGGDWJEdX6bhN4ncE7k7hm9puPz84gOfm5AjX1rG1cbVPGd4ct0VczExDA20pqM/j+hVs/KzLrQle8tDmKpg5JGYFmBN0DZmRGhnrNNfj8Y9EfrkTeJvm8zJ66g5IWfr9TDYKWNOy8+HaYIgOJu6+s0zbq/xjFfEPPyHP9m6bmgyx0+iJg0wx8OBO0I1IlukzG0ZjUMsPBME0mNn45g9PHaP7OFVd1r6TTJX0AmIRDMQrmWVjGpqEI6aH8T9eAeiqWJUr4tUI75H8dVmViAeAzExLEltUspjpiaD1/D/AF4U4Fjbk8LqHXtkNpatlacQdMzdMx7kWfErkOGhUGWUS+iaIYP5GMm0bVOQviorDaixkSd9ILQZ3IGjOFluocyum14KRMFWEIliwJtf5mJiYm3qPCJR9THMfO7xaT3eybWbRGdm9JU5MAJl1ZErWumNrFEGo7hsZ6gl9W7v1GFgZYOcTExMTE2zbMTAmBOJxMzMz8keu3YiiWcv4tPneD20fU2bU1dsDswFV7q2SdrM1tN25aS1dtqdkaXE+Btedh9OR/YRXXDwc9OZgzBm2bZtm0THyP11o05yatx7PD0WJO2+MTZNs2zE0rL8RZQLFbSiLpqxGoRYv1XV41O3y7DNsOm7pZBWrQwqxS2s1O3r+D+p6mmgJNwhYwEyy1g4v8z6cheZzMGbTO2crc4c2KZlFXT/ANsHrcu5P7Cyq8TEFgjtu6WDy1WFjenn8eZuE3rO4sNyzvQ34gtJ8WlHn4nci2ZMv5gs81VuAfBp/V90Ondo9SKBeu3vZlusrrl+oRhW2ZmHHVjgaal1fVNsHdOO4dosYwswmWm+bpnkw8QmMYG4zkBseLTelzNG1AEq1O6JaJawzqtOM0jHXIm4Sm1d4ljRvM9lGXA1G/4UMw0qCIdresIjcTM/Zzm9t1v1JmekOYx4T14xzGnrPSETGZwJtLeLT8V3G1paLIc509nlLZW3L1PgVnODum0mAGKCjKeHbkGBYF5welo8tL9LD0DgG9+3X+7OFznp6xsYUcE4gMHJJOcHAnpOIvX9dD5amzm4ZjpK1sWKzbqWwjvut4mDnpiUWbkeAbZdqVqh/kLM16vUsUuvwLQSo2zdwelv0sWaEQz9geZmJiqTBwGxnoOZ5ocmDmbdx9D4P3aehpBFlOB/52VxoOLDkkMZztXLRsg1HYSZZkxlCTNcNlaysm0dtduSs3QzPOoGK84H7xCMx+CTwsOIo3BgI0HpPWbgJnn1PgUZa71URTmEAjcAu7dGDTneGMxiBwZnEzkAmZOEzGhoDFNGkFIEHpZ6DEzAPN/Iajfatm8HkE4gJyw8/wCwFhBMP0pDyW9Fz0wB0BwfBQD3bPUmCwAtd5Sd0zgbufWwDwGZmdpDrgmLiAiFsTuR3zBu3KONTqRUPU1ttKcwLgcGftRiZBn0hcGZ2xYfUPwfpD8esHDdEUuwXEXC12GPugRjBXhQMCcYqOGzDnOQs3jLnAUnBJz5WldsL8G7EN2Z3uBFmo1ngVysFzTuZissDCY5PlAMciZ2r6hVyP3YIOJnzRjtGNlHc33Z8rDM7SzZk2oK6piWfTXkwenEIHVVhEuyLKLA5cFTumTEMa1K1tveyAeADw5MFrCd0QFZkQnMU4H75nJm09Pdtu03ctFVVc8s2pAig8zVOGt6NyKjiYONvLtghlMZkAOqAjauwwkt0DdxcQ8Q2HOIB4PWAfJWwrFdGnE3LN6zeJvlrYmlH+RnzHpmZCjUa82uBBwTPWBgsN5hY9T0Ag6LlT3EweZt8Pr8zE3kSpw64meh5mhK97uYtaX62umP/I3MbLnsmk+oPN+B3SzFs+I9P0Oh4HLFTx4QMfMMM0zYubiY8C6pw1+vseE9dEo2dtZqWAicL48T9DpacmujKldjdSYPmtDF4b6gZjoYeJnnrpvLU7bK2O5vHjpjoeAqyrVdut2L29HaKuPnOYBMSq0POZu6VfUSdg6ou5uBNTaCq/Jx1zuPXkTkwLj5zTbMT0gcKajvTI6OxFFnCdMStu2xvNgYwfLxMY/DHQtibyZtzNgmhYYPqZc5sstPng8Y/N/TPNuYFxPTohKsly29Hyzk5aZ6DoOo/C+Fsx8PZGqsX5Bj8wJOBMwDrTZ2n//EACkRAAICAQMFAAEDBQAAAAAAAAECABEDEBIhBBMgMDEyFCJAIzNBUGD/2gAIAQIRAT8B1TCzjiPiZPsrSiY+Jl+ytFxs/wAlaUdalSoMRIuV5dD+JjgFAhjYlFzavHEXGo3NUemZbm1XybQPkzooS50nxomGluZVAycRUXjiDCFsztKHEKrsuZkC8xcQFCBAFaZtiIOIfHpGAU3O4oQTMwZt07i/t5ncWmW4XXcsXKFyzNXM6VgLuFw+PbcYjfxNwUKTFzhgwneU5BHde2eZkyg4hFzKQGi5AUadUwKjzvwvx+RsrN9ig/4nIMszaauXL9eHGpUQYE3GZ8aqgInTY1ZSTH6ZWqocCCdhN1VBiSrqLjCZOIMA5YzqE7bcTI/9Ae3GB21qAje06n+0BOk/ExsgXaJmJLk3xL/cDFFrNw7k32hqZvyjoe2L9u4zeZuJmPOyChCxJubjNxn6hgu2bjNx0v8A5gJNkKSv4iL4GEe7GOY/3UQHiDS9WHtUUIdRoJcN6c6FSPWq6bNVgnzUwRRMx49Syp9gMyaCCEaDUGNyfUug0c6CJLuVKrVm9aas3grRZc+w6H76qiiXC3kp0vRm0uX6A1TeIXl+QNT7LqF/K5fpvyBhN/xz/swLmxvT/8QAJBEAAgEDBAICAwAAAAAAAAAAAAERAhAgEiEwMUBBAyJQUWD/2gAIAQERAT8Bu6khOcJV5XDOdfYu5JbP2b7C6N0pKeysb3F0NmqYJlHspGz2ind5VohyUdQNPcaexDga+oisiHbvY0waXAk5KVuafRG5QvBSjCeWpuTUxNyVtiqFUanBLG5Q6ijopX25d5PRT2fJ2RMi6s+yNiN5EJ7+A1N4NKI/goII8d1GoVRNn4VTxT5qhcVL5WLq7wVoVpnjbsqrsnJso4ngrOyIxW3E7wK7FjHG7rB2nx4wpVoI4INIqSMoIIFRlBGUDtBGMeAynNcz/Ctwak+Bn//EADYQAAEDAgQEBgEDAwMFAAAAAAEAAhEhMQMQEiAiQVFhMDJAcXKBE1BikUJSsTOCoQQjcJLB/9oACAEAAAY/Av1YleUqHNIVLb/KVWm7yqo9BK4WUF/GHuiif7StDTpWkmURqTSaA2Ws9YWlpXeUYKFfdAcyEzE1+bkgeuX2nL8uvnEIHIjUhqMUQeVDTp5rSVdYeJq8yxHaqhBmuJX42v4f/iLW17psumRKn7WjVDYsFGpadXiD3Ujh+S/Ew6jdxUkE0iiYdJunLD9k75oexX3kCQDxolYbQ08KrSH5feX4wDIKxJFiMih7I/JO+BTT0KIWB9rF9wgejl/1DxYCAnJvxXsYR+KKkATpifEHvkT0dC+ig2XSUVh/FO+aHsV9r7X+/LC0mKKuotB55feTdNKlFsktGRQ9kT+5P+BTW9SisD7WL7he6J/vcnJvxXu5H4or6HhmLr/TKHDxHknNxGQJmVqwxq7L82Pwhvlb1Re3DJBKwhp4oiE9uIyBMoOw2z3VGTVD/t1IlOD2QAZXdNYMO1jKbggybuy1DDK4qOQY1mocivxzL3VdkVAGqOabgAzHmPdHQ3VIhflxvN/S1cIkpurrRixA5kC90NIkrBwyKgSVLYlDW3Q0XKGFhCWtoFJwr0ui4YZKa7EGkkW/QZfiPn2TQ1znUqT+pXCodlSqepkouDDpHNSvKi0tsg4iJtl5VbI6WEgXUoDTdEFtk0lt1ZRGWFy4UdLSYvmFhu5kn1I4tK/Cw6qySFifBfacsH45M+ScsP8AcKrHFJhPb+2Uz5J6wvYLG9kzuUQsLu1Y09E5v7cgVgt/Z6kNTj1csT4FfacsH45M+ScsFPc9urSiG4YaSIumfJPWF8QsdYfyCcsH4p5e3VC4cIB0dcobUojpT1IKdhhvPVKcOrCvtai3TKwo5CMmkCYKOkGSbJmELsCxGupMLEx3DhiBKnmCi5w0zVYfssQaZlB/9LKlFYcf2rEa6hIWJjuHABTIVWG4/wBXqtADWjsFwtaD1iqnS0nuFqdB9wgKQOmTX8PW6PllVVWtd7hcRop0tJ7hFx0knqE1gDU4HRB7rR/T2C8rfsKKCOgVWtd7hVsMrA+6l3/kG6vtv6qrlzK8io0KrVUQuEzttnf1t1UrhGUknZTORQri/Qyc5UbaLvs0O9Kc653ytlfPSrZUysvf9Br4JzhS7O2VMrfo8KTfwaDN3qoF1UbtSCLj4rvVE74KhAb6qy7bH+q0jkhvE5XV9kNCl253f1M9PB+kSVeF2UG+UcyuMy7sqD+Vp4Gn2Kl4aWcyOS06xIVHtVD4nLbbwQFZHedAkxWbBOe/EdHZatWkHqZRh8rUBEc0C7FcJ5BN1OqyioC4f5VaA9Ag19+qdgyXUQcwmYXFwhasNxd2PNU/hQcTiUZWVs7q/jhzqBXheZTEjspLTG5zP2rS76XFK8k+6ZDRJdkffL+pXhGK9IChueplJuUzi8yPpZffYEZqCtTDrb/yFbO61t8zf8KoIblqcf55o40cIo0ZHryWkHSeiq2T01LiwY2FUWGengXV1fPyqytuJVVTKVK77+FS/FI7BWn3QYyzeWQ1lYb23nceabKapU5XVSr5X21yruKouhUG+QWsOhXnO6uh3U562O0lRAjqiX6iVQKNwUcmjLTtlDdbfKoWriUoVQPMKhVLq6rmCNldk8xtkqf4yGVVVQoWnKtlS2VfrKu9o7bJiilVWkW212UqVQEKGiVxYcjsqiD0O33VTQbNV1ZWVVClaipXbOUPA7oHI0VEVIUc1fKM+Ff6f+5eVUVKKCoOxirnCAyg5VULoFTOBkNoGUnKERmQVCqoVsrbOi5qijboaeFmcqoUlDKAr5wrZd13XbaDy2yM3RnbZOc74ZGtTkcpyrlK1GyplbLUqhUGz/KlCMvNlfYfA0eV4sofyVM4GZbh/wA7KZWV8qZQuFRzUZAZzlKZNzUoNH2ozAElcXmNhsJ8GHXUBWzqV0HhXzvlSm9rBzMKpjDAiAuHDavKf5Xk/wCVRgyPamVVRFUXE5XouSuFSqpAUm5y7quXD/Kr4/UZ3zsVCZ2qj32anUC/FgW5uVc5/wA5QKeDKrf0tqjbDjGqyDSZyjzu7Lhho7LicSic+w8SVO6B4475V2aiA9FoAZsJPMqygcvF0qafa07IF/QA50zKnYz2Wrn40laDhB0WKOIaTyzgX9Fps5VC8pyPCIhOJ2AdcoCnxPbZZdPRggoO656aSSg3ZqiYVafolFVWRw5ryyohKjp+j0VdgI5IxeMtZhE5U9S0iDqE3Vh/7KrT4OnfPLmv/8QAKRABAAICAQQBAwUBAQEAAAAAAQARITFBEFFhcYEgkaEwQLHB8FDR4f/aAAgBAAABPyH/AK1wi1wTNQnzEfKeALo/Wsa2aiKoW5H6eLlOFfa4jgw+f2A2r+IoEotXr9Vh+2j73aV+TfEVBjd1FthnMs43LuCdmZea6S9w5SVsOICI+WaG10QVbcWp3YBHxLZFLEavwvpumwc3j7Ss+g1Uiq1nHS9jDFtRY9TLhSZiUwB+EU5MYj0MdkQh7r95brwhNhjVzS5Zvt3QEIr+U70gO3iJazHlZGOPShVxuOF1CYVN1jt+m/1KE7R3Rd1TNCtCCswUjnS7eJgfM/gx0X+4h/wcTj6/zN/tgxS6pEfo7eIzpdmsTOlwCzuf/Om6bEdpZKVm6xKWpaLOj+/PxUT0UmExxeCdvp/i89GJ0n/tCYCKubzMfSmbXbj+Z+e6O6u5HZj9N59T8FGW9ankk/tPxtCcy4CXz75n8D+Ibf8A3E/0O04+v89Jq9v4gRepeJk+cxbagdl9N0ik94mK2ZIHzJ7Tmfk9F96nOQoxaNxX9uf4PPRxtfBhvt7e4QfIn46Ky86T8t0+v/WD9PULpgg0Rkb2QwClbaYDPsd4mfJTtmpX45hldHWAVqvYzMaX7dyGsUxlDPtGwm53RSDFYmFXvNBgal8csUqqBXJuNXHV4mSoWykzGgTE71iZYJYMtQqwODxDc/PlVjAZH5gMMsCt2p3EQgI5ee8Itn94IY90XmWeirMEL6MSglROyxPcBFGBaV/MZXA9vmBaAFWNQBRe7Ibmi5VX+P2HPXcz/npvrmHUmTT12QNheJACnJ6c/Vtz0z/w7630dwntcAWCdz6C7AgC1f7m0EFYt0GguzZBWGzMjDbMv505cRUn8Q5v5SuF69xQLcBM1ucQVI44jm5KjMVsvbMZrjZniHJxmwRmAGzMsgGFZotFrtCsBOKlwbtVCpP4T9zZwA2upe28GolEeE/JUNfX+Zj8XXgbsiP5l3vmf5/AikQqGdzdKy+ZPxv8z7J6CJbi6EyvJQ/MPhCpWRwkBUF18z31P26HHxTwJb7/ALl+wSUG3XfxP8bxDX1/n6F/w82fMYV03NM8outxFum3mfi/5n5nQH4hCp8/2T89/mH7OYN6IXH6wRvz0FlY4JqNE/cn9GonJCzsIOyqFTnmrRs6gQe0LhOie2Aod5wMlyIekEfMzysGIGEFedEGrlVU7bAPaC04B9o10BMnEZg5jzLtzKM9A+YMr2qaiCsbZ8w4CCvvLO8HZ2f3OL0RfcCVXGQm2c0q61rJUTg6VBmh0AqWtW4KC40EyvDbmwGvmKq8l3Dph9ZqlJRTQYCYzwCDUpDEqYQH1B2ArceIXsHZRcyaS/JDxBaFEClMaLqlGM4AKOlAWvwLgGjBQGj/ALd37C5cv9lf6o2X/wAa/ovoyzuRDnoU5lO8v9El/uXkEq4Q71RHFsv2J5DpL8v0jMP/AGGff6XH01KY1ykHsQlpTB7cItxSHFxGGgax9JZHuEp5ngZTiPAR7lTy4t7Wb/Qz9IrOGDowz+ncc5BMIPxFuscRgjH81GlqzL7nOWjcD6R8phqLY+qXA37wBzc1Pj6alSpX0Y+uhGW2EHwe03+jTuQkNvEXZXMFIjwhH8IQqd3BABSmJcLjc0liU4G4SuIElo1mBk44Xib610qVK+mpUqVK+g1Gk02bgLW08yzv+emaLLZ4x4CX4tG3l6V/DmWFkBokHPlC7xBrQRrEXAVMGeI3QIkPDcWpecRy7MpLuzH7ThjKgj4ZEQrNSpUr6UOfMvxR6n+GUAyzs9DEcztiVQ8wE9p5l/U4R9pfNKRZJm05v9oaej0GJr+tmfxccqsxgO2VQDI4oI3UCo22QxGaTzCOdHe37HmPQ2j1Zo9Sgkb6PqUaaiANSx6xN0xdL6CWriZdoyY3EVslDqWJ+D+0OpIw0RvxMK3m/qc6o3Eycy2iufmCmWKX9pQvMBnM3mxSwWq9zyCmNTMs1FLomkdv2ZNDHow2QQBt1KfN/aGwxfH10+xuYRc9pkEgjAuHQOLMupS2VCmTMMVk7R376QN4gVPoV9VSpUt2lu0H2luu04ejGbQhb93SPA8H10bdnFRXbQTzqKcWiKXeVzDlzRPY1HbFuzHxK6jxb/yDYX94VRhydO8urYcdo7g+ZZop4g5eei0v2l5ee0p3lfM8E8E9IsdpXvH3nozMcdCcRjEmgmh52wtuUeH9arUOxQSuR2VLe0FUH0COoxAmPCKyDiMzBeuc5RTX5lu8ijmmZ/VFtUZLUkFblgs1GDF6cTkC7cK/mmC4qri3EyvidEVnIafc9GWzUvCTyktHslO/Qp2lHaY8THRj0IejeokLwvMa6vwl1ULPEMtS25xAlekGly3ee5Kd5WEcpi59/wD2P7A2Cafro1N6m7YpBDH/ALMqSyGmxHBRRxWo0H4Ey2O68TdbcaB7YCooKitupQlPc+7NRWEwxbv9Fs+Jn6/jpx0IQwGgLXiaGf4zYmfiUdMuabgxqnJ3ipA2SN5g00ldpPKHQYA3xG3/ACpWDDWtzvXtI1UOwqjOgB/uMVYbmkd6lI8uLXxKIP2ilZtfeHAyfEEYjAv4DMKiwuPUsVo2z4PP0fMx0p2Twp4ES5g+8T3T2s4RHXQhrXTNDRiUiU5yqYJgYpDYG2AU4YZoHNTJvrTAXuYubPhodQBseVcSocggOEUAHYmLmkPJUrDNaqVcHTvmKxEU47ykjZzUA21ahlAOmctArYuLpiq2WWMW37lcNJTvF3WwOhsbTcajuHoYnHQtndgKtriWl32GX7SCbYuvlxKh6dyldlqcRTuTxInwlDHovJMASwliwZxyMX57q4UCY7s8chhBZjgN3LeCy9DEGg3UPMyVFUzf0lX3eYqq7lBvbBw0RAtoiRAjua53KwNx7sKIhUQBzlBPYZjpxCMFHsxBfmkI4FeIxkbipKUlb75QubnEo6paqidBXKqLMMguX9RRtNzZm5NjzAq8ljtzTAtlx+0yj3ccy7DiNcy1onuEtMvC5cQt2qJpMpkkuu7tFchRGuSY+neEgVUsXEybYTUa8oJaXL3gN8ZieyKksx0egjFQbhBeY1CPmhgKH7EVy+O0QTEnaQfeUGrzO4niqgY7s4RsKs3M2Jtyw+hG4jXte2JmPecyjJ6BggriLhOYJGYphY73KMu0ELFbuaguoD3h5lKTGJKS4KYi7NdSsflMFriXRRntBvfiBS+ErX/iZij5lci6mMrjXXiG5wmYPEd5qIKyuWCxsNyiuCIaBeYgAVMKh4glbVswhs6TYupiy53Mhd94amZ81E8hvazL6UH5jdjcEFg8xoBaaZcqyTJuZD3lkZxHr0ZYuynqLs63ALbzKtY61iYBTxDyTEu2aAd5dAQK1oiyp8zFGcShVcaZ5mPb4lCMdXXSjOWB1NSh5oeTEC5NQReMSyOagMmYPJalGowD4suKSuvtMBr4lutJ9vLMuo7OKmtPkMwlt8wnJPEuWQXkdJthltu0QkeB5jDzKeqUuRDBpXoQbDcpIPSPABThioQ75YBBzUZUQCjSNGqGOTVuWG7tK7N9TqXX3iBDR7QXFZUqSwhplnNAXMBiMyiLx4hdARKLcz8yq4EHzUtxDyWYC1zNVXieCaIU4g8ohVOIyxPuZgS7yX4jaLKt3PRu5bJp1MRWooEWtNw12S1RMrKV9IlNN3AttVLXrTmFg8c9468jKvyJjLBAjZXUgBgynRNlvvAcsO4RbG9fCovqZNXecjMVliWMrbPEuLgqJCCXSDgyRFqKiYvI5S7kTzcoBkx4gmo/PHaYAoVL5hDK4qIcrT2yrbW5UCLU6+JmXTceWbBo+ZplPmJctS9LlgjwJTI+ISzvuZxdTW6kQR4gKYzH0ul6pQ9KPvTlaswM7Eut4J5IU16C4y5FWaPMu/UQ4zBVuAgUpouKGrCPHETWJwlDGqql2EfDEjLZLlZn3jdMdp5ZRbioQ5uWjN2l6HrOhUqOOhUrrVpHzOSv3BTJT3i2ws54WxjDfmZsl2z0lXYqHAHRt9rMxFNchmEF55+8z4vUnefdLJe7uYcLoe0FhsxQMYg1Cti8HGY5HLvMg6IB1IjcZ0M1qNI+KKL3kWAjY5NTNcdzwVBGK+UDW2rKJUrpVvHQCVK6VKlSo9khraHsyxyRgO/+I8F/ab7JQpztiLA2vhC3BS5W8yziui9SrmWkbPxdiGhyghXahDClVBybbjygEVzLe8uP6BUVAxbSRf1gAlYldcquOYFeuh+hXQ6zNack7ahwjGu+8GrAUvMtjAVqZrUsreHQ+Znzwk1beWDN4PtK8OTVxKL8TX/zRtpfVj0VKrohGKuJ/XxE39FRV7kAY6P6LHRUUrLrCPHCwRnZnpiy6cb4YWYdlqFinNOZmhCOkHMRwh/aD2OZf0uJuMVhB0qzoyykyHdb9S5Dmtcn0ZeZAGunP6XmKK9S3bGNDW5bmAQVGbAFrM9qxLLi+l5gyvKWltMRgHPMXiXFh0CJc2lXNYR2M9wwRyhmqDCLgl9EOfn4lXuu+p9R9DqZaOlhK3gfeWTZ6lOPR0RYoFuc6nXVc8gKgBS0EuHedzJVL6nQ6J0Dwy6YXKbOXTmJZBCm3mdopCOPqepDryTLE72YRTaZShD7SlGGp/qgimU9Qt9mHQih7xDtCEeZgB0IQ6EOh5lRNUkAKCuvM/1/VzF6MuH0bLFgbNRxDcFmrlEcKGfSYOzRGgUywgRZ28SyBxB0ckHIcQywhNuh0PouMqVKlSvqevboTnoy6tOEwbtXBExtNzakpuw2DLRE2alnneCoHkJ5ehk2xZlZggdR1Olw+gwda+hwXUMCJ7E9IbdbuZnn69ZZAcQzcY3Zzu4xJmJaKj//2gAMAwAAARECEQAAEAwxzWFQRRwwhSwdANXQcCoAwwww4sWMAp3yVRQBxsLiTjNBgwwxwJ1KdEhDs3m1WCbR4AIwwwww0A/s44oM0o+B1IIkgFngwwwwwww0pzeCxDu2X9Ue4wwwwwwwwwwwoeQy3MhQ/Ci+gwwwww5wghgw6NRwIsA+E3UoQwwww/nv/wD+4MX+80U8MMMMOM8MeMYSLb7/APeCw0wGAWOCOCeyu2CqK6i+3++e04fuJ1si+6GYyWOuEkG2+qk8JBFsysfUKS84imGCgsWsnL0ouKQ/xd0D8Y8+gY0YsgcQInM4oJBpUTJxcMMI6u8IcEc+quk0RT4He7KFniKSuiMZ8AKw+keaAP3/ALZqDSc4juo/7xBITdcc/lbT2xC0nR0Oj+3pzxFBdi/IEhJF2qfKpUd9MHzE8pGP+sCYIApRa3w/6nN44tuwgpATsG3uvm4HO6zWWSNWIMmz7S8Ff9yu3ephpnGgxahO9C4EDJta1S6BEkW53GyR97THCJvzCmXEp8jPXRvE0vnXTGF/JNPJ3qldkLfHSPP779xbOi13WGDV5LPWIKgpJZpn+3KpVvC5aSTWxv/EACERAQEBAAICAwEBAQEAAAAAAAEAESExECAwQVFAcWHB/9oACAECEQE/EPOyMAI7hNjDMLFTmyiRIxTuSji/yfusWx3LZa7tZpYb1bj11PaIxQwzILL2iQ5FwJxkHYCbAG7cQgLHbMzpLTjkuG+oSJ2RGj7/APZgBwkVl3E2dM1OySN9d+5DbTRgzeMg1Q0A5thpwTNXhgVbqsVsFgHIZv4wo84li5vUEN4DuIAHmOR1ls5hPy9vEBice31liFt3qLR3ZvKSQfWXOQ63IIORI45SYGP0nFjiF0wgy3fhLSDVgjnUBXLBjuW9PcYiZjGb8I+/2uPeEmAN56jxoYk8/Gd3QndgN7IJIb/nbp3ISAIUzJFD9hOL0XBvnYYDdYDK098ss8gGDcu7PYZkHDaBKdt/3jBQbowfTbKQH3z0z1z+TP5t9t8Lb/GxzxKxVllnzZzlmeQ2+5vuI5hPuyZ8umrHTyeYDENkyfpck8/Mc5PVfPe3J8aSlyy6LHJfxOpmXc/EGy3IZLTSeHPHa5cMIJ5yxyDDiVgQT6Z6nmFJEDIG8eO9vcIM5lFuRl0+M5wngLPYcz1kEY6tG3wXEpR+ZTl022DMLJ8HnPYWHV3ZFm5LnZz9S43wCcX3fA0sJxAEu9+N8bl9Nsm7suC2IBlhu7LbZgEAQNy79N8btl+lkc23Uu+S3yD6/UWyJXyttnavg2PQ8EtvjfTpxHXwlvhjrxvl+HPbljiJvryz8Gy9JA1Lk79d8Df/xAAeEQEBAQADAQEBAQEAAAAAAAABABEQITEgQTBRQP/aAAgBAREBPxDn2GHocbPRDePHUl7dWXts6Rbwkct+j0uiH5HYLvttvQvsEVuk257Jln+T3vejKwP2VA9Z9Y/7K7CqQUd+mUlupBOiF4hKyNBkrkJonUNTI7MClikSkP8Alg3LJcsXtvuKAH5MLv8AE4ze7zyN52FaFoGt68Y3qLf5g7dWoNujCQJRdkUdtNTtkkoYEl7XY/q9u2dJd7wYShwdSoZI7WjdoCvMWv64WFhux9oMgCxu2zshsODD/gT53+WT8nxlljZZztv2/QRyAzhNszls4PnJ5zgvITol8IWO+DEkdcb9JPUHGQ6XbhxmyR1YkOlnLZZZZ8rq88MOEne2ZwEnH4THGS28Pws9YcG8Qb5J33BAnDgDGODbfteDC/DjbxdLVYUt22XeDs7Pztpz4t+Xm20wtkzgkuqWPkzlWZOJMOHDjerAWM2cHA4fp4NrDeNtjbl2nhIHOCW3fpcl3gO4MgnhJgZJb+T+tktlFjefAQSltSft0eBmyCWnUo9tPll2wWQWSE6l2WcBhB3drpBrFxYXXBIYPg5eEJ4eHvgOTjI4Lxll5bHGTJwXrhIOQsn+DHw9yS59EfY+od7ycY8jq//EACkQAQACAgIBAwMFAQEBAAAAAAEAESExQVFhcYGREKGxIDDB0fDhQPH/2gAIAQAAAT8Q/X8fM+//AILv/wALjFN/ukaBvkZsEugMl8REmKwEaeU6gNkYEefpaekEdMv/AFyzhM6zFQVzRq8wIFirLbLIxxkIx6xBO7nfcMxaSugWXoDTWjmo1QezYhz9n63eDcvrPpDMubwbl6tC/MsoyWlhcNmTMs7PmWVZm9eZf0dCgNBX7RjJJMB3f8Qb4qvMvFzn8ef2lv0hGfG73P6jNysIFrF4JYnAIKtF18l+8E0C2WwQ6hYeh49pgMmFIQ4cyOXLPUQU7BOAP8/mW6CslK3EjKgo4W4hUqoKyV/cDlsAWjaB8BHCo4TpFtwKc8o0F/OpkFcTV6lZM1nNMJTX9MwD6WAPYKZZQmJ3ayw2gq1HJHEv1YMaqDgIjETyYzcEVWk2f7uAPCXyOX3SOgNSqytalHtgBxiXQiIhiqEri0+is3mXlG5W6KzLSyHGYMP2Mcul6F0RaRtTlAoZRRAWH+9ph1BEU4aCa35sGhf4IqJjXanJ8QutUb+eP2ucAiNApXTDYAaI0e3qJggpYtxX2hlbELckJaTJnjTMNHQF62R0czV7EXDUfwwnvu+6Y2IaoVeb+KGajOg0YPSAGlADQowQwRIFlnxCWoxMXk/l8zGKNxU/+ZIjeh1ziKcYrblWMc4hrhmY3Y1ftN47jCjTkMdFxq9iUi27z2Nf0S9BpMnxHVRwj1yQKUBaHRdn2Y3sqW5gOr1lorWWeIFeFI8K1/fxPILTGqa/qelD/aCqHZWENvn5l6rr+mat8kJnMopKL+bYZbu5X7OBfi0FWVmz5gc7Fga0OIlPms7KP7EZVzhvUMQOKKhq5dp2BdtQg15gpbP/ADmP+zKHYZkF1wZH4i77/gRW0IROcQuKWQzsz9opZZA3q1nurhdHOZxK0/MWiXB+x/veKNWobwf9jMUF3lx94GHrHFhb0/BF2WivNYlOEX/FTEwEPUv7MHF1t5Gv4iEc4b4k+8H5nWHsMU/iIHce2+R8/mOJxl+blDT/AM4w5fkwFfkqZ/6cSjjvIVXMyHFdUKooqZ/ZYd7lHM8tEAR9YBJIThq0z7/EfEBAbITDxREwyCgyjeOfaExhTB+ZHOAzZR4zCZ2IhVYOd4+8WZXWirQhV6ogI7BBQHd+0Rj6umQ33DKxU41eHfSHzDzLFzREofSUCjDbqnOYUEwuSzd3XtF8D5N4PSKlwWnmK9Z1HzFaAMq3pZ8krILF1aZvOM9xCR63IahYTZcBZYCwK0CzDZ3qZcBBpW5TlqhlB5qYOEAW3KMOLSXk2t173C/4bo2tfGeInG6k2NVvzGSLTamLUcRtGT5mDQlI1fu8ytgmVUcO4N8Cra58rl0x3QEb3EIVgAv5fEOWAFtSGU7LftoJSCOGIpTfpdxhd5vVZ1Xp8x0Y1rMtbbfKxLdHtKxzfrqN7V6Vgl2VXJTlkjbZbqvb2iWV53Uy7u3be58/MuiDV9KQJOsDUp6Ps/xBWrx5g7A/n/dyi9Z8ytesAREUIb8s0OpGrjmB/rgpYsXkja20vmIOyyplbbWpnG66W4WaaxxFQVaFF5iXv38/MbAXg0bD+oUGvvG+LDwzigo8Qbc5f3WB9MfVQnrOLBllXZLPEsdI+8UJZV2fMv6Gb8Qb1xPUTfWsXFqvPmA1Z8y6aYwgzaIzjAdlkufNTGPWoDEurZRAPH6cTH/juaBaALVjJAq2wevGyD6MCd8fP8RpKoFXnMU2TMNI6iPaMQKhdOYlYFb6RyXmuXxFagLb3XcrkCtrB2xJZOdGNAqByXRLWzRPI4gAlRUa7Q0UALKce8sqAXjJUQUAD5faNoAyFF9+Za67gDuNGA2XDPcRruqeAjepoDYa/iHP/n1MqvLosGftEyTaAVRX2j3SqXyUfz8wHN/FAF5S6HBBRHk38v8AcXDsaYRABIxm4FahRiyYyiUQZXh9iAjIGCM2Gf8AXA6YuvCGvZfibRp0+mCrs214ysG7Nt70xFIG5XDYyynAS7pX8xXd8jnUZZSl6VH4pZNJzjziBUzuXTlf5Jd27HnxzA6BY941HVDOl2wKx/6KGdlO24RKzpGUBi/U+89Lf54wnD4Wz8MT4v5Y6fSbCNU/eUrYL18xTMGnrB2XllSzeT2iwruRPd6XM0vLb5dYMOm7XuxH+3TPZfiuVtND1pNNxX5EzBgLH1IeQIKMr2QqO1uSiOGA0GbtP+R5QNB7R8wExgo/u/8A0anUjZ42ytxsaqBHxgheb7czY0+wy6uQKZzmIetyLG2ZMNo1gRbIn4mQvo7XgmYFinJ5JkIqBu26+7LBryycjka9ZVzSPldUl/EV1D0HCJGEq4bNs3CTs9zsMZIseQ3uYKov0AxTCvVTmznmActb5N2QiwQtbH7Sr01+LorzCyxyvHrWYawWM1aEJZxspAb8lPv+9f7dgLRzZZ954jmX1/8AIAAqAA6Uq/iGNlFKw0mLPmYRnYIHg4j7XWlCh/U7jRo8ygGBBaZpiRU7OLZo5EQNkKeXuU0B2Du8O/vFajT/AGARartiLsdypNC6XXAcSxlCVRH/ALL+JZRHQr0uYDPdQZ8waF1aijjcc4yhDO4Rcexyuy8nsx4twcehP7ve4R+K8cxEiEPQ+D9vmvpce5fr8y/X5l+vzL9fmX6/MHPPz+rm+ZWfp8fEx1NyvB7FQAKAA0dfSp/tfSjolc6e4l8D6/mbbcs9oYK+n4/eo07OYtjK/YNy/wBDie6e6e6Df/gcT3Qb/YWmpjsl+YKZMM/NT9owwb/RUqVKlSvrUr9upqXKS5frLJSDGXKN/JOCX4JdeIbvlslpYG/tAOkf2VUp9czMzMzMybly81n4l9T4+Zc9rl/W5cv1lzWeJZLuA0PVizm9bnI+K4vDDh1Fte5PGHpFuXtF1/NBHp9pftPv6/RDkLwy+1VxCQL6v2jT6XLmO4J0WTxVELoUW2zAdryky5TBWBZQib5MQ1lxiEA08pK3yXiUCSWCtnNTXbiAch7x7j2jw+pUeQfKx4Q+8VoegiuW3wxFaHu5RsHxf0v1gstj9bDiXcz0zcSEUUcJK52Gubiy7N/p9j9A1LuYPc0iO2IKD1lLWyWrWD0gHMoaK6MEluQNDKGs5WWw64jyqJccfvDGmjCHkfeXhclKuEzbYzgAAjp7lbbeZV8JTmVEZX0a/WqVPWXwlQa4jFUzLrMdlwRpk8wsjR7QMCtnhBPZLlxa39faBccRG9wagLNIHlim05N5igneXuGWHPMSFIpKJQHp6BLXcC78Sir2Nyq3NvMcoC1GalR7F4nHhyk0dnvuYMVebjJkcKgQY9nuU6gM2uJccv0ln+Igykp19FJWL9OYvmgoD1Gxwj7ygAWwyx6P4iDkEVXy8wNBHcc+HyxZaE9IlsgcmItye8ptmsatg7j0CxzW4nLDZeK9oJYrR8TMNrwnMywsyYlhE4k68TKhAqg1EPIrJNUwpdQFFbWAgV8odAydsytpKi3YVK7ZneZWZUr6Pcu5cuH7Go7IwPnlk9oh3KETic+LuM6Fasl429YUZUDiVEsdQgWxkJC9r5IEFURw1zDklxglGCYIdRYjyTPzC9XoVAmhcVivHMAOBsgmqD6DCWrDymkUvRiXNlxcmhi9WOJ6a+tTcqv27nEdTL15wekN+sTcQVQfDA5lD3KlfSoMMYH1JT1tOtxjl6+CUjo5NTUXGipyGohvcoxk6jgsWNZQJkID4gm1vNStaA6lhlvqZ8kdFTVNOvpUvP1r6MNfSs/pqdOLmF9MdT72aHpEWJTxDYzH3EBCVzePMoSw5Y7+lfRe9QWV63UY5w5IXCyzv0h1ThAeypWNTXUr4jkefMVcZ9ZVva+Yl6TWMzFV8up8cEvkAekuavDFRdh+/wBa/VU19KlT2jd6nEdqdxt6qXBb8MePScswcIcQDrHiHzXioRejxzv+JaitbvBjP6LjUAQsfDBpVPccRbylLHLP4goJfEECnCZjbPyuXUYOpW5GJysoMssOnFMK0KGYrKRGG9Q9oWglpZ6TZhzxKOoRGZX0v60H6L+lwfpmVK44Jv5gvE0zVxa9I51E6l0rKvqEnaAO1l8GrLW1uAN1lbf6Ujpjpfe/lOPSHhwYoZY0Ru8jipkGGYCWuzI6g1bkTVYJaBfEZSC6wNwLsBm2Em3S3UPBLdHEvmilsFO7zNAYyYjmUKLXcbx4+lS0qA8xF4n5lQS4JeXh2J1r4iTMeE+ZqGD3lvazq7uNY8kVZmSs0xydhaBi3X2j51edGIxCiQX19Of0eO8SwYlzquP5jKthZqHFGnmLVC5TbL+QmCrEAMjHPcSMmhqbrf8AUHUkvMPFq+YCnnBrP88yggsUL++JQM61Vujx3mbwqTKlkbPGZWDt8GGhbCsaYRYg0W9QbkvxC7Sy03nhqHM1PRhzO554D5Mo0PeUIOpT2h0j2nS+yNzc93LelHNqzPabD5h886gz4qae8yLFZOHVSySuW1u8n9QK2VBUhBAzLmZUqVxKPeEK5IiCBkytj8MU+CVUtqzb9yPvLaU1VftM6RrSghAUatpQedj/AKocl6ehjebedMp996CXdHvutRHkt2PjeMekZkaKWgBWERhDzix7VxA0wlQ4cviGtfbMLM1WpYvbQVa8EMgVDa9VAhkNMjbOXxG0F9FbOnmpbd2qjgo/hmTlZZpPqyzge88ZO74IJtHp9AcyZRxfrDrfEr4VKf8AxKvitT0JtXEBBxiaO6YNX1HVQIMM65h2wREt/rN8B2r4lILwKq4mpBUYZu03UZluX8uoaFg6Z2g9YI37U7JOtYz5SAsUWvaKgCupWqLlpNLjIH47lGGFId9gai9IjpbKvljBGU3F2hojklMF0N8L4cx2nAvkZ2e8EBjuxfeNqDGb/wCUwADYhZi0gEU2BV0bmICk3eoLHNFo8koDFHBMAHfmDqcnOcdvMvaWra75/wB6yzwyzz8TWrfUljVY9Jb/AGjh15lPdyokDxMTHU8/QlWlj9GHsj1DDFUAyyudhgWj1eZiCvlKRAczww/KGZZhOE4+WYS9OYrX2mZJXWg6Tn1gvArLy8dy+Cr+JFDI9iA275DLGz2y9oiygFum5rh9vQPTuVNzylejqC6jAbu2leeiGNqtupRwUyjaRqJVU371A9VKE1d0HrG18lt8woU1QaTPBQ1jEyUcZ6gVLZUGgLftF4FMDjpDz1YVvogYtsc0cDv+4gdEGNO1mBxapfKvmPeA9YiZ+SIb+SJ83oS+y+NzCIG6KNw9UnUfXNirnGy8LHj9Bui5lwYJ94CkcavFv/I2IEMgkM0gYiqKus4lOYqZGGUAKKgdKLQ9zJGK1EVlMK7JmZ6jC1oWqL5LmNUdqmGUfPCe8Ls1lCPNsIhI2KrBdaItoNXjRFdLii34jiYSdornMrquopdHvLi1DxMVig2S82yqBZBB2kqCQtuAU/1SsWEFYAiGSL95e3R1iOXSq6gkNviDVF4gmegMchc3eBFAHQ32w4dpg1YweCWjmueoZSXfD1DAl0xZBIRdazNYUlDOVyuIvLzEsV0Rsa1CV2qAO6iIAplcxcVmCBYyOLwylHryzTI5ktecrWMzXjMnGPScMniC8HmJbH3i9N/iMuuRrmO6VLxiW7t41FjgXF69Db49PFRaJUl2jfiDZtpDK8ekqE9W5HsjW7BjP5ipRseoq1eGoaxax1Nhxr3hQpJnDqGmS48+WHxINe3L8xDuQcVLmqiguS4IjI8NQ1Blg6lws/CTPsUZYpAvzUpQbug1G9JtPaAshND0gUDg5lAJH5JvY/AS0PTtgPWQY1E5CMcvtNvyQYP9xMt4I7xV7Tlf+TeKaS6j3Nci/wD5KEqYu8/MQFVWt3CXDsJsuFZrBDhcSycK1+uYTAWi9QJgWLuBFBD5gkKEzbzKSiR/mAIP/wB5/MbJrnMPDfDcLjBtE0R5Ya4aWLQ1vzFS5sbK1FVGqlNOviY+A5vuIjkZ+ZVpYMCqcC7xxEcRNPoyl7Fye5HMIboZ0iZTmIjgQ94w5iFhipppBRG55NNy0EctsWlS8L1LgUD3ZUige6OrAc4gZ06PESqUrAGZi/Ey5oOwhfrLEvN8M1rmoTusQOJNvtMLOHccMrnEa6rODcTCO9FxSsnFWj2QjqCq3jz5gEW0o4UoQPDqKC8DjuI22UzfmU5imYhbExmX1gVA8amjdd2XMTVyXqhl7FVY3KUBXWfiAOBXqgSMirHL05glCKQaJkOOtl2RlKLd5gm1CxhlujNxgG0GYANj7st6xNGdr6xtllT0h2fkEZgB0iNYC8BomjDnO4J2VzDoyVUNwYQN205i1ANAurlNwa6RQoHVHUUgomPMAA07cfCLU6Mxc2VVvmWhWrHhLjqO54itd1UKRdWX8xAbwD4gKW6UczNROWyeI4DpCZ3gF5HzLtuWEdShSXOFIG+JsWd1Kcj0NRoA27jqqIccxgwi7aI4Bh5MaNNuOI6zLRV1LYyMC35xMwANf2iigjFuvSKBlOK/ERRNkzn1mm2azVTXGO6isUO1wLUBh8wqsFuc3WPxNwwbGWBiUCmKzCYoc4NCWN5XUQ4usgRACu0pwQq6HmWUAc3eJYCn5RVorbUPd1qGBWOWRe5xxFwozbXMRsrZGOc67EBwo3n6e5MHAoPNxtkolm2GA34iOl8M4GMFG9eYUAlNohaXbdeIZU0ItaildKwsqno1uBwRqsVUYPYHuE4TV08xSLLVAMETc61hqEuY9NQ52VXmo1RwbuKtA6ssxZzhHxLKKW6bQkEBVMRuoxrM0mDgpuINLkcDMnAGWuY5WKBiBYYTBcpDlQK8MorU2u4QhatHEeBgDeyLZKHuGpQLzFZhdrWZQCIbazL2Quw5iBVWmyK2nYhkQNIcEIZY4jdEwwVLNrmBqOVZyVCXbkoyMSOG/Et7YhI00o/+pXQo5YjtXXXM0wJTw9MbvBrmPjEqX8xDYpz5jaKAs4QsIFMzf/wiC00BrMcqqNJEow9RuWZGDqXCaGpbIAYeIigXOW5Q2FEyJqCgohorcoNKriZWlvcqoNHEsGb+I2wzbbBLEXS8xQxNXYYkO0XkzYZxDrzHURoHZ3DxLS/SKHDVesUFdnEJ0GKQNOzDEQIv4lpgMBy9xJabMsy5TtOYSoPdLZQpCsAri8RXubPVLS0LV8wgCw4vmfeO+oMS4wgcfPjqUCtTYHXkGCGoVO29szHjxxGLsXlF8X6rVQwTQy4mAOXXco1RNwg7LO9wXqZxcNRRwxm27luyOF3b8xSjLV8EW4Qx50whCWDBqo3kpUNdUvtwFdGASx5FQ0FfRzNiuBbPvKIFvBx7y2uFLctVA5JmM0mlz8O5ZrRdu17mH0ggad2mGqbLicaKKEdRwixXTEQbhrL8Q2FTGsrHZbtlQQqUyJqVgOWgrcDYtenmXZvnYqW/FgGVV+IGpopbQcxI7Sw4IOJM0cbZd9EHaAxjmFJmTaXI+CiJVBV3IcShKRYKx/6AmURON1MUDrcemv5iFotsW6cRdCqoQTKhzBUVoeYNRd1qGr+azNkjNdw0KIWToa9JarNwvEF7gqFkb0ahHkbHzGoihbpRD/aVnU6ZpR+UJXtXywCC+a7iK19bE8zni+IXgy8Qlt2lkLEyOZV4TE13g1B4teIYbZecmFRb88IDYBiiJJz58ymuHmtyrYrd+UwqvRAqImu24MwWMqwVHy52qAFvpLlQJeVz9pl2OrqK6x5/zC7bVYI/W1vvKxrTVgRe1xtu/JCOAbFPzBkRua/irnZOx8vyzDBcyzDxCtjWBxUO22WSbgQYeEAQUM1AUmmZnRHnDLdhPViuPhnBDK6irVNnDAq/aAlCXlUYF/LmQ0dGFsDWRuNdlwTITdrKWMPdTTOYeEpqC5k/eeCejCnEYojHKOOhljhK0mIcCq53AhXL/gl7MimrAXK3ZZWyMFjeCElgiw4OoiQuxyDDLlZRFc6m4BebgGaPSCHN15iVYbU6PeCCK9FLy6BOYDUa5apxzLQ4rEqEzWbgGocnkdkVlZmI9+TIrlMWyq/WKVpcRu6eYqu2A7vEIut4JVxj3gBVnwkZlnrOI0qukoCY7lTA95hAIUesShvsIYKxoEABgzFJc39KzK0RLIzZ1WHDzCQ0aQmmOIBt7gbAB9JUtSzGI10W+GKVnapnSL9ePrB/LN8PtxLMeTgJbjHmyeYWXB1k+WMVYsjty8hSmx7VGxKsLTGj6rg6OuV2Yu2U3XEs8/apd4ZiDE0x7wLmDthQeWDU7IqWC4heuPIq5U6l3msxrVSviJL795mAttrRK1avXco6cPcGcQNxKzNxhma3Kpuce0y7mCb5lBlq+oPYGX8S1vHB5vEaaLLrqVYnsTA5zW4MVoEQcnnw3zDZkrJ477vuKpOruXFyyhg8czM6mXpKxbA9QP7lNaOQ8yxpAbB3FVZQWWMXiCQjnEGU8tx+Cp4GJqYGio6ks9yVAOxcpVqh55goCrKrA5F5IlfTi+JcVara4IYG3LzAori5cLWiXzHmOLm8wMbO5u+1l8RcQyvhiY242EOl0i37y6UNhe4hi9huMEuswrzUCAKAZgTG7pd4qFwZVZZbg0ZuYAK2XBrAWzGbW/5ng4+pjgZNn7zDlLxYLgU1mWnMpVUUWphTl0x4CGq6g5ZQwEQdjW3+IN7ACwutvOo7OkNNMRnFVxLrxcVos/8ArCS3YV5WG5beJhwwXmPiLV+v8RamyuYK4NTibv1i4rvEoG2uIasmZYacxwGVllWOK6/7horphOqN8I9XV4vqCPB8c8V5ljL5XB16wAc5PtDVcE6xAbsIKgEAuqIFYjLhiZCbWiEXAuBKhjMUM/TWOBmPRKUYzgWFSsKncurvPOSWWwavWoVGc7lhHUbCOmw55iCjbtdsCq0V4hCESJr2izaHbGoPdzKO5oubLzGhDACZL3K6EKtG4YEezOLhu1Z6S3ZC2JV2V5eJRKaMVTSeYzLbKQtVdm4+NzJnEFiw0HljWoZAcMw+S9OCeiJazNJtKTn6g5dVKOhZE3gjFYL5lVQ6Ii55+n2SxxkPuhn0lH0qcHrLy6+it15i1FNePpWocxZ7Rqb4SpKo6+qRwX3Lqo2PCzmDTmUJt/8AYbYWDSTOAgX1KjGBRVuB+D7yqFgKlRjmJd8sSuoBi45sUm/oNFTb6BhAz9BBDE4gwiyvrLEyPpZPLENYmYRzqvaYuuKilZla9JmrbqX+JhN0lbbNR0npMvkqvpL3Ie5dl4QBhviZuT1FbNV1XEQJyA/JGMfHldkHiCX4PEYQFsION1EVm8Kll2FRa1SANo3KGRuE5NTAfQIMwkD6JxDEcIsvpDuN20y1zmiWyFfQ15YcJVW6qbNnxLqsXmj1ljT1DS+EW4eNYxYX95W86lPkxBKcHzLxLt9ozGpdU+JdxJIdFAuuY7eUY0UyzgzxELS2za3c5MQnCmTlpcJP/9k="},
-			want:    "",
-			wantErr: true,
+			want:    "image/jpg",
+			wantErr: false,
 		},
 		{
-			name:    "incorrect mime type",
+			name:    "jpeg declared as png",
 			args:    args{b64: "data:image/png;base64,/9j/4AAQSkZJRgABAgAAAQABAAD/7QCcUGhvdG9zaG9wIDMuMAA4QklNBAQAAAAAAIAcAmcAFEJDamk0N3gteHg5UHNqQXFVWkRIHAIoAGJGQk1EMDEwMDBhYmYwMzAwMDA5OTBhMDAwMDAzMTQwMDAwZTAxNTAwMDBlZjE2MDAwMGE3MWQwMDAwY2EyYjAwMDBhZTJkMDAwMGUzMmYwMDAwZDYzMTAwMDA2YzRkMDAwMP/iAhxJQ0NfUFJPRklMRQABAQAAAgxsY21zAhAAAG1udHJSR0IgWFlaIAfcAAEAGQADACkAOWFjc3BBUFBMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD21gABAAAAANMtbGNtcwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACmRlc2MAAAD8AAAAXmNwcnQAAAFcAAAAC3d0cHQAAAFoAAAAFGJrcHQAAAF8AAAAFHJYWVoAAAGQAAAAFGdYWVoAAAGkAAAAFGJYWVoAAAG4AAAAFHJUUkMAAAHMAAAAQGdUUkMAAAHMAAAAQGJUUkMAAAHMAAAAQGRlc2MAAAAAAAAAA2MyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAHRleHQAAAAARkIAAFhZWiAAAAAAAAD21gABAAAAANMtWFlaIAAAAAAAAAMWAAADMwAAAqRYWVogAAAAAAAAb6IAADj1AAADkFhZWiAAAAAAAABimQAAt4UAABjaWFlaIAAAAAAAACSgAAAPhAAAts9jdXJ2AAAAAAAAABoAAADLAckDYwWSCGsL9hA/FVEbNCHxKZAyGDuSRgVRd13ta3B6BYmxmnysab9908PpMP///9sAQwAJBgcIBwYJCAgICgoJCw4XDw4NDQ4cFBURFyIeIyMhHiAgJSo1LSUnMiggIC4/LzI3OTw8PCQtQkZBOkY1Ozw5/9sAQwEKCgoODA4bDw8bOSYgJjk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5/8IAEQgBgAGAAwAiAAERAQIRAf/EABoAAAIDAQEAAAAAAAAAAAAAAAABAgMEBQb/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/2gAMAwAAARECEQAAAZAZgAAAEM067DLpGCvNgACGZNc6AF5gABjm9gFwAWAKaYCAJGIVgAABCE6XAXAAyAAAAAAC5HX4/H6u+zPuayxqmtc69c3LNdC88vQ5+7PTLtnh1xnrxbtc3yOvyE7OGdE7a7a7Oni5lufbw+jbQnrEYUapqTLLnNq5/YucdeTbnpY4RuaNGP0FzlA6/LAEAAAAAXI6ufl9OUdFk3k2492ufF2w057547clzj3Q0Z0+d1cu+G2uvVrhn5PWyZ6T1FLpcrqd+LkbsXQ4fVrXRw75Yde/CF0NCcvqZOjefA3GjPeuvbXccnvczpXlSB0+eAAAAAAAmKJghghgmAmCphYJkCYJgIHVeitZ6NOFzJpomAEXKwLkTBDAAQAAAAAAAAAAAABa6LI8/dOieLPbboydDXGvHs5pdswXzqraVClmsa003wsMkjO+pjsq1yusya9+Xn9DF1M9qGHTwAAAAAAAAAAAAAAAAAALgupOP1rsOvJN69/K6euEeb0uWlllPVnoybMWm8OZbRLHq31ui4qutymospuOhVGXX51HQ5G2dGBrygAAAAAAAACGIViBiBgIACvpGrq4haVELPpHbVmQ4uyoqx1ERknO9sImuFtaC6kELagAEAAAAAACEqLVYAAAAAASiEgEABDBMAAAAAAAAAAAAAATABAwABDBDIxWtwVaHnnFoAAAAAAxBIAQykMESQEMcbzj113Dk2R0iqwZCJaUI0GaJrWOBtjkDWsrL1UFigEnWywCk0yyzHfFomAAAAAMSGs/nq9Hi49tbMjkmN6WtN1FlhC2gOhyHL6V8nr5RJBEkyBIEpMgTIipqkMEMJgqGmRrshFujn2GuNES9UsmkUozUcvOnqWutUXdTt4341+zM78Pn93zE8nZ0su85YEbmfe893zcBkJggAAAAAAAAAkmVGUZCjKJCFkSyRKIlkBDVABxJzwVL1vD9bz66LZTz0jCxRmp1xXFi7OVnyHH9dyOnLi9jn79Y7AAAxAAmQmFIGIaGkyQIJKRGLiEJIsvp156GSdbLEayAFXE78l19SOXn365gslvM9C7qeCWdbTgczrw9Fnkc/qORvnYg1hgAAAAhkIYIkVEmyCaHKMiMJRIgRvla+fowV21dOIxXINBsx6pZ1a+fnpTpx2t9nHbgzd0+TCzs15pptt84rPQYcXQZzPp4ripzLIOTIkgi2CJMgrCq1azGmhyjIjFkR36t03QTeOvHr6E9Y5T6OLXKBMI3VjXU4/R0Y68e/oi6uP14zWOGW1Z5uhtZjl05jPl2cOas6aWsRcjfniSQhoQIZCJaZkanhY4tVJxkQ24uhL06aObjt2NXnutFXL6vMXq5M+rXOA1rm9WOa9XNvhz78rTTzm+5TwLrnd0uba31s1lecVxlWsOP2dC5OeZ9+e+uMLnTGqFk4QsIyqZKIlhMikgDYEalKEyPW5XXmudwu5z8d+H1sE7j0GjzfopuvOp645paHcZbbVb6PKVc/RXbTXWvT5SS+py02Jbs59+bCECSVmDLrOO+BrjFES2ItSUrKpUghSFRKLixORIDUJwsIdTm787zuOrPXDq28uUprjrFN+bRrm7a5kQnFu/k3TWjJotnbFKF7U9qukxxuysXU2ELnZV05XoEdTikrK9FSUZRGqYKyuQnZWsbKJlwGsubnLLTuwZ6Z68lLfY51ymIhDWIa8W2xlUxlNiMrgvTObbnt1L+bXNdjPyGbcdlszowZeNvCtrl05dDTytGbtoqnLfc1Fme6sTjYtFjiiTZVOyqtEJZbnuc/uk3Tk6MprkWdW5OPh1ZmVXZWZ9+LXZKFZVcriIyxZqjs5qt6FVscdKr6Mx3OTmt1iEprXOKshStJkSaqLZE7cyl6VnIuy2WRtWp2RIqcCoo2anr6Ml0TOPxK9t5rzt53ZZpYt+fAaaXiVk4CRVWV1TON0OyFtGrLzmujFzSBIsiOEKxsBlCaGgAGRU4xBmeOvo4/cmohKORCUOmd1WblytDlO5w/R5beR1/NFsoLeZkGTFIhG6ohKTC2EjNux9esKrsBFRKULRiCSAcXGACmxAAU5rISr1fmexlpJRl5FlUumceHVmlBqWfq/M6Y3clOyxwlU5RmkpQnTTZVKxRCM0AIQ2QlMpMAaYJIaFDlF1JAJESuUKI0xpnHpdHM3zXnozjvOKsWaTrkoRmSsjMlKMknIdhOLqTQScWJNAphBWIi2CZslxx2UzVA46wA4UkVKJEeWxmbROETRKDucKxf/EAC4QAAICAQMCBAYCAwEBAAAAAAECAAMRBBIhEBMgIjEzFDAyNEBBBSMkQ1BCYP/aAAgBAAABBQL/AKzNtXvid7BBz4++J6eLvz0/AY7RUzWn5q/Vrveo5or3320sWZ3cM5tUVkmlDZbZ6W61mW5rGxee1H3rUpysb3NY+yzz9ms7ljO4a3uoVP8AXWXvel2Y2sys+4UVbmqpL22bGfU32bGs7lRsz29NZZYdzh6S1l/y1Pmubv3WhadLpXFdunDfEaji7WxfsNF9z/t1/uafHwurffc776qQfhY3ufyB/wAjf/j6UHsyz69d7oP+FovcrO19YMXW/ZaT2F/rup95z59Z9a/ZaH3bfrLdu35a/Xrzm4LnR6D7g6mwG73NbF+w0X3P+3X+7R9nf7iOyacXPcsb3Nf9xpmK6UXvZ0t+vX+6B/g6L3Khus133Fv2Wk9i7izT8aSzh9b9ajH8foPdt+u76/lNnaNJqJqqbXurptTT112JZVSysaLrm1NNpiU2rpqab9/wt+7UU322VVXJRbXvAr1D1sopqg0t7zV1lmrTUCsIKKZZ9b6e+2yxVSumu1W02nOnltT2s+mvFdFFyV21s7Oprptq3H4a+99Tyum019dh019hdbAP+AunqMYKB/0dyiZB8BYCA5/Jdgg7rYR9478stKMM7T6d+d/mdwmK+5RdkvbtZ3ZF78rt3m8AWYAq7pMR94mo+qtcab8iyvfHZF0+iGW/2a37j/TKff1R/vuP9FBA0+k5NXv6v39V7Gm9jSnF+p4s1B/o07AVaPlpqYw21fkag8rxodB9f+zXfcf6ZR7+q9+4f4+kRGRWqSVfcav7jVexpvYo97Wfcan2NIiMFapDLVLjUe7+RqByrE0aI4sPD6hizht1MrO22wm27VV4XT2CuvTV7oMo95JfUHOnqs21aCvddqGzZZiyjTuKxp0DmXk5RCKfyTadi2lQrbSbCWawsD6Jprg296m9YDiWWPZEbYe4S1++6aemxIbWKI22M5aA4llrWdBjNljWH/tA/wDz+4dMzP8AxMiblm9Zvm6bjMnxj8MsojaqoT42fGWGfGGDWJEdX6bhN4ncE7k7hm9puPz84gOfm5AjX1rG1cbVPGd4ct0VczExDA20pqM/j+hVs/KzLrQle8tDmKpg5JGYFmBN0DZmRGhnrNNfj8Y9EfrkTeJvm8zJ66g5IWfr9TDYKWNOy8+HaYIgOJu6+s0zbq/xjFfEPPyHP9m6bmgyx0+iJg0wx8OBO0I1IlukzG0ZjUMsPBME0mNn45g9PHaP7OFVd1r6TTJX0AmIRDMQrmWVjGpqEI6aH8T9eAeiqWJUr4tUI75H8dVmViAeAzExLEltUspjpiaD1/D/AF4U4Fjbk8LqHXtkNpatlacQdMzdMx7kWfErkOGhUGWUS+iaIYP5GMm0bVOQviorDaixkSd9ILQZ3IGjOFluocyum14KRMFWEIliwJtf5mJiYm3qPCJR9THMfO7xaT3eybWbRGdm9JU5MAJl1ZErWumNrFEGo7hsZ6gl9W7v1GFgZYOcTExMTE2zbMTAmBOJxMzMz8keu3YiiWcv4tPneD20fU2bU1dsDswFV7q2SdrM1tN25aS1dtqdkaXE+Btedh9OR/YRXXDwc9OZgzBm2bZtm0THyP11o05yatx7PD0WJO2+MTZNs2zE0rL8RZQLFbSiLpqxGoRYv1XV41O3y7DNsOm7pZBWrQwqxS2s1O3r+D+p6mmgJNwhYwEyy1g4v8z6cheZzMGbTO2crc4c2KZlFXT/ANsHrcu5P7Cyq8TEFgjtu6WDy1WFjenn8eZuE3rO4sNyzvQ34gtJ8WlHn4nci2ZMv5gs81VuAfBp/V90Ondo9SKBeu3vZlusrrl+oRhW2ZmHHVjgaal1fVNsHdOO4dosYwswmWm+bpnkw8QmMYG4zkBseLTelzNG1AEq1O6JaJawzqtOM0jHXIm4Sm1d4ljRvM9lGXA1G/4UMw0qCIdresIjcTM/Zzm9t1v1JmekOYx4T14xzGnrPSETGZwJtLeLT8V3G1paLIc509nlLZW3L1PgVnODum0mAGKCjKeHbkGBYF5welo8tL9LD0DgG9+3X+7OFznp6xsYUcE4gMHJJOcHAnpOIvX9dD5amzm4ZjpK1sWKzbqWwjvut4mDnpiUWbkeAbZdqVqh/kLM16vUsUuvwLQSo2zdwelv0sWaEQz9geZmJiqTBwGxnoOZ5ocmDmbdx9D4P3aehpBFlOB/52VxoOLDkkMZztXLRsg1HYSZZkxlCTNcNlaysm0dtduSs3QzPOoGK84H7xCMx+CTwsOIo3BgI0HpPWbgJnn1PgUZa71URTmEAjcAu7dGDTneGMxiBwZnEzkAmZOEzGhoDFNGkFIEHpZ6DEzAPN/Iajfatm8HkE4gJyw8/wCwFhBMP0pDyW9Fz0wB0BwfBQD3bPUmCwAtd5Sd0zgbufWwDwGZmdpDrgmLiAiFsTuR3zBu3KONTqRUPU1ttKcwLgcGftRiZBn0hcGZ2xYfUPwfpD8esHDdEUuwXEXC12GPugRjBXhQMCcYqOGzDnOQs3jLnAUnBJz5WldsL8G7EN2Z3uBFmo1ngVysFzTuZissDCY5PlAMciZ2r6hVyP3YIOJnzRjtGNlHc33Z8rDM7SzZk2oK6piWfTXkwenEIHVVhEuyLKLA5cFTumTEMa1K1tveyAeADw5MFrCd0QFZkQnMU4H75nJm09Pdtu03ctFVVc8s2pAig8zVOGt6NyKjiYONvLtghlMZkAOqAjauwwkt0DdxcQ8Q2HOIB4PWAfJWwrFdGnE3LN6zeJvlrYmlH+RnzHpmZCjUa82uBBwTPWBgsN5hY9T0Ag6LlT3EweZt8Pr8zE3kSpw64meh5mhK97uYtaX62umP/I3MbLnsmk+oPN+B3SzFs+I9P0Oh4HLFTx4QMfMMM0zYubiY8C6pw1+vseE9dEo2dtZqWAicL48T9DpacmujKldjdSYPmtDF4b6gZjoYeJnnrpvLU7bK2O5vHjpjoeAqyrVdut2L29HaKuPnOYBMSq0POZu6VfUSdg6ou5uBNTaCq/Jx1zuPXkTkwLj5zTbMT0gcKajvTI6OxFFnCdMStu2xvNgYwfLxMY/DHQtibyZtzNgmhYYPqZc5sstPng8Y/N/TPNuYFxPTohKsly29Hyzk5aZ6DoOo/C+Fsx8PZGqsX5Bj8wJOBMwDrTZ2n//EACkRAAICAQMFAAEDBQAAAAAAAAECABEDEBIhBBMgMDEyFCJAIzNBUGD/2gAIAQIRAT8B1TCzjiPiZPsrSiY+Jl+ytFxs/wAlaUdalSoMRIuV5dD+JjgFAhjYlFzavHEXGo3NUemZbm1XybQPkzooS50nxomGluZVAycRUXjiDCFsztKHEKrsuZkC8xcQFCBAFaZtiIOIfHpGAU3O4oQTMwZt07i/t5ncWmW4XXcsXKFyzNXM6VgLuFw+PbcYjfxNwUKTFzhgwneU5BHde2eZkyg4hFzKQGi5AUadUwKjzvwvx+RsrN9ig/4nIMszaauXL9eHGpUQYE3GZ8aqgInTY1ZSTH6ZWqocCCdhN1VBiSrqLjCZOIMA5YzqE7bcTI/9Ae3GB21qAje06n+0BOk/ExsgXaJmJLk3xL/cDFFrNw7k32hqZvyjoe2L9u4zeZuJmPOyChCxJubjNxn6hgu2bjNx0v8A5gJNkKSv4iL4GEe7GOY/3UQHiDS9WHtUUIdRoJcN6c6FSPWq6bNVgnzUwRRMx49Syp9gMyaCCEaDUGNyfUug0c6CJLuVKrVm9aas3grRZc+w6H76qiiXC3kp0vRm0uX6A1TeIXl+QNT7LqF/K5fpvyBhN/xz/swLmxvT/8QAJBEAAgEDBAICAwAAAAAAAAAAAAERAhAgEiEwMUBBAyJQUWD/2gAIAQERAT8Bu6khOcJV5XDOdfYu5JbP2b7C6N0pKeysb3F0NmqYJlHspGz2ind5VohyUdQNPcaexDga+oisiHbvY0waXAk5KVuafRG5QvBSjCeWpuTUxNyVtiqFUanBLG5Q6ijopX25d5PRT2fJ2RMi6s+yNiN5EJ7+A1N4NKI/goII8d1GoVRNn4VTxT5qhcVL5WLq7wVoVpnjbsqrsnJso4ngrOyIxW3E7wK7FjHG7rB2nx4wpVoI4INIqSMoIIFRlBGUDtBGMeAynNcz/Ctwak+Bn//EADYQAAEDAgQEBgEDAwMFAAAAAAEAAhEhMQMQEiAiQVFhMDJAcXKBE1BikUJSsTOCoQQjcJLB/9oACAEAAAY/Av1YleUqHNIVLb/KVWm7yqo9BK4WUF/GHuiif7StDTpWkmURqTSaA2Ws9YWlpXeUYKFfdAcyEzE1+bkgeuX2nL8uvnEIHIjUhqMUQeVDTp5rSVdYeJq8yxHaqhBmuJX42v4f/iLW17psumRKn7WjVDYsFGpadXiD3Ujh+S/Ew6jdxUkE0iiYdJunLD9k75oexX3kCQDxolYbQ08KrSH5feX4wDIKxJFiMih7I/JO+BTT0KIWB9rF9wgejl/1DxYCAnJvxXsYR+KKkATpifEHvkT0dC+ig2XSUVh/FO+aHsV9r7X+/LC0mKKuotB55feTdNKlFsktGRQ9kT+5P+BTW9SisD7WL7he6J/vcnJvxXu5H4or6HhmLr/TKHDxHknNxGQJmVqwxq7L82Pwhvlb1Re3DJBKwhp4oiE9uIyBMoOw2z3VGTVD/t1IlOD2QAZXdNYMO1jKbggybuy1DDK4qOQY1mocivxzL3VdkVAGqOabgAzHmPdHQ3VIhflxvN/S1cIkpurrRixA5kC90NIkrBwyKgSVLYlDW3Q0XKGFhCWtoFJwr0ui4YZKa7EGkkW/QZfiPn2TQ1znUqT+pXCodlSqepkouDDpHNSvKi0tsg4iJtl5VbI6WEgXUoDTdEFtk0lt1ZRGWFy4UdLSYvmFhu5kn1I4tK/Cw6qySFifBfacsH45M+ScsP8AcKrHFJhPb+2Uz5J6wvYLG9kzuUQsLu1Y09E5v7cgVgt/Z6kNTj1csT4FfacsH45M+ScsFPc9urSiG4YaSIumfJPWF8QsdYfyCcsH4p5e3VC4cIB0dcobUojpT1IKdhhvPVKcOrCvtai3TKwo5CMmkCYKOkGSbJmELsCxGupMLEx3DhiBKnmCi5w0zVYfssQaZlB/9LKlFYcf2rEa6hIWJjuHABTIVWG4/wBXqtADWjsFwtaD1iqnS0nuFqdB9wgKQOmTX8PW6PllVVWtd7hcRop0tJ7hFx0knqE1gDU4HRB7rR/T2C8rfsKKCOgVWtd7hVsMrA+6l3/kG6vtv6qrlzK8io0KrVUQuEzttnf1t1UrhGUknZTORQri/Qyc5UbaLvs0O9Kc653ytlfPSrZUysvf9Br4JzhS7O2VMrfo8KTfwaDN3qoF1UbtSCLj4rvVE74KhAb6qy7bH+q0jkhvE5XV9kNCl253f1M9PB+kSVeF2UG+UcyuMy7sqD+Vp4Gn2Kl4aWcyOS06xIVHtVD4nLbbwQFZHedAkxWbBOe/EdHZatWkHqZRh8rUBEc0C7FcJ5BN1OqyioC4f5VaA9Ag19+qdgyXUQcwmYXFwhasNxd2PNU/hQcTiUZWVs7q/jhzqBXheZTEjspLTG5zP2rS76XFK8k+6ZDRJdkffL+pXhGK9IChueplJuUzi8yPpZffYEZqCtTDrb/yFbO61t8zf8KoIblqcf55o40cIo0ZHryWkHSeiq2T01LiwY2FUWGengXV1fPyqytuJVVTKVK77+FS/FI7BWn3QYyzeWQ1lYb23nceabKapU5XVSr5X21yruKouhUG+QWsOhXnO6uh3U562O0lRAjqiX6iVQKNwUcmjLTtlDdbfKoWriUoVQPMKhVLq6rmCNldk8xtkqf4yGVVVQoWnKtlS2VfrKu9o7bJiilVWkW212UqVQEKGiVxYcjsqiD0O33VTQbNV1ZWVVClaipXbOUPA7oHI0VEVIUc1fKM+Ff6f+5eVUVKKCoOxirnCAyg5VULoFTOBkNoGUnKERmQVCqoVsrbOi5qijboaeFmcqoUlDKAr5wrZd13XbaDy2yM3RnbZOc74ZGtTkcpyrlK1GyplbLUqhUGz/KlCMvNlfYfA0eV4sofyVM4GZbh/wA7KZWV8qZQuFRzUZAZzlKZNzUoNH2ozAElcXmNhsJ8GHXUBWzqV0HhXzvlSm9rBzMKpjDAiAuHDavKf5Xk/wCVRgyPamVVRFUXE5XouSuFSqpAUm5y7quXD/Kr4/UZ3zsVCZ2qj32anUC/FgW5uVc5/wA5QKeDKrf0tqjbDjGqyDSZyjzu7Lhho7LicSic+w8SVO6B4475V2aiA9FoAZsJPMqygcvF0qafa07IF/QA50zKnYz2Wrn40laDhB0WKOIaTyzgX9Fps5VC8pyPCIhOJ2AdcoCnxPbZZdPRggoO656aSSg3ZqiYVafolFVWRw5ryyohKjp+j0VdgI5IxeMtZhE5U9S0iDqE3Vh/7KrT4OnfPLmv/8QAKRABAAICAQQBAwUBAQEAAAAAAQARITFBEFFhcYEgkaEwQLHB8FDR4f/aAAgBAAABPyH/AK1wi1wTNQnzEfKeALo/Wsa2aiKoW5H6eLlOFfa4jgw+f2A2r+IoEotXr9Vh+2j73aV+TfEVBjd1FthnMs43LuCdmZea6S9w5SVsOICI+WaG10QVbcWp3YBHxLZFLEavwvpumwc3j7Ss+g1Uiq1nHS9jDFtRY9TLhSZiUwB+EU5MYj0MdkQh7r95brwhNhjVzS5Zvt3QEIr+U70gO3iJazHlZGOPShVxuOF1CYVN1jt+m/1KE7R3Rd1TNCtCCswUjnS7eJgfM/gx0X+4h/wcTj6/zN/tgxS6pEfo7eIzpdmsTOlwCzuf/Om6bEdpZKVm6xKWpaLOj+/PxUT0UmExxeCdvp/i89GJ0n/tCYCKubzMfSmbXbj+Z+e6O6u5HZj9N59T8FGW9ankk/tPxtCcy4CXz75n8D+Ibf8A3E/0O04+v89Jq9v4gRepeJk+cxbagdl9N0ik94mK2ZIHzJ7Tmfk9F96nOQoxaNxX9uf4PPRxtfBhvt7e4QfIn46Ky86T8t0+v/WD9PULpgg0Rkb2QwClbaYDPsd4mfJTtmpX45hldHWAVqvYzMaX7dyGsUxlDPtGwm53RSDFYmFXvNBgal8csUqqBXJuNXHV4mSoWykzGgTE71iZYJYMtQqwODxDc/PlVjAZH5gMMsCt2p3EQgI5ee8Itn94IY90XmWeirMEL6MSglROyxPcBFGBaV/MZXA9vmBaAFWNQBRe7Ibmi5VX+P2HPXcz/npvrmHUmTT12QNheJACnJ6c/Vtz0z/w7630dwntcAWCdz6C7AgC1f7m0EFYt0GguzZBWGzMjDbMv505cRUn8Q5v5SuF69xQLcBM1ucQVI44jm5KjMVsvbMZrjZniHJxmwRmAGzMsgGFZotFrtCsBOKlwbtVCpP4T9zZwA2upe28GolEeE/JUNfX+Zj8XXgbsiP5l3vmf5/AikQqGdzdKy+ZPxv8z7J6CJbi6EyvJQ/MPhCpWRwkBUF18z31P26HHxTwJb7/ALl+wSUG3XfxP8bxDX1/n6F/w82fMYV03NM8outxFum3mfi/5n5nQH4hCp8/2T89/mH7OYN6IXH6wRvz0FlY4JqNE/cn9GonJCzsIOyqFTnmrRs6gQe0LhOie2Aod5wMlyIekEfMzysGIGEFedEGrlVU7bAPaC04B9o10BMnEZg5jzLtzKM9A+YMr2qaiCsbZ8w4CCvvLO8HZ2f3OL0RfcCVXGQm2c0q61rJUTg6VBmh0AqWtW4KC40EyvDbmwGvmKq8l3Dph9ZqlJRTQYCYzwCDUpDEqYQH1B2ArceIXsHZRcyaS/JDxBaFEClMaLqlGM4AKOlAWvwLgGjBQGj/ALd37C5cv9lf6o2X/wAa/ovoyzuRDnoU5lO8v9El/uXkEq4Q71RHFsv2J5DpL8v0jMP/AGGff6XH01KY1ykHsQlpTB7cItxSHFxGGgax9JZHuEp5ngZTiPAR7lTy4t7Wb/Qz9IrOGDowz+ncc5BMIPxFuscRgjH81GlqzL7nOWjcD6R8phqLY+qXA37wBzc1Pj6alSpX0Y+uhGW2EHwe03+jTuQkNvEXZXMFIjwhH8IQqd3BABSmJcLjc0liU4G4SuIElo1mBk44Xib610qVK+mpUqVK+g1Gk02bgLW08yzv+emaLLZ4x4CX4tG3l6V/DmWFkBokHPlC7xBrQRrEXAVMGeI3QIkPDcWpecRy7MpLuzH7ThjKgj4ZEQrNSpUr6UOfMvxR6n+GUAyzs9DEcztiVQ8wE9p5l/U4R9pfNKRZJm05v9oaej0GJr+tmfxccqsxgO2VQDI4oI3UCo22QxGaTzCOdHe37HmPQ2j1Zo9Sgkb6PqUaaiANSx6xN0xdL6CWriZdoyY3EVslDqWJ+D+0OpIw0RvxMK3m/qc6o3Eycy2iufmCmWKX9pQvMBnM3mxSwWq9zyCmNTMs1FLomkdv2ZNDHow2QQBt1KfN/aGwxfH10+xuYRc9pkEgjAuHQOLMupS2VCmTMMVk7R376QN4gVPoV9VSpUt2lu0H2luu04ejGbQhb93SPA8H10bdnFRXbQTzqKcWiKXeVzDlzRPY1HbFuzHxK6jxb/yDYX94VRhydO8urYcdo7g+ZZop4g5eei0v2l5ee0p3lfM8E8E9IsdpXvH3nozMcdCcRjEmgmh52wtuUeH9arUOxQSuR2VLe0FUH0COoxAmPCKyDiMzBeuc5RTX5lu8ijmmZ/VFtUZLUkFblgs1GDF6cTkC7cK/mmC4qri3EyvidEVnIafc9GWzUvCTyktHslO/Qp2lHaY8THRj0IejeokLwvMa6vwl1ULPEMtS25xAlekGly3ee5Kd5WEcpi59/wD2P7A2Cafro1N6m7YpBDH/ALMqSyGmxHBRRxWo0H4Ey2O68TdbcaB7YCooKitupQlPc+7NRWEwxbv9Fs+Jn6/jpx0IQwGgLXiaGf4zYmfiUdMuabgxqnJ3ipA2SN5g00ldpPKHQYA3xG3/ACpWDDWtzvXtI1UOwqjOgB/uMVYbmkd6lI8uLXxKIP2ilZtfeHAyfEEYjAv4DMKiwuPUsVo2z4PP0fMx0p2Twp4ES5g+8T3T2s4RHXQhrXTNDRiUiU5yqYJgYpDYG2AU4YZoHNTJvrTAXuYubPhodQBseVcSocggOEUAHYmLmkPJUrDNaqVcHTvmKxEU47ykjZzUA21ahlAOmctArYuLpiq2WWMW37lcNJTvF3WwOhsbTcajuHoYnHQtndgKtriWl32GX7SCbYuvlxKh6dyldlqcRTuTxInwlDHovJMASwliwZxyMX57q4UCY7s8chhBZjgN3LeCy9DEGg3UPMyVFUzf0lX3eYqq7lBvbBw0RAtoiRAjua53KwNx7sKIhUQBzlBPYZjpxCMFHsxBfmkI4FeIxkbipKUlb75QubnEo6paqidBXKqLMMguX9RRtNzZm5NjzAq8ljtzTAtlx+0yj3ccy7DiNcy1onuEtMvC5cQt2qJpMpkkuu7tFchRGuSY+neEgVUsXEybYTUa8oJaXL3gN8ZieyKksx0egjFQbhBeY1CPmhgKH7EVy+O0QTEnaQfeUGrzO4niqgY7s4RsKs3M2Jtyw+hG4jXte2JmPecyjJ6BggriLhOYJGYphY73KMu0ELFbuaguoD3h5lKTGJKS4KYi7NdSsflMFriXRRntBvfiBS+ErX/iZij5lci6mMrjXXiG5wmYPEd5qIKyuWCxsNyiuCIaBeYgAVMKh4glbVswhs6TYupiy53Mhd94amZ81E8hvazL6UH5jdjcEFg8xoBaaZcqyTJuZD3lkZxHr0ZYuynqLs63ALbzKtY61iYBTxDyTEu2aAd5dAQK1oiyp8zFGcShVcaZ5mPb4lCMdXXSjOWB1NSh5oeTEC5NQReMSyOagMmYPJalGowD4suKSuvtMBr4lutJ9vLMuo7OKmtPkMwlt8wnJPEuWQXkdJthltu0QkeB5jDzKeqUuRDBpXoQbDcpIPSPABThioQ75YBBzUZUQCjSNGqGOTVuWG7tK7N9TqXX3iBDR7QXFZUqSwhplnNAXMBiMyiLx4hdARKLcz8yq4EHzUtxDyWYC1zNVXieCaIU4g8ohVOIyxPuZgS7yX4jaLKt3PRu5bJp1MRWooEWtNw12S1RMrKV9IlNN3AttVLXrTmFg8c9468jKvyJjLBAjZXUgBgynRNlvvAcsO4RbG9fCovqZNXecjMVliWMrbPEuLgqJCCXSDgyRFqKiYvI5S7kTzcoBkx4gmo/PHaYAoVL5hDK4qIcrT2yrbW5UCLU6+JmXTceWbBo+ZplPmJctS9LlgjwJTI+ISzvuZxdTW6kQR4gKYzH0ul6pQ9KPvTlaswM7Eut4J5IU16C4y5FWaPMu/UQ4zBVuAgUpouKGrCPHETWJwlDGqql2EfDEjLZLlZn3jdMdp5ZRbioQ5uWjN2l6HrOhUqOOhUrrVpHzOSv3BTJT3i2ws54WxjDfmZsl2z0lXYqHAHRt9rMxFNchmEF55+8z4vUnefdLJe7uYcLoe0FhsxQMYg1Cti8HGY5HLvMg6IB1IjcZ0M1qNI+KKL3kWAjY5NTNcdzwVBGK+UDW2rKJUrpVvHQCVK6VKlSo9khraHsyxyRgO/+I8F/ab7JQpztiLA2vhC3BS5W8yziui9SrmWkbPxdiGhyghXahDClVBybbjygEVzLe8uP6BUVAxbSRf1gAlYldcquOYFeuh+hXQ6zNack7ahwjGu+8GrAUvMtjAVqZrUsreHQ+Znzwk1beWDN4PtK8OTVxKL8TX/zRtpfVj0VKrohGKuJ/XxE39FRV7kAY6P6LHRUUrLrCPHCwRnZnpiy6cb4YWYdlqFinNOZmhCOkHMRwh/aD2OZf0uJuMVhB0qzoyykyHdb9S5Dmtcn0ZeZAGunP6XmKK9S3bGNDW5bmAQVGbAFrM9qxLLi+l5gyvKWltMRgHPMXiXFh0CJc2lXNYR2M9wwRyhmqDCLgl9EOfn4lXuu+p9R9DqZaOlhK3gfeWTZ6lOPR0RYoFuc6nXVc8gKgBS0EuHedzJVL6nQ6J0Dwy6YXKbOXTmJZBCm3mdopCOPqepDryTLE72YRTaZShD7SlGGp/qgimU9Qt9mHQih7xDtCEeZgB0IQ6EOh5lRNUkAKCuvM/1/VzF6MuH0bLFgbNRxDcFmrlEcKGfSYOzRGgUywgRZ28SyBxB0ckHIcQywhNuh0PouMqVKlSvqevboTnoy6tOEwbtXBExtNzakpuw2DLRE2alnneCoHkJ5ehk2xZlZggdR1Olw+gwda+hwXUMCJ7E9IbdbuZnn69ZZAcQzcY3Zzu4xJmJaKj//2gAMAwAAARECEQAAEAwxzWFQRRwwhSwdANXQcCoAwwww4sWMAp3yVRQBxsLiTjNBgwwxwJ1KdEhDs3m1WCbR4AIwwwww0A/s44oM0o+B1IIkgFngwwwwwww0pzeCxDu2X9Ue4wwwwwwwwwwwoeQy3MhQ/Ci+gwwwww5wghgw6NRwIsA+E3UoQwwww/nv/wD+4MX+80U8MMMMOM8MeMYSLb7/APeCw0wGAWOCOCeyu2CqK6i+3++e04fuJ1si+6GYyWOuEkG2+qk8JBFsysfUKS84imGCgsWsnL0ouKQ/xd0D8Y8+gY0YsgcQInM4oJBpUTJxcMMI6u8IcEc+quk0RT4He7KFniKSuiMZ8AKw+keaAP3/ALZqDSc4juo/7xBITdcc/lbT2xC0nR0Oj+3pzxFBdi/IEhJF2qfKpUd9MHzE8pGP+sCYIApRa3w/6nN44tuwgpATsG3uvm4HO6zWWSNWIMmz7S8Ff9yu3ephpnGgxahO9C4EDJta1S6BEkW53GyR97THCJvzCmXEp8jPXRvE0vnXTGF/JNPJ3qldkLfHSPP779xbOi13WGDV5LPWIKgpJZpn+3KpVvC5aSTWxv/EACERAQEBAAICAwEBAQEAAAAAAAEAESExECAwQVFAcWHB/9oACAECEQE/EPOyMAI7hNjDMLFTmyiRIxTuSji/yfusWx3LZa7tZpYb1bj11PaIxQwzILL2iQ5FwJxkHYCbAG7cQgLHbMzpLTjkuG+oSJ2RGj7/APZgBwkVl3E2dM1OySN9d+5DbTRgzeMg1Q0A5thpwTNXhgVbqsVsFgHIZv4wo84li5vUEN4DuIAHmOR1ls5hPy9vEBice31liFt3qLR3ZvKSQfWXOQ63IIORI45SYGP0nFjiF0wgy3fhLSDVgjnUBXLBjuW9PcYiZjGb8I+/2uPeEmAN56jxoYk8/Gd3QndgN7IJIb/nbp3ISAIUzJFD9hOL0XBvnYYDdYDK098ss8gGDcu7PYZkHDaBKdt/3jBQbowfTbKQH3z0z1z+TP5t9t8Lb/GxzxKxVllnzZzlmeQ2+5vuI5hPuyZ8umrHTyeYDENkyfpck8/Mc5PVfPe3J8aSlyy6LHJfxOpmXc/EGy3IZLTSeHPHa5cMIJ5yxyDDiVgQT6Z6nmFJEDIG8eO9vcIM5lFuRl0+M5wngLPYcz1kEY6tG3wXEpR+ZTl022DMLJ8HnPYWHV3ZFm5LnZz9S43wCcX3fA0sJxAEu9+N8bl9Nsm7suC2IBlhu7LbZgEAQNy79N8btl+lkc23Uu+S3yD6/UWyJXyttnavg2PQ8EtvjfTpxHXwlvhjrxvl+HPbljiJvryz8Gy9JA1Lk79d8Df/xAAeEQEBAQADAQEBAQEAAAAAAAABABEQITEgQTBRQP/aAAgBAREBPxDn2GHocbPRDePHUl7dWXts6Rbwkct+j0uiH5HYLvttvQvsEVuk257Jln+T3vejKwP2VA9Z9Y/7K7CqQUd+mUlupBOiF4hKyNBkrkJonUNTI7MClikSkP8Alg3LJcsXtvuKAH5MLv8AE4ze7zyN52FaFoGt68Y3qLf5g7dWoNujCQJRdkUdtNTtkkoYEl7XY/q9u2dJd7wYShwdSoZI7WjdoCvMWv64WFhux9oMgCxu2zshsODD/gT53+WT8nxlljZZztv2/QRyAzhNszls4PnJ5zgvITol8IWO+DEkdcb9JPUHGQ6XbhxmyR1YkOlnLZZZZ8rq88MOEne2ZwEnH4THGS28Pws9YcG8Qb5J33BAnDgDGODbfteDC/DjbxdLVYUt22XeDs7Pztpz4t+Xm20wtkzgkuqWPkzlWZOJMOHDjerAWM2cHA4fp4NrDeNtjbl2nhIHOCW3fpcl3gO4MgnhJgZJb+T+tktlFjefAQSltSft0eBmyCWnUo9tPll2wWQWSE6l2WcBhB3drpBrFxYXXBIYPg5eEJ4eHvgOTjI4Lxll5bHGTJwXrhIOQsn+DHw9yS59EfY+od7ycY8jq//EACkQAQACAgIBAwMFAQEBAAAAAAEAESExQVFhcYGREKGxIDDB0fDhQPH/2gAIAQAAAT8Q/X8fM+//AILv/wALjFN/ukaBvkZsEugMl8REmKwEaeU6gNkYEefpaekEdMv/AFyzhM6zFQVzRq8wIFirLbLIxxkIx6xBO7nfcMxaSugWXoDTWjmo1QezYhz9n63eDcvrPpDMubwbl6tC/MsoyWlhcNmTMs7PmWVZm9eZf0dCgNBX7RjJJMB3f8Qb4qvMvFzn8ef2lv0hGfG73P6jNysIFrF4JYnAIKtF18l+8E0C2WwQ6hYeh49pgMmFIQ4cyOXLPUQU7BOAP8/mW6CslK3EjKgo4W4hUqoKyV/cDlsAWjaB8BHCo4TpFtwKc8o0F/OpkFcTV6lZM1nNMJTX9MwD6WAPYKZZQmJ3ayw2gq1HJHEv1YMaqDgIjETyYzcEVWk2f7uAPCXyOX3SOgNSqytalHtgBxiXQiIhiqEri0+is3mXlG5W6KzLSyHGYMP2Mcul6F0RaRtTlAoZRRAWH+9ph1BEU4aCa35sGhf4IqJjXanJ8QutUb+eP2ucAiNApXTDYAaI0e3qJggpYtxX2hlbELckJaTJnjTMNHQF62R0czV7EXDUfwwnvu+6Y2IaoVeb+KGajOg0YPSAGlADQowQwRIFlnxCWoxMXk/l8zGKNxU/+ZIjeh1ziKcYrblWMc4hrhmY3Y1ftN47jCjTkMdFxq9iUi27z2Nf0S9BpMnxHVRwj1yQKUBaHRdn2Y3sqW5gOr1lorWWeIFeFI8K1/fxPILTGqa/qelD/aCqHZWENvn5l6rr+mat8kJnMopKL+bYZbu5X7OBfi0FWVmz5gc7Fga0OIlPms7KP7EZVzhvUMQOKKhq5dp2BdtQg15gpbP/ADmP+zKHYZkF1wZH4i77/gRW0IROcQuKWQzsz9opZZA3q1nurhdHOZxK0/MWiXB+x/veKNWobwf9jMUF3lx94GHrHFhb0/BF2WivNYlOEX/FTEwEPUv7MHF1t5Gv4iEc4b4k+8H5nWHsMU/iIHce2+R8/mOJxl+blDT/AM4w5fkwFfkqZ/6cSjjvIVXMyHFdUKooqZ/ZYd7lHM8tEAR9YBJIThq0z7/EfEBAbITDxREwyCgyjeOfaExhTB+ZHOAzZR4zCZ2IhVYOd4+8WZXWirQhV6ogI7BBQHd+0Rj6umQ33DKxU41eHfSHzDzLFzREofSUCjDbqnOYUEwuSzd3XtF8D5N4PSKlwWnmK9Z1HzFaAMq3pZ8krILF1aZvOM9xCR63IahYTZcBZYCwK0CzDZ3qZcBBpW5TlqhlB5qYOEAW3KMOLSXk2t173C/4bo2tfGeInG6k2NVvzGSLTamLUcRtGT5mDQlI1fu8ytgmVUcO4N8Cra58rl0x3QEb3EIVgAv5fEOWAFtSGU7LftoJSCOGIpTfpdxhd5vVZ1Xp8x0Y1rMtbbfKxLdHtKxzfrqN7V6Vgl2VXJTlkjbZbqvb2iWV53Uy7u3be58/MuiDV9KQJOsDUp6Ps/xBWrx5g7A/n/dyi9Z8ytesAREUIb8s0OpGrjmB/rgpYsXkja20vmIOyyplbbWpnG66W4WaaxxFQVaFF5iXv38/MbAXg0bD+oUGvvG+LDwzigo8Qbc5f3WB9MfVQnrOLBllXZLPEsdI+8UJZV2fMv6Gb8Qb1xPUTfWsXFqvPmA1Z8y6aYwgzaIzjAdlkufNTGPWoDEurZRAPH6cTH/juaBaALVjJAq2wevGyD6MCd8fP8RpKoFXnMU2TMNI6iPaMQKhdOYlYFb6RyXmuXxFagLb3XcrkCtrB2xJZOdGNAqByXRLWzRPI4gAlRUa7Q0UALKce8sqAXjJUQUAD5faNoAyFF9+Za67gDuNGA2XDPcRruqeAjepoDYa/iHP/n1MqvLosGftEyTaAVRX2j3SqXyUfz8wHN/FAF5S6HBBRHk38v8AcXDsaYRABIxm4FahRiyYyiUQZXh9iAjIGCM2Gf8AXA6YuvCGvZfibRp0+mCrs214ysG7Nt70xFIG5XDYyynAS7pX8xXd8jnUZZSl6VH4pZNJzjziBUzuXTlf5Jd27HnxzA6BY941HVDOl2wKx/6KGdlO24RKzpGUBi/U+89Lf54wnD4Wz8MT4v5Y6fSbCNU/eUrYL18xTMGnrB2XllSzeT2iwruRPd6XM0vLb5dYMOm7XuxH+3TPZfiuVtND1pNNxX5EzBgLH1IeQIKMr2QqO1uSiOGA0GbtP+R5QNB7R8wExgo/u/8A0anUjZ42ytxsaqBHxgheb7czY0+wy6uQKZzmIetyLG2ZMNo1gRbIn4mQvo7XgmYFinJ5JkIqBu26+7LBryycjka9ZVzSPldUl/EV1D0HCJGEq4bNs3CTs9zsMZIseQ3uYKov0AxTCvVTmznmActb5N2QiwQtbH7Sr01+LorzCyxyvHrWYawWM1aEJZxspAb8lPv+9f7dgLRzZZ954jmX1/8AIAAqAA6Uq/iGNlFKw0mLPmYRnYIHg4j7XWlCh/U7jRo8ygGBBaZpiRU7OLZo5EQNkKeXuU0B2Du8O/vFajT/AGARartiLsdypNC6XXAcSxlCVRH/ALL+JZRHQr0uYDPdQZ8waF1aijjcc4yhDO4Rcexyuy8nsx4twcehP7ve4R+K8cxEiEPQ+D9vmvpce5fr8y/X5l+vzL9fmX6/MHPPz+rm+ZWfp8fEx1NyvB7FQAKAA0dfSp/tfSjolc6e4l8D6/mbbcs9oYK+n4/eo07OYtjK/YNy/wBDie6e6e6Df/gcT3Qb/YWmpjsl+YKZMM/NT9owwb/RUqVKlSvrUr9upqXKS5frLJSDGXKN/JOCX4JdeIbvlslpYG/tAOkf2VUp9czMzMzMybly81n4l9T4+Zc9rl/W5cv1lzWeJZLuA0PVizm9bnI+K4vDDh1Fte5PGHpFuXtF1/NBHp9pftPv6/RDkLwy+1VxCQL6v2jT6XLmO4J0WTxVELoUW2zAdryky5TBWBZQib5MQ1lxiEA08pK3yXiUCSWCtnNTXbiAch7x7j2jw+pUeQfKx4Q+8VoegiuW3wxFaHu5RsHxf0v1gstj9bDiXcz0zcSEUUcJK52Gubiy7N/p9j9A1LuYPc0iO2IKD1lLWyWrWD0gHMoaK6MEluQNDKGs5WWw64jyqJccfvDGmjCHkfeXhclKuEzbYzgAAjp7lbbeZV8JTmVEZX0a/WqVPWXwlQa4jFUzLrMdlwRpk8wsjR7QMCtnhBPZLlxa39faBccRG9wagLNIHlim05N5igneXuGWHPMSFIpKJQHp6BLXcC78Sir2Nyq3NvMcoC1GalR7F4nHhyk0dnvuYMVebjJkcKgQY9nuU6gM2uJccv0ln+Igykp19FJWL9OYvmgoD1Gxwj7ygAWwyx6P4iDkEVXy8wNBHcc+HyxZaE9IlsgcmItye8ptmsatg7j0CxzW4nLDZeK9oJYrR8TMNrwnMywsyYlhE4k68TKhAqg1EPIrJNUwpdQFFbWAgV8odAydsytpKi3YVK7ZneZWZUr6Pcu5cuH7Go7IwPnlk9oh3KETic+LuM6Fasl429YUZUDiVEsdQgWxkJC9r5IEFURw1zDklxglGCYIdRYjyTPzC9XoVAmhcVivHMAOBsgmqD6DCWrDymkUvRiXNlxcmhi9WOJ6a+tTcqv27nEdTL15wekN+sTcQVQfDA5lD3KlfSoMMYH1JT1tOtxjl6+CUjo5NTUXGipyGohvcoxk6jgsWNZQJkID4gm1vNStaA6lhlvqZ8kdFTVNOvpUvP1r6MNfSs/pqdOLmF9MdT72aHpEWJTxDYzH3EBCVzePMoSw5Y7+lfRe9QWV63UY5w5IXCyzv0h1ThAeypWNTXUr4jkefMVcZ9ZVva+Yl6TWMzFV8up8cEvkAekuavDFRdh+/wBa/VU19KlT2jd6nEdqdxt6qXBb8MePScswcIcQDrHiHzXioRejxzv+JaitbvBjP6LjUAQsfDBpVPccRbylLHLP4goJfEECnCZjbPyuXUYOpW5GJysoMssOnFMK0KGYrKRGG9Q9oWglpZ6TZhzxKOoRGZX0v60H6L+lwfpmVK44Jv5gvE0zVxa9I51E6l0rKvqEnaAO1l8GrLW1uAN1lbf6Ujpjpfe/lOPSHhwYoZY0Ru8jipkGGYCWuzI6g1bkTVYJaBfEZSC6wNwLsBm2Em3S3UPBLdHEvmilsFO7zNAYyYjmUKLXcbx4+lS0qA8xF4n5lQS4JeXh2J1r4iTMeE+ZqGD3lvazq7uNY8kVZmSs0xydhaBi3X2j51edGIxCiQX19Of0eO8SwYlzquP5jKthZqHFGnmLVC5TbL+QmCrEAMjHPcSMmhqbrf8AUHUkvMPFq+YCnnBrP88yggsUL++JQM61Vujx3mbwqTKlkbPGZWDt8GGhbCsaYRYg0W9QbkvxC7Sy03nhqHM1PRhzO554D5Mo0PeUIOpT2h0j2nS+yNzc93LelHNqzPabD5h886gz4qae8yLFZOHVSySuW1u8n9QK2VBUhBAzLmZUqVxKPeEK5IiCBkytj8MU+CVUtqzb9yPvLaU1VftM6RrSghAUatpQedj/AKocl6ehjebedMp996CXdHvutRHkt2PjeMekZkaKWgBWERhDzix7VxA0wlQ4cviGtfbMLM1WpYvbQVa8EMgVDa9VAhkNMjbOXxG0F9FbOnmpbd2qjgo/hmTlZZpPqyzge88ZO74IJtHp9AcyZRxfrDrfEr4VKf8AxKvitT0JtXEBBxiaO6YNX1HVQIMM65h2wREt/rN8B2r4lILwKq4mpBUYZu03UZluX8uoaFg6Z2g9YI37U7JOtYz5SAsUWvaKgCupWqLlpNLjIH47lGGFId9gai9IjpbKvljBGU3F2hojklMF0N8L4cx2nAvkZ2e8EBjuxfeNqDGb/wCUwADYhZi0gEU2BV0bmICk3eoLHNFo8koDFHBMAHfmDqcnOcdvMvaWra75/wB6yzwyzz8TWrfUljVY9Jb/AGjh15lPdyokDxMTHU8/QlWlj9GHsj1DDFUAyyudhgWj1eZiCvlKRAczww/KGZZhOE4+WYS9OYrX2mZJXWg6Tn1gvArLy8dy+Cr+JFDI9iA275DLGz2y9oiygFum5rh9vQPTuVNzylejqC6jAbu2leeiGNqtupRwUyjaRqJVU371A9VKE1d0HrG18lt8woU1QaTPBQ1jEyUcZ6gVLZUGgLftF4FMDjpDz1YVvogYtsc0cDv+4gdEGNO1mBxapfKvmPeA9YiZ+SIb+SJ83oS+y+NzCIG6KNw9UnUfXNirnGy8LHj9Bui5lwYJ94CkcavFv/I2IEMgkM0gYiqKus4lOYqZGGUAKKgdKLQ9zJGK1EVlMK7JmZ6jC1oWqL5LmNUdqmGUfPCe8Ls1lCPNsIhI2KrBdaItoNXjRFdLii34jiYSdornMrquopdHvLi1DxMVig2S82yqBZBB2kqCQtuAU/1SsWEFYAiGSL95e3R1iOXSq6gkNviDVF4gmegMchc3eBFAHQ32w4dpg1YweCWjmueoZSXfD1DAl0xZBIRdazNYUlDOVyuIvLzEsV0Rsa1CV2qAO6iIAplcxcVmCBYyOLwylHryzTI5ktecrWMzXjMnGPScMniC8HmJbH3i9N/iMuuRrmO6VLxiW7t41FjgXF69Db49PFRaJUl2jfiDZtpDK8ekqE9W5HsjW7BjP5ipRseoq1eGoaxax1Nhxr3hQpJnDqGmS48+WHxINe3L8xDuQcVLmqiguS4IjI8NQ1Blg6lws/CTPsUZYpAvzUpQbug1G9JtPaAshND0gUDg5lAJH5JvY/AS0PTtgPWQY1E5CMcvtNvyQYP9xMt4I7xV7Tlf+TeKaS6j3Nci/wD5KEqYu8/MQFVWt3CXDsJsuFZrBDhcSycK1+uYTAWi9QJgWLuBFBD5gkKEzbzKSiR/mAIP/wB5/MbJrnMPDfDcLjBtE0R5Ya4aWLQ1vzFS5sbK1FVGqlNOviY+A5vuIjkZ+ZVpYMCqcC7xxEcRNPoyl7Fye5HMIboZ0iZTmIjgQ94w5iFhipppBRG55NNy0EctsWlS8L1LgUD3ZUige6OrAc4gZ06PESqUrAGZi/Ey5oOwhfrLEvN8M1rmoTusQOJNvtMLOHccMrnEa6rODcTCO9FxSsnFWj2QjqCq3jz5gEW0o4UoQPDqKC8DjuI22UzfmU5imYhbExmX1gVA8amjdd2XMTVyXqhl7FVY3KUBXWfiAOBXqgSMirHL05glCKQaJkOOtl2RlKLd5gm1CxhlujNxgG0GYANj7st6xNGdr6xtllT0h2fkEZgB0iNYC8BomjDnO4J2VzDoyVUNwYQN205i1ANAurlNwa6RQoHVHUUgomPMAA07cfCLU6Mxc2VVvmWhWrHhLjqO54itd1UKRdWX8xAbwD4gKW6UczNROWyeI4DpCZ3gF5HzLtuWEdShSXOFIG+JsWd1Kcj0NRoA27jqqIccxgwi7aI4Bh5MaNNuOI6zLRV1LYyMC35xMwANf2iigjFuvSKBlOK/ERRNkzn1mm2azVTXGO6isUO1wLUBh8wqsFuc3WPxNwwbGWBiUCmKzCYoc4NCWN5XUQ4usgRACu0pwQq6HmWUAc3eJYCn5RVorbUPd1qGBWOWRe5xxFwozbXMRsrZGOc67EBwo3n6e5MHAoPNxtkolm2GA34iOl8M4GMFG9eYUAlNohaXbdeIZU0ItaildKwsqno1uBwRqsVUYPYHuE4TV08xSLLVAMETc61hqEuY9NQ52VXmo1RwbuKtA6ssxZzhHxLKKW6bQkEBVMRuoxrM0mDgpuINLkcDMnAGWuY5WKBiBYYTBcpDlQK8MorU2u4QhatHEeBgDeyLZKHuGpQLzFZhdrWZQCIbazL2Quw5iBVWmyK2nYhkQNIcEIZY4jdEwwVLNrmBqOVZyVCXbkoyMSOG/Et7YhI00o/+pXQo5YjtXXXM0wJTw9MbvBrmPjEqX8xDYpz5jaKAs4QsIFMzf/wiC00BrMcqqNJEow9RuWZGDqXCaGpbIAYeIigXOW5Q2FEyJqCgohorcoNKriZWlvcqoNHEsGb+I2wzbbBLEXS8xQxNXYYkO0XkzYZxDrzHURoHZ3DxLS/SKHDVesUFdnEJ0GKQNOzDEQIv4lpgMBy9xJabMsy5TtOYSoPdLZQpCsAri8RXubPVLS0LV8wgCw4vmfeO+oMS4wgcfPjqUCtTYHXkGCGoVO29szHjxxGLsXlF8X6rVQwTQy4mAOXXco1RNwg7LO9wXqZxcNRRwxm27luyOF3b8xSjLV8EW4Qx50whCWDBqo3kpUNdUvtwFdGASx5FQ0FfRzNiuBbPvKIFvBx7y2uFLctVA5JmM0mlz8O5ZrRdu17mH0ggad2mGqbLicaKKEdRwixXTEQbhrL8Q2FTGsrHZbtlQQqUyJqVgOWgrcDYtenmXZvnYqW/FgGVV+IGpopbQcxI7Sw4IOJM0cbZd9EHaAxjmFJmTaXI+CiJVBV3IcShKRYKx/6AmURON1MUDrcemv5iFotsW6cRdCqoQTKhzBUVoeYNRd1qGr+azNkjNdw0KIWToa9JarNwvEF7gqFkb0ahHkbHzGoihbpRD/aVnU6ZpR+UJXtXywCC+a7iK19bE8zni+IXgy8Qlt2lkLEyOZV4TE13g1B4teIYbZecmFRb88IDYBiiJJz58ymuHmtyrYrd+UwqvRAqImu24MwWMqwVHy52qAFvpLlQJeVz9pl2OrqK6x5/zC7bVYI/W1vvKxrTVgRe1xtu/JCOAbFPzBkRua/irnZOx8vyzDBcyzDxCtjWBxUO22WSbgQYeEAQUM1AUmmZnRHnDLdhPViuPhnBDK6irVNnDAq/aAlCXlUYF/LmQ0dGFsDWRuNdlwTITdrKWMPdTTOYeEpqC5k/eeCejCnEYojHKOOhljhK0mIcCq53AhXL/gl7MimrAXK3ZZWyMFjeCElgiw4OoiQuxyDDLlZRFc6m4BebgGaPSCHN15iVYbU6PeCCK9FLy6BOYDUa5apxzLQ4rEqEzWbgGocnkdkVlZmI9+TIrlMWyq/WKVpcRu6eYqu2A7vEIut4JVxj3gBVnwkZlnrOI0qukoCY7lTA95hAIUesShvsIYKxoEABgzFJc39KzK0RLIzZ1WHDzCQ0aQmmOIBt7gbAB9JUtSzGI10W+GKVnapnSL9ePrB/LN8PtxLMeTgJbjHmyeYWXB1k+WMVYsjty8hSmx7VGxKsLTGj6rg6OuV2Yu2U3XEs8/apd4ZiDE0x7wLmDthQeWDU7IqWC4heuPIq5U6l3msxrVSviJL795mAttrRK1avXco6cPcGcQNxKzNxhma3Kpuce0y7mCb5lBlq+oPYGX8S1vHB5vEaaLLrqVYnsTA5zW4MVoEQcnnw3zDZkrJ477vuKpOruXFyyhg8czM6mXpKxbA9QP7lNaOQ8yxpAbB3FVZQWWMXiCQjnEGU8tx+Cp4GJqYGio6ks9yVAOxcpVqh55goCrKrA5F5IlfTi+JcVara4IYG3LzAori5cLWiXzHmOLm8wMbO5u+1l8RcQyvhiY242EOl0i37y6UNhe4hi9huMEuswrzUCAKAZgTG7pd4qFwZVZZbg0ZuYAK2XBrAWzGbW/5ng4+pjgZNn7zDlLxYLgU1mWnMpVUUWphTl0x4CGq6g5ZQwEQdjW3+IN7ACwutvOo7OkNNMRnFVxLrxcVos/8ArCS3YV5WG5beJhwwXmPiLV+v8RamyuYK4NTibv1i4rvEoG2uIasmZYacxwGVllWOK6/7horphOqN8I9XV4vqCPB8c8V5ljL5XB16wAc5PtDVcE6xAbsIKgEAuqIFYjLhiZCbWiEXAuBKhjMUM/TWOBmPRKUYzgWFSsKncurvPOSWWwavWoVGc7lhHUbCOmw55iCjbtdsCq0V4hCESJr2izaHbGoPdzKO5oubLzGhDACZL3K6EKtG4YEezOLhu1Z6S3ZC2JV2V5eJRKaMVTSeYzLbKQtVdm4+NzJnEFiw0HljWoZAcMw+S9OCeiJazNJtKTn6g5dVKOhZE3gjFYL5lVQ6Ii55+n2SxxkPuhn0lH0qcHrLy6+it15i1FNePpWocxZ7Rqb4SpKo6+qRwX3Lqo2PCzmDTmUJt/8AYbYWDSTOAgX1KjGBRVuB+D7yqFgKlRjmJd8sSuoBi45sUm/oNFTb6BhAz9BBDE4gwiyvrLEyPpZPLENYmYRzqvaYuuKilZla9JmrbqX+JhN0lbbNR0npMvkqvpL3Ie5dl4QBhviZuT1FbNV1XEQJyA/JGMfHldkHiCX4PEYQFsION1EVm8Kll2FRa1SANo3KGRuE5NTAfQIMwkD6JxDEcIsvpDuN20y1zmiWyFfQ15YcJVW6qbNnxLqsXmj1ljT1DS+EW4eNYxYX95W86lPkxBKcHzLxLt9ozGpdU+JdxJIdFAuuY7eUY0UyzgzxELS2za3c5MQnCmTlpcJP/9k="},
-			want:    "",
-			wantErr: true,
+			want:    "image/jpg",
+			wantErr: false,
 		},
 		{
 			name:    "no mime type",
 			args:    args{b64: "data:;base64,/9j/4AAQSkZJRgABAgAAAQABAAD/7QCcUGhvdG9zaG9wIDMuMAA4QklNBAQAAAAAAIAcAmcAFEJDamk0N3gteHg5UHNqQXFVWkRIHAIoAGJGQk1EMDEwMDBhYmYwMzAwMDA5OTBhMDAwMDAzMTQwMDAwZTAxNTAwMDBlZjE2MDAwMGE3MWQwMDAwY2EyYjAwMDBhZTJkMDAwMGUzMmYwMDAwZDYzMTAwMDA2YzRkMDAwMP/iAhxJQ0NfUFJPRklMRQABAQAAAgxsY21zAhAAAG1udHJSR0IgWFlaIAfcAAEAGQADACkAOWFjc3BBUFBMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD21gABAAAAANMtbGNtcwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACmRlc2MAAAD8AAAAXmNwcnQAAAFcAAAAC3d0cHQAAAFoAAAAFGJrcHQAAAF8AAAAFHJYWVoAAAGQAAAAFGdYWVoAAAGkAAAAFGJYWVoAAAG4AAAAFHJUUkMAAAHMAAAAQGdUUkMAAAHMAAAAQGJUUkMAAAHMAAAAQGRlc2MAAAAAAAAAA2MyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAHRleHQAAAAARkIAAFhZWiAAAAAAAAD21gABAAAAANMtWFlaIAAAAAAAAAMWAAADMwAAAqRYWVogAAAAAAAAb6IAADj1AAADkFhZWiAAAAAAAABimQAAt4UAABjaWFlaIAAAAAAAACSgAAAPhAAAts9jdXJ2AAAAAAAAABoAAADLAckDYwWSCGsL9hA/FVEbNCHxKZAyGDuSRgVRd13ta3B6BYmxmnysab9908PpMP///9sAQwAJBgcIBwYJCAgICgoJCw4XDw4NDQ4cFBURFyIeIyMhHiAgJSo1LSUnMiggIC4/LzI3OTw8PCQtQkZBOkY1Ozw5/9sAQwEKCgoODA4bDw8bOSYgJjk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5OTk5/8IAEQgBgAGAAwAiAAERAQIRAf/EABoAAAIDAQEAAAAAAAAAAAAAAAABAgMEBQb/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/xAAZAQEBAQEBAQAAAAAAAAAAAAAAAQIDBAX/2gAMAwAAARECEQAAAZAZgAAAEM067DLpGCvNgACGZNc6AF5gABjm9gFwAWAKaYCAJGIVgAABCE6XAXAAyAAAAAAC5HX4/H6u+zPuayxqmtc69c3LNdC88vQ5+7PTLtnh1xnrxbtc3yOvyE7OGdE7a7a7Oni5lufbw+jbQnrEYUapqTLLnNq5/YucdeTbnpY4RuaNGP0FzlA6/LAEAAAAAXI6ufl9OUdFk3k2492ufF2w057547clzj3Q0Z0+d1cu+G2uvVrhn5PWyZ6T1FLpcrqd+LkbsXQ4fVrXRw75Yde/CF0NCcvqZOjefA3GjPeuvbXccnvczpXlSB0+eAAAAAAAmKJghghgmAmCphYJkCYJgIHVeitZ6NOFzJpomAEXKwLkTBDAAQAAAAAAAAAAAABa6LI8/dOieLPbboydDXGvHs5pdswXzqraVClmsa003wsMkjO+pjsq1yusya9+Xn9DF1M9qGHTwAAAAAAAAAAAAAAAAAALgupOP1rsOvJN69/K6euEeb0uWlllPVnoybMWm8OZbRLHq31ui4qutymospuOhVGXX51HQ5G2dGBrygAAAAAAAACGIViBiBgIACvpGrq4haVELPpHbVmQ4uyoqx1ERknO9sImuFtaC6kELagAEAAAAAACEqLVYAAAAAASiEgEABDBMAAAAAAAAAAAAAATABAwABDBDIxWtwVaHnnFoAAAAAAxBIAQykMESQEMcbzj113Dk2R0iqwZCJaUI0GaJrWOBtjkDWsrL1UFigEnWywCk0yyzHfFomAAAAAMSGs/nq9Hi49tbMjkmN6WtN1FlhC2gOhyHL6V8nr5RJBEkyBIEpMgTIipqkMEMJgqGmRrshFujn2GuNES9UsmkUozUcvOnqWutUXdTt4341+zM78Pn93zE8nZ0su85YEbmfe893zcBkJggAAAAAAAAAkmVGUZCjKJCFkSyRKIlkBDVABxJzwVL1vD9bz66LZTz0jCxRmp1xXFi7OVnyHH9dyOnLi9jn79Y7AAAxAAmQmFIGIaGkyQIJKRGLiEJIsvp156GSdbLEayAFXE78l19SOXn365gslvM9C7qeCWdbTgczrw9Fnkc/qORvnYg1hgAAAAhkIYIkVEmyCaHKMiMJRIgRvla+fowV21dOIxXINBsx6pZ1a+fnpTpx2t9nHbgzd0+TCzs15pptt84rPQYcXQZzPp4ripzLIOTIkgi2CJMgrCq1azGmhyjIjFkR36t03QTeOvHr6E9Y5T6OLXKBMI3VjXU4/R0Y68e/oi6uP14zWOGW1Z5uhtZjl05jPl2cOas6aWsRcjfniSQhoQIZCJaZkanhY4tVJxkQ24uhL06aObjt2NXnutFXL6vMXq5M+rXOA1rm9WOa9XNvhz78rTTzm+5TwLrnd0uba31s1lecVxlWsOP2dC5OeZ9+e+uMLnTGqFk4QsIyqZKIlhMikgDYEalKEyPW5XXmudwu5z8d+H1sE7j0GjzfopuvOp645paHcZbbVb6PKVc/RXbTXWvT5SS+py02Jbs59+bCECSVmDLrOO+BrjFES2ItSUrKpUghSFRKLixORIDUJwsIdTm787zuOrPXDq28uUprjrFN+bRrm7a5kQnFu/k3TWjJotnbFKF7U9qukxxuysXU2ELnZV05XoEdTikrK9FSUZRGqYKyuQnZWsbKJlwGsubnLLTuwZ6Z68lLfY51ymIhDWIa8W2xlUxlNiMrgvTObbnt1L+bXNdjPyGbcdlszowZeNvCtrl05dDTytGbtoqnLfc1Fme6sTjYtFjiiTZVOyqtEJZbnuc/uk3Tk6MprkWdW5OPh1ZmVXZWZ9+LXZKFZVcriIyxZqjs5qt6FVscdKr6Mx3OTmt1iEprXOKshStJkSaqLZE7cyl6VnIuy2WRtWp2RIqcCoo2anr6Ml0TOPxK9t5rzt53ZZpYt+fAaaXiVk4CRVWV1TON0OyFtGrLzmujFzSBIsiOEKxsBlCaGgAGRU4xBmeOvo4/cmohKORCUOmd1WblytDlO5w/R5beR1/NFsoLeZkGTFIhG6ohKTC2EjNux9esKrsBFRKULRiCSAcXGACmxAAU5rISr1fmexlpJRl5FlUumceHVmlBqWfq/M6Y3clOyxwlU5RmkpQnTTZVKxRCM0AIQ2QlMpMAaYJIaFDlF1JAJESuUKI0xpnHpdHM3zXnozjvOKsWaTrkoRmSsjMlKMknIdhOLqTQScWJNAphBWIi2CZslxx2UzVA46wA4UkVKJEeWxmbROETRKDucKxf/EAC4QAAICAQMCBAYCAwEBAAAAAAECAAMRBBIhEBMgIjEzFDAyNEBBBSMkQ1BCYP/aAAgBAAABBQL/AKzNtXvid7BBz4++J6eLvz0/AY7RUzWn5q/Vrveo5or3320sWZ3cM5tUVkmlDZbZ6W61mW5rGxee1H3rUpysb3NY+yzz9ms7ljO4a3uoVP8AXWXvel2Y2sys+4UVbmqpL22bGfU32bGs7lRsz29NZZYdzh6S1l/y1Pmubv3WhadLpXFdunDfEaji7WxfsNF9z/t1/uafHwurffc776qQfhY3ufyB/wAjf/j6UHsyz69d7oP+FovcrO19YMXW/ZaT2F/rup95z59Z9a/ZaH3bfrLdu35a/Xrzm4LnR6D7g6mwG73NbF+w0X3P+3X+7R9nf7iOyacXPcsb3Nf9xpmK6UXvZ0t+vX+6B/g6L3Khus133Fv2Wk9i7izT8aSzh9b9ajH8foPdt+u76/lNnaNJqJqqbXurptTT112JZVSysaLrm1NNpiU2rpqab9/wt+7UU322VVXJRbXvAr1D1sopqg0t7zV1lmrTUCsIKKZZ9b6e+2yxVSumu1W02nOnltT2s+mvFdFFyV21s7Oprptq3H4a+99Tyum019dh019hdbAP+AunqMYKB/0dyiZB8BYCA5/Jdgg7rYR9478stKMM7T6d+d/mdwmK+5RdkvbtZ3ZF78rt3m8AWYAq7pMR94mo+qtcab8iyvfHZF0+iGW/2a37j/TKff1R/vuP9FBA0+k5NXv6v39V7Gm9jSnF+p4s1B/o07AVaPlpqYw21fkag8rxodB9f+zXfcf6ZR7+q9+4f4+kRGRWqSVfcav7jVexpvYo97Wfcan2NIiMFapDLVLjUe7+RqByrE0aI4sPD6hizht1MrO22wm27VV4XT2CuvTV7oMo95JfUHOnqs21aCvddqGzZZiyjTuKxp0DmXk5RCKfyTadi2lQrbSbCWawsD6Jprg296m9YDiWWPZEbYe4S1++6aemxIbWKI22M5aA4llrWdBjNljWH/tA/wDz+4dMzP8AxMiblm9Zvm6bjMnxj8MsojaqoT42fGWGfGGDWJEdX6bhN4ncE7k7hm9puPz84gOfm5AjX1rG1cbVPGd4ct0VczExDA20pqM/j+hVs/KzLrQle8tDmKpg5JGYFmBN0DZmRGhnrNNfj8Y9EfrkTeJvm8zJ66g5IWfr9TDYKWNOy8+HaYIgOJu6+s0zbq/xjFfEPPyHP9m6bmgyx0+iJg0wx8OBO0I1IlukzG0ZjUMsPBME0mNn45g9PHaP7OFVd1r6TTJX0AmIRDMQrmWVjGpqEI6aH8T9eAeiqWJUr4tUI75H8dVmViAeAzExLEltUspjpiaD1/D/AF4U4Fjbk8LqHXtkNpatlacQdMzdMx7kWfErkOGhUGWUS+iaIYP5GMm0bVOQviorDaixkSd9ILQZ3IGjOFluocyum14KRMFWEIliwJtf5mJiYm3qPCJR9THMfO7xaT3eybWbRGdm9JU5MAJl1ZErWumNrFEGo7hsZ6gl9W7v1GFgZYOcTExMTE2zbMTAmBOJxMzMz8keu3YiiWcv4tPneD20fU2bU1dsDswFV7q2SdrM1tN25aS1dtqdkaXE+Btedh9OR/YRXXDwc9OZgzBm2bZtm0THyP11o05yatx7PD0WJO2+MTZNs2zE0rL8RZQLFbSiLpqxGoRYv1XV41O3y7DNsOm7pZBWrQwqxS2s1O3r+D+p6mmgJNwhYwEyy1g4v8z6cheZzMGbTO2crc4c2KZlFXT/ANsHrcu5P7Cyq8TEFgjtu6WDy1WFjenn8eZuE3rO4sNyzvQ34gtJ8WlHn4nci2ZMv5gs81VuAfBp/V90Ondo9SKBeu3vZlusrrl+oRhW2ZmHHVjgaal1fVNsHdOO4dosYwswmWm+bpnkw8QmMYG4zkBseLTelzNG1AEq1O6JaJawzqtOM0jHXIm4Sm1d4ljRvM9lGXA1G/4UMw0qCIdresIjcTM/Zzm9t1v1JmekOYx4T14xzGnrPSETGZwJtLeLT8V3G1paLIc509nlLZW3L1PgVnODum0mAGKCjKeHbkGBYF5welo8tL9LD0DgG9+3X+7OFznp6xsYUcE4gMHJJOcHAnpOIvX9dD5amzm4ZjpK1sWKzbqWwjvut4mDnpiUWbkeAbZdqVqh/kLM16vUsUuvwLQSo2zdwelv0sWaEQz9geZmJiqTBwGxnoOZ5ocmDmbdx9D4P3aehpBFlOB/52VxoOLDkkMZztXLRsg1HYSZZkxlCTNcNlaysm0dtduSs3QzPOoGK84H7xCMx+CTwsOIo3BgI0HpPWbgJnn1PgUZa71URTmEAjcAu7dGDTneGMxiBwZnEzkAmZOEzGhoDFNGkFIEHpZ6DEzAPN/Iajfatm8HkE4gJyw8/wCwFhBMP0pDyW9Fz0wB0BwfBQD3bPUmCwAtd5Sd0zgbufWwDwGZmdpDrgmLiAiFsTuR3zBu3KONTqRUPU1ttKcwLgcGftRiZBn0hcGZ2xYfUPwfpD8esHDdEUuwXEXC12GPugRjBXhQMCcYqOGzDnOQs3jLnAUnBJz5WldsL8G7EN2Z3uBFmo1ngVysFzTuZissDCY5PlAMciZ2r6hVyP3YIOJnzRjtGNlHc33Z8rDM7SzZk2oK6piWfTXkwenEIHVVhEuyLKLA5cFTumTEMa1K1tveyAeADw5MFrCd0QFZkQnMU4H75nJm09Pdtu03ctFVVc8s2pAig8zVOGt6NyKjiYONvLtghlMZkAOqAjauwwkt0DdxcQ8Q2HOIB4PWAfJWwrFdGnE3LN6zeJvlrYmlH+RnzHpmZCjUa82uBBwTPWBgsN5hY9T0Ag6LlT3EweZt8Pr8zE3kSpw64meh5mhK97uYtaX62umP/I3MbLnsmk+oPN+B3SzFs+I9P0Oh4HLFTx4QMfMMM0zYubiY8C6pw1+vseE9dEo2dtZqWAicL48T9DpacmujKldjdSYPmtDF4b6gZjoYeJnnrpvLU7bK2O5vHjpjoeAqyrVdut2L29HaKuPnOYBMSq0POZu6VfUSdg6ou5uBNTaCq/Jx1zuPXkTkwLj5zTbMT0gcKajvTI6OxFFnCdMStu2xvNgYwfLxMY/DHQtibyZtzNgmhYYPqZc5sstPng8Y/N/TPNuYFxPTohKsly29Hyzk5aZ6DoOo/C+Fsx8PZGqsX5Bj8wJOBMwDrTZ2n//EACkRAAICAQMFAAEDBQAAAAAAAAECABEDEBIhBBMgMDEyFCJAIzNBUGD/2gAIAQIRAT8B1TCzjiPiZPsrSiY+Jl+ytFxs/wAlaUdalSoMRIuV5dD+JjgFAhjYlFzavHEXGo3NUemZbm1XybQPkzooS50nxomGluZVAycRUXjiDCFsztKHEKrsuZkC8xcQFCBAFaZtiIOIfHpGAU3O4oQTMwZt07i/t5ncWmW4XXcsXKFyzNXM6VgLuFw+PbcYjfxNwUKTFzhgwneU5BHde2eZkyg4hFzKQGi5AUadUwKjzvwvx+RsrN9ig/4nIMszaauXL9eHGpUQYE3GZ8aqgInTY1ZSTH6ZWqocCCdhN1VBiSrqLjCZOIMA5YzqE7bcTI/9Ae3GB21qAje06n+0BOk/ExsgXaJmJLk3xL/cDFFrNw7k32hqZvyjoe2L9u4zeZuJmPOyChCxJubjNxn6hgu2bjNx0v8A5gJNkKSv4iL4GEe7GOY/3UQHiDS9WHtUUIdRoJcN6c6FSPWq6bNVgnzUwRRMx49Syp9gMyaCCEaDUGNyfUug0c6CJLuVKrVm9aas3grRZc+w6H76qiiXC3kp0vRm0uX6A1TeIXl+QNT7LqF/K5fpvyBhN/xz/swLmxvT/8QAJBEAAgEDBAICAwAAAAAAAAAAAAERAhAgEiEwMUBBAyJQUWD/2gAIAQERAT8Bu6khOcJV5XDOdfYu5JbP2b7C6N0pKeysb3F0NmqYJlHspGz2ind5VohyUdQNPcaexDga+oisiHbvY0waXAk5KVuafRG5QvBSjCeWpuTUxNyVtiqFUanBLG5Q6ijopX25d5PRT2fJ2RMi6s+yNiN5EJ7+A1N4NKI/goII8d1GoVRNn4VTxT5qhcVL5WLq7wVoVpnjbsqrsnJso4ngrOyIxW3E7wK7FjHG7rB2nx4wpVoI4INIqSMoIIFRlBGUDtBGMeAynNcz/Ctwak+Bn//EADYQAAEDAgQEBgEDAwMFAAAAAAEAAhEhMQMQEiAiQVFhMDJAcXKBE1BikUJSsTOCoQQjcJLB/9oACAEAAAY/Av1YleUqHNIVLb/KVWm7yqo9BK4WUF/GHuiif7StDTpWkmURqTSaA2Ws9YWlpXeUYKFfdAcyEzE1+bkgeuX2nL8uvnEIHIjUhqMUQeVDTp5rSVdYeJq8yxHaqhBmuJX42v4f/iLW17psumRKn7WjVDYsFGpadXiD3Ujh+S/Ew6jdxUkE0iiYdJunLD9k75oexX3kCQDxolYbQ08KrSH5feX4wDIKxJFiMih7I/JO+BTT0KIWB9rF9wgejl/1DxYCAnJvxXsYR+KKkATpifEHvkT0dC+ig2XSUVh/FO+aHsV9r7X+/LC0mKKuotB55feTdNKlFsktGRQ9kT+5P+BTW9SisD7WL7he6J/vcnJvxXu5H4or6HhmLr/TKHDxHknNxGQJmVqwxq7L82Pwhvlb1Re3DJBKwhp4oiE9uIyBMoOw2z3VGTVD/t1IlOD2QAZXdNYMO1jKbggybuy1DDK4qOQY1mocivxzL3VdkVAGqOabgAzHmPdHQ3VIhflxvN/S1cIkpurrRixA5kC90NIkrBwyKgSVLYlDW3Q0XKGFhCWtoFJwr0ui4YZKa7EGkkW/QZfiPn2TQ1znUqT+pXCodlSqepkouDDpHNSvKi0tsg4iJtl5VbI6WEgXUoDTdEFtk0lt1ZRGWFy4UdLSYvmFhu5kn1I4tK/Cw6qySFifBfacsH45M+ScsP8AcKrHFJhPb+2Uz5J6wvYLG9kzuUQsLu1Y09E5v7cgVgt/Z6kNTj1csT4FfacsH45M+ScsFPc9urSiG4YaSIumfJPWF8QsdYfyCcsH4p5e3VC4cIB0dcobUojpT1IKdhhvPVKcOrCvtai3TKwo5CMmkCYKOkGSbJmELsCxGupMLEx3DhiBKnmCi5w0zVYfssQaZlB/9LKlFYcf2rEa6hIWJjuHABTIVWG4/wBXqtADWjsFwtaD1iqnS0nuFqdB9wgKQOmTX8PW6PllVVWtd7hcRop0tJ7hFx0knqE1gDU4HRB7rR/T2C8rfsKKCOgVWtd7hVsMrA+6l3/kG6vtv6qrlzK8io0KrVUQuEzttnf1t1UrhGUknZTORQri/Qyc5UbaLvs0O9Kc653ytlfPSrZUysvf9Br4JzhS7O2VMrfo8KTfwaDN3qoF1UbtSCLj4rvVE74KhAb6qy7bH+q0jkhvE5XV9kNCl253f1M9PB+kSVeF2UG+UcyuMy7sqD+Vp4Gn2Kl4aWcyOS06xIVHtVD4nLbbwQFZHedAkxWbBOe/EdHZatWkHqZRh8rUBEc0C7FcJ5BN1OqyioC4f5VaA9Ag19+qdgyXUQcwmYXFwhasNxd2PNU/hQcTiUZWVs7q/jhzqBXheZTEjspLTG5zP2rS76XFK8k+6ZDRJdkffL+pXhGK9IChueplJuUzi8yPpZffYEZqCtTDrb/yFbO61t8zf8KoIblqcf55o40cIo0ZHryWkHSeiq2T01LiwY2FUWGengXV1fPyqytuJVVTKVK77+FS/FI7BWn3QYyzeWQ1lYb23nceabKapU5XVSr5X21yruKouhUG+QWsOhXnO6uh3U562O0lRAjqiX6iVQKNwUcmjLTtlDdbfKoWriUoVQPMKhVLq6rmCNldk8xtkqf4yGVVVQoWnKtlS2VfrKu9o7bJiilVWkW212UqVQEKGiVxYcjsqiD0O33VTQbNV1ZWVVClaipXbOUPA7oHI0VEVIUc1fKM+Ff6f+5eVUVKKCoOxirnCAyg5VULoFTOBkNoGUnKERmQVCqoVsrbOi5qijboaeFmcqoUlDKAr5wrZd13XbaDy2yM3RnbZOc74ZGtTkcpyrlK1GyplbLUqhUGz/KlCMvNlfYfA0eV4sofyVM4GZbh/wA7KZWV8qZQuFRzUZAZzlKZNzUoNH2ozAElcXmNhsJ8GHXUBWzqV0HhXzvlSm9rBzMKpjDAiAuHDavKf5Xk/wCVRgyPamVVRFUXE5XouSuFSqpAUm5y7quXD/Kr4/UZ3zsVCZ2qj32anUC/FgW5uVc5/wA5QKeDKrf0tqjbDjGqyDSZyjzu7Lhho7LicSic+w8SVO6B4475V2aiA9FoAZsJPMqygcvF0qafa07IF/QA50zKnYz2Wrn40laDhB0WKOIaTyzgX9Fps5VC8pyPCIhOJ2AdcoCnxPbZZdPRggoO656aSSg3ZqiYVafolFVWRw5ryyohKjp+j0VdgI5IxeMtZhE5U9S0iDqE3Vh/7KrT4OnfPLmv/8QAKRABAAICAQQBAwUBAQEAAAAAAQARITFBEFFhcYEgkaEwQLHB8FDR4f/aAAgBAAABPyH/AK1wi1wTNQnzEfKeALo/Wsa2aiKoW5H6eLlOFfa4jgw+f2A2r+IoEotXr9Vh+2j73aV+TfEVBjd1FthnMs43LuCdmZea6S9w5SVsOICI+WaG10QVbcWp3YBHxLZFLEavwvpumwc3j7Ss+g1Uiq1nHS9jDFtRY9TLhSZiUwB+EU5MYj0MdkQh7r95brwhNhjVzS5Zvt3QEIr+U70gO3iJazHlZGOPShVxuOF1CYVN1jt+m/1KE7R3Rd1TNCtCCswUjnS7eJgfM/gx0X+4h/wcTj6/zN/tgxS6pEfo7eIzpdmsTOlwCzuf/Om6bEdpZKVm6xKWpaLOj+/PxUT0UmExxeCdvp/i89GJ0n/tCYCKubzMfSmbXbj+Z+e6O6u5HZj9N59T8FGW9ankk/tPxtCcy4CXz75n8D+Ibf8A3E/0O04+v89Jq9v4gRepeJk+cxbagdl9N0ik94mK2ZIHzJ7Tmfk9F96nOQoxaNxX9uf4PPRxtfBhvt7e4QfIn46Ky86T8t0+v/WD9PULpgg0Rkb2QwClbaYDPsd4mfJTtmpX45hldHWAVqvYzMaX7dyGsUxlDPtGwm53RSDFYmFXvNBgal8csUqqBXJuNXHV4mSoWykzGgTE71iZYJYMtQqwODxDc/PlVjAZH5gMMsCt2p3EQgI5ee8Itn94IY90XmWeirMEL6MSglROyxPcBFGBaV/MZXA9vmBaAFWNQBRe7Ibmi5VX+P2HPXcz/npvrmHUmTT12QNheJACnJ6c/Vtz0z/w7630dwntcAWCdz6C7AgC1f7m0EFYt0GguzZBWGzMjDbMv505cRUn8Q5v5SuF69xQLcBM1ucQVI44jm5KjMVsvbMZrjZniHJxmwRmAGzMsgGFZotFrtCsBOKlwbtVCpP4T9zZwA2upe28GolEeE/JUNfX+Zj8XXgbsiP5l3vmf5/AikQqGdzdKy+ZPxv8z7J6CJbi6EyvJQ/MPhCpWRwkBUF18z31P26HHxTwJb7/ALl+wSUG3XfxP8bxDX1/n6F/w82fMYV03NM8outxFum3mfi/5n5nQH4hCp8/2T89/mH7OYN6IXH6wRvz0FlY4JqNE/cn9GonJCzsIOyqFTnmrRs6gQe0LhOie2Aod5wMlyIekEfMzysGIGEFedEGrlVU7bAPaC04B9o10BMnEZg5jzLtzKM9A+YMr2qaiCsbZ8w4CCvvLO8HZ2f3OL0RfcCVXGQm2c0q61rJUTg6VBmh0AqWtW4KC40EyvDbmwGvmKq8l3Dph9ZqlJRTQYCYzwCDUpDEqYQH1B2ArceIXsHZRcyaS/JDxBaFEClMaLqlGM4AKOlAWvwLgGjBQGj/ALd37C5cv9lf6o2X/wAa/ovoyzuRDnoU5lO8v9El/uXkEq4Q71RHFsv2J5DpL8v0jMP/AGGff6XH01KY1ykHsQlpTB7cItxSHFxGGgax9JZHuEp5ngZTiPAR7lTy4t7Wb/Qz9IrOGDowz+ncc5BMIPxFuscRgjH81GlqzL7nOWjcD6R8phqLY+qXA37wBzc1Pj6alSpX0Y+uhGW2EHwe03+jTuQkNvEXZXMFIjwhH8IQqd3BABSmJcLjc0liU4G4SuIElo1mBk44Xib610qVK+mpUqVK+g1Gk02bgLW08yzv+emaLLZ4x4CX4tG3l6V/DmWFkBokHPlC7xBrQRrEXAVMGeI3QIkPDcWpecRy7MpLuzH7ThjKgj4ZEQrNSpUr6UOfMvxR6n+GUAyzs9DEcztiVQ8wE9p5l/U4R9pfNKRZJm05v9oaej0GJr+tmfxccqsxgO2VQDI4oI3UCo22QxGaTzCOdHe37HmPQ2j1Zo9Sgkb6PqUaaiANSx6xN0xdL6CWriZdoyY3EVslDqWJ+D+0OpIw0RvxMK3m/qc6o3Eycy2iufmCmWKX9pQvMBnM3mxSwWq9zyCmNTMs1FLomkdv2ZNDHow2QQBt1KfN/aGwxfH10+xuYRc9pkEgjAuHQOLMupS2VCmTMMVk7R376QN4gVPoV9VSpUt2lu0H2luu04ejGbQhb93SPA8H10bdnFRXbQTzqKcWiKXeVzDlzRPY1HbFuzHxK6jxb/yDYX94VRhydO8urYcdo7g+ZZop4g5eei0v2l5ee0p3lfM8E8E9IsdpXvH3nozMcdCcRjEmgmh52wtuUeH9arUOxQSuR2VLe0FUH0COoxAmPCKyDiMzBeuc5RTX5lu8ijmmZ/VFtUZLUkFblgs1GDF6cTkC7cK/mmC4qri3EyvidEVnIafc9GWzUvCTyktHslO/Qp2lHaY8THRj0IejeokLwvMa6vwl1ULPEMtS25xAlekGly3ee5Kd5WEcpi59/wD2P7A2Cafro1N6m7YpBDH/ALMqSyGmxHBRRxWo0H4Ey2O68TdbcaB7YCooKitupQlPc+7NRWEwxbv9Fs+Jn6/jpx0IQwGgLXiaGf4zYmfiUdMuabgxqnJ3ipA2SN5g00ldpPKHQYA3xG3/ACpWDDWtzvXtI1UOwqjOgB/uMVYbmkd6lI8uLXxKIP2ilZtfeHAyfEEYjAv4DMKiwuPUsVo2z4PP0fMx0p2Twp4ES5g+8T3T2s4RHXQhrXTNDRiUiU5yqYJgYpDYG2AU4YZoHNTJvrTAXuYubPhodQBseVcSocggOEUAHYmLmkPJUrDNaqVcHTvmKxEU47ykjZzUA21ahlAOmctArYuLpiq2WWMW37lcNJTvF3WwOhsbTcajuHoYnHQtndgKtriWl32GX7SCbYuvlxKh6dyldlqcRTuTxInwlDHovJMASwliwZxyMX57q4UCY7s8chhBZjgN3LeCy9DEGg3UPMyVFUzf0lX3eYqq7lBvbBw0RAtoiRAjua53KwNx7sKIhUQBzlBPYZjpxCMFHsxBfmkI4FeIxkbipKUlb75QubnEo6paqidBXKqLMMguX9RRtNzZm5NjzAq8ljtzTAtlx+0yj3ccy7DiNcy1onuEtMvC5cQt2qJpMpkkuu7tFchRGuSY+neEgVUsXEybYTUa8oJaXL3gN8ZieyKksx0egjFQbhBeY1CPmhgKH7EVy+O0QTEnaQfeUGrzO4niqgY7s4RsKs3M2Jtyw+hG4jXte2JmPecyjJ6BggriLhOYJGYphY73KMu0ELFbuaguoD3h5lKTGJKS4KYi7NdSsflMFriXRRntBvfiBS+ErX/iZij5lci6mMrjXXiG5wmYPEd5qIKyuWCxsNyiuCIaBeYgAVMKh4glbVswhs6TYupiy53Mhd94amZ81E8hvazL6UH5jdjcEFg8xoBaaZcqyTJuZD3lkZxHr0ZYuynqLs63ALbzKtY61iYBTxDyTEu2aAd5dAQK1oiyp8zFGcShVcaZ5mPb4lCMdXXSjOWB1NSh5oeTEC5NQReMSyOagMmYPJalGowD4suKSuvtMBr4lutJ9vLMuo7OKmtPkMwlt8wnJPEuWQXkdJthltu0QkeB5jDzKeqUuRDBpXoQbDcpIPSPABThioQ75YBBzUZUQCjSNGqGOTVuWG7tK7N9TqXX3iBDR7QXFZUqSwhplnNAXMBiMyiLx4hdARKLcz8yq4EHzUtxDyWYC1zNVXieCaIU4g8ohVOIyxPuZgS7yX4jaLKt3PRu5bJp1MRWooEWtNw12S1RMrKV9IlNN3AttVLXrTmFg8c9468jKvyJjLBAjZXUgBgynRNlvvAcsO4RbG9fCovqZNXecjMVliWMrbPEuLgqJCCXSDgyRFqKiYvI5S7kTzcoBkx4gmo/PHaYAoVL5hDK4qIcrT2yrbW5UCLU6+JmXTceWbBo+ZplPmJctS9LlgjwJTI+ISzvuZxdTW6kQR4gKYzH0ul6pQ9KPvTlaswM7Eut4J5IU16C4y5FWaPMu/UQ4zBVuAgUpouKGrCPHETWJwlDGqql2EfDEjLZLlZn3jdMdp5ZRbioQ5uWjN2l6HrOhUqOOhUrrVpHzOSv3BTJT3i2ws54WxjDfmZsl2z0lXYqHAHRt9rMxFNchmEF55+8z4vUnefdLJe7uYcLoe0FhsxQMYg1Cti8HGY5HLvMg6IB1IjcZ0M1qNI+KKL3kWAjY5NTNcdzwVBGK+UDW2rKJUrpVvHQCVK6VKlSo9khraHsyxyRgO/+I8F/ab7JQpztiLA2vhC3BS5W8yziui9SrmWkbPxdiGhyghXahDClVBybbjygEVzLe8uP6BUVAxbSRf1gAlYldcquOYFeuh+hXQ6zNack7ahwjGu+8GrAUvMtjAVqZrUsreHQ+Znzwk1beWDN4PtK8OTVxKL8TX/zRtpfVj0VKrohGKuJ/XxE39FRV7kAY6P6LHRUUrLrCPHCwRnZnpiy6cb4YWYdlqFinNOZmhCOkHMRwh/aD2OZf0uJuMVhB0qzoyykyHdb9S5Dmtcn0ZeZAGunP6XmKK9S3bGNDW5bmAQVGbAFrM9qxLLi+l5gyvKWltMRgHPMXiXFh0CJc2lXNYR2M9wwRyhmqDCLgl9EOfn4lXuu+p9R9DqZaOlhK3gfeWTZ6lOPR0RYoFuc6nXVc8gKgBS0EuHedzJVL6nQ6J0Dwy6YXKbOXTmJZBCm3mdopCOPqepDryTLE72YRTaZShD7SlGGp/qgimU9Qt9mHQih7xDtCEeZgB0IQ6EOh5lRNUkAKCuvM/1/VzF6MuH0bLFgbNRxDcFmrlEcKGfSYOzRGgUywgRZ28SyBxB0ckHIcQywhNuh0PouMqVKlSvqevboTnoy6tOEwbtXBExtNzakpuw2DLRE2alnneCoHkJ5ehk2xZlZggdR1Olw+gwda+hwXUMCJ7E9IbdbuZnn69ZZAcQzcY3Zzu4xJmJaKj//2gAMAwAAARECEQAAEAwxzWFQRRwwhSwdANXQcCoAwwww4sWMAp3yVRQBxsLiTjNBgwwxwJ1KdEhDs3m1WCbR4AIwwwww0A/s44oM0o+B1IIkgFngwwwwwww0pzeCxDu2X9Ue4wwwwwwwwwwwoeQy3MhQ/Ci+gwwwww5wghgw6NRwIsA+E3UoQwwww/nv/wD+4MX+80U8MMMMOM8MeMYSLb7/APeCw0wGAWOCOCeyu2CqK6i+3++e04fuJ1si+6GYyWOuEkG2+qk8JBFsysfUKS84imGCgsWsnL0ouKQ/xd0D8Y8+gY0YsgcQInM4oJBpUTJxcMMI6u8IcEc+quk0RT4He7KFniKSuiMZ8AKw+keaAP3/ALZqDSc4juo/7xBITdcc/lbT2xC0nR0Oj+3pzxFBdi/IEhJF2qfKpUd9MHzE8pGP+sCYIApRa3w/6nN44tuwgpATsG3uvm4HO6zWWSNWIMmz7S8Ff9yu3ephpnGgxahO9C4EDJta1S6BEkW53GyR97THCJvzCmXEp8jPXRvE0vnXTGF/JNPJ3qldkLfHSPP779xbOi13WGDV5LPWIKgpJZpn+3KpVvC5aSTWxv/EACERAQEBAAICAwEBAQEAAAAAAAEAESExECAwQVFAcWHB/9oACAECEQE/EPOyMAI7hNjDMLFTmyiRIxTuSji/yfusWx3LZa7tZpYb1bj11PaIxQwzILL2iQ5FwJxkHYCbAG7cQgLHbMzpLTjkuG+oSJ2RGj7/APZgBwkVl3E2dM1OySN9d+5DbTRgzeMg1Q0A5thpwTNXhgVbqsVsFgHIZv4wo84li5vUEN4DuIAHmOR1ls5hPy9vEBice31liFt3qLR3ZvKSQfWXOQ63IIORI45SYGP0nFjiF0wgy3fhLSDVgjnUBXLBjuW9PcYiZjGb8I+/2uPeEmAN56jxoYk8/Gd3QndgN7IJIb/nbp3ISAIUzJFD9hOL0XBvnYYDdYDK098ss8gGDcu7PYZkHDaBKdt/3jBQbowfTbKQH3z0z1z+TP5t9t8Lb/GxzxKxVllnzZzlmeQ2+5vuI5hPuyZ8umrHTyeYDENkyfpck8/Mc5PVfPe3J8aSlyy6LHJfxOpmXc/EGy3IZLTSeHPHa5cMIJ5yxyDDiVgQT6Z6nmFJEDIG8eO9vcIM5lFuRl0+M5wngLPYcz1kEY6tG3wXEpR+ZTl022DMLJ8HnPYWHV3ZFm5LnZz9S43wCcX3fA0sJxAEu9+N8bl9Nsm7suC2IBlhu7LbZgEAQNy79N8btl+lkc23Uu+S3yD6/UWyJXyttnavg2PQ8EtvjfTpxHXwlvhjrxvl+HPbljiJvryz8Gy9JA1Lk79d8Df/xAAeEQEBAQADAQEBAQEAAAAAAAABABEQITEgQTBRQP/aAAgBAREBPxDn2GHocbPRDePHUl7dWXts6Rbwkct+j0uiH5HYLvttvQvsEVuk257Jln+T3vejKwP2VA9Z9Y/7K7CqQUd+mUlupBOiF4hKyNBkrkJonUNTI7MClikSkP8Alg3LJcsXtvuKAH5MLv8AE4ze7zyN52FaFoGt68Y3qLf5g7dWoNujCQJRdkUdtNTtkkoYEl7XY/q9u2dJd7wYShwdSoZI7WjdoCvMWv64WFhux9oMgCxu2zshsODD/gT53+WT8nxlljZZztv2/QRyAzhNszls4PnJ5zgvITol8IWO+DEkdcb9JPUHGQ6XbhxmyR1YkOlnLZZZZ8rq88MOEne2ZwEnH4THGS28Pws9YcG8Qb5J33BAnDgDGODbfteDC/DjbxdLVYUt22XeDs7Pztpz4t+Xm20wtkzgkuqWPkzlWZOJMOHDjerAWM2cHA4fp4NrDeNtjbl2nhIHOCW3fpcl3gO4MgnhJgZJb+T+tktlFjefAQSltSft0eBmyCWnUo9tPll2wWQWSE6l2WcBhB3drpBrFxYXXBIYPg5eEJ4eHvgOTjI4Lxll5bHGTJwXrhIOQsn+DHw9yS59EfY+od7ycY8jq//EACkQAQACAgIBAwMFAQEBAAAAAAEAESExQVFhcYGREKGxIDDB0fDhQPH/2gAIAQAAAT8Q/X8fM+//AILv/wALjFN/ukaBvkZsEugMl8REmKwEaeU6gNkYEefpaekEdMv/AFyzhM6zFQVzRq8wIFirLbLIxxkIx6xBO7nfcMxaSugWXoDTWjmo1QezYhz9n63eDcvrPpDMubwbl6tC/MsoyWlhcNmTMs7PmWVZm9eZf0dCgNBX7RjJJMB3f8Qb4qvMvFzn8ef2lv0hGfG73P6jNysIFrF4JYnAIKtF18l+8E0C2WwQ6hYeh49pgMmFIQ4cyOXLPUQU7BOAP8/mW6CslK3EjKgo4W4hUqoKyV/cDlsAWjaB8BHCo4TpFtwKc8o0F/OpkFcTV6lZM1nNMJTX9MwD6WAPYKZZQmJ3ayw2gq1HJHEv1YMaqDgIjETyYzcEVWk2f7uAPCXyOX3SOgNSqytalHtgBxiXQiIhiqEri0+is3mXlG5W6KzLSyHGYMP2Mcul6F0RaRtTlAoZRRAWH+9ph1BEU4aCa35sGhf4IqJjXanJ8QutUb+eP2ucAiNApXTDYAaI0e3qJggpYtxX2hlbELckJaTJnjTMNHQF62R0czV7EXDUfwwnvu+6Y2IaoVeb+KGajOg0YPSAGlADQowQwRIFlnxCWoxMXk/l8zGKNxU/+ZIjeh1ziKcYrblWMc4hrhmY3Y1ftN47jCjTkMdFxq9iUi27z2Nf0S9BpMnxHVRwj1yQKUBaHRdn2Y3sqW5gOr1lorWWeIFeFI8K1/fxPILTGqa/qelD/aCqHZWENvn5l6rr+mat8kJnMopKL+bYZbu5X7OBfi0FWVmz5gc7Fga0OIlPms7KP7EZVzhvUMQOKKhq5dp2BdtQg15gpbP/ADmP+zKHYZkF1wZH4i77/gRW0IROcQuKWQzsz9opZZA3q1nurhdHOZxK0/MWiXB+x/veKNWobwf9jMUF3lx94GHrHFhb0/BF2WivNYlOEX/FTEwEPUv7MHF1t5Gv4iEc4b4k+8H5nWHsMU/iIHce2+R8/mOJxl+blDT/AM4w5fkwFfkqZ/6cSjjvIVXMyHFdUKooqZ/ZYd7lHM8tEAR9YBJIThq0z7/EfEBAbITDxREwyCgyjeOfaExhTB+ZHOAzZR4zCZ2IhVYOd4+8WZXWirQhV6ogI7BBQHd+0Rj6umQ33DKxU41eHfSHzDzLFzREofSUCjDbqnOYUEwuSzd3XtF8D5N4PSKlwWnmK9Z1HzFaAMq3pZ8krILF1aZvOM9xCR63IahYTZcBZYCwK0CzDZ3qZcBBpW5TlqhlB5qYOEAW3KMOLSXk2t173C/4bo2tfGeInG6k2NVvzGSLTamLUcRtGT5mDQlI1fu8ytgmVUcO4N8Cra58rl0x3QEb3EIVgAv5fEOWAFtSGU7LftoJSCOGIpTfpdxhd5vVZ1Xp8x0Y1rMtbbfKxLdHtKxzfrqN7V6Vgl2VXJTlkjbZbqvb2iWV53Uy7u3be58/MuiDV9KQJOsDUp6Ps/xBWrx5g7A/n/dyi9Z8ytesAREUIb8s0OpGrjmB/rgpYsXkja20vmIOyyplbbWpnG66W4WaaxxFQVaFF5iXv38/MbAXg0bD+oUGvvG+LDwzigo8Qbc5f3WB9MfVQnrOLBllXZLPEsdI+8UJZV2fMv6Gb8Qb1xPUTfWsXFqvPmA1Z8y6aYwgzaIzjAdlkufNTGPWoDEurZRAPH6cTH/juaBaALVjJAq2wevGyD6MCd8fP8RpKoFXnMU2TMNI6iPaMQKhdOYlYFb6RyXmuXxFagLb3XcrkCtrB2xJZOdGNAqByXRLWzRPI4gAlRUa7Q0UALKce8sqAXjJUQUAD5faNoAyFF9+Za67gDuNGA2XDPcRruqeAjepoDYa/iHP/n1MqvLosGftEyTaAVRX2j3SqXyUfz8wHN/FAF5S6HBBRHk38v8AcXDsaYRABIxm4FahRiyYyiUQZXh9iAjIGCM2Gf8AXA6YuvCGvZfibRp0+mCrs214ysG7Nt70xFIG5XDYyynAS7pX8xXd8jnUZZSl6VH4pZNJzjziBUzuXTlf5Jd27HnxzA6BY941HVDOl2wKx/6KGdlO24RKzpGUBi/U+89Lf54wnD4Wz8MT4v5Y6fSbCNU/eUrYL18xTMGnrB2XllSzeT2iwruRPd6XM0vLb5dYMOm7XuxH+3TPZfiuVtND1pNNxX5EzBgLH1IeQIKMr2QqO1uSiOGA0GbtP+R5QNB7R8wExgo/u/8A0anUjZ42ytxsaqBHxgheb7czY0+wy6uQKZzmIetyLG2ZMNo1gRbIn4mQvo7XgmYFinJ5JkIqBu26+7LBryycjka9ZVzSPldUl/EV1D0HCJGEq4bNs3CTs9zsMZIseQ3uYKov0AxTCvVTmznmActb5N2QiwQtbH7Sr01+LorzCyxyvHrWYawWM1aEJZxspAb8lPv+9f7dgLRzZZ954jmX1/8AIAAqAA6Uq/iGNlFKw0mLPmYRnYIHg4j7XWlCh/U7jRo8ygGBBaZpiRU7OLZo5EQNkKeXuU0B2Du8O/vFajT/AGARartiLsdypNC6XXAcSxlCVRH/ALL+JZRHQr0uYDPdQZ8waF1aijjcc4yhDO4Rcexyuy8nsx4twcehP7ve4R+K8cxEiEPQ+D9vmvpce5fr8y/X5l+vzL9fmX6/MHPPz+rm+ZWfp8fEx1NyvB7FQAKAA0dfSp/tfSjolc6e4l8D6/mbbcs9oYK+n4/eo07OYtjK/YNy/wBDie6e6e6Df/gcT3Qb/YWmpjsl+YKZMM/NT9owwb/RUqVKlSvrUr9upqXKS5frLJSDGXKN/JOCX4JdeIbvlslpYG/tAOkf2VUp9czMzMzMybly81n4l9T4+Zc9rl/W5cv1lzWeJZLuA0PVizm9bnI+K4vDDh1Fte5PGHpFuXtF1/NBHp9pftPv6/RDkLwy+1VxCQL6v2jT6XLmO4J0WTxVELoUW2zAdryky5TBWBZQib5MQ1lxiEA08pK3yXiUCSWCtnNTXbiAch7x7j2jw+pUeQfKx4Q+8VoegiuW3wxFaHu5RsHxf0v1gstj9bDiXcz0zcSEUUcJK52Gubiy7N/p9j9A1LuYPc0iO2IKD1lLWyWrWD0gHMoaK6MEluQNDKGs5WWw64jyqJccfvDGmjCHkfeXhclKuEzbYzgAAjp7lbbeZV8JTmVEZX0a/WqVPWXwlQa4jFUzLrMdlwRpk8wsjR7QMCtnhBPZLlxa39faBccRG9wagLNIHlim05N5igneXuGWHPMSFIpKJQHp6BLXcC78Sir2Nyq3NvMcoC1GalR7F4nHhyk0dnvuYMVebjJkcKgQY9nuU6gM2uJccv0ln+Igykp19FJWL9OYvmgoD1Gxwj7ygAWwyx6P4iDkEVXy8wNBHcc+HyxZaE9IlsgcmItye8ptmsatg7j0CxzW4nLDZeK9oJYrR8TMNrwnMywsyYlhE4k68TKhAqg1EPIrJNUwpdQFFbWAgV8odAydsytpKi3YVK7ZneZWZUr6Pcu5cuH7Go7IwPnlk9oh3KETic+LuM6Fasl429YUZUDiVEsdQgWxkJC9r5IEFURw1zDklxglGCYIdRYjyTPzC9XoVAmhcVivHMAOBsgmqD6DCWrDymkUvRiXNlxcmhi9WOJ6a+tTcqv27nEdTL15wekN+sTcQVQfDA5lD3KlfSoMMYH1JT1tOtxjl6+CUjo5NTUXGipyGohvcoxk6jgsWNZQJkID4gm1vNStaA6lhlvqZ8kdFTVNOvpUvP1r6MNfSs/pqdOLmF9MdT72aHpEWJTxDYzH3EBCVzePMoSw5Y7+lfRe9QWV63UY5w5IXCyzv0h1ThAeypWNTXUr4jkefMVcZ9ZVva+Yl6TWMzFV8up8cEvkAekuavDFRdh+/wBa/VU19KlT2jd6nEdqdxt6qXBb8MePScswcIcQDrHiHzXioRejxzv+JaitbvBjP6LjUAQsfDBpVPccRbylLHLP4goJfEECnCZjbPyuXUYOpW5GJysoMssOnFMK0KGYrKRGG9Q9oWglpZ6TZhzxKOoRGZX0v60H6L+lwfpmVK44Jv5gvE0zVxa9I51E6l0rKvqEnaAO1l8GrLW1uAN1lbf6Ujpjpfe/lOPSHhwYoZY0Ru8jipkGGYCWuzI6g1bkTVYJaBfEZSC6wNwLsBm2Em3S3UPBLdHEvmilsFO7zNAYyYjmUKLXcbx4+lS0qA8xF4n5lQS4JeXh2J1r4iTMeE+ZqGD3lvazq7uNY8kVZmSs0xydhaBi3X2j51edGIxCiQX19Of0eO8SwYlzquP5jKthZqHFGnmLVC5TbL+QmCrEAMjHPcSMmhqbrf8AUHUkvMPFq+YCnnBrP88yggsUL++JQM61Vujx3mbwqTKlkbPGZWDt8GGhbCsaYRYg0W9QbkvxC7Sy03nhqHM1PRhzO554D5Mo0PeUIOpT2h0j2nS+yNzc93LelHNqzPabD5h886gz4qae8yLFZOHVSySuW1u8n9QK2VBUhBAzLmZUqVxKPeEK5IiCBkytj8MU+CVUtqzb9yPvLaU1VftM6RrSghAUatpQedj/AKocl6ehjebedMp996CXdHvutRHkt2PjeMekZkaKWgBWERhDzix7VxA0wlQ4cviGtfbMLM1WpYvbQVa8EMgVDa9VAhkNMjbOXxG0F9FbOnmpbd2qjgo/hmTlZZpPqyzge88ZO74IJtHp9AcyZRxfrDrfEr4VKf8AxKvitT0JtXEBBxiaO6YNX1HVQIMM65h2wREt/rN8B2r4lILwKq4mpBUYZu03UZluX8uoaFg6Z2g9YI37U7JOtYz5SAsUWvaKgCupWqLlpNLjIH47lGGFId9gai9IjpbKvljBGU3F2hojklMF0N8L4cx2nAvkZ2e8EBjuxfeNqDGb/wCUwADYhZi0gEU2BV0bmICk3eoLHNFo8koDFHBMAHfmDqcnOcdvMvaWra75/wB6yzwyzz8TWrfUljVY9Jb/AGjh15lPdyokDxMTHU8/QlWlj9GHsj1DDFUAyyudhgWj1eZiCvlKRAczww/KGZZhOE4+WYS9OYrX2mZJXWg6Tn1gvArLy8dy+Cr+JFDI9iA275DLGz2y9oiygFum5rh9vQPTuVNzylejqC6jAbu2leeiGNqtupRwUyjaRqJVU371A9VKE1d0HrG18lt8woU1QaTPBQ1jEyUcZ6gVLZUGgLftF4FMDjpDz1YVvogYtsc0cDv+4gdEGNO1mBxapfKvmPeA9YiZ+SIb+SJ83oS+y+NzCIG6KNw9UnUfXNirnGy8LHj9Bui5lwYJ94CkcavFv/I2IEMgkM0gYiqKus4lOYqZGGUAKKgdKLQ9zJGK1EVlMK7JmZ6jC1oWqL5LmNUdqmGUfPCe8Ls1lCPNsIhI2KrBdaItoNXjRFdLii34jiYSdornMrquopdHvLi1DxMVig2S82yqBZBB2kqCQtuAU/1SsWEFYAiGSL95e3R1iOXSq6gkNviDVF4gmegMchc3eBFAHQ32w4dpg1YweCWjmueoZSXfD1DAl0xZBIRdazNYUlDOVyuIvLzEsV0Rsa1CV2qAO6iIAplcxcVmCBYyOLwylHryzTI5ktecrWMzXjMnGPScMniC8HmJbH3i9N/iMuuRrmO6VLxiW7t41FjgXF69Db49PFRaJUl2jfiDZtpDK8ekqE9W5HsjW7BjP5ipRseoq1eGoaxax1Nhxr3hQpJnDqGmS48+WHxINe3L8xDuQcVLmqiguS4IjI8NQ1Blg6lws/CTPsUZYpAvzUpQbug1G9JtPaAshND0gUDg5lAJH5JvY/AS0PTtgPWQY1E5CMcvtNvyQYP9xMt4I7xV7Tlf+TeKaS6j3Nci/wD5KEqYu8/MQFVWt3CXDsJsuFZrBDhcSycK1+uYTAWi9QJgWLuBFBD5gkKEzbzKSiR/mAIP/wB5/MbJrnMPDfDcLjBtE0R5Ya4aWLQ1vzFS5sbK1FVGqlNOviY+A5vuIjkZ+ZVpYMCqcC7xxEcRNPoyl7Fye5HMIboZ0iZTmIjgQ94w5iFhipppBRG55NNy0EctsWlS8L1LgUD3ZUige6OrAc4gZ06PESqUrAGZi/Ey5oOwhfrLEvN8M1rmoTusQOJNvtMLOHccMrnEa6rODcTCO9FxSsnFWj2QjqCq3jz5gEW0o4UoQPDqKC8DjuI22UzfmU5imYhbExmX1gVA8amjdd2XMTVyXqhl7FVY3KUBXWfiAOBXqgSMirHL05glCKQaJkOOtl2RlKLd5gm1CxhlujNxgG0GYANj7st6xNGdr6xtllT0h2fkEZgB0iNYC8BomjDnO4J2VzDoyVUNwYQN205i1ANAurlNwa6RQoHVHUUgomPMAA07cfCLU6Mxc2VVvmWhWrHhLjqO54itd1UKRdWX8xAbwD4gKW6UczNROWyeI4DpCZ3gF5HzLtuWEdShSXOFIG+JsWd1Kcj0NRoA27jqqIccxgwi7aI4Bh5MaNNuOI6zLRV1LYyMC35xMwANf2iigjFuvSKBlOK/ERRNkzn1mm2azVTXGO6isUO1wLUBh8wqsFuc3WPxNwwbGWBiUCmKzCYoc4NCWN5XUQ4usgRACu0pwQq6HmWUAc3eJYCn5RVorbUPd1qGBWOWRe5xxFwozbXMRsrZGOc67EBwo3n6e5MHAoPNxtkolm2GA34iOl8M4GMFG9eYUAlNohaXbdeIZU0ItaildKwsqno1uBwRqsVUYPYHuE4TV08xSLLVAMETc61hqEuY9NQ52VXmo1RwbuKtA6ssxZzhHxLKKW6bQkEBVMRuoxrM0mDgpuINLkcDMnAGWuY5WKBiBYYTBcpDlQK8MorU2u4QhatHEeBgDeyLZKHuGpQLzFZhdrWZQCIbazL2Quw5iBVWmyK2nYhkQNIcEIZY4jdEwwVLNrmBqOVZyVCXbkoyMSOG/Et7YhI00o/+pXQo5YjtXXXM0wJTw9MbvBrmPjEqX8xDYpz5jaKAs4QsIFMzf/wiC00BrMcqqNJEow9RuWZGDqXCaGpbIAYeIigXOW5Q2FEyJqCgohorcoNKriZWlvcqoNHEsGb+I2wzbbBLEXS8xQxNXYYkO0XkzYZxDrzHURoHZ3DxLS/SKHDVesUFdnEJ0GKQNOzDEQIv4lpgMBy9xJabMsy5TtOYSoPdLZQpCsAri8RXubPVLS0LV8wgCw4vmfeO+oMS4wgcfPjqUCtTYHXkGCGoVO29szHjxxGLsXlF8X6rVQwTQy4mAOXXco1RNwg7LO9wXqZxcNRRwxm27luyOF3b8xSjLV8EW4Qx50whCWDBqo3kpUNdUvtwFdGASx5FQ0FfRzNiuBbPvKIFvBx7y2uFLctVA5JmM0mlz8O5ZrRdu17mH0ggad2mGqbLicaKKEdRwixXTEQbhrL8Q2FTGsrHZbtlQQqUyJqVgOWgrcDYtenmXZvnYqW/FgGVV+IGpopbQcxI7Sw4IOJM0cbZd9EHaAxjmFJmTaXI+CiJVBV3IcShKRYKx/6AmURON1MUDrcemv5iFotsW6cRdCqoQTKhzBUVoeYNRd1qGr+azNkjNdw0KIWToa9JarNwvEF7gqFkb0ahHkbHzGoihbpRD/aVnU6ZpR+UJXtXywCC+a7iK19bE8zni+IXgy8Qlt2lkLEyOZV4TE13g1B4teIYbZecmFRb88IDYBiiJJz58ymuHmtyrYrd+UwqvRAqImu24MwWMqwVHy52qAFvpLlQJeVz9pl2OrqK6x5/zC7bVYI/W1vvKxrTVgRe1xtu/JCOAbFPzBkRua/irnZOx8vyzDBcyzDxCtjWBxUO22WSbgQYeEAQUM1AUmmZnRHnDLdhPViuPhnBDK6irVNnDAq/aAlCXlUYF/LmQ0dGFsDWRuNdlwTITdrKWMPdTTOYeEpqC5k/eeCejCnEYojHKOOhljhK0mIcCq53AhXL/gl7MimrAXK3ZZWyMFjeCElgiw4OoiQuxyDDLlZRFc6m4BebgGaPSCHN15iVYbU6PeCCK9FLy6BOYDUa5apxzLQ4rEqEzWbgGocnkdkVlZmI9+TIrlMWyq/WKVpcRu6eYqu2A7vEIut4JVxj3gBVnwkZlnrOI0qukoCY7lTA95hAIUesShvsIYKxoEABgzFJc39KzK0RLIzZ1WHDzCQ0aQmmOIBt7gbAB9JUtSzGI10W+GKVnapnSL9ePrB/LN8PtxLMeTgJbjHmyeYWXB1k+WMVYsjty8hSmx7VGxKsLTGj6rg6OuV2Yu2U3XEs8/apd4ZiDE0x7wLmDthQeWDU7IqWC4heuPIq5U6l3msxrVSviJL795mAttrRK1avXco6cPcGcQNxKzNxhma3Kpuce0y7mCb5lBlq+oPYGX8S1vHB5vEaaLLrqVYnsTA5zW4MVoEQcnnw3zDZkrJ477vuKpOruXFyyhg8czM6mXpKxbA9QP7lNaOQ8yxpAbB3FVZQWWMXiCQjnEGU8tx+Cp4GJqYGio6ks9yVAOxcpVqh55goCrKrA5F5IlfTi+JcVara4IYG3LzAori5cLWiXzHmOLm8wMbO5u+1l8RcQyvhiY242EOl0i37y6UNhe4hi9huMEuswrzUCAKAZgTG7pd4qFwZVZZbg0ZuYAK2XBrAWzGbW/5ng4+pjgZNn7zDlLxYLgU1mWnMpVUUWphTl0x4CGq6g5ZQwEQdjW3+IN7ACwutvOo7OkNNMRnFVxLrxcVos/8ArCS3YV5WG5beJhwwXmPiLV+v8RamyuYK4NTibv1i4rvEoG2uIasmZYacxwGVllWOK6/7horphOqN8I9XV4vqCPB8c8V5ljL5XB16wAc5PtDVcE6xAbsIKgEAuqIFYjLhiZCbWiEXAuBKhjMUM/TWOBmPRKUYzgWFSsKncurvPOSWWwavWoVGc7lhHUbCOmw55iCjbtdsCq0V4hCESJr2izaHbGoPdzKO5oubLzGhDACZL3K6EKtG4YEezOLhu1Z6S3ZC2JV2V5eJRKaMVTSeYzLbKQtVdm4+NzJnEFiw0HljWoZAcMw+S9OCeiJazNJtKTn6g5dVKOhZE3gjFYL5lVQ6Ii55+n2SxxkPuhn0lH0qcHrLy6+it15i1FNePpWocxZ7Rqb4SpKo6+qRwX3Lqo2PCzmDTmUJt/8AYbYWDSTOAgX1KjGBRVuB+D7yqFgKlRjmJd8sSuoBi45sUm/oNFTb6BhAz9BBDE4gwiyvrLEyPpZPLENYmYRzqvaYuuKilZla9JmrbqX+JhN0lbbNR0npMvkqvpL3Ie5dl4QBhviZuT1FbNV1XEQJyA/JGMfHldkHiCX4PEYQFsION1EVm8Kll2FRa1SANo3KGRuE5NTAfQIMwkD6JxDEcIsvpDuN20y1zmiWyFfQ15YcJVW6qbNnxLqsXmj1ljT1DS+EW4eNYxYX95W86lPkxBKcHzLxLt9ozGpdU+JdxJIdFAuuY7eUY0UyzgzxELS2za3c5MQnCmTlpcJP/9k="},
-			want:    "",
-			wantErr: true,
+			want:    "image/jpg",
+			wantErr: false,
 		},
 		{
 			name:    "comlpete png",
@@ -59,6 +59,12 @@ func TestB64ToImage(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name:    "not an image",
+			args:    args{b64: "data:image/png;base64,PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciLz4="},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name:    "empty b64 string",
 			args:    args{b64: ""},
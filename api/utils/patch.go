@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//MergePatch applies the JSON merge patch (RFC 7396) patch to the JSON document doc, objects are merged
+//recursively, null removes a member and any other value replaces it
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+
+	if _, ok := p.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("merge patch is not an object")
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+//PatchKeys returns the members set by the merge patch object patch, including the ones it removes
+func PatchKeys(patch []byte) ([]string, error) {
+	var p map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
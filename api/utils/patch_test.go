@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc, patch string
+		want       string
+		wantErr    bool
+	}{
+		{"replace", `{"a":"b","c":1}`, `{"a":"c"}`, `{"a":"c","c":1}`, false},
+		{"add", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`, false},
+		{"remove", `{"a":"b","c":1}`, `{"a":null}`, `{"c":1}`, false},
+		{"zero values", `{"public":true,"couch_number":2}`, `{"public":false,"couch_number":0}`, `{"public":false,"couch_number":0}`, false},
+		{"nested", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":"g"}}`, `{"a":{"b":"c","f":"g"}}`, false},
+		{"arrays are replaced", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`, false},
+		{"not an object", `{"a":"b"}`, `["c"]`, "", true},
+		{"invalid", `{"a":"b"}`, `{"a":`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MergePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var g, w interface{}
+			json.Unmarshal(got, &g)
+			json.Unmarshal([]byte(tt.want), &w)
+			if !reflect.DeepEqual(g, w) {
+				t.Errorf("MergePatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchKeys(t *testing.T) {
+	got, err := PatchKeys([]byte(`{"name":"Hossegor","couch_number":null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(got)
+	if want := []string{"couch_number", "name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PatchKeys() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	//totpSkew is the number of periods accepted before and after the current one
+	totpSkew = 1
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+//NewTOTPSecret returns a random base32 encoded secret suitable for authenticator apps
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return b32.EncodeToString(b), nil
+}
+
+//TOTPProvisioningURI builds the otpauth:// URI rendered as a QR code by the client
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+//TOTPCode computes the code of secret for the period containing t
+func TOTPCode(secret string, t time.Time) (string, error) {
+	key, err := b32.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix()/totpPeriod)), nil
+}
+
+//ValidateTOTP tells whether code matches secret at t, tolerating a small clock drift, and returns the time step
+//of the code. Only the steps after lastStep are accepted, a code cannot be used twice
+func ValidateTOTP(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	key, err := b32.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, false
+	}
+
+	counter := t.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		if counter+i <= lastStep {
+			continue
+		}
+		if hmac.Equal([]byte(hotp(key, uint64(counter+i))), []byte(code)) {
+			return counter + i, true
+		}
+	}
+	return 0, false
+}
+
+//hotp implements RFC 4226
+func hotp(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+//NewRecoveryCode returns a random human friendly one-time code like abcde-12345
+func NewRecoveryCode() (string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
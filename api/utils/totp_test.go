@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+//RFC 6238 appendix B secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "59", unix: 59, want: "287082"},
+		{name: "1111111109", unix: 1111111109, want: "081804"},
+		{name: "1234567890", unix: 1234567890, want: "005924"},
+		{name: "2000000000", unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TOTPCode(rfcSecret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Errorf("TOTPCode() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TOTPCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / 30
+	tests := []struct {
+		name     string
+		code     string
+		at       time.Time
+		lastStep int64
+		want     bool
+	}{
+		{name: "current period", code: "005924", at: now, want: true},
+		{name: "previous period", code: "005924", at: now.Add(30 * time.Second), want: true},
+		{name: "too old", code: "005924", at: now.Add(90 * time.Second), want: false},
+		{name: "wrong code", code: "123456", at: now, want: false},
+		{name: "wrong length", code: "05924", at: now, want: false},
+		{name: "after the last step", code: "005924", at: now, lastStep: step - 1, want: true},
+		{name: "replayed", code: "005924", at: now, lastStep: step, want: false},
+		{name: "before the last step", code: "005924", at: now.Add(30 * time.Second), lastStep: step + 1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(rfcSecret, tt.code, tt.at, tt.lastStep)
+			if ok != tt.want {
+				t.Errorf("ValidateTOTP() = %v, want %v", ok, tt.want)
+			}
+			if ok && got != step {
+				t.Errorf("ValidateTOTP() step = %d, want %d", got, step)
+			}
+		})
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	got := TOTPProvisioningURI("CouchSport", "john@doe.com", rfcSecret)
+	if !strings.HasPrefix(got, "otpauth://totp/CouchSport:john@doe.com?") {
+		t.Errorf("TOTPProvisioningURI() = %v", got)
+	}
+	if !strings.Contains(got, "secret="+rfcSecret) {
+		t.Errorf("TOTPProvisioningURI() = %v, missing secret", got)
+	}
+}
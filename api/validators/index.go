@@ -23,4 +23,12 @@ func Init() {
 		re := regexp.MustCompile("^[0-9a-zA-ZàáâäãåąčćęèéêëėįìíîïłńòóôöõøùúûüųūÿýżźñçčšžÀÁÂÄÃÅĄĆČĖĘÈÉÊËÌÍÎÏĮŁŃÒÓÔÖÕØÙÚÛÜŲŪŸÝŻŹÑßÇŒÆČŠŽ∂ð ,!?.'-]+$")
 		return re.MatchString(str)
 	})
+
+	//longtext is free text on several lines, any character but the markup and control ones
+	govalidator.TagMap["longtext"] = govalidator.Validator(func(str string) bool {
+		re := regexp.MustCompile("^[^<>\\x00-\\x08\\x0B\\x0C\\x0E-\\x1F\\x7F]+$")
+		return re.MatchString(str)
+	})
+
+	govalidator.TagMap["imageuri"] = govalidator.Validator(isImageURI)
 }
@@ -0,0 +1,230 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/asaskevich/govalidator"
+	log "github.com/sirupsen/logrus"
+)
+
+//Validator is implemented by the bodies having rules the valid tags cannot express, the returned fields
+//are relative to the body
+type Validator interface {
+	Validate() []models.InvalidField
+}
+
+//Struct checks the valid tags of v and of the structs it holds, then their Validate rules. It returns
+//an apperror validation error listing every invalid field by its json path, i.e images.0.url
+func Struct(v interface{}) error {
+	return check(v, nil)
+}
+
+//Partial is Struct only reporting the listed fields, for the bodies holding a subset of a model
+func Partial(v interface{}, fields ...string) error {
+	return check(v, fields)
+}
+
+//Field returns the error of field, its message key is validation.<code> and vars its template data
+func Field(field, code string, vars map[string]string) apperror.FieldError {
+	return apperror.FieldError{Field: field, Code: code, Key: "validation." + code, Vars: vars}
+}
+
+func check(v interface{}, only []string) error {
+	var fields []apperror.FieldError
+	walk(reflect.ValueOf(v), "", &fields)
+
+	if only != nil {
+		kept := fields[:0]
+		for _, f := range fields {
+			for _, name := range only {
+				if f.Field == name || strings.HasPrefix(f.Field, name+".") {
+					kept = append(kept, f)
+					break
+				}
+			}
+		}
+		fields = kept
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func walk(v reflect.Value, path string, fields *[]apperror.FieldError) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), join(path, strconv.Itoa(i)), fields)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	if v.Type() == timeType {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("valid")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		//embedded structs are flattened like encoding/json does
+		if f.Anonymous && name == "" {
+			walk(v.Field(i), path, fields)
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		if tag != "" {
+			if err, ok := rule(v.Field(i), join(path, name), tag); !ok {
+				*fields = append(*fields, err)
+				continue
+			}
+		}
+
+		walk(v.Field(i), join(path, name), fields)
+	}
+
+	var validator Validator
+	switch {
+	case !v.CanInterface():
+	case v.CanAddr():
+		validator, _ = v.Addr().Interface().(Validator)
+	default:
+		validator, _ = v.Interface().(Validator)
+	}
+
+	if validator != nil {
+		for _, f := range validator.Validate() {
+			*fields = append(*fields, Field(join(path, f.Field), f.Code, f.Vars))
+		}
+	}
+}
+
+//rule checks value against the rules of tag, empty values are only rejected by required
+func rule(value reflect.Value, field, tag string) (apperror.FieldError, bool) {
+	rules := strings.Split(tag, ",")
+
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			break
+		}
+		value = value.Elem()
+	}
+
+	if !value.IsValid() || value.IsZero() {
+		for _, r := range rules {
+			if r == "required" {
+				return Field(field, "required", nil), false
+			}
+		}
+		return apperror.FieldError{}, true
+	}
+
+	str := toString(value)
+	for _, r := range rules {
+		if r == "required" {
+			continue
+		}
+
+		if validate, ok := govalidator.TagMap[r]; ok {
+			if !validate(str) {
+				return Field(field, r, nil), false
+			}
+			continue
+		}
+
+		name := r
+		if i := strings.Index(r, "("); i > 0 {
+			name = r[:i]
+		}
+
+		validate, ok := govalidator.ParamTagMap[name]
+		if !ok {
+			log.Errorf("validators: unknown rule %s on %s", r, field)
+			continue
+		}
+
+		params := govalidator.ParamTagRegexMap[name].FindStringSubmatch(r)
+		if len(params) < 2 {
+			log.Errorf("validators: invalid rule %s on %s", r, field)
+			continue
+		}
+
+		if !validate(str, params[1:]...) {
+			return Field(field, name, vars(params[1:])), false
+		}
+	}
+
+	return apperror.FieldError{}, true
+}
+
+//vars returns the template data of a rule message, Min and Max for the ranges, Values for the lists
+func vars(params []string) map[string]string {
+	if len(params) == 2 {
+		return map[string]string{"Min": params[0], "Max": params[1]}
+	}
+	return map[string]string{"Values": strings.Join(strings.Split(params[0], "|"), ", ")}
+}
+
+func toString(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+var dataURI = regexp.MustCompile("^data:image/[a-z+]+;base64,")
+
+//isImageURI tells whether str is an url or the base64 data of an uploaded image
+func isImageURI(str string) bool {
+	return govalidator.IsRequestURI(str) || dataURI.MatchString(str)
+}
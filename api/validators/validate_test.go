@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+type testImage struct {
+	URL string `valid:"imageuri,required" json:"url"`
+}
+
+type testBase struct {
+	ID uint `valid:"numeric" json:"id"`
+}
+
+type testPage struct {
+	testBase
+	Name   string      `valid:"text,required,stringlength(1|5)" json:"name"`
+	About  string      `valid:"longtext" json:"about"`
+	Gender string      `valid:"in(Male|Female)" json:"gender"`
+	Lat    float64     `valid:"latitude" json:"lat"`
+	Count  *int        `valid:"numeric" json:"count"`
+	Images []testImage `json:"images"`
+	Owner  *testPage   `valid:"-" json:"owner"`
+	ToID   uint        `json:"to_id"`
+}
+
+func (me testPage) Validate() []models.InvalidField {
+	if me.ToID < 1 {
+		return []models.InvalidField{{Field: "to_id", Code: "required"}}
+	}
+	return nil
+}
+
+func TestStruct(t *testing.T) {
+	Init()
+
+	tests := []struct {
+		name string
+		page testPage
+		want []string
+	}{
+		{"valid", testPage{Name: "Spot", Lat: 0.00001, ToID: 1, Images: []testImage{{URL: "/static/img/a.png"}, {URL: "data:image/png;base64,iVBO"}}}, nil},
+		{"long text", testPage{Name: "Spot", About: "Swell: 2m (\"perfect\")\n\tsee you 😀", ToID: 1}, nil},
+		{"required", testPage{ToID: 1}, []string{"name:required"}},
+		{"rules", testPage{Name: "<b>", About: "<script>", Gender: "Other", Lat: 91, ToID: 1}, []string{"name:text", "about:longtext", "gender:in", "lat:latitude"}},
+		{"length", testPage{Name: "Hossegor", ToID: 1}, []string{"name:stringlength"}},
+		{"nested", testPage{Name: "Spot", ToID: 1, Images: []testImage{{URL: "/a.png"}, {}}, Owner: &testPage{}}, []string{"images.1.url:required"}},
+		{"rules of the body", testPage{Name: "Spot"}, []string{"to_id:required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.page)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Struct() = %s, want nil", err)
+				}
+				return
+			}
+
+			var e *apperror.Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Struct() = %v, want an *apperror.Error", err)
+			}
+
+			var got []string
+			for _, f := range e.Fields {
+				got = append(got, f.Field+":"+f.Code)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartial(t *testing.T) {
+	Init()
+
+	err := Partial(testPage{Gender: "Other"}, "gender")
+
+	var e *apperror.Error
+	if !errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0].Field != "gender" {
+		t.Errorf("Partial() = %v, want only the gender error", err)
+	}
+
+	if err := Partial(testPage{Gender: "Male"}, "gender"); err != nil {
+		t.Errorf("Partial() = %s, want nil", err)
+	}
+}
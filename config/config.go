@@ -13,7 +13,7 @@ type Config struct {
 	Port                                                                     int
 	Populate, Verbose                                                        bool
 	Env, FilePrefix, Username, Password, DataFile, PublicPath, ImageBasePath string
-	DataSourceName, DatabaseParams, DriverName, FixtureFile                  string
+	DataSourceName, DatabaseParams, DriverName, FixturePath                  string
 	Logger                                                                   struct {
 		Name, Mode, FilePath string
 	}
@@ -24,8 +24,47 @@ type Config struct {
 	Localizer struct {
 		LanguageFiles []string
 	}
+	Security struct {
+		//BcryptCost is used when hashing passwords, weaker hashes are upgraded on login
+		BcryptCost int
+		//MaxLoginAttempts failures are allowed per account (MaxIPLoginAttempts per IP) before locking
+		MaxLoginAttempts, MaxIPLoginAttempts int
+		//LockoutSeconds is the first lock duration, doubled on every new failure up to MaxLockoutSeconds
+		LockoutSeconds, MaxLockoutSeconds int
+	}
+	//ExportPath holds the members data exports, it must not be publicly served
+	ExportPath      string
+	AccountDeletion struct {
+		//GraceDays the member has to cancel the deletion of its account, 0 deletes it right away
+		GraceDays *int
+		//KeepConversations keeps the counterpart copy of conversations with the member anonymized,
+		//otherwise conversations are deleted for both sides
+		KeepConversations *bool
+	}
+	Trash struct {
+		//RetentionDays the deleted pages and images are kept, they can be restored until then
+		RetentionDays int
+	}
+	Uploads struct {
+		//MaxBytes is the size of the largest file accepted by the uploads, the chunks of a resumable upload included
+		MaxBytes int64
+	}
+	Images struct {
+		//MaxWidth and MaxHeight bound the saved images, the larger ones are scaled down keeping their aspect ratio
+		MaxWidth, MaxHeight int
+	}
+	API struct {
+		//Deprecated maps the deprecated api versions to their sunset date (2006-01-02), empty when not planned yet
+		Deprecated map[string]string
+	}
 }
 
+//DefaultGraceDays and DefaultKeepConversations apply when the AccountDeletion settings are not set
+const (
+	DefaultGraceDays         = 14
+	DefaultKeepConversations = true
+)
+
 //Load loads the configuration according to env parameter. i.e config.dev.json
 func Load(env string) *Config {
 	jsonFile, err := ioutil.ReadFile("config." + env + ".json")
@@ -41,5 +80,63 @@ func Load(env string) *Config {
 		config.Env = env
 	}
 
+	if config.ExportPath == "" {
+		config.ExportPath = "./exports"
+	}
+
+	if config.FixturePath == "" {
+		config.FixturePath = "./fixtures"
+	}
+
+	if config.AccountDeletion.GraceDays == nil {
+		days := DefaultGraceDays
+		config.AccountDeletion.GraceDays = &days
+	}
+
+	if config.AccountDeletion.KeepConversations == nil {
+		keep := DefaultKeepConversations
+		config.AccountDeletion.KeepConversations = &keep
+	}
+
+	if config.Trash.RetentionDays == 0 {
+		config.Trash.RetentionDays = 30
+	}
+
+	if config.Uploads.MaxBytes == 0 {
+		config.Uploads.MaxBytes = 10 << 20
+	}
+
+	if config.Images.MaxWidth == 0 {
+		config.Images.MaxWidth = 2048
+	}
+
+	if config.Images.MaxHeight == 0 {
+		config.Images.MaxHeight = 2048
+	}
+
+	setSecurityDefaults(config)
+
 	return config
 }
+
+func setSecurityDefaults(config *Config) {
+	if config.Security.BcryptCost == 0 {
+		config.Security.BcryptCost = 12
+	}
+
+	if config.Security.MaxLoginAttempts == 0 {
+		config.Security.MaxLoginAttempts = 5
+	}
+
+	if config.Security.MaxIPLoginAttempts == 0 {
+		config.Security.MaxIPLoginAttempts = 20
+	}
+
+	if config.Security.LockoutSeconds == 0 {
+		config.Security.LockoutSeconds = 30
+	}
+
+	if config.Security.MaxLockoutSeconds == 0 {
+		config.Security.MaxLockoutSeconds = 60 * 60
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/utils"
+)
+
+func TestAPI_Account(t *testing.T) {
+	ts := newTestServer(t)
+
+	anonymous := ts.client()
+	if code := anonymous.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET /profiles/me without session: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := anonymous.do(http.MethodPost, "/sessions", map[string]string{"email": "nobody@couchsport.test", "password": "password"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("login of an unknown email: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if _, total, err := ts.Stores.AuditStore().Query(models.AuditFilter{Action: "session.login_failed"}, 0, 10); err != nil || total != 1 {
+		t.Errorf("failed login audit events = %d, %v, want 1", total, err)
+	}
+
+	member := ts.member("member@couchsport.test")
+
+	res, err := http.Post(ts.URL+"/api/sessions", "application/json", strings.NewReader(`{"email":"member@couchsport.test","password":"password"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if cookies := res.Cookies(); len(cookies) != 1 || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Errorf("login cookies = %+v, want one SameSite=Lax cookie", cookies)
+	}
+
+	if code := member.do(http.MethodPost, "/users", map[string]string{"email": "member@couchsport.test", "password": "password"}, nil); code != http.StatusForbidden {
+		t.Errorf("signup twice: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	var languages []models.Language
+	if code := member.do(http.MethodGet, "/languages", nil, &languages); code != http.StatusOK || len(languages) == 0 {
+		t.Fatalf("languages: status %d, %d languages", code, len(languages))
+	}
+
+	profile := member.profile()
+	profile.Username = "member"
+	profile.City = "Biarritz"
+	profile.Languages = []*models.Language{&languages[0]}
+
+	if code := member.do(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), profile, nil); code != http.StatusOK {
+		t.Fatalf("PATCH /profiles/%d: status %d", profile.ID, code)
+	}
+
+	if got := member.profile(); got.Username != "member" || got.City != "Biarritz" {
+		t.Errorf("profile after update = %s from %s, want member from Biarritz", got.Username, got.City)
+	}
+
+	other := ts.member("other@couchsport.test")
+	profile.Username = "stolen"
+	if code := other.do(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), profile, nil); code == http.StatusOK {
+		t.Errorf("PATCH of another member profile succeeded")
+	}
+
+	if code := member.do(http.MethodDelete, "/sessions", nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE /sessions: status %d", code)
+	}
+
+	if code := member.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET /profiles/me after logout: status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestAPI_Roles(t *testing.T) {
+	ts := newTestServer(t)
+	//the first member is the admin
+	admin := ts.member("admin@couchsport.test")
+	member := ts.member("member@couchsport.test")
+
+	adminUser, _ := ts.Stores.UserStore().GetByEmail("admin@couchsport.test", false)
+	memberUser, _ := ts.Stores.UserStore().GetByEmail("member@couchsport.test", false)
+	adminPath := fmt.Sprintf("/users/%d/roles/", adminUser.ID)
+	memberPath := fmt.Sprintf("/users/%d/roles/", memberUser.ID)
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		if code := member.do(method, memberPath+models.RoleModerator, nil, nil); code != http.StatusForbidden {
+			t.Errorf("%s %s by a member: status %d, want %d", method, memberPath+models.RoleModerator, code, http.StatusForbidden)
+		}
+	}
+
+	if code := member.do(http.MethodGet, "/admin/pages", nil, nil); code != http.StatusForbidden {
+		t.Fatalf("GET /admin/pages by a member: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	var roles []models.Role
+	if code := admin.do(http.MethodPut, memberPath+models.RoleModerator, nil, &roles); code != http.StatusOK || len(roles) != len(models.DefaultRoles)+1 {
+		t.Fatalf("PUT %s: status %d, %d roles", memberPath+models.RoleModerator, code, len(roles))
+	}
+
+	if code := member.do(http.MethodGet, "/admin/pages", nil, nil); code != http.StatusOK {
+		t.Errorf("GET /admin/pages by a moderator: status %d, want %d", code, http.StatusOK)
+	}
+
+	if code := admin.do(http.MethodDelete, memberPath+models.RoleModerator, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", memberPath+models.RoleModerator, code)
+	}
+
+	if code := member.do(http.MethodGet, "/admin/pages", nil, nil); code != http.StatusForbidden {
+		t.Errorf("GET /admin/pages once revoked: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	if code := admin.do(http.MethodPut, memberPath+"owner", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("PUT of an unknown role: status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if code := admin.do(http.MethodDelete, adminPath+models.RoleAdmin, nil, nil); code != http.StatusBadRequest {
+		t.Errorf("DELETE of the last admin: status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if code := admin.do(http.MethodPut, "/users/999/roles/"+models.RoleModerator, nil, nil); code != http.StatusNotFound {
+		t.Errorf("PUT of a role to an unknown user: status %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestAPI_Audit(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.member("admin@couchsport.test")
+	member := ts.member("member@couchsport.test")
+
+	if code := member.do(http.MethodGet, "/admin/audit", nil, nil); code != http.StatusForbidden {
+		t.Errorf("GET /admin/audit by a member: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	memberUser, _ := ts.Stores.UserStore().GetByEmail("member@couchsport.test", false)
+	if code := admin.do(http.MethodPut, fmt.Sprintf("/admin/users/%d/suspension", memberUser.ID), nil, nil); code != http.StatusOK {
+		t.Fatalf("PUT /admin/users/%d/suspension: status %d", memberUser.ID, code)
+	}
+
+	var page struct {
+		Events []models.AuditEvent `json:"events"`
+		Total  int64               `json:"total"`
+	}
+	if code := admin.do(http.MethodGet, "/admin/audit?target_type=user&action=session.login", nil, &page); code != http.StatusOK || page.Total != 2 {
+		t.Fatalf("GET /admin/audit of the logins: status %d, %d events, want 2", code, page.Total)
+	}
+
+	if code := admin.do(http.MethodGet, "/admin/audit?limit=1&offset=1", nil, &page); code != http.StatusOK || len(page.Events) != 1 || page.Total < 3 {
+		t.Errorf("GET /admin/audit?limit=1&offset=1: status %d, %d events of %d", code, len(page.Events), page.Total)
+	}
+
+	if code := admin.do(http.MethodGet, "/admin/audit?from=yesterday", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("GET /admin/audit with an invalid date: status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	res, body := admin.send(http.MethodGet, fmt.Sprintf("/admin/audit/export?target_id=%d&action=user.suspend", memberUser.ID), "", "")
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("GET /admin/audit/export: status %d, %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	var event models.AuditEvent
+	if len(lines) != 1 || json.Unmarshal([]byte(lines[0]), &event) != nil || event.Action != "user.suspend" || event.TargetID != memberUser.ID {
+		t.Errorf("GET /admin/audit/export = %q, want the suspension only", body)
+	}
+}
+
+func TestAPI_AdminUsers(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.member("admin@couchsport.test")
+	member := ts.member("member@couchsport.test")
+	anonymous := ts.client()
+
+	adminUser, _ := ts.Stores.UserStore().GetByEmail("admin@couchsport.test", false)
+	memberUser, _ := ts.Stores.UserStore().GetByEmail("member@couchsport.test", false)
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		res, body := admin.send(method, fmt.Sprintf("/admin/users/%d/suspension", adminUser.ID), "", "")
+		var got apperror.Body
+		if err := json.Unmarshal(body, &got); err != nil || res.StatusCode != http.StatusBadRequest || got.Code != "admin.self_target" {
+			t.Errorf("%s of the own suspension: status %d, body %s", method, res.StatusCode, body)
+		}
+	}
+
+	if res, body := admin.send(http.MethodDelete, fmt.Sprintf("/admin/users/%d", adminUser.ID), "", ""); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("DELETE of the own account: status %d, body %s", res.StatusCode, body)
+	}
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		if code := admin.do(method, "/admin/users/999/suspension", nil, nil); code != http.StatusNotFound {
+			t.Errorf("%s of the suspension of an unknown user: status %d, want %d", method, code, http.StatusNotFound)
+		}
+	}
+
+	if code := admin.do(http.MethodDelete, "/admin/users/999", nil, nil); code != http.StatusNotFound {
+		t.Errorf("DELETE of an unknown user: status %d, want %d", code, http.StatusNotFound)
+	}
+
+	events, _, err := ts.Stores.AuditStore().Query(models.AuditFilter{TargetType: "user", TargetID: 999}, 0, 10)
+	if err != nil || len(events) != 0 {
+		t.Errorf("audit of the unknown user = %+v, %v, want none", events, err)
+	}
+
+	var page models.Page
+	if code := member.do(http.MethodPost, "/pages", models.Page{Name: "Biarritz", Description: "surf spot"}, &page); code != http.StatusOK {
+		t.Fatalf("POST /pages: status %d", code)
+	}
+	pagePath := fmt.Sprintf("/pages/%d", page.ID)
+	if code := member.do(http.MethodPut, pagePath+"/status", map[string]string{"status": models.PagePending}, nil); code != http.StatusOK {
+		t.Fatalf("PUT %s/status: status %d", pagePath, code)
+	}
+	if code := admin.do(http.MethodPost, "/admin"+pagePath+"/approval", nil, nil); code != http.StatusOK {
+		t.Fatalf("POST /admin%s/approval: status %d", pagePath, code)
+	}
+
+	profilePath := fmt.Sprintf("/profiles/%d", memberUser.ProfileID)
+	if res, body := member.send(http.MethodPatch, profilePath, "", `{"username":"member"}`); res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH %s: status %d, body %s", profilePath, res.StatusCode, body)
+	}
+	if code := anonymous.do(http.MethodGet, profilePath, nil, nil); code != http.StatusOK {
+		t.Fatalf("GET %s: status %d", profilePath, code)
+	}
+
+	if code := admin.do(http.MethodDelete, fmt.Sprintf("/admin/users/%d", memberUser.ID), nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE /admin/users/%d: status %d", memberUser.ID, code)
+	}
+
+	//the deleted member leaves nothing public behind
+	for _, path := range []string{pagePath, profilePath} {
+		if code := anonymous.do(http.MethodGet, path, nil, nil); code != http.StatusNotFound {
+			t.Errorf("GET %s once the member is deleted: status %d, want %d", path, code, http.StatusNotFound)
+		}
+	}
+
+	if code := member.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET /profiles/me once deleted: status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestAPI_TwoFactor(t *testing.T) {
+	ts := newTestServer(t)
+
+	//enable enrolls and confirms 2FA for the member of c, it returns the secret and the recovery codes
+	enable := func(c *apiClient) (string, []string) {
+		var enrolled struct{ Secret, URI string }
+		if code := c.do(http.MethodPost, "/users/me/2fa", nil, &enrolled); code != http.StatusOK || enrolled.Secret == "" || !strings.HasPrefix(enrolled.URI, "otpauth://") {
+			t.Fatalf("POST /users/me/2fa: status %d, %+v", code, enrolled)
+		}
+
+		if code := c.do(http.MethodPost, "/users/me/2fa/confirm", map[string]string{"code": "000000"}, nil); code != http.StatusBadRequest {
+			t.Errorf("confirm with a wrong code: status %d, want %d", code, http.StatusBadRequest)
+		}
+
+		var confirmed struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		if code := c.do(http.MethodPost, "/users/me/2fa/confirm", map[string]string{"code": totp(t, enrolled.Secret)}, &confirmed); code != http.StatusOK || len(confirmed.RecoveryCodes) != 10 {
+			t.Fatalf("POST /users/me/2fa/confirm: status %d, %d recovery codes", code, len(confirmed.RecoveryCodes))
+		}
+
+		return enrolled.Secret, confirmed.RecoveryCodes
+	}
+
+	//login logs email in on a new client, the session is partial until the second factor is verified
+	login := func(email string) (*apiClient, int) {
+		c := ts.client()
+		var res models.LoginResponseModel
+		code := c.do(http.MethodPost, "/sessions", map[string]string{"email": email, "password": "password"}, &res)
+		if code == http.StatusOK && !res.TwoFactorRequired {
+			t.Errorf("login of %s with 2FA enabled: the second factor is not required", email)
+		}
+		return c, code
+	}
+
+	verify := func(c *apiClient, code string) int {
+		return c.do(http.MethodPost, "/sessions/verify", map[string]string{"code": code}, nil)
+	}
+
+	member := ts.member("member@couchsport.test")
+	secret, recovery := enable(member)
+
+	c, code := login("member@couchsport.test")
+	if code != http.StatusOK {
+		t.Fatalf("POST /sessions: status %d", code)
+	}
+
+	if code := c.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET /profiles/me with a partial session: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := verify(c, "000000"); code != http.StatusUnauthorized {
+		t.Errorf("verify with a wrong code: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	//a password alone does not close the sessions of the member, the second factor does
+	member.profile()
+
+	if code := verify(c, recovery[0]); code != http.StatusOK {
+		t.Fatalf("verify with a recovery code: status %d", code)
+	}
+
+	c.profile()
+	if code := member.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET /profiles/me with the session opened before the verification: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	c, _ = login("member@couchsport.test")
+	if code := verify(c, recovery[0]); code != http.StatusUnauthorized {
+		t.Errorf("verify with a used recovery code: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	//the code confirming the enrolment is spent, the next period one is accepted for the clock drift
+	if code := verify(c, totp(t, secret)); code != http.StatusUnauthorized {
+		t.Errorf("verify with the code of the enrolment: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	next := totpAt(t, secret, time.Now().Add(30*time.Second))
+	if code := verify(c, next); code != http.StatusOK {
+		t.Fatalf("verify with a TOTP code: status %d", code)
+	}
+
+	replay, _ := login("member@couchsport.test")
+	if code := verify(replay, next); code != http.StatusUnauthorized {
+		t.Errorf("verify with a replayed TOTP code: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := c.do(http.MethodPost, "/users/me/2fa/recovery-codes", map[string]string{"password": "wrong"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("recovery codes with a wrong password: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	var regenerated struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	if code := c.do(http.MethodPost, "/users/me/2fa/recovery-codes", map[string]string{"password": "password"}, &regenerated); code != http.StatusOK || len(regenerated.RecoveryCodes) != 10 {
+		t.Fatalf("POST /users/me/2fa/recovery-codes: status %d, %d codes", code, len(regenerated.RecoveryCodes))
+	}
+
+	c, _ = login("member@couchsport.test")
+	if code := verify(c, recovery[1]); code != http.StatusUnauthorized {
+		t.Errorf("verify with a replaced recovery code: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := verify(c, regenerated.RecoveryCodes[0]); code != http.StatusOK {
+		t.Fatalf("verify with a new recovery code: status %d", code)
+	}
+
+	if code := c.do(http.MethodDelete, "/users/me/2fa", map[string]string{"password": "wrong"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("disable with a wrong password: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := c.do(http.MethodDelete, "/users/me/2fa", map[string]string{"password": "password"}, nil); code != http.StatusOK {
+		t.Fatalf("DELETE /users/me/2fa: status %d", code)
+	}
+
+	c = ts.client()
+	var res models.LoginResponseModel
+	if code := c.do(http.MethodPost, "/sessions", map[string]string{"email": "member@couchsport.test", "password": "password"}, &res); code != http.StatusOK || res.TwoFactorRequired {
+		t.Errorf("login with 2FA disabled: status %d, second factor required %t", code, res.TwoFactorRequired)
+	}
+	c.profile()
+
+	//the codes share the lockout of the passwords, logging in again does not reset it
+	guessed := ts.member("guessed@couchsport.test")
+	secret, _ = enable(guessed)
+
+	c, _ = login("guessed@couchsport.test")
+	for i := 1; i < 5; i++ {
+		if code := verify(c, "000000"); code != http.StatusUnauthorized {
+			t.Fatalf("wrong code %d: status %d, want %d", i, code, http.StatusUnauthorized)
+		}
+
+		if i == 2 {
+			c, _ = login("guessed@couchsport.test")
+		}
+	}
+
+	if code := verify(c, "000000"); code != http.StatusTooManyRequests {
+		t.Fatalf("wrong code 5: status %d, want %d", code, http.StatusTooManyRequests)
+	}
+
+	if code := verify(c, totp(t, secret)); code != http.StatusUnauthorized {
+		t.Errorf("verify after the lockout: status %d, want the partial session dropped", code)
+	}
+
+	if _, code := login("guessed@couchsport.test"); code != http.StatusTooManyRequests {
+		t.Errorf("login after the lockout: status %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
+
+//totp returns the current code of secret
+func totp(t *testing.T, secret string) string {
+	return totpAt(t, secret, time.Now())
+}
+
+//totpAt returns the code of secret for the period containing at
+func totpAt(t *testing.T, secret string, at time.Time) string {
+	code, err := utils.TOTPCode(secret, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/gorilla/websocket"
+)
+
+func TestAPI_Messaging(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+	guest := ts.member("guest@couchsport.test")
+
+	var hostUser models.User
+	if err := ts.Stores.Db.Where("email = ?", "host@couchsport.test").First(&hostUser).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	hostWs := host.dial()
+
+	//the hub only registers the logged members, on their own profile
+	if _, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/ws?id=1", nil); err == nil || res == nil || res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /ws without a session: %v, want status %d", err, http.StatusUnauthorized)
+	}
+
+	send := func(text string) models.MessageView {
+		var message models.MessageView
+		body := map[string]interface{}{"email": "guest@couchsport.test", "text": text, "to_id": hostUser.ID}
+		if code := guest.do(http.MethodPost, "/messages", body, &message); code != http.StatusOK {
+			t.Fatalf("POST /messages: status %d", code)
+		}
+		return message
+	}
+
+	send("Hello, is your couch free next weekend ?")
+	if m := hostWs.next(t); m["mutation"] != "NEW_CONVERSATION" || m["namespace"] != "conversations" {
+		t.Errorf("first message pushed %v, want a NEW_CONVERSATION mutation", m)
+	}
+
+	if sent := send("I would arrive on friday"); sent.Email != "guest@couchsport.test" {
+		t.Errorf("sent message email = %q, the sender should see its own email", sent.Email)
+	}
+	m := hostWs.next(t)
+	if m["mutation"] != "CONVERSATION_ADD_MESSAGE" {
+		t.Fatalf("second message pushed %v, want a CONVERSATION_ADD_MESSAGE mutation", m)
+	}
+
+	var message models.Message
+	if err := json.Unmarshal([]byte(m["data"].(string)), &message); err != nil || message.Text != "I would arrive on friday" {
+		t.Errorf("pushed message = %+v, %v", message, err)
+	}
+
+	//the email of the guest is private by default, the message does not show it to the host
+	if strings.Contains(m["data"].(string), "guest@couchsport.test") {
+		t.Errorf("pushed message %s shows the email of the sender", m["data"])
+	}
+
+	var conversations []models.Conversation
+	if code := host.do(http.MethodGet, "/profiles/me/conversations", nil, &conversations); code != http.StatusOK {
+		t.Fatalf("GET /profiles/me/conversations: status %d", code)
+	}
+
+	if len(conversations) != 1 || len(conversations[0].Messages) != 2 {
+		t.Errorf("host conversations = %+v, want one conversation of two messages", conversations)
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+func TestAPI_Pages(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+	other := ts.member("guest@couchsport.test")
+
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", map[string]interface{}{
+		"name":        "Hossegor",
+		"description": "surf spot",
+		"lat":         43.66,
+		"lng":         -1.44,
+	}, &page); code != http.StatusOK {
+		t.Fatalf("POST /pages: status %d", code)
+	}
+
+	pages := func(c *apiClient) []models.Page {
+		var pages []models.Page
+		if code := c.do(http.MethodGet, "/profiles/me/pages", nil, &pages); code != http.StatusOK {
+			t.Fatalf("GET /profiles/me/pages: status %d", code)
+		}
+		return pages
+	}
+
+	if got := pages(host); len(got) != 1 || got[0].ID != page.ID || got[0].Public || got[0].Status != models.PageDraft {
+		t.Fatalf("host pages = %+v, want page %d in draft", got, page.ID)
+	}
+
+	path := fmt.Sprintf("/pages/%d", page.ID)
+
+	tests := []struct {
+		name   string
+		client *apiClient
+		method string
+		path   string
+		body   map[string]interface{}
+		ok     bool
+	}{
+		{"update by another member", other, http.MethodPatch, path, map[string]interface{}{"name": "Stolen", "description": "surf spot"}, false},
+		{"update", host, http.MethodPatch, path, map[string]interface{}{"name": "Seignosse", "description": "surf spot"}, true},
+		{"submit by another member", other, http.MethodPut, path + "/publish", map[string]interface{}{"public": true}, false},
+		{"submit", host, http.MethodPut, path + "/publish", map[string]interface{}{"public": true}, true},
+		{"approve by a member", other, http.MethodPost, "/admin" + path + "/approval", nil, false},
+		{"approve", host, http.MethodPost, "/admin" + path + "/approval", nil, true},
+		{"unpublish by another member", other, http.MethodPut, path + "/publish", map[string]interface{}{"public": false}, false},
+		{"unpublish", host, http.MethodPut, path + "/publish", map[string]interface{}{"public": false}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := tt.client.do(tt.method, tt.path, tt.body, nil); (code == http.StatusOK) != tt.ok {
+				t.Errorf("%s %s: status %d", tt.method, tt.path, code)
+			}
+		})
+	}
+
+	if got := pages(host); len(got) != 1 || got[0].Name != "Seignosse" || got[0].Public || got[0].Status != models.PageUnpublished {
+		t.Errorf("host pages = %+v, want Seignosse unpublished", got)
+	}
+
+	if code := other.do(http.MethodDelete, path, nil, nil); code == http.StatusOK {
+		t.Errorf("DELETE by another member succeeded")
+	}
+
+	if code := host.do(http.MethodDelete, path, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", path, code)
+	}
+
+	if got := pages(host); len(got) != 0 {
+		t.Errorf("host pages after delete = %+v", got)
+	}
+}
+
+func TestAPI_Moderation(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.member("admin@couchsport.test")
+	host := ts.member("host@couchsport.test")
+	anonymous := ts.client()
+
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Hossegor", Description: "surf spot"}, &page); code != http.StatusOK {
+		t.Fatalf("POST /pages: status %d", code)
+	}
+	path := fmt.Sprintf("/pages/%d", page.ID)
+
+	visible := func(c *apiClient) bool {
+		return c.do(http.MethodGet, path, nil, nil) == http.StatusOK
+	}
+
+	if visible(anonymous) || !visible(host) {
+		t.Errorf("draft visible to a visitor: %t, to its owner: %t, want false, true", visible(anonymous), visible(host))
+	}
+
+	setStatus := func(ifMatch, status string, want int) models.Page {
+		res, body := host.send(http.MethodPut, path+"/status", ifMatch, fmt.Sprintf(`{"status":%q}`, status))
+		if res.StatusCode != want {
+			t.Fatalf("PUT %s/status %s: status %d, want %d, body %s", path, status, res.StatusCode, want, body)
+		}
+
+		var page models.Page
+		if want == http.StatusOK {
+			if err := json.Unmarshal(body, &page); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return page
+	}
+
+	setStatus(`"1"`, models.PagePublished, http.StatusUnprocessableEntity)
+	setStatus(`"2"`, models.PagePending, http.StatusPreconditionFailed)
+	if got := setStatus(`"1"`, models.PagePending, http.StatusOK); got.Status != models.PagePending {
+		t.Errorf("submitted page status = %s, want %s", got.Status, models.PagePending)
+	}
+
+	queue := func() []uint {
+		var queue struct {
+			Pages []models.Page `json:"pages"`
+			Total int64         `json:"total"`
+		}
+		if code := admin.do(http.MethodGet, "/admin/pages", nil, &queue); code != http.StatusOK {
+			t.Fatalf("GET /admin/pages: status %d", code)
+		}
+
+		ids := []uint{}
+		for _, p := range queue.Pages {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	if got := queue(); !reflect.DeepEqual(got, []uint{page.ID}) {
+		t.Errorf("moderation queue = %v, want [%d]", got, page.ID)
+	}
+
+	if code := host.do(http.MethodGet, "/admin/pages", nil, nil); code != http.StatusForbidden {
+		t.Errorf("GET /admin/pages by a member: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	if code := admin.do(http.MethodPost, "/admin"+path+"/rejection", map[string]string{}, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("rejection without a reason: status %d, want %d", code, http.StatusUnprocessableEntity)
+	}
+
+	var rejected models.Page
+	if code := admin.do(http.MethodPost, "/admin"+path+"/rejection", map[string]string{"reason": "add some pictures"}, &rejected); code != http.StatusOK {
+		t.Fatalf("POST /admin%s/rejection: status %d", path, code)
+	}
+
+	if rejected.Status != models.PageDraft || rejected.RejectionReason != "add some pictures" || len(queue()) != 0 {
+		t.Errorf("rejected page = %s %q, want a draft with the reason out of the queue", rejected.Status, rejected.RejectionReason)
+	}
+
+	publishAt := time.Now().Add(time.Hour)
+	if res, body := host.send(http.MethodPatch, path, "", fmt.Sprintf(`{"publish_at":%q}`, publishAt.Format(time.RFC3339))); res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH %s: status %d, body %s", path, res.StatusCode, body)
+	}
+
+	resubmitted := setStatus("", models.PagePending, http.StatusOK)
+	if resubmitted.RejectionReason != "" {
+		t.Errorf("resubmitted page keeps the rejection reason %q", resubmitted.RejectionReason)
+	}
+
+	var approved models.Page
+	if code := admin.do(http.MethodPost, "/admin"+path+"/approval", nil, &approved); code != http.StatusOK || approved.Status != models.PageScheduled {
+		t.Fatalf("POST /admin%s/approval: status %d, page %s, want %s", path, code, approved.Status, models.PageScheduled)
+	}
+
+	if code := admin.do(http.MethodPost, "/admin"+path+"/approval", nil, nil); code != http.StatusConflict {
+		t.Errorf("approval of a scheduled page: status %d, want %d", code, http.StatusConflict)
+	}
+
+	if visible(anonymous) {
+		t.Errorf("scheduled page visible to a visitor")
+	}
+
+	if n, err := ts.Stores.PageStore().ApplySchedule(publishAt.Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("ApplySchedule() = %d, %v, want 1", n, err)
+	}
+
+	if !visible(anonymous) {
+		t.Errorf("page not visible to a visitor once its publication time passed")
+	}
+
+	//the location is not reviewed, the content is
+	if res, body := host.send(http.MethodPatch, path, "", `{"lat":43.66,"name":"Hossegor"}`); res.StatusCode != http.StatusOK || !visible(anonymous) {
+		t.Fatalf("PATCH %s without a content change: status %d, visible %t, body %s", path, res.StatusCode, visible(anonymous), body)
+	}
+
+	var edited models.Page
+	if res, body := host.send(http.MethodPatch, path, "", `{"name":"Hossegor, la Gravière"}`); res.StatusCode != http.StatusOK || json.Unmarshal(body, &edited) != nil {
+		t.Fatalf("PATCH %s: status %d, body %s", path, res.StatusCode, body)
+	}
+
+	if edited.Status != models.PagePending || edited.Public || visible(anonymous) || !reflect.DeepEqual(queue(), []uint{page.ID}) {
+		t.Errorf("renamed page = %s, public %t, visible to a visitor %t, want it back in the queue", edited.Status, edited.Public, visible(anonymous))
+	}
+
+	if code := admin.do(http.MethodPost, "/admin"+path+"/approval", nil, nil); code != http.StatusOK {
+		t.Fatalf("POST /admin%s/approval: status %d", path, code)
+	}
+
+	if n, err := ts.Stores.PageStore().ApplySchedule(publishAt.Add(time.Minute)); err != nil || n != 1 || !visible(anonymous) {
+		t.Fatalf("ApplySchedule() = %d, %v, visible %t, want the approved page published again", n, err, visible(anonymous))
+	}
+
+	if got := setStatus("", models.PageArchived, http.StatusOK); got.Status != models.PageArchived || visible(anonymous) {
+		t.Errorf("archived page status = %s, visible to a visitor: %t", got.Status, visible(anonymous))
+	}
+
+	setStatus("", models.PagePending, http.StatusConflict)
+	setStatus("", models.PageDraft, http.StatusOK)
+}
+
+func TestAPI_Trash(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+	other := ts.member("other@couchsport.test")
+
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Hossegor", Description: "surf spot", Images: []models.Image{
+		{URL: pngDataURL(t), File: "beach.png", Alt: "beach"},
+		{URL: pngDataURL(t), File: "waves.png", Alt: "waves"},
+	}}, &page); code != http.StatusOK || len(page.Images) != 2 {
+		t.Fatalf("POST /pages: status %d, %d images", code, len(page.Images))
+	}
+
+	path := fmt.Sprintf("/pages/%d", page.ID)
+	imagePath := fmt.Sprintf("%s/images/%d", path, page.Images[0].ID)
+
+	trash := func() models.Trash {
+		var trash models.Trash
+		if code := host.do(http.MethodGet, "/profiles/me/trash", nil, &trash); code != http.StatusOK {
+			t.Fatalf("GET /profiles/me/trash: status %d", code)
+		}
+		return trash
+	}
+
+	if code := host.do(http.MethodDelete, imagePath, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", imagePath, code)
+	}
+
+	if got := trash(); len(got.Images) != 1 || got.Images[0].ID != page.Images[0].ID || len(got.Pages) != 0 || got.RetentionDays != 30 {
+		t.Errorf("trash = %+v, want image %d kept 30 days", got, page.Images[0].ID)
+	}
+
+	if code := host.do(http.MethodDelete, imagePath, nil, nil); code == http.StatusOK {
+		t.Errorf("DELETE %s of a deleted image succeeded", imagePath)
+	}
+
+	if code := other.do(http.MethodPost, imagePath+"/restoration", nil, nil); code != http.StatusNotFound {
+		t.Errorf("restoration by another member: status %d, want %d", code, http.StatusNotFound)
+	}
+
+	if code := host.do(http.MethodPost, imagePath+"/restoration", nil, nil); code != http.StatusOK {
+		t.Fatalf("POST %s/restoration: status %d", imagePath, code)
+	}
+
+	if got := trash(); len(got.Images) != 0 {
+		t.Errorf("trash after restoration = %+v, want empty", got)
+	}
+
+	if code := host.do(http.MethodDelete, imagePath, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", imagePath, code)
+	}
+
+	if code := host.do(http.MethodDelete, path, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", path, code)
+	}
+
+	if code := host.do(http.MethodGet, path, nil, nil); code != http.StatusNotFound {
+		t.Errorf("GET %s of a deleted page: status %d, want %d", path, code, http.StatusNotFound)
+	}
+
+	if got := trash(); len(got.Pages) != 1 || got.Pages[0].ID != page.ID || len(got.Pages[0].Images) != 1 || len(got.Images) != 0 {
+		t.Errorf("trash = %+v, want page %d with its remaining image", got, page.ID)
+	}
+
+	if code := other.do(http.MethodPost, path+"/restoration", nil, nil); code != http.StatusNotFound {
+		t.Errorf("restoration by another member: status %d, want %d", code, http.StatusNotFound)
+	}
+
+	var restored models.Page
+	if code := host.do(http.MethodPost, path+"/restoration", nil, &restored); code != http.StatusOK || restored.DeletedAt.Valid || len(restored.Images) != 1 {
+		t.Fatalf("POST %s/restoration: status %d, page %+v", path, code, restored)
+	}
+
+	if code := host.do(http.MethodDelete, path, nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE %s: status %d", path, code)
+	}
+
+	//a page of another member pointing to a file of the host, purged with it
+	var kept, foreign models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Seignosse", Description: "surf spot", Images: []models.Image{
+		{URL: pngDataURL(t), File: "dunes.png", Alt: "dunes"},
+	}}, &kept); code != http.StatusOK || len(kept.Images) != 1 {
+		t.Fatalf("POST /pages: status %d, %d images", code, len(kept.Images))
+	}
+
+	if code := other.do(http.MethodPost, "/pages", models.Page{Name: "Capbreton", Description: "surf spot", Images: []models.Image{
+		{URL: kept.Images[0].URL, Alt: "dunes"},
+	}}, &foreign); code != http.StatusOK {
+		t.Fatalf("POST /pages: status %d", code)
+	}
+
+	if code := other.do(http.MethodDelete, fmt.Sprintf("/pages/%d", foreign.ID), nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE /pages/%d: status %d", foreign.ID, code)
+	}
+
+	if n, err := ts.Stores.TrashStore().PurgeDue(time.Now()); err != nil || n != 0 {
+		t.Errorf("PurgeDue() within the retention period = %d, %v, want 0", n, err)
+	}
+
+	for _, i := range page.Images {
+		if _, err := os.Stat(filepath.Join(ts.PublicPath, i.URL)); err != nil {
+			t.Errorf("file %s of a deleted image: %v, want it kept until the purge", i.URL, err)
+		}
+	}
+
+	if n, err := ts.Stores.TrashStore().PurgeDue(time.Now().Add(31 * 24 * time.Hour)); err != nil || n != 5 {
+		t.Fatalf("PurgeDue() after the retention period = %d, %v, want both pages and their 3 images", n, err)
+	}
+
+	for _, file := range append(kept.Images[0].Variants.URLs(), kept.Images[0].URL) {
+		if _, err := os.Stat(filepath.Join(ts.PublicPath, file)); err != nil {
+			t.Errorf("file %s of another member: %v, want it kept", file, err)
+		}
+	}
+
+	for _, i := range page.Images {
+		if _, err := os.Stat(filepath.Join(ts.PublicPath, i.URL)); !os.IsNotExist(err) {
+			t.Errorf("file %s of a purged image: %v, want it removed", i.URL, err)
+		}
+	}
+
+	if got := trash(); len(got.Pages) != 0 || len(got.Images) != 0 {
+		t.Errorf("trash after the purge = %+v, want empty", got)
+	}
+
+	if code := host.do(http.MethodPost, path+"/restoration", nil, nil); code != http.StatusNotFound {
+		t.Errorf("restoration of a purged page: status %d, want %d", code, http.StatusNotFound)
+	}
+}
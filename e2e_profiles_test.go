@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+)
+
+func TestAPI_Patch(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+
+	couches := 2
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Hossegor", Description: "surf spot", LongDescription: "the best waves", CouchNumber: &couches}, &page); code != http.StatusOK {
+		t.Fatalf("POST /pages: status %d", code)
+	}
+
+	path := fmt.Sprintf("/pages/%d", page.ID)
+	res, _ := host.send(http.MethodGet, path, "", "")
+	if etag := res.Header.Get("ETag"); etag != `"1"` {
+		t.Fatalf("GET %s: ETag %s, want \"1\"", path, etag)
+	}
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		patch   string
+		status  int
+		etag    string
+		check   func(models.Page) bool
+	}{
+		{"zero values", `"1"`, `{"couch_number":0}`, http.StatusOK, `"2"`, func(p models.Page) bool {
+			return p.CouchNumber != nil && *p.CouchNumber == 0 && p.Name == "Hossegor" && p.LongDescription == "the best waves"
+		}},
+		{"stale version", `"1"`, `{"name":"Seignosse"}`, http.StatusPreconditionFailed, "", nil},
+		{"remove a member", `"2"`, `{"long_description":null}`, http.StatusOK, `"3"`, func(p models.Page) bool {
+			return p.LongDescription == "" && p.Description == "surf spot"
+		}},
+		{"read only members are ignored", "", `{"name":"Seignosse","owner_id":999,"version":42}`, http.StatusOK, `"4"`, func(p models.Page) bool {
+			return p.Name == "Seignosse" && p.OwnerID == page.OwnerID
+		}},
+		{"invalid result", "", `{"description":null}`, http.StatusUnprocessableEntity, "", nil},
+		{"not an object", "", `["name"]`, http.StatusBadRequest, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, body := host.send(http.MethodPatch, path, tt.ifMatch, tt.patch)
+			if res.StatusCode != tt.status {
+				t.Fatalf("PATCH %s: status %d, want %d, body %s", path, res.StatusCode, tt.status, body)
+			}
+
+			if tt.check == nil {
+				return
+			}
+
+			if etag := res.Header.Get("ETag"); etag != tt.etag {
+				t.Errorf("PATCH %s: ETag %s, want %s", path, etag, tt.etag)
+			}
+
+			var got models.Page
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.check(got) {
+				t.Errorf("PATCH %s = %+v", path, got)
+			}
+		})
+	}
+
+	profile := host.profile()
+	profilePath := fmt.Sprintf("/profiles/%d", profile.ID)
+	if res, body := host.send(http.MethodPatch, profilePath, `"1"`, `{"firstname":"Amaury","street_number":12}`); res.StatusCode != http.StatusOK || res.Header.Get("ETag") != `"2"` {
+		t.Fatalf("PATCH %s: status %d, ETag %s, body %s", profilePath, res.StatusCode, res.Header.Get("ETag"), body)
+	}
+
+	if res, body := host.send(http.MethodPatch, profilePath, "", `{"street_number":0}`); res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH %s: status %d, body %s", profilePath, res.StatusCode, body)
+	}
+
+	if got := host.profile(); got.Firstname != "Amaury" || got.StreetNumber != 0 || got.Version != 3 {
+		t.Errorf("profile = %+v, want the firstname kept and the street number cleared", got)
+	}
+
+	if res, _ := host.send(http.MethodPatch, profilePath, `"2"`, `{"firstname":"Other"}`); res.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("PATCH %s with a stale version: status %d, want %d", profilePath, res.StatusCode, http.StatusPreconditionFailed)
+	}
+}
+
+func TestAPI_Privacy(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+	guest := ts.member("guest@couchsport.test")
+	anonymous := ts.client()
+
+	profile := host.profile()
+	if profile.Privacy != models.DefaultPrivacy {
+		t.Errorf("new profile privacy = %+v, want %+v", profile.Privacy, models.DefaultPrivacy)
+	}
+
+	patch := `{"phone":"0601020304","zip_code":"40150","street_name":"avenue de la plage","privacy":{"email":"members"}}`
+	if res, body := host.send(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), "", patch); res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH /profiles/%d: status %d, body %s", profile.ID, res.StatusCode, body)
+	}
+
+	if res, _ := host.send(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), "", `{"privacy":{"phone":"friends"}}`); res.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("PATCH unknown visibility: status %d, want %d", res.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	host.publish(models.Page{Name: "Hossegor", Description: "surf spot"})
+
+	owner := func(c *apiClient) models.PublicProfile {
+		var pages []models.PageView
+		if code := c.do(http.MethodGet, "/pages?profile=1", nil, &pages); code != http.StatusOK || len(pages) != 1 {
+			t.Fatalf("GET /pages: status %d, %d pages", code, len(pages))
+		}
+		return pages[0].Owner
+	}
+
+	tests := []struct {
+		name   string
+		client *apiClient
+		want   models.PublicProfile
+	}{
+		{"visitor", anonymous, models.PublicProfile{}},
+		{"member", guest, models.PublicProfile{Email: "host@couchsport.test", ZipCode: "40150"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := owner(tt.client)
+			if got.ID != profile.ID || got.Email != tt.want.Email || got.ZipCode != tt.want.ZipCode || got.Phone != "" || got.StreetName != "" {
+				t.Errorf("page owner = %+v, want the contact fields %+v", got, tt.want)
+			}
+		})
+	}
+
+	var hostUser models.User
+	if err := ts.Stores.Db.Where("email = ?", "host@couchsport.test").First(&hostUser).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var guestUser models.User
+	if err := ts.Stores.Db.Where("email = ?", "guest@couchsport.test").First(&guestUser).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	//the stays are not recorded yet, writing to each other does not show the fields visible after a stay
+	send := func(from *apiClient, email string, toID uint) models.MessageView {
+		var message models.MessageView
+		body := map[string]interface{}{"email": email, "text": "Hello", "to_id": toID}
+		if code := from.do(http.MethodPost, "/messages", body, &message); code != http.StatusOK {
+			t.Fatalf("POST /messages: status %d", code)
+		}
+		return message
+	}
+
+	send(guest, "guest@couchsport.test", hostUser.ID)
+	reply := send(host, "host@couchsport.test", guestUser.ID)
+	if reply.From.Phone != "0601020304" {
+		t.Errorf("sender sees its own profile %+v", reply.From)
+	}
+
+	if got := owner(guest); got.Phone != "" || got.StreetName != "" {
+		t.Errorf("page owner = %+v, want the phone and street kept private", got)
+	}
+
+	if res, body := host.send(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), "", `{"privacy":{"phone":"stay","street_name":"members"}}`); res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH /profiles/%d: status %d, body %s", profile.ID, res.StatusCode, body)
+	}
+
+	if got := owner(guest); got.Phone != "" || got.StreetName != "avenue de la plage" {
+		t.Errorf("page owner = %+v, want the street shown to the members and the phone to nobody", got)
+	}
+
+	if got := owner(anonymous); got.Email != "" || got.Phone != "" {
+		t.Errorf("page owner = %+v, want no contact field shown to a visitor", got)
+	}
+
+	//anyone can send a message with the email of a member, the answer does not show its profile
+	if m := send(anonymous, "host@couchsport.test", guestUser.ID); m.From.Phone != "" || m.From.ZipCode != "" {
+		t.Errorf("anonymous sender sees the profile %+v", m.From)
+	}
+}
+
+func TestAPI_Directory(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+	surfer := ts.member("surfer@couchsport.test")
+	ts.member("unnamed@couchsport.test")
+	anonymous := ts.client()
+
+	var activities []models.Activity
+	if code := anonymous.do(http.MethodGet, "/activities", nil, &activities); code != http.StatusOK || len(activities) < 2 {
+		t.Fatalf("GET /activities: status %d, %d activities", code, len(activities))
+	}
+
+	setup := func(c *apiClient, patch string) models.Profile {
+		profile := c.profile()
+		if res, body := c.send(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), "", patch); res.StatusCode != http.StatusOK {
+			t.Fatalf("PATCH /profiles/%d: status %d, body %s", profile.ID, res.StatusCode, body)
+		}
+		return profile
+	}
+
+	hostProfile := setup(host, fmt.Sprintf(`{"username":"Kelly","city":"Hossegor","country":"France","phone":"0601020304","activities":[{"id":%d}]}`, activities[0].ID))
+	surferProfile := setup(surfer, fmt.Sprintf(`{"username":"Laird","city":"Biarritz","country":"France","activities":[{"id":%d}]}`, activities[1].ID))
+
+	leaving := ts.member("leaving@couchsport.test")
+	setup(leaving, `{"username":"Leaving","city":"Hossegor","country":"France"}`)
+	if code := leaving.do(http.MethodPost, "/users/me/deletion", map[string]string{"password": "password"}, nil); code != http.StatusAccepted {
+		t.Fatalf("POST /users/me/deletion: status %d", code)
+	}
+
+	host.publish(models.Page{Name: "Hossegor", Description: "surf spot"})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"all listed", "", []string{"Kelly", "Laird"}},
+		{"search", "?q=kel", []string{"Kelly"}},
+		{"wildcards are literal", "?q=k_lly", nil},
+		{"percent is literal", "?q=%25", nil},
+		{"activity", fmt.Sprintf("?activity_id=%d", activities[1].ID), []string{"Laird"}},
+		{"city", "?city=hossegor&country=france", []string{"Kelly"}},
+		{"page", "?offset=1&limit=1", []string{"Laird"}},
+		{"none", "?city=Paris", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var directory struct {
+				Profiles []models.PublicProfile `json:"profiles"`
+				Total    int64                  `json:"total"`
+			}
+			if code := anonymous.do(http.MethodGet, "/profiles"+tt.query, nil, &directory); code != http.StatusOK {
+				t.Fatalf("GET /profiles%s: status %d", tt.query, code)
+			}
+
+			var got []string
+			for _, p := range directory.Profiles {
+				got = append(got, p.Username)
+				if p.Phone != "" || p.Email != "" {
+					t.Errorf("directory shows the contact fields of %+v", p)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GET /profiles%s = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if code := anonymous.do(http.MethodGet, "/profiles?activity_id=x", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("GET /profiles?activity_id=x: status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	for _, path := range []string{"/profiles/by-username/Kelly", fmt.Sprintf("/profiles/%d", hostProfile.ID)} {
+		var member models.MemberView
+		if code := anonymous.do(http.MethodGet, path, nil, &member); code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", path, code)
+		}
+
+		if member.ID != hostProfile.ID || member.Phone != "" || len(member.Pages) != 1 || len(member.Activities) != 1 {
+			t.Errorf("GET %s = %+v", path, member)
+		}
+	}
+
+	for _, path := range []string{"/profiles/Kelly", "/profiles/by-username/nobody", "/profiles/by-username/Leaving"} {
+		if code := anonymous.do(http.MethodGet, path, nil, nil); code != http.StatusNotFound {
+			t.Errorf("GET %s: status %d, want %d", path, code, http.StatusNotFound)
+		}
+	}
+
+	//a username made of digits is not taken for the ID of another profile
+	numeric := fmt.Sprint(hostProfile.ID)
+	if err := ts.Stores.Db.Model(&models.Profile{}).Where("id = ?", surferProfile.ID).Update("username", numeric).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var member models.MemberView
+	if code := anonymous.do(http.MethodGet, "/profiles/by-username/"+numeric, nil, &member); code != http.StatusOK || member.ID != surferProfile.ID {
+		t.Errorf("GET /profiles/by-username/%s: status %d, profile %d, want %d", numeric, code, member.ID, surferProfile.ID)
+	}
+	if code := anonymous.do(http.MethodGet, "/profiles/"+numeric, nil, &member); code != http.StatusOK || member.ID != hostProfile.ID {
+		t.Errorf("GET /profiles/%s: status %d, profile %d, want %d", numeric, code, member.ID, hostProfile.ID)
+	}
+
+	if code := host.do(http.MethodGet, "/profiles/me", nil, nil); code != http.StatusOK {
+		t.Errorf("GET /profiles/me: status %d, the own profile route is shadowed", code)
+	}
+}
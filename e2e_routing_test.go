@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/config"
+)
+
+func TestAPI_Routing(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		header string
+		value  string
+	}{
+		{"method not allowed", http.MethodPost, "/api/languages", http.StatusMethodNotAllowed, "Allow", "GET, HEAD"},
+		{"current route", http.MethodGet, "/api/activities", http.StatusOK, "Deprecation", ""},
+		{"deprecated route", http.MethodGet, "/api/profiles/mine", http.StatusUnauthorized, "Link", `</api/profiles/me>; rel="successor-version"`},
+		{"unknown page", http.MethodGet, "/api/pages/999", http.StatusNotFound, "", ""},
+		{"no legacy admin route", http.MethodGet, "/api/admin/activities/delete?id=1", http.StatusMethodNotAllowed, "Allow", "DELETE, PATCH"},
+		{"admin route of another method", http.MethodGet, "/api/admin/users/1/suspension", http.StatusMethodNotAllowed, "Allow", "DELETE, PUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.code {
+				t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, res.StatusCode, tt.code)
+			}
+
+			if tt.header != "" && res.Header.Get(tt.header) != tt.value {
+				t.Errorf("%s %s: %s %q, want %q", tt.method, tt.path, tt.header, res.Header.Get(tt.header), tt.value)
+			}
+		})
+	}
+}
+
+func TestAPI_Versions(t *testing.T) {
+	ts := newTestServer(t, func(c *config.Config) {
+		c.API.Deprecated = map[string]string{"v1": "2027-06-30"}
+	})
+
+	sunset := "Wed, 30 Jun 2027 00:00:00 GMT"
+
+	tests := []struct {
+		name        string
+		path        string
+		code        int
+		deprecation string
+		sunset      string
+	}{
+		{"v1", "/api/v1/languages", http.StatusOK, "true", sunset},
+		{"unversioned is v1", "/api/languages", http.StatusOK, "true", sunset},
+		{"v2", "/api/v2/languages", http.StatusOK, "", ""},
+		{"v1 legacy route", "/api/v1/users/change-password", http.StatusUnauthorized, "true", sunset},
+		{"no legacy route in v2", "/api/v2/users/change-password", http.StatusNotFound, "", ""},
+		{"unknown version", "/api/v3/languages", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := http.Get(ts.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.code {
+				t.Errorf("GET %s: status %d, want %d", tt.path, res.StatusCode, tt.code)
+			}
+
+			if got := res.Header.Get("Deprecation"); got != tt.deprecation {
+				t.Errorf("GET %s: Deprecation %q, want %q", tt.path, got, tt.deprecation)
+			}
+
+			if got := res.Header.Get("Sunset"); got != tt.sunset {
+				t.Errorf("GET %s: Sunset %q, want %q", tt.path, got, tt.sunset)
+			}
+		})
+	}
+}
+
+//TestAPI_VersionsRoutes documents the difference between the versions, v2 is v1 without the legacy action paths
+func TestAPI_VersionsRoutes(t *testing.T) {
+	ts := newTestServer(t)
+
+	paths := map[string]map[string]map[string]interface{}{}
+	for _, version := range []string{"v1", "v2"} {
+		res, err := http.Get(ts.URL + "/api/" + version + "/openapi.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var doc struct {
+			Paths map[string]map[string]interface{} `json:"paths"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&doc)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths[version] = doc.Paths
+	}
+
+	for path, operations := range paths["v1"] {
+		for method, op := range operations {
+			legacy := op.(map[string]interface{})["deprecated"] == true
+			if _, ok := paths["v2"][path][method]; ok == legacy {
+				t.Errorf("%s %s: in v2 %v, deprecated in v1 %v", method, path, ok, legacy)
+			}
+		}
+	}
+
+	for path, operations := range paths["v2"] {
+		for method := range operations {
+			if _, ok := paths["v1"][path][method]; !ok {
+				t.Errorf("%s %s is in v2 only", method, path)
+			}
+		}
+	}
+
+	if paths["v1"]["/pages/update"] == nil || paths["v2"]["/pages/update"] != nil {
+		t.Errorf("the legacy /pages/update should be served by v1 only")
+	}
+}
+
+func TestAPI_Errors(t *testing.T) {
+	ts := newTestServer(t)
+	ts.member("taken@couchsport.test")
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		language string
+		want     apperror.Body
+	}{
+		{"not found", http.MethodGet, "/api/pages/999", "", "fr", apperror.Body{Code: apperror.NotFound, Status: http.StatusNotFound, Message: "la ressource demandée n'existe pas"}},
+		{"no route", http.MethodGet, "/api/unknown", "", "en", apperror.Body{Code: apperror.NotFound, Status: http.StatusNotFound, Message: "the requested resource does not exist"}},
+		{"method not allowed", http.MethodDelete, "/api/languages", "", "en", apperror.Body{Code: apperror.MethodNotAllowed, Status: http.StatusMethodNotAllowed, Message: "this method is not allowed on this resource"}},
+		{"not logged", http.MethodGet, "/api/profiles/me", "", "en", apperror.Body{Code: apperror.Unauthorized, Status: http.StatusUnauthorized, Message: "you are not logged in"}},
+		{"invalid json", http.MethodPost, "/api/users", `{"email":`, "en", apperror.Body{Code: apperror.InvalidRequest, Status: http.StatusBadRequest, Message: "invalid request"}},
+		{"duplicate", http.MethodPost, "/api/users", `{"email":"taken@couchsport.test","password":"password"}`, "fr", apperror.Body{Code: "user.already_exists", Status: http.StatusForbidden, Message: "un compte existe déjà pour cet email"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept-Language", tt.language)
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			var got apperror.Body
+			if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+				t.Fatalf("%s %s: %s", tt.method, tt.path, err)
+			}
+
+			if res.StatusCode != tt.want.Status || got.Code != tt.want.Code || got.Status != tt.want.Status || got.Message != tt.want.Message {
+				t.Errorf("%s %s: status %d, body %+v, want %+v", tt.method, tt.path, res.StatusCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPI_Validation(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+
+	tests := []struct {
+		name   string
+		client *apiClient
+		path   string
+		body   string
+		want   []apperror.FieldBody
+	}{
+		{"sign up", ts.client(), "/api/users", `{"email":"host","password":"short"}`, []apperror.FieldBody{
+			{Field: "email", Code: "email", Message: "cette adresse email n'est pas valide"},
+			{Field: "password", Code: "length", Message: "doit faire entre 8 et 255 octets"},
+		}},
+		{"page", host, "/api/pages", `{"name":"","description":"<b>spot</b>","lat":120,"images":[{"alt":"spot"}]}`, []apperror.FieldBody{
+			{Field: "name", Code: "required", Message: "ce champ est obligatoire"},
+			{Field: "description", Code: "text", Message: "seuls les lettres, les chiffres, les espaces et ,!?.'- sont autorisés"},
+			{Field: "images.0.url", Code: "required", Message: "ce champ est obligatoire"},
+			{Field: "lat", Code: "latitude", Message: "cette latitude n'est pas valide"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept-Language", "fr")
+
+			res, err := tt.client.http.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			var got apperror.Body
+			if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+
+			if res.StatusCode != http.StatusUnprocessableEntity || got.Code != apperror.ValidationFailed {
+				t.Errorf("POST %s: status %d, code %s, want %d %s", tt.path, res.StatusCode, got.Code, http.StatusUnprocessableEntity, apperror.ValidationFailed)
+			}
+
+			if !reflect.DeepEqual(got.Fields, tt.want) {
+				t.Errorf("POST %s: fields %+v, want %+v", tt.path, got.Fields, tt.want)
+			}
+		})
+	}
+}
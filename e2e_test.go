@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/handlers"
+	"github.com/amaurybrisou/couchsport.back/api/migrations"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+	"github.com/amaurybrisou/couchsport.back/api/validators"
+	"github.com/amaurybrisou/couchsport.back/config"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"github.com/amaurybrisou/couchsport.back/server"
+	"github.com/gorilla/websocket"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//testServer is the application router served on a throwaway in-memory SQLite database
+type testServer struct {
+	t      *testing.T
+	URL    string
+	Stores *stores.StoreFactory
+	//PublicPath holds the uploaded files
+	PublicPath string
+}
+
+//newTestServer boots the full router like main does, with the schema migrated and the reference data loaded,
+//configure functions adjust the test configuration before the handlers are registered
+func newTestServer(t *testing.T, configure ...func(*config.Config)) *testServer {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared&_foreign_keys=1"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrations.New(db).Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "couchsport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	c := &config.Config{Name: "CouchSport", Env: "test", PublicPath: dir, ImageBasePath: "/static/img", FilePrefix: "isupload.", ExportPath: dir + "/exports", FixturePath: "./fixtures"}
+	c.Security.MaxLoginAttempts, c.Security.MaxIPLoginAttempts = 5, 20
+	c.Security.LockoutSeconds, c.Security.MaxLockoutSeconds = 30, 60*60
+	c.Trash.RetentionDays = 30
+	c.Uploads.MaxBytes = 10 << 20
+	for _, f := range configure {
+		f(c)
+	}
+
+	models.SetPasswordCost(bcrypt.MinCost)
+	validators.Init()
+
+	l := localizer.NewLocalizer([]string{"./localizer/en.json", "./localizer/fr.json"})
+
+	storeFactory := stores.NewStoreFactory(db, l, *c)
+	storeFactory.Init(true)
+
+	srv := server.New(c, db)
+	registerHandlers(srv, handlers.NewHandlerFactory(storeFactory, l, &websocket.Upgrader{}))
+
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	return &testServer{t: t, URL: ts.URL, Stores: storeFactory, PublicPath: dir}
+}
+
+//apiClient is a browser of the api, it keeps its session cookie
+type apiClient struct {
+	t    *testing.T
+	url  string
+	http *http.Client
+}
+
+func (me *testServer) client() *apiClient {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+	return &apiClient{t: me.t, url: me.URL, http: &http.Client{Jar: jar}}
+}
+
+//member signs up email then logs it in
+func (me *testServer) member(email string) *apiClient {
+	c := me.client()
+	credentials := map[string]string{"email": email, "password": "password"}
+
+	if code := c.do(http.MethodPost, "/users", credentials, nil); code != http.StatusOK {
+		me.t.Fatalf("POST /users %s: status %d", email, code)
+	}
+
+	if code := c.do(http.MethodPost, "/sessions", credentials, nil); code != http.StatusOK {
+		me.t.Fatalf("POST /sessions %s: status %d", email, code)
+	}
+
+	return c
+}
+
+//do sends body as JSON to the api path and decodes the response in out when the request succeeded
+func (me *apiClient) do(method, path string, body, out interface{}) int {
+	var b []byte
+	if body != nil {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			me.t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, me.url+"/api"+path, bytes.NewReader(b))
+	if err != nil {
+		me.t.Fatal(err)
+	}
+
+	res, err := me.http.Do(req)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK && out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			me.t.Fatalf("%s %s: %s", method, path, err)
+		}
+	}
+
+	return res.StatusCode
+}
+
+func (me *apiClient) profile() models.Profile {
+	var profile models.Profile
+	if code := me.do(http.MethodGet, "/profiles/me", nil, &profile); code != http.StatusOK {
+		me.t.Fatalf("GET /profiles/me: status %d", code)
+	}
+	return profile
+}
+
+//publish creates page, submits it and approves it, the client must be an administrator
+func (me *apiClient) publish(page models.Page) models.Page {
+	if code := me.do(http.MethodPost, "/pages", page, &page); code != http.StatusOK {
+		me.t.Fatalf("POST /pages: status %d", code)
+	}
+
+	path := fmt.Sprintf("/pages/%d", page.ID)
+	if code := me.do(http.MethodPut, path+"/status", map[string]string{"status": models.PagePending}, nil); code != http.StatusOK {
+		me.t.Fatalf("PUT %s/status: status %d", path, code)
+	}
+	if code := me.do(http.MethodPost, "/admin"+path+"/approval", nil, &page); code != http.StatusOK {
+		me.t.Fatalf("POST /admin%s/approval: status %d", path, code)
+	}
+
+	return page
+}
+
+//wsClient is a websocket connection to the hub, the received messages are pushed to messages
+type wsClient struct {
+	conn     *websocket.Conn
+	messages chan map[string]interface{}
+}
+
+//dial connects the profile of the client to the hub with its session and returns once the hub reads the connection
+func (me *apiClient) dial() *wsClient {
+	u, err := url.Parse(me.url + "/api/ws")
+	if err != nil {
+		me.t.Fatal(err)
+	}
+
+	header := http.Header{}
+	for _, c := range me.http.Jar.Cookies(u) {
+		header.Add("Cookie", c.String())
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(u.String(), "http"), header)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+	me.t.Cleanup(func() { conn.Close() })
+
+	//the hub answers pings once the client is registered
+	registered := make(chan bool, 1)
+	conn.SetPongHandler(func(string) error {
+		registered <- true
+		return nil
+	})
+
+	ws := &wsClient{conn: conn, messages: make(chan map[string]interface{}, 16)}
+	go func() {
+		for {
+			_, b, err := conn.ReadMessage()
+			if err != nil {
+				close(ws.messages)
+				return
+			}
+
+			//the hub may batch several messages separated by new lines
+			for _, line := range bytes.Split(b, []byte{'\n'}) {
+				m := map[string]interface{}{}
+				if err := json.Unmarshal(line, &m); err == nil {
+					ws.messages <- m
+				}
+			}
+		}
+	}()
+
+	if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+		me.t.Fatal(err)
+	}
+
+	select {
+	case <-registered:
+	case <-time.After(5 * time.Second):
+		me.t.Fatal("ws: the hub did not register the client")
+	}
+
+	return ws
+}
+
+//next returns the next message received, it fails the test after a timeout
+func (me *wsClient) next(t *testing.T) map[string]interface{} {
+	select {
+	case m, ok := <-me.messages:
+		if !ok {
+			t.Fatal("ws: connection closed")
+		}
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("ws: no message received")
+	}
+	return nil
+}
+
+//send sends the raw body to the api path with the If-Match header when ifMatch is set, it returns the response and its body
+func (me *apiClient) send(method, path, ifMatch, body string) (*http.Response, []byte) {
+	header := map[string]string{"Content-Type": "application/merge-patch+json"}
+	if ifMatch != "" {
+		header["If-Match"] = ifMatch
+	}
+
+	return me.sendWith(method, path, header, strings.NewReader(body))
+}
+
+//sendWith sends body to the api path with the headers, it returns the response and its body
+func (me *apiClient) sendWith(method, path string, header map[string]string, body io.Reader) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, me.url+"/api"+path, body)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	res, err := me.http.Do(req)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		me.t.Fatal(err)
+	}
+
+	return res, b
+}
+
+//errorCode returns the code of an error response body
+func errorCode(b []byte) string {
+	var body apperror.Body
+	json.Unmarshal(b, &body)
+	return body.Code
+}
+
+//pngDataURL returns a one pixel PNG as the data URL of an upload
+func pngDataURL(t *testing.T) string {
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(pngFile(t))
+}
+
+//pngFile returns a one pixel PNG
+func pngFile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
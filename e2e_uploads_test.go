@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/config"
+)
+
+func TestAPI_Uploads(t *testing.T) {
+	ts := newTestServer(t)
+	host := ts.member("host@couchsport.test")
+
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(ts.PublicPath, path))
+		return err == nil
+	}
+
+	profile := host.profile()
+	avatar := func(ifMatch, file string) (models.Profile, int) {
+		res, body := host.send(http.MethodPatch, fmt.Sprintf("/profiles/%d", profile.ID), ifMatch, fmt.Sprintf(`{"avatar_file":%q,"avatar":%q}`, file, pngDataURL(t)))
+
+		var p models.Profile
+		if res.StatusCode == http.StatusOK {
+			if err := json.Unmarshal(body, &p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return p, res.StatusCode
+	}
+
+	first, code := avatar("", "me.png")
+	if code != http.StatusOK || !exists(first.Avatar) {
+		t.Fatalf("PATCH avatar: status %d, file %q", code, first.Avatar)
+	}
+
+	second, code := avatar("", "me-again.png")
+	if code != http.StatusOK || !exists(second.Avatar) || exists(first.Avatar) {
+		t.Errorf("replaced avatar: status %d, new file kept: %t, old file kept: %t", code, exists(second.Avatar), exists(first.Avatar))
+	}
+
+	userDir := filepath.Join(ts.PublicPath, filepath.Dir(second.Avatar))
+	if _, code := avatar(`"1"`, "stale.png"); code != http.StatusPreconditionFailed {
+		t.Errorf("PATCH avatar with a stale version: status %d, want %d", code, http.StatusPreconditionFailed)
+	}
+
+	if files, err := ioutil.ReadDir(userDir); err != nil || len(files) != 1 {
+		t.Errorf("avatar files after a failed update = %d, %v, want 1", len(files), err)
+	}
+
+	guest := ts.member("guest@couchsport.test")
+	guestPath := fmt.Sprintf("/profiles/%d", guest.profile().ID)
+	for _, foreign := range []string{second.Avatar, "/" + second.Avatar, "https://elsewhere.test/me.png"} {
+		if res, b := guest.send(http.MethodPatch, guestPath, "", fmt.Sprintf(`{"avatar":%q}`, foreign)); res.StatusCode != http.StatusUnprocessableEntity || errorCode(b) != "profile.invalid_avatar" {
+			t.Errorf("PATCH avatar %s of another member: status %d %s, want %d", foreign, res.StatusCode, b, http.StatusUnprocessableEntity)
+		}
+	}
+
+	if res, b := guest.send(http.MethodPatch, guestPath, "", fmt.Sprintf(`{"avatar_file":"guest.png","avatar":%q}`, pngDataURL(t))); res.StatusCode != http.StatusOK {
+		t.Errorf("PATCH guest avatar: status %d %s", res.StatusCode, b)
+	}
+	if !exists(second.Avatar) {
+		t.Errorf("the avatar of another member was removed")
+	}
+
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Hossegor", Description: "surf spot", Images: []models.Image{
+		{URL: pngDataURL(t), File: "beach.png", Alt: "beach"},
+		{URL: pngDataURL(t), File: "waves.png", Alt: "waves"},
+	}}, &page); code != http.StatusOK || len(page.Images) != 2 {
+		t.Fatalf("POST /pages: status %d, %d images", code, len(page.Images))
+	}
+
+	kept, lost := page.Images[0].URL, page.Images[1].URL
+	if err := os.Remove(filepath.Join(ts.PublicPath, lost)); err != nil {
+		t.Fatal(err)
+	}
+
+	orphan := filepath.Join(filepath.Dir(kept), "isupload.orphan.png")
+	if err := ioutil.WriteFile(filepath.Join(ts.PublicPath, orphan), []byte("orphan"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	uploads := ts.Stores.UploadStore()
+	later := time.Now().Add(2 * time.Hour)
+
+	if report, err := uploads.Collect(time.Now(), time.Hour, false); err != nil || len(report.Orphans) != 0 || !exists(orphan) {
+		t.Errorf("Collect() of a recent file = %+v, %v, want it kept", report, err)
+	}
+
+	report, err := uploads.Collect(later, time.Hour, true)
+	if err != nil || !reflect.DeepEqual(report.Orphans, []string{orphan}) || !exists(orphan) {
+		t.Errorf("Collect() dry run = %+v, %v, want %s listed and kept", report, err, orphan)
+	}
+
+	report, err = uploads.Collect(later, time.Hour, false)
+	if err != nil || !reflect.DeepEqual(report.Orphans, []string{orphan}) || exists(orphan) {
+		t.Errorf("Collect() = %+v, %v, want %s removed", report, err, orphan)
+	}
+
+	//the variants of both images are kept with the image and the avatars of both members
+	if !reflect.DeepEqual(report.Missing, []string{lost}) || report.Kept != 9 || !exists(kept) || !exists(second.Avatar) {
+		t.Errorf("Collect() = %+v, want %s missing, the image, its variants and the avatars kept", report, lost)
+	}
+}
+
+func TestAPI_ImageUploads(t *testing.T) {
+	ts := newTestServer(t, func(c *config.Config) { c.Uploads.MaxBytes = 4096 })
+	host := ts.member("host@couchsport.test")
+	other := ts.member("other@couchsport.test")
+
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(ts.PublicPath, path))
+		return err == nil
+	}
+
+	multipartUpload := func(c *apiClient, field, filename string, content []byte) (models.Upload, *http.Response, []byte) {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		if err := w.WriteField("alt", "ignored"); err != nil {
+			t.Fatal(err)
+		}
+		f, err := w.CreateFormFile(field, filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write(content)
+		w.Close()
+
+		res, b := c.sendWith(http.MethodPost, "/uploads", map[string]string{"Content-Type": w.FormDataContentType()}, &body)
+
+		var upload models.Upload
+		if res.StatusCode == http.StatusCreated {
+			if err := json.Unmarshal(b, &upload); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return upload, res, b
+	}
+
+	photo, res, _ := multipartUpload(host, "file", "my photo.txt", pngFile(t))
+	if res.StatusCode != http.StatusCreated || !photo.Complete || photo.Mime != "image/png" || !exists(photo.URL) {
+		t.Fatalf("POST /uploads: status %d, upload %+v", res.StatusCode, photo)
+	}
+
+	if got := res.Header.Get("Upload-Offset"); got != fmt.Sprint(len(pngFile(t))) {
+		t.Errorf("POST /uploads: Upload-Offset %s, want %d", got, len(pngFile(t)))
+	}
+
+	if _, res, b := multipartUpload(host, "file", "fake.png", []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")); res.StatusCode != http.StatusUnsupportedMediaType || errorCode(b) != "upload.unsupported_type" {
+		t.Errorf("POST /uploads of a svg named png: status %d %s, want %d", res.StatusCode, b, http.StatusUnsupportedMediaType)
+	}
+
+	//a gif of a few bytes declaring a 65535x65535 image is refused before it is decoded
+	var bomb bytes.Buffer
+	if err := gif.Encode(&bomb, image.NewPaletted(image.Rect(0, 0, 1, 1), palette.Plan9), nil); err != nil {
+		t.Fatal(err)
+	}
+	copy(bomb.Bytes()[6:10], []byte{0xFF, 0xFF, 0xFF, 0xFF})
+	if _, res, b := multipartUpload(host, "file", "bomb.gif", bomb.Bytes()); res.StatusCode != http.StatusRequestEntityTooLarge || errorCode(b) != "upload.too_many_pixels" {
+		t.Errorf("POST /uploads of a decompression bomb: status %d %s, want %d", res.StatusCode, b, http.StatusRequestEntityTooLarge)
+	}
+
+	if _, res, b := multipartUpload(host, "file", "huge.png", make([]byte, 5000)); res.StatusCode != http.StatusRequestEntityTooLarge || errorCode(b) != "upload.too_large" {
+		t.Errorf("POST /uploads of a large file: status %d %s, want %d", res.StatusCode, b, http.StatusRequestEntityTooLarge)
+	}
+
+	if _, res, _ := multipartUpload(host, "image", "photo.png", pngFile(t)); res.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("POST /uploads without file field: status %d, want %d", res.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	for _, variant := range []string{photo.Variants.ThumbnailURL, photo.Variants.MediumURL, photo.Variants.LargeURL} {
+		if variant == "" || !exists(variant) {
+			t.Errorf("POST /uploads: variant %q not saved, upload %+v", variant, photo)
+		}
+	}
+
+	if files, err := ioutil.ReadDir(filepath.Join(ts.PublicPath, filepath.Dir(photo.URL))); err != nil || len(files) != 4 {
+		t.Errorf("upload files = %d, %v, want only the accepted one and its variants", len(files), err)
+	}
+
+	//resumable upload sent in two chunks
+	content := pngFile(t)
+	var chunked models.Upload
+	if code := host.do(http.MethodPost, "/uploads", models.UploadBodyModel{Filename: "avatar.png", Size: 5000}, nil); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST /uploads of a large resumable upload: status %d, want %d", code, http.StatusRequestEntityTooLarge)
+	}
+
+	res, b := host.sendWith(http.MethodPost, "/uploads", nil, strings.NewReader(fmt.Sprintf(`{"filename":"avatar.png","size":%d}`, len(content))))
+	if err := json.Unmarshal(b, &chunked); err != nil || res.StatusCode != http.StatusCreated || chunked.Complete || chunked.Received != 0 {
+		t.Fatalf("POST /uploads resumable: status %d, upload %s", res.StatusCode, b)
+	}
+
+	path := fmt.Sprintf("/uploads/%d", chunked.ID)
+	chunk := func(c *apiClient, offset int, data []byte) (models.Upload, *http.Response, []byte) {
+		res, b := c.sendWith(http.MethodPatch, path, map[string]string{"Upload-Offset": fmt.Sprint(offset)}, bytes.NewReader(data))
+
+		var upload models.Upload
+		if res.StatusCode == http.StatusOK {
+			if err := json.Unmarshal(b, &upload); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return upload, res, b
+	}
+
+	half := len(content) / 2
+	if got, res, _ := chunk(host, 0, content[:half]); res.StatusCode != http.StatusOK || got.Received != int64(half) || got.Complete {
+		t.Fatalf("PATCH %s first chunk: status %d, upload %+v", path, res.StatusCode, got)
+	}
+
+	if _, res, b := chunk(host, 0, content[:half]); res.StatusCode != http.StatusConflict || errorCode(b) != "upload.offset_mismatch" {
+		t.Errorf("PATCH %s replayed chunk: status %d %s, want %d", path, res.StatusCode, b, http.StatusConflict)
+	}
+
+	if _, res, _ := chunk(other, half, content[half:]); res.StatusCode != http.StatusNotFound {
+		t.Errorf("PATCH %s by another member: status %d, want %d", path, res.StatusCode, http.StatusNotFound)
+	}
+
+	if res, _ := host.sendWith(http.MethodGet, path, nil, nil); res.StatusCode != http.StatusOK || res.Header.Get("Upload-Offset") != fmt.Sprint(half) {
+		t.Errorf("GET %s: status %d, Upload-Offset %s, want %d", path, res.StatusCode, res.Header.Get("Upload-Offset"), half)
+	}
+
+	//the avatar cannot reference an upload before it is complete
+	profile := host.profile()
+	profilePath := fmt.Sprintf("/profiles/%d", profile.ID)
+	if res, b := host.send(http.MethodPatch, profilePath, "", fmt.Sprintf(`{"avatar_upload_id":%d}`, chunked.ID)); res.StatusCode != http.StatusUnprocessableEntity || errorCode(b) != "upload.unavailable" {
+		t.Errorf("PATCH avatar with an incomplete upload: status %d %s, want %d", res.StatusCode, b, http.StatusUnprocessableEntity)
+	}
+
+	chunked, res, _ = chunk(host, half, content[half:])
+	if res.StatusCode != http.StatusOK || !chunked.Complete || chunked.Mime != "image/png" {
+		t.Fatalf("PATCH %s last chunk: status %d, upload %+v", path, res.StatusCode, chunked)
+	}
+
+	res, b = host.send(http.MethodPatch, profilePath, "", fmt.Sprintf(`{"avatar_upload_id":%d}`, chunked.ID))
+	if err := json.Unmarshal(b, &profile); err != nil || res.StatusCode != http.StatusOK || profile.Avatar != chunked.URL || !exists(profile.Avatar) {
+		t.Fatalf("PATCH avatar with an upload: status %d, profile %s", res.StatusCode, b)
+	}
+
+	if exists(chunked.Variants.ThumbnailURL) {
+		t.Errorf("PATCH avatar with an upload: variant %s kept, the avatars have none", chunked.Variants.ThumbnailURL)
+	}
+
+	//the pages reference the uploads of their owner only, once
+	page := models.Page{Name: "Hossegor", Description: "surf spot", Images: []models.Image{{UploadID: photo.ID, Alt: "beach"}}}
+	if code := other.do(http.MethodPost, "/pages", page, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /pages with the upload of another member: status %d, want %d", code, http.StatusUnprocessableEntity)
+	}
+
+	if code := host.do(http.MethodPost, "/pages", page, &page); code != http.StatusOK || len(page.Images) != 1 || page.Images[0].URL != photo.URL || page.Images[0].Variants != photo.Variants {
+		t.Fatalf("POST /pages with an upload: status %d, images %+v", code, page.Images)
+	}
+
+	if code := host.do(http.MethodGet, fmt.Sprintf("/uploads/%d", photo.ID), nil, nil); code != http.StatusNotFound {
+		t.Errorf("GET a referenced upload: status %d, want %d", code, http.StatusNotFound)
+	}
+
+	if code := host.do(http.MethodPost, "/pages", page, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /pages with a consumed upload: status %d, want %d", code, http.StatusUnprocessableEntity)
+	}
+
+	//the uploads nothing references expire with their file
+	stale, _, _ := multipartUpload(host, "file", "stale.png", pngFile(t))
+	uploads := ts.Stores.UploadStore()
+
+	if n, err := uploads.PurgeExpired(time.Now()); err != nil || n != 0 {
+		t.Errorf("PurgeExpired() = %d, %v, want 0", n, err)
+	}
+
+	if n, err := uploads.PurgeExpired(time.Now().Add(25 * time.Hour)); err != nil || n != 1 || exists(stale.URL) || exists(stale.Variants.LargeURL) {
+		t.Errorf("PurgeExpired() a day later = %d, %v, want the stale upload and its files", n, err)
+	}
+
+	if !exists(photo.URL) || !exists(photo.Variants.LargeURL) || !exists(profile.Avatar) {
+		t.Errorf("files of the referenced uploads removed")
+	}
+}
+
+//rotatedJPEG returns a w x h JPEG whose EXIF orientation 6 asks to turn it a quarter clockwise, with GPS
+//coordinates left in its metadata
+func rotatedJPEG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	//TIFF header, one entry (orientation 6 as a SHORT) and no next directory, then a fake GPS block
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, 6, 0, 0, 0, 0, 0, 0}
+	segment := append(append([]byte("Exif\x00\x00"), tiff...), "GPS 43.66N 1.44W"...)
+
+	data := buf.Bytes()
+	out := append([]byte{}, data[:2]...)
+	out = append(out, 0xFF, 0xE1, byte((len(segment)+2)>>8), byte(len(segment)+2))
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestAPI_ImageProcessing(t *testing.T) {
+	ts := newTestServer(t, func(c *config.Config) { c.Images.MaxWidth, c.Images.MaxHeight = 32, 32 })
+	host := ts.member("host@couchsport.test")
+
+	decode := func(path string) (image.Image, []byte) {
+		data, err := ioutil.ReadFile(filepath.Join(ts.PublicPath, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %s", path, err)
+		}
+		return img, data
+	}
+
+	content := rotatedJPEG(t, 60, 30)
+
+	var upload models.Upload
+	res, b := host.sendWith(http.MethodPost, "/uploads", nil, strings.NewReader(fmt.Sprintf(`{"filename":"photo.jpg","size":%d}`, len(content))))
+	if err := json.Unmarshal(b, &upload); err != nil || res.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /uploads: status %d, upload %s", res.StatusCode, b)
+	}
+
+	res, b = host.sendWith(http.MethodPatch, fmt.Sprintf("/uploads/%d", upload.ID), map[string]string{"Upload-Offset": "0"}, bytes.NewReader(content))
+	if err := json.Unmarshal(b, &upload); err != nil || res.StatusCode != http.StatusOK || !upload.Complete || upload.Mime != "image/jpeg" {
+		t.Fatalf("PATCH upload: status %d, upload %s", res.StatusCode, b)
+	}
+
+	//turned upright then scaled down to the max dimensions, without its metadata
+	img, data := decode(upload.URL)
+	if img.Bounds().Size() != image.Pt(16, 32) {
+		t.Errorf("uploaded image size = %v, want 16x32", img.Bounds().Size())
+	}
+
+	if bytes.Contains(data, []byte("Exif")) || bytes.Contains(data, []byte("GPS")) {
+		t.Errorf("uploaded image kept its EXIF metadata")
+	}
+
+	for _, variant := range []string{upload.Variants.ThumbnailURL, upload.Variants.MediumURL, upload.Variants.LargeURL} {
+		if img, _ := decode(variant); img.Bounds().Size() != image.Pt(16, 32) {
+			t.Errorf("variant %s size = %v, the variants are never scaled up", variant, img.Bounds().Size())
+		}
+	}
+
+	//the variants sent by the client are ignored, the uploaded images get theirs
+	large := image.NewGray(image.Rect(0, 0, 100, 50))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, large); err != nil {
+		t.Fatal(err)
+	}
+
+	var page models.Page
+	if code := host.do(http.MethodPost, "/pages", models.Page{Name: "Hossegor", Description: "surf spot", Images: []models.Image{
+		{URL: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), File: "beach.png", Alt: "beach"},
+		{URL: "https://example.com/waves.png", Alt: "waves", Variants: models.ImageVariants{ThumbnailURL: "/static/img/user-1/avatar.png"}},
+	}}, &page); code != http.StatusOK || len(page.Images) != 2 {
+		t.Fatalf("POST /pages: status %d, %d images", code, len(page.Images))
+	}
+
+	beach, waves := page.Images[0], page.Images[1]
+	if img, _ := decode(beach.URL); img.Bounds().Size() != image.Pt(32, 16) {
+		t.Errorf("page image size = %v, want 32x16", img.Bounds().Size())
+	}
+
+	if beach.Variants.ThumbnailURL == "" || beach.Variants.MediumURL == "" || beach.Variants.LargeURL == "" {
+		t.Errorf("page image variants = %+v, want the three of them", beach.Variants)
+	}
+
+	if waves.Variants != (models.ImageVariants{}) {
+		t.Errorf("linked image variants = %+v, want none", waves.Variants)
+	}
+}
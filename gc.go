@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+const gcUsage = "usage: gc [-dry-run] [-min-age <duration>]"
+
+//runGC implements the gc subcommand, it removes the uploaded files no image or avatar references anymore
+func runGC(storeFactory *stores.StoreFactory, args []string) error {
+	flags := flag.NewFlagSet("gc", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	dryRun := flags.Bool("dry-run", false, "list the orphan files without removing them")
+	minAge := flags.Duration("min-age", 24*time.Hour, "leave the files younger than this alone, their upload may not be recorded yet")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return fmt.Errorf(gcUsage)
+	}
+
+	report, err := storeFactory.UploadStore().Collect(time.Now(), *minAge, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "removed"
+	if *dryRun {
+		verb = "orphan"
+	}
+
+	for _, f := range report.Orphans {
+		fmt.Printf("%s %s\n", verb, f)
+	}
+
+	for _, f := range report.Missing {
+		fmt.Printf("missing %s\n", f)
+	}
+
+	fmt.Printf("%d orphan files, %d kept, %d missing\n", len(report.Orphans), report.Kept, len(report.Missing))
+
+	return nil
+}
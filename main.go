@@ -8,6 +8,8 @@ import (
 	"os/signal"
 
 	"github.com/amaurybrisou/couchsport.back/api/handlers"
+	"github.com/amaurybrisou/couchsport.back/api/migrations"
+	"github.com/amaurybrisou/couchsport.back/api/models"
 	"github.com/amaurybrisou/couchsport.back/api/stores"
 	"github.com/amaurybrisou/couchsport.back/api/validators"
 	"github.com/amaurybrisou/couchsport.back/config"
@@ -19,17 +21,51 @@ import (
 
 func main() {
 	env := flag.String("env", "dev", "select environment config file to use (will load config.[env].json")
-	populate := flag.Bool("populate", false, "inject fixtures in database")
+	populate := flag.Bool("populate", false, "apply pending migrations and inject reference data in database")
 	flag.Parse()
 
 	c := config.Load(*env)
 	c.Populate = *populate
 
+	models.SetPasswordCost(c.Security.BcryptCost)
+
 	localizer := localizer.NewLocalizer(c.Localizer.LanguageFiles)
 
 	srv := server.NewInstance(c)
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(srv.Db, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if c.Populate {
+		if _, err := migrations.New(srv.Db).Up(); err != nil {
+			log.Fatal(err)
+		}
+	} else if pending, err := migrations.New(srv.Db).Pending(); err != nil {
+		log.Errorf("could not check the database schema version: %s", err)
+	} else if len(pending) > 0 {
+		log.Warnf("database schema is not up to date (%d pending migrations), run the migrate up command", len(pending))
+	}
+
 	storeFactory := stores.NewStoreFactory(srv.Db, localizer, *c)
+
+	if flag.Arg(0) == "seed" {
+		if err := runSeed(storeFactory, c.Env, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "gc" {
+		if err := runGC(storeFactory, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	storeFactory.Init(c.Populate)
 
 	var upgrader = websocket.Upgrader{
@@ -46,57 +82,7 @@ func main() {
 
 	validators.Init()
 
-	srv.RegisterHandler("/ws", handlerFactory.WsHandler().EntryPoint)
-
-	srv.RegisterHandler("/languages", handlerFactory.LanguageHandler().All)
-	srv.RegisterHandler("/activities", handlerFactory.ActivityHandler().All)
-
-	srv.RegisterHandler("/conversations/message/send", handlerFactory.ConversationHandler().HandleMessage)
-	srv.RegisterHandler("/conversations/delete", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.ConversationHandler().Delete),
-	)
-
-	srv.RegisterHandler("/pages", handlerFactory.PageHandler().All)
-	srv.RegisterHandler("/pages/new", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.PageHandler().New),
-	)
-	srv.RegisterHandler("/pages/update", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.PageHandler().Update),
-	)
-	srv.RegisterHandler("/pages/publish", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.PageHandler().Publish),
-	)
-	srv.RegisterHandler("/pages/delete", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.PageHandler().Delete),
-	)
-
-	srv.RegisterHandler("/images/delete", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.ImageHandler().Delete),
-	)
-
-	// srv.RegisterHandler("/users", handlerFactory.UserHandler().All)
-
-	srv.RegisterHandler("/profiles/update", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.ProfileHandler().Update),
-	)
-	srv.RegisterHandler("/profiles/mine", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.UserHandler().Profile),
-	)
-	srv.RegisterHandler("/profiles/pages", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.PageHandler().ProfilePages),
-	)
-	srv.RegisterHandler("/profile/conversations", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.ConversationHandler().ProfileConversations),
-	)
-
-	srv.RegisterHandler("/login", handlerFactory.UserHandler().Login)
-	srv.RegisterHandler("/signup", handlerFactory.UserHandler().SignUp)
-	srv.RegisterHandler("/logout", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.UserHandler().Logout),
-	)
-	srv.RegisterHandler("/users/change-password", handlerFactory.UserHandler().IsLogged(
-		handlerFactory.UserHandler().ChangePassword),
-	)
+	registerHandlers(srv, handlerFactory)
 
 	// srv.ServePublic(c.PublicPath)
 
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/amaurybrisou/couchsport.back/api/migrations"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | down | status | to <version>"
+
+//runMigrate implements the migrate subcommand
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	migrator := migrations.New(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		printMigrations("applied", applied)
+		return err
+	case "down":
+		reverted, ok, err := migrator.Down()
+		if ok {
+			printMigrations("reverted", []migrations.Migration{reverted})
+		}
+		return err
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf(migrateUsage)
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		done, err := migrator.To(uint(version))
+		printMigrations("migrated", done)
+		return err
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range status {
+			at := "pending"
+			if s.AppliedAt != nil {
+				at = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, at)
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf(migrateUsage)
+}
+
+func printMigrations(verb string, done []migrations.Migration) {
+	if len(done) == 0 {
+		fmt.Println("nothing to do")
+		return
+	}
+
+	for _, m := range done {
+		fmt.Printf("%s %d %s\n", verb, m.Version, m.Name)
+	}
+}
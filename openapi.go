@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/apperror"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/api/openapi"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"github.com/amaurybrisou/couchsport.back/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// sessionScheme is the security scheme of the routes requiring a logged user, the cookie set on login
+const sessionScheme = "session"
+
+// result is the body answered by the actions returning whether they succeeded
+type result struct{ Result bool }
+
+// endpoints documents the routes of every version by method and path, a route registered
+// without its entry here is left out of the OpenAPI document and fails TestOpenAPI_Routes,
+// so does an entry whose route is gone
+var endpoints = map[string]openapi.Endpoint{
+	"GET /openapi.json": {Tag: "api", Summary: "OpenAPI document of the version", Response: map[string]interface{}{}},
+	"GET /ws":           {Tag: "api", Summary: "Websocket receiving the mutations of the profile", Security: sessionScheme, Status: http.StatusSwitchingProtocols},
+
+	"GET /languages":  {Tag: "reference", Summary: "Languages", Response: []models.Language{}},
+	"GET /activities": {Tag: "reference", Summary: "Activities", Response: []models.Activity{}},
+
+	"GET /pages":         {Tag: "pages", Summary: "Pages", Query: []string{"id", "name", "owner_id", "profile", "followers"}, Response: []models.PageView{}},
+	"POST /pages":        {Tag: "pages", Summary: "Create a page", Security: sessionScheme, Request: models.Page{}, Response: models.Page{}},
+	"GET /pages/{id}":    {Tag: "pages", Summary: "Published page", Response: models.PageView{}, Versioned: true},
+	"PATCH /pages/{id}":  {Tag: "pages", Summary: "Update an owned page", Security: sessionScheme, Request: models.Page{}, Response: models.Page{}, Versioned: true},
+	"DELETE /pages/{id}": {Tag: "pages", Summary: "Move an owned page to the trash", Security: sessionScheme, Response: result{}},
+	"PUT /pages/{id}/publish": {Tag: "pages", Summary: "Submit an owned page for review or unpublish it", Security: sessionScheme, Request: struct {
+		Public bool `json:"public"`
+	}{}, Response: result{}},
+	"PUT /pages/{id}/status":                       {Tag: "pages", Summary: "Move an owned page along its lifecycle", Security: sessionScheme, Request: models.PageStatusBodyModel{}, Response: models.Page{}, Versioned: true},
+	"POST /pages/{id}/restoration":                 {Tag: "pages", Summary: "Restore an owned page from the trash", Security: sessionScheme, Response: models.Page{}},
+	"DELETE /pages/{pageID}/images/{id}":           {Tag: "pages", Summary: "Move an image of an owned page to the trash", Security: sessionScheme, Response: result{}},
+	"POST /pages/{pageID}/images/{id}/restoration": {Tag: "pages", Summary: "Restore an image of an owned page from the trash", Security: sessionScheme, Response: models.Image{}},
+
+	"POST /uploads":       {Tag: "uploads", Summary: "Upload an image in the file field of a multipart/form-data body, or open a resumable upload", Security: sessionScheme, Request: models.UploadBodyModel{}, Response: models.Upload{}, Status: http.StatusCreated},
+	"GET /uploads/{id}":   {Tag: "uploads", Summary: "Upload of the logged user, Upload-Offset is its received size", Security: sessionScheme, Response: models.Upload{}},
+	"PATCH /uploads/{id}": {Tag: "uploads", Summary: "Append the body to a resumable upload at the Upload-Offset header", Security: sessionScheme, Response: models.Upload{}},
+
+	"GET /profiles":                        {Tag: "profiles", Summary: "Member directory", Query: []string{"q", "activity_id", "language_id", "country", "city", "offset", "limit"}, Response: directory{}},
+	"GET /profiles/{id}":                   {Tag: "profiles", Summary: "Public profile by ID", Response: models.MemberView{}},
+	"GET /profiles/by-username/{username}": {Tag: "profiles", Summary: "Public profile by username", Response: models.MemberView{}},
+	"GET /profiles/me":                     {Tag: "profiles", Summary: "Profile of the logged user", Security: sessionScheme, Response: models.Profile{}, Versioned: true},
+	"PATCH /profiles/{id}":                 {Tag: "profiles", Summary: "Update the profile of the logged user", Security: sessionScheme, Request: models.Profile{}, Response: models.Profile{}, Versioned: true},
+	"GET /profiles/me/pages":               {Tag: "profiles", Summary: "Pages of the logged user", Security: sessionScheme, Response: []models.Page{}},
+	"GET /profiles/me/conversations":       {Tag: "profiles", Summary: "Conversations of the logged user", Security: sessionScheme, Response: []models.ConversationView{}},
+	"GET /profiles/me/trash":               {Tag: "profiles", Summary: "Deleted pages and images of the logged user", Security: sessionScheme, Response: models.Trash{}},
+
+	"POST /messages":             {Tag: "conversations", Summary: "Send a message, the account of an unknown email is created", Request: models.SendMessageBodyModel{}, Response: models.MessageView{}},
+	"DELETE /conversations/{id}": {Tag: "conversations", Summary: "Delete a conversation of the logged user", Security: sessionScheme, Response: result{}},
+	"POST /conversations/{id}/reports": {Tag: "conversations", Summary: "Report a conversation to the moderators", Security: sessionScheme, Request: struct {
+		Reason string `json:"reason"`
+	}{}, Response: result{}},
+
+	"POST /users":                       {Tag: "account", Summary: "Sign up", Request: models.User{}, Response: models.User{}},
+	"POST /sessions":                    {Tag: "account", Summary: "Log in, the session cookie is set", Request: models.User{}, Response: models.LoginResponseModel{}},
+	"DELETE /sessions":                  {Tag: "account", Summary: "Log out", Security: sessionScheme, Response: result{}},
+	"POST /sessions/verify":             {Tag: "account", Summary: "Complete a login with a TOTP or recovery code", Request: models.TwoFactorBodyModel{}, Response: models.LoginResponseModel{}},
+	"PUT /users/me/password":            {Tag: "account", Summary: "Change the password", Security: sessionScheme, Request: models.User{}, Response: models.User{}},
+	"POST /users/me/2fa":                {Tag: "account", Summary: "Enroll in two-factor authentication", Security: sessionScheme, Response: enrollment{}},
+	"POST /users/me/2fa/confirm":        {Tag: "account", Summary: "Confirm the enrolment with a TOTP code", Security: sessionScheme, Request: models.TwoFactorBodyModel{}, Response: recoveryCodes{}},
+	"POST /users/me/2fa/recovery-codes": {Tag: "account", Summary: "Regenerate the recovery codes", Security: sessionScheme, Request: models.TwoFactorBodyModel{}, Response: recoveryCodes{}},
+	"DELETE /users/me/2fa":              {Tag: "account", Summary: "Disable two-factor authentication", Security: sessionScheme, Request: models.TwoFactorBodyModel{}, Response: result{}},
+	"GET /users/me/roles":               {Tag: "account", Summary: "Roles of the logged user", Security: sessionScheme, Response: []models.Role{}},
+	"POST /users/me/exports":            {Tag: "account", Summary: "Request an export of the member data", Security: sessionScheme, Response: models.DataExport{}, Status: http.StatusAccepted},
+	"GET /users/me/exports":             {Tag: "account", Summary: "Data exports of the logged user", Security: sessionScheme, Response: []models.DataExport{}},
+	"GET /users/me/exports/{id}":        {Tag: "account", Summary: "Download a data export", Security: sessionScheme, ContentType: "application/zip"},
+	"POST /users/me/deletion":           {Tag: "account", Summary: "Schedule the deletion of the account", Security: sessionScheme, Request: models.AccountDeletionBodyModel{}, Response: deletionSchedule{}, Status: http.StatusAccepted},
+	"DELETE /users/me/deletion":         {Tag: "account", Summary: "Cancel the deletion of the account", Security: sessionScheme, Response: result{}},
+
+	"GET /roles":                      {Tag: "roles", Summary: "Roles and their permissions", Security: sessionScheme, Response: []models.Role{}},
+	"PUT /users/{id}/roles/{role}":    {Tag: "roles", Summary: "Grant a role", Security: sessionScheme, Response: []models.Role{}},
+	"DELETE /users/{id}/roles/{role}": {Tag: "roles", Summary: "Revoke a role", Security: sessionScheme, Response: []models.Role{}},
+
+	"GET /admin/users":                    {Tag: "admin", Summary: "Search users by email or username", Security: sessionScheme, Query: []string{"q", "offset", "limit"}, Response: []models.User{}},
+	"GET /admin/users/{id}":               {Tag: "admin", Summary: "What the user sees", Security: sessionScheme, Response: userView{}},
+	"DELETE /admin/users/{id}":            {Tag: "admin", Summary: "Purge a user and its data", Security: sessionScheme, Response: result{}},
+	"PUT /admin/users/{id}/suspension":    {Tag: "admin", Summary: "Suspend a user", Security: sessionScheme, Response: result{}},
+	"DELETE /admin/users/{id}/suspension": {Tag: "admin", Summary: "Reinstate a user", Security: sessionScheme, Response: result{}},
+	"PATCH /admin/pages/{id}":             {Tag: "admin", Summary: "Update a page", Security: sessionScheme, Request: models.Page{}, Response: models.Page{}, Versioned: true},
+	"GET /admin/pages":                    {Tag: "admin", Summary: "Moderation queue, the pending pages by default", Security: sessionScheme, Query: []string{"status", "offset", "limit"}, Response: moderationQueue{}},
+	"POST /admin/pages/{id}/unpublish":    {Tag: "admin", Summary: "Unpublish a page", Security: sessionScheme, Response: result{}},
+	"POST /admin/pages/{id}/approval":     {Tag: "admin", Summary: "Approve a pending page", Security: sessionScheme, Response: models.Page{}},
+	"POST /admin/pages/{id}/rejection":    {Tag: "admin", Summary: "Reject a pending page, the reason is mailed to its owner", Security: sessionScheme, Request: models.PageRejectionBodyModel{}, Response: models.Page{}},
+	"DELETE /admin/images/{id}":           {Tag: "admin", Summary: "Delete an image", Security: sessionScheme, Response: result{}},
+	"POST /admin/activities":              {Tag: "admin", Summary: "Create an activity", Security: sessionScheme, Request: models.Activity{}, Response: models.Activity{}},
+	"PATCH /admin/activities/{id}":        {Tag: "admin", Summary: "Update an activity", Security: sessionScheme, Request: models.Activity{}, Response: models.Activity{}},
+	"DELETE /admin/activities/{id}":       {Tag: "admin", Summary: "Delete an activity", Security: sessionScheme, Response: result{}},
+	"POST /admin/languages":               {Tag: "admin", Summary: "Create a language", Security: sessionScheme, Request: models.Language{}, Response: models.Language{}},
+	"PATCH /admin/languages/{id}":         {Tag: "admin", Summary: "Update a language", Security: sessionScheme, Request: models.Language{}, Response: models.Language{}},
+	"DELETE /admin/languages/{id}":        {Tag: "admin", Summary: "Delete a language", Security: sessionScheme, Response: result{}},
+	"GET /admin/conversations":            {Tag: "admin", Summary: "Reported conversations", Security: sessionScheme, Response: []models.Conversation{}},
+	"GET /admin/conversations/{id}":       {Tag: "admin", Summary: "Reported conversation with its messages", Security: sessionScheme, Response: models.Conversation{}},
+	"GET /admin/audit":                    {Tag: "admin", Summary: "Audit log", Security: sessionScheme, Query: auditQuery, Response: auditEvents{}},
+	"GET /admin/audit/export":             {Tag: "admin", Summary: "Audit log as JSON Lines", Security: sessionScheme, Query: auditQuery, ContentType: "application/x-ndjson"},
+
+	"POST /login":        {Tag: "account", Summary: "Log in, use POST /sessions", Request: models.User{}, Response: models.LoginResponseModel{}, Deprecated: true},
+	"POST /signup":       {Tag: "account", Summary: "Sign up, use POST /users", Request: models.User{}, Response: models.User{}, Deprecated: true},
+	"POST /login/verify": {Tag: "account", Summary: "Complete a login, use POST /sessions/verify", Request: models.TwoFactorBodyModel{}, Response: models.LoginResponseModel{}, Deprecated: true},
+}
+
+var auditQuery = []string{"actor_id", "action", "target_type", "target_id", "from", "to", "offset", "limit"}
+
+type enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type deletionSchedule struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+type recoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type userView struct {
+	User          models.User           `json:"user"`
+	Pages         []models.Page         `json:"pages"`
+	Conversations []models.Conversation `json:"conversations"`
+}
+
+type auditEvents struct {
+	Events []models.AuditEvent `json:"events"`
+	Total  int64               `json:"total"`
+}
+
+type moderationQueue struct {
+	Pages []models.Page `json:"pages"`
+	Total int64         `json:"total"`
+}
+
+type directory struct {
+	Profiles []models.PublicProfile `json:"profiles"`
+	Total    int64                  `json:"total"`
+}
+
+// apiDocument returns the OpenAPI document of the routes registered on api, the legacy
+// routes answering any method are documented as deprecated POST routes pointing to their successor
+func apiDocument(title string, api *server.API) *openapi.Document {
+	doc := openapi.New(title, api.Name, api.Prefix())
+	doc.Components.SecuritySchemes[sessionScheme] = openapi.SecurityScheme{Type: "apiKey", In: "cookie", Name: "user-token"}
+
+	//every error is answered with the same document
+	failure := openapi.Response{Description: "error", Content: map[string]openapi.MediaType{"application/json": {Schema: doc.Schema(apperror.Body{})}}}
+
+	for _, route := range api.Routes() {
+		if route.Successor != "" {
+			op := doc.Add(http.MethodPost, route.Path, openapi.Endpoint{Tag: "legacy", Summary: "Replaced by " + route.Successor, Deprecated: true})
+			op.Responses["default"] = failure
+			continue
+		}
+
+		endpoint, ok := endpoints[route.Method+" "+route.Path]
+		if !ok || route.Method == router.Any {
+			log.Warnf("openapi: %s %s%s is not documented", route.Method, api.Prefix(), route.Path)
+			continue
+		}
+
+		op := doc.Add(route.Method, route.Path, endpoint)
+		op.Responses["default"] = failure
+	}
+
+	return doc
+}
+
+// openapiHandler serves the OpenAPI document of api, it is built on the first request once every route is registered
+func openapiHandler(title string, api *server.API, l *localizer.Localizer) http.HandlerFunc {
+	var once sync.Once
+	var b []byte
+	var err error
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			b, err = json.Marshal(apiDocument(title, api))
+		})
+
+		if err != nil {
+			apperror.Write(w, r, l, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
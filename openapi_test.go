@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/amaurybrisou/couchsport.back/api/handlers"
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	"github.com/amaurybrisou/couchsport.back/api/stores/memory"
+	"github.com/amaurybrisou/couchsport.back/config"
+	"github.com/amaurybrisou/couchsport.back/localizer"
+	"github.com/amaurybrisou/couchsport.back/server"
+)
+
+//TestOpenAPI_Routes fails when a route is registered without its entry in endpoints, when an entry documents a route
+//no version registers and when the methods documented on a path are not the ones the router answers there
+func TestOpenAPI_Routes(t *testing.T) {
+	l := localizer.NewLocalizer([]string{"./localizer/en.json"})
+	srv := server.New(&config.Config{Name: "CouchSport", Env: "test"}, nil)
+	registerHandlers(srv, handlers.NewHandlerFactory(memory.New(l), l, nil))
+
+	registered := map[string]bool{}
+	for _, version := range []string{"v1", "v2"} {
+		api := srv.Version(version)
+		doc := apiDocument("CouchSport", api)
+
+		for _, route := range api.Routes() {
+			method := route.Method
+			if method == router.Any {
+				method = http.MethodPost
+			}
+			registered[route.Method+" "+route.Path] = true
+
+			if !doc.Has(method, route.Path) {
+				t.Errorf("%s %s%s is missing from the OpenAPI document, document it in endpoints", route.Method, api.Prefix(), route.Path)
+			}
+		}
+
+		for path, item := range doc.Paths {
+			var documented []string
+			for method, op := range item {
+				//the legacy routes answer any method
+				if op.Deprecated && len(op.Tags) > 0 && op.Tags[0] == "legacy" {
+					documented = nil
+					break
+				}
+				documented = append(documented, strings.ToUpper(method))
+			}
+			if documented == nil {
+				continue
+			}
+			sort.Strings(documented)
+
+			//the path parameters are filled with an ID, a static route shadowing the path answers its own methods
+			sample := regexp.MustCompile(`{[^}]+}`).ReplaceAllString(path, "1")
+			w := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodTrace, api.Prefix()+sample, nil))
+
+			var allowed []string
+			for _, method := range strings.Split(w.Header().Get("Allow"), ", ") {
+				if method != http.MethodHead && method != http.MethodOptions && method != "" {
+					allowed = append(allowed, method)
+				}
+			}
+			sort.Strings(allowed)
+
+			if !reflect.DeepEqual(documented, allowed) {
+				t.Errorf("%s%s: documented %v, the router answers %v", api.Prefix(), path, documented, allowed)
+			}
+		}
+	}
+
+	for key := range endpoints {
+		if !registered[key] {
+			t.Errorf("%s is documented in endpoints but no version registers it", key)
+		}
+	}
+}
+
+func TestAPI_OpenAPI(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, path := range []string{"/api/openapi.json", "/api/v2/openapi.json"} {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var doc struct {
+			OpenAPI string                            `json:"openapi"`
+			Paths   map[string]map[string]interface{} `json:"paths"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&doc)
+		res.Body.Close()
+
+		if err != nil || res.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: status %d, %v", path, res.StatusCode, err)
+		}
+
+		if doc.OpenAPI == "" || doc.Paths["/pages/{id}"]["patch"] == nil {
+			t.Errorf("GET %s: document without PATCH /pages/{id}", path)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/amaurybrisou/couchsport.back/api/handlers"
+	"github.com/amaurybrisou/couchsport.back/api/models"
+	"github.com/amaurybrisou/couchsport.back/server"
+)
+
+//registerHandlers registers every api version of the application on srv
+func registerHandlers(srv *server.Instance, handlerFactory *handlers.HandlerFactory) {
+	srv.HandleErrors(handlerFactory.ErrorHandler(http.StatusNotFound), handlerFactory.ErrorHandler(http.StatusMethodNotAllowed))
+
+	v1 := srv.Version("v1")
+	registerResources(v1, handlerFactory)
+	registerLegacyHandlers(v1, handlerFactory)
+	v1.Route(http.MethodGet, "/openapi.json", openapiHandler(srv.C.Name, v1, handlerFactory.Localizer()))
+
+	//v2 serves the v1 resources without the legacy action paths, no payload differs yet. A route whose payload
+	//changes in v2 moves out of registerResources and is registered on each version with its own handler
+	v2 := srv.Version("v2")
+	registerResources(v2, handlerFactory)
+	v2.Route(http.MethodGet, "/openapi.json", openapiHandler(srv.C.Name, v2, handlerFactory.Localizer()))
+}
+
+//adminHandler returns the wrapper of the admin routes, they require a logged user having the route permission
+func adminHandler(handlerFactory *handlers.HandlerFactory) func(string, func(uint, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	logged := handlerFactory.UserHandler().IsLogged
+	can := handlerFactory.RoleHandler().Can
+
+	return func(permission string, pass func(uint, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+		return logged(can(permission, pass))
+	}
+}
+
+//registerResources registers the resource routes on api
+func registerResources(api *server.API, handlerFactory *handlers.HandlerFactory) {
+	logged := handlerFactory.UserHandler().IsLogged
+	can := handlerFactory.RoleHandler().Can
+	admin := adminHandler(handlerFactory)
+
+	api.Route(http.MethodGet, "/ws", logged(handlerFactory.WsHandler().EntryPoint))
+
+	api.Route(http.MethodGet, "/languages", handlerFactory.LanguageHandler().All)
+	api.Route(http.MethodGet, "/activities", handlerFactory.ActivityHandler().All)
+
+	api.Route(http.MethodGet, "/pages", handlerFactory.PageHandler().All)
+	api.Route(http.MethodPost, "/pages", logged(can(models.PermPagesCreate, handlerFactory.PageHandler().New)))
+	api.Route(http.MethodGet, "/pages/{id}", handlerFactory.PageHandler().Get)
+	api.Route(http.MethodPatch, "/pages/{id}", logged(handlerFactory.PageHandler().Update))
+	api.Route(http.MethodDelete, "/pages/{id}", logged(handlerFactory.PageHandler().Delete))
+	api.Route(http.MethodPut, "/pages/{id}/publish", logged(handlerFactory.PageHandler().Publish))
+	api.Route(http.MethodPut, "/pages/{id}/status", logged(handlerFactory.PageHandler().SetStatus))
+	api.Route(http.MethodPost, "/pages/{id}/restoration", logged(handlerFactory.TrashHandler().RestorePage))
+	api.Route(http.MethodDelete, "/pages/{pageID}/images/{id}", logged(handlerFactory.ImageHandler().Delete))
+	api.Route(http.MethodPost, "/pages/{pageID}/images/{id}/restoration", logged(handlerFactory.TrashHandler().RestoreImage))
+
+	api.Route(http.MethodPost, "/uploads", logged(handlerFactory.UploadHandler().New))
+	api.Route(http.MethodGet, "/uploads/{id}", logged(handlerFactory.UploadHandler().Get))
+	api.Route(http.MethodPatch, "/uploads/{id}", logged(handlerFactory.UploadHandler().Append))
+
+	api.Route(http.MethodGet, "/profiles", handlerFactory.ProfileHandler().Directory)
+	api.Route(http.MethodGet, "/profiles/{id}", handlerFactory.ProfileHandler().Get)
+	api.Route(http.MethodGet, "/profiles/by-username/{username}", handlerFactory.ProfileHandler().GetByUsername)
+	api.Route(http.MethodGet, "/profiles/me", logged(handlerFactory.UserHandler().Profile))
+	api.Route(http.MethodPatch, "/profiles/{id}", logged(handlerFactory.ProfileHandler().Update))
+	api.Route(http.MethodGet, "/profiles/me/pages", logged(handlerFactory.PageHandler().ProfilePages))
+	api.Route(http.MethodGet, "/profiles/me/conversations", logged(handlerFactory.ConversationHandler().ProfileConversations))
+	api.Route(http.MethodGet, "/profiles/me/trash", logged(handlerFactory.TrashHandler().Content))
+
+	api.Route(http.MethodPost, "/messages", handlerFactory.ConversationHandler().HandleMessage)
+	api.Route(http.MethodDelete, "/conversations/{id}", logged(handlerFactory.ConversationHandler().Delete))
+	api.Route(http.MethodPost, "/conversations/{id}/reports", logged(handlerFactory.ConversationHandler().Report))
+
+	api.Route(http.MethodPost, "/users", handlerFactory.UserHandler().SignUp)
+	api.Route(http.MethodPost, "/sessions", handlerFactory.UserHandler().Login)
+	api.Route(http.MethodDelete, "/sessions", logged(handlerFactory.UserHandler().Logout))
+	api.Route(http.MethodPost, "/sessions/verify", handlerFactory.TwoFactorHandler().Verify)
+	api.Route(http.MethodPut, "/users/me/password", logged(handlerFactory.UserHandler().ChangePassword))
+	api.Route(http.MethodPost, "/users/me/2fa", logged(handlerFactory.TwoFactorHandler().Enroll))
+	api.Route(http.MethodPost, "/users/me/2fa/confirm", logged(handlerFactory.TwoFactorHandler().Confirm))
+	api.Route(http.MethodPost, "/users/me/2fa/recovery-codes", logged(handlerFactory.TwoFactorHandler().RecoveryCodes))
+	api.Route(http.MethodDelete, "/users/me/2fa", logged(handlerFactory.TwoFactorHandler().Disable))
+	api.Route(http.MethodGet, "/users/me/roles", logged(handlerFactory.RoleHandler().Mine))
+	api.Route(http.MethodPost, "/users/me/exports", logged(handlerFactory.DataExportHandler().Request))
+	api.Route(http.MethodGet, "/users/me/exports", logged(handlerFactory.DataExportHandler().All))
+	api.Route(http.MethodGet, "/users/me/exports/{id}", logged(handlerFactory.DataExportHandler().Download))
+	api.Route(http.MethodPost, "/users/me/deletion", logged(handlerFactory.AccountDeletionHandler().Request))
+	api.Route(http.MethodDelete, "/users/me/deletion", logged(handlerFactory.AccountDeletionHandler().Cancel))
+
+	api.Route(http.MethodGet, "/roles", admin(models.PermRolesManage, handlerFactory.RoleHandler().All))
+	api.Route(http.MethodPut, "/users/{id}/roles/{role}", admin(models.PermRolesManage, handlerFactory.RoleHandler().Grant))
+	api.Route(http.MethodDelete, "/users/{id}/roles/{role}", admin(models.PermRolesManage, handlerFactory.RoleHandler().Revoke))
+
+	api.Route(http.MethodGet, "/admin/users", admin(models.PermUsersManage, handlerFactory.AdminHandler().Users))
+	api.Route(http.MethodGet, "/admin/users/{id}", admin(models.PermUsersManage, handlerFactory.AdminHandler().ViewUser))
+	api.Route(http.MethodDelete, "/admin/users/{id}", admin(models.PermUsersManage, handlerFactory.AdminHandler().DeleteUser))
+	api.Route(http.MethodPut, "/admin/users/{id}/suspension", admin(models.PermUsersManage, handlerFactory.AdminHandler().SuspendUser))
+	api.Route(http.MethodDelete, "/admin/users/{id}/suspension", admin(models.PermUsersManage, handlerFactory.AdminHandler().SuspendUser))
+	api.Route(http.MethodPatch, "/admin/pages/{id}", admin(models.PermPagesModerate, handlerFactory.AdminHandler().UpdatePage))
+	api.Route(http.MethodGet, "/admin/pages", admin(models.PermPagesModerate, handlerFactory.AdminHandler().Pages))
+	api.Route(http.MethodPost, "/admin/pages/{id}/unpublish", admin(models.PermPagesModerate, handlerFactory.AdminHandler().UnpublishPage))
+	api.Route(http.MethodPost, "/admin/pages/{id}/approval", admin(models.PermPagesModerate, handlerFactory.AdminHandler().ApprovePage))
+	api.Route(http.MethodPost, "/admin/pages/{id}/rejection", admin(models.PermPagesModerate, handlerFactory.AdminHandler().RejectPage))
+	api.Route(http.MethodDelete, "/admin/images/{id}", admin(models.PermImagesModerate, handlerFactory.AdminHandler().DeleteImage))
+	api.Route(http.MethodPost, "/admin/activities", admin(models.PermReferenceManage, handlerFactory.AdminHandler().NewActivity))
+	api.Route(http.MethodPatch, "/admin/activities/{id}", admin(models.PermReferenceManage, handlerFactory.AdminHandler().UpdateActivity))
+	api.Route(http.MethodDelete, "/admin/activities/{id}", admin(models.PermReferenceManage, handlerFactory.AdminHandler().DeleteActivity))
+	api.Route(http.MethodPost, "/admin/languages", admin(models.PermReferenceManage, handlerFactory.AdminHandler().NewLanguage))
+	api.Route(http.MethodPatch, "/admin/languages/{id}", admin(models.PermReferenceManage, handlerFactory.AdminHandler().UpdateLanguage))
+	api.Route(http.MethodDelete, "/admin/languages/{id}", admin(models.PermReferenceManage, handlerFactory.AdminHandler().DeleteLanguage))
+	api.Route(http.MethodGet, "/admin/conversations", admin(models.PermConversationsModerate, handlerFactory.AdminHandler().ReportedConversations))
+	api.Route(http.MethodGet, "/admin/conversations/{id}", admin(models.PermConversationsModerate, handlerFactory.AdminHandler().ViewConversation))
+	api.Route(http.MethodGet, "/admin/audit", admin(models.PermAuditRead, handlerFactory.AdminHandler().AuditEvents))
+	api.Route(http.MethodGet, "/admin/audit/export", admin(models.PermAuditRead, handlerFactory.AdminHandler().ExportAuditEvents))
+}
+
+//registerLegacyHandlers registers the action style routes the deployed client still uses, they answer
+//any method and take the ids from the body or the query. They are removed at the end of the deprecation window.
+//The administration has no legacy route, a link followed by an administrator must not change anything
+func registerLegacyHandlers(api *server.API, handlerFactory *handlers.HandlerFactory) {
+	logged := handlerFactory.UserHandler().IsLogged
+
+	api.Route(http.MethodPost, "/login", handlerFactory.UserHandler().Login)
+	api.Route(http.MethodPost, "/signup", handlerFactory.UserHandler().SignUp)
+	api.Route(http.MethodPost, "/login/verify", handlerFactory.TwoFactorHandler().Verify)
+
+	api.RegisterLegacyHandler("/conversations/message/send", "/messages", handlerFactory.ConversationHandler().HandleMessage)
+	api.RegisterLegacyHandler("/conversations/delete", "/conversations/{id}", logged(handlerFactory.ConversationHandler().Delete))
+	api.RegisterLegacyHandler("/conversations/report", "/conversations/{id}/reports", logged(handlerFactory.ConversationHandler().Report))
+
+	api.RegisterLegacyHandler("/pages/new", "/pages", logged(
+		handlerFactory.RoleHandler().Can(models.PermPagesCreate, handlerFactory.PageHandler().New)),
+	)
+	api.RegisterLegacyHandler("/pages/update", "/pages/{id}", logged(handlerFactory.PageHandler().Update))
+	api.RegisterLegacyHandler("/pages/publish", "/pages/{id}/publish", logged(handlerFactory.PageHandler().Publish))
+	api.RegisterLegacyHandler("/pages/delete", "/pages/{id}", logged(handlerFactory.PageHandler().Delete))
+	api.RegisterLegacyHandler("/images/delete", "/pages/{pageID}/images/{id}", logged(handlerFactory.ImageHandler().Delete))
+
+	api.RegisterLegacyHandler("/profiles/update", "/profiles/{id}", logged(handlerFactory.ProfileHandler().Update))
+	api.RegisterLegacyHandler("/profiles/mine", "/profiles/me", logged(handlerFactory.UserHandler().Profile))
+	api.RegisterLegacyHandler("/profiles/pages", "/profiles/me/pages", logged(handlerFactory.PageHandler().ProfilePages))
+	api.RegisterLegacyHandler("/profile/conversations", "/profiles/me/conversations", logged(handlerFactory.ConversationHandler().ProfileConversations))
+
+	api.RegisterLegacyHandler("/logout", "/sessions", logged(handlerFactory.UserHandler().Logout))
+	api.RegisterLegacyHandler("/users/2fa/enroll", "/users/me/2fa", logged(handlerFactory.TwoFactorHandler().Enroll))
+	api.RegisterLegacyHandler("/users/2fa/confirm", "/users/me/2fa/confirm", logged(handlerFactory.TwoFactorHandler().Confirm))
+	api.RegisterLegacyHandler("/users/2fa/recovery-codes", "/users/me/2fa/recovery-codes", logged(handlerFactory.TwoFactorHandler().RecoveryCodes))
+	api.RegisterLegacyHandler("/users/2fa/disable", "/users/me/2fa", logged(handlerFactory.TwoFactorHandler().Disable))
+	api.RegisterLegacyHandler("/users/roles", "/users/me/roles", logged(handlerFactory.RoleHandler().Mine))
+	api.RegisterLegacyHandler("/users/export", "/users/me/exports", logged(handlerFactory.DataExportHandler().Request))
+	api.RegisterLegacyHandler("/users/exports", "/users/me/exports", logged(handlerFactory.DataExportHandler().All))
+	api.RegisterLegacyHandler("/users/exports/download", "/users/me/exports/{id}", logged(handlerFactory.DataExportHandler().Download))
+	api.RegisterLegacyHandler("/users/delete", "/users/me/deletion", logged(handlerFactory.AccountDeletionHandler().Request))
+	api.RegisterLegacyHandler("/users/delete/cancel", "/users/me/deletion", logged(handlerFactory.AccountDeletionHandler().Cancel))
+	api.RegisterLegacyHandler("/users/change-password", "/users/me/password", logged(handlerFactory.UserHandler().ChangePassword))
+}
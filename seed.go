@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/amaurybrisou/couchsport.back/api/stores"
+)
+
+const seedUsage = "usage: seed [-force] [reference | demo | all]"
+
+//runSeed implements the seed subcommand, reference data is loaded unless told otherwise.
+//The demo users have known passwords, an admin among them, they are only loaded in the dev environment unless forced
+func runSeed(storeFactory *stores.StoreFactory, env string, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	force := flags.Bool("force", false, "load the demo users outside of the dev environment")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() > 1 {
+		return fmt.Errorf(seedUsage)
+	}
+
+	what := "reference"
+	if flags.NArg() > 0 {
+		what = flags.Arg(0)
+	}
+
+	switch what {
+	case "reference":
+		storeFactory.RoleStore().Seed()
+		return storeFactory.FixtureStore().LoadReference()
+	case "demo", "all":
+		if env != "dev" && !*force {
+			return fmt.Errorf("the demo users are only loaded in the dev environment, not %q, unless -force is given", env)
+		}
+		storeFactory.RoleStore().Seed()
+		if err := storeFactory.FixtureStore().LoadReference(); err != nil {
+			return err
+		}
+		return storeFactory.FixtureStore().LoadDemo()
+	}
+
+	return fmt.Errorf(seedUsage)
+}
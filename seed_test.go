@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestSeed_Demo(t *testing.T) {
+	ts := newTestServer(t)
+
+	if err := runSeed(ts.Stores, "prod", []string{"demo"}); err == nil {
+		t.Fatal("the demo users should not be loaded outside of the dev environment")
+	}
+	if _, err := ts.Stores.UserStore().GetByEmail("admin@couchsport.test", false); err == nil {
+		t.Fatal("the refused seed should not create the demo admin")
+	}
+
+	if err := runSeed(ts.Stores, "prod", []string{"-force", "demo"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Stores.UserStore().GetByEmail("admin@couchsport.test", false); err != nil {
+		t.Errorf("a forced seed should create the demo admin: %s", err)
+	}
+
+	if err := runSeed(ts.Stores, "dev", []string{"demo", "reference"}); err == nil {
+		t.Errorf("seed takes a single argument")
+	}
+}
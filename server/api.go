@@ -0,0 +1,117 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amaurybrisou/couchsport.back/api/router"
+	log "github.com/sirupsen/logrus"
+)
+
+//DefaultVersion is the api version also mounted on the unversioned prefix, the deployed client still calls /api
+const DefaultVersion = "v1"
+
+//API registers the handlers of one version of the api, each version owns its routes
+//so the payloads of a version do not change when the next one evolves
+type API struct {
+	s          *Instance
+	Name       string
+	prefixes   []string
+	deprecated bool
+	sunset     time.Time
+	routes     []Route
+}
+
+//Route is a route registered on a version, Successor is the route replacing a legacy one
+type Route struct {
+	Method, Path, Successor string
+}
+
+//Version returns the api version name mounted on /api/<name>, versions listed in the API.Deprecated
+//configuration answer with the Deprecation header and the Sunset header when their removal date is set
+func (s *Instance) Version(name string) *API {
+	if api, ok := s.versions[name]; ok {
+		return api
+	}
+
+	api := &API{s: s, Name: name, prefixes: []string{prefix + "/" + name}}
+	if name == DefaultVersion {
+		api.prefixes = append(api.prefixes, prefix)
+	}
+
+	if sunset, ok := s.C.API.Deprecated[name]; ok {
+		api.deprecated = true
+		if sunset != "" {
+			t, err := time.Parse("2006-01-02", sunset)
+			if err != nil {
+				log.Errorf("api %s: invalid sunset date %s : %s", name, sunset, err)
+			}
+			api.sunset = t
+		}
+	}
+
+	s.versions[name] = api
+	return api
+}
+
+// RegisterHandler registers api handlers answering any method
+func (me *API) RegisterHandler(path string, handler http.HandlerFunc) {
+	me.Route(router.Any, path, handler)
+}
+
+//RegisterLegacyHandler registers an action style handler kept during the deprecation window of the
+//resource routes, its responses point to the successor route
+func (me *API) RegisterLegacyHandler(path, successor string, handler http.HandlerFunc) {
+	me.routes = append(me.routes, Route{Method: router.Any, Path: path, Successor: successor})
+	for _, p := range me.prefixes {
+		link := "<" + p + successor + ">; rel=\"successor-version\""
+		me.route(p, router.Any, path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", link)
+			handler(w, r)
+		})
+	}
+}
+
+//Route registers the api handler of method on path, {name} segments of path are read with router.Param
+func (me *API) Route(method, path string, handler http.HandlerFunc) {
+	me.routes = append(me.routes, Route{Method: method, Path: path})
+	for _, p := range me.prefixes {
+		me.route(p, method, path, handler)
+	}
+}
+
+//Routes returns the routes registered on the version, in registration order
+func (me *API) Routes() []Route {
+	return me.routes
+}
+
+//Prefix is the path the version is mounted on
+func (me *API) Prefix() string {
+	return me.prefixes[0]
+}
+
+func (me *API) route(prefix, method, path string, handler http.HandlerFunc) {
+	log.Infof("registering handler %s %s in %s environment, cors is enabled in dev", method, prefix+path, me.s.C.Env)
+
+	if me.deprecated {
+		handler = me.deprecate(handler)
+	}
+
+	if me.s.C.Env == "dev" {
+		handler = me.s.enableCors(handler)
+		//preflight requests are answered by enableCors
+		me.s.router.Handle(http.MethodOptions, prefix+path, handler)
+	}
+	me.s.router.Handle(method, prefix+path, handler)
+}
+
+func (me *API) deprecate(pass http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		if !me.sunset.IsZero() {
+			w.Header().Set("Sunset", me.sunset.UTC().Format(http.TimeFormat))
+		}
+		pass(w, r)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/amaurybrisou/couchsport.back/config"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -22,8 +24,12 @@ func mustOpenDb(c *config.Config) *gorm.DB {
 		},
 	)
 	log.Println(c.DriverName, c.DataSourceName)
-	dsn := fmt.Sprintf("%s?%s", c.DataSourceName, c.DatabaseParams)
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: newLogger})
+	dialector, err := dialector(c)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: newLogger})
 
 	if err != nil {
 		log.Fatal(err)
@@ -35,3 +41,27 @@ func mustOpenDb(c *config.Config) *gorm.DB {
 
 	return db
 }
+
+//dialector returns the gorm dialector matching c.DriverName, mysql is used when it is empty.
+//DatabaseParams are appended to DataSourceName the way each driver expects them
+func dialector(c *config.Config) (gorm.Dialector, error) {
+	switch c.DriverName {
+	case "", "mysql":
+		return mysql.Open(withParams(c.DataSourceName, "?", c.DatabaseParams)), nil
+	case "postgres":
+		//key=value DSN, i.e "host=localhost user=couchsport dbname=couchsport"
+		return postgres.Open(withParams(c.DataSourceName, " ", c.DatabaseParams)), nil
+	case "sqlite", "sqlite3":
+		//the file path, i.e "couchsport.db", "_foreign_keys=1" enables the constraints
+		return sqlite.Open(withParams(c.DataSourceName, "?", c.DatabaseParams)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported database driver %q", c.DriverName)
+}
+
+func withParams(dsn, sep, params string) string {
+	if params == "" {
+		return dsn
+	}
+	return dsn + sep + params
+}
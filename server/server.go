@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/amaurybrisou/couchsport.back/api/router"
 	"github.com/amaurybrisou/couchsport.back/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -17,7 +18,8 @@ type Instance struct {
 	Db         *gorm.DB
 	C          *config.Config
 	HTTPServer *http.Server
-	router     *http.ServeMux
+	router     *router.Router
+	versions   map[string]*API
 }
 
 var s *Instance
@@ -27,20 +29,39 @@ func NewInstance(c *config.Config) *Instance {
 	if s != nil {
 		return s
 	}
-	r := http.NewServeMux()
 
 	db := mustOpenDb(c)
 	// Setup(s.C, s.Db)
 
-	s = &Instance{
+	s = New(c, db)
+
+	return s
+}
+
+//New creates a server object on an already opened db, unlike NewInstance every call returns a new one
+func New(c *config.Config, db *gorm.DB) *Instance {
+	r := router.New()
+
+	return &Instance{
 		C:          c,
 		router:     r,
 		HTTPServer: &http.Server{Addr: c.Listen + ":" + strconv.Itoa(c.Port), Handler: r},
 		Db:         db,
+		versions:   map[string]*API{},
 		// just in case you need some setup here
 	}
+}
 
-	return s
+//Handler returns the router holding the registered handlers
+func (s *Instance) Handler() http.Handler {
+	return s.router
+}
+
+//HandleErrors sets the handlers answering the requests matching no route and the ones
+//matching a route registered for other methods
+func (s *Instance) HandleErrors(notFound, methodNotAllowed http.Handler) {
+	s.router.NotFound = notFound
+	s.router.MethodNotAllowed = methodNotAllowed
 }
 
 //Start the current Instance
@@ -72,23 +93,14 @@ func (s *Instance) Shutdown() {
 	}
 }
 
-// RegisterHandler registers api handlers
-func (s *Instance) RegisterHandler(path string, handler http.HandlerFunc) {
-	log.Infof("registering handler at path in %s environment %s, cors is enabled in dev", prefix+path, s.C.Env)
-	if s.C.Env == "dev" {
-		handler = s.enableCors(handler)
-	}
-	s.router.Handle(prefix+path, handler)
-}
-
 //ServerPublic ...
 func (s *Instance) ServePublic(path string) {
 	log.Infof("serving files at %s", http.Dir(path))
-	s.router.Handle("/static/", http.StripPrefix(`/static/`, http.FileServer(http.Dir(path+"/static"))))
-	s.router.Handle("/lib/", http.StripPrefix(`/lib/`, http.FileServer(http.Dir(path+"/lib"))))
-	s.router.Handle("/uploads/", http.StripPrefix(`/uploads/`, http.FileServer(http.Dir(path+"/uploads"))))
-	s.router.Handle("/fonts/", http.StripPrefix(`/fonts/`, http.FileServer(http.Dir(path+"/fonts"))))
-	s.router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	s.router.Handle(router.Any, "/static/", http.StripPrefix(`/static/`, http.FileServer(http.Dir(path+"/static"))))
+	s.router.Handle(router.Any, "/lib/", http.StripPrefix(`/lib/`, http.FileServer(http.Dir(path+"/lib"))))
+	s.router.Handle(router.Any, "/uploads/", http.StripPrefix(`/uploads/`, http.FileServer(http.Dir(path+"/uploads"))))
+	s.router.Handle(router.Any, "/fonts/", http.StripPrefix(`/fonts/`, http.FileServer(http.Dir(path+"/fonts"))))
+	s.router.HandleFunc(router.Any, "/", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, path+"/index.html")
 	})
 }
@@ -100,8 +112,9 @@ func (s *Instance) enableCors(pass http.HandlerFunc) http.HandlerFunc {
 		w.Header().Set("Access-Control-Request-Headers", "X-Requested-With")
 		w.Header().Set("Vary", "Origin")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, PATCH, DELETE")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 		if r.Method == "OPTIONS" {
 			return
 		}